@@ -1,119 +1,438 @@
 package application
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"lms-web-services-main/controllers"
 	"lms-web-services-main/database/datasources"
+	"lms-web-services-main/logging"
+	"lms-web-services-main/metrics"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
 	"lms-web-services-main/repositories"
 	"lms-web-services-main/routers"
 	services "lms-web-services-main/services"
+	"lms-web-services-main/tracing"
 
 	"github.com/LGYtech/lgo"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"gorm.io/gorm"
 )
 
-var (
-	router = gin.Default()
-)
+// Version, derleme sırasında -ldflags "-X lms-web-services-main/application.Version=..." ile atanır
+var Version = "dev"
+
+type Application struct {
+	config        *Config
+	database      *gorm.DB
+	cache         *redis.Client
+	router        *gin.Engine
+	metrics       *metrics.Metrics
+	logger        *slog.Logger
+	healthService services.HealthService
+
+	eventDispatcher *services.EventDispatcher
+	webhookSender   *services.WebhookSender
+	businessGauges  []*metrics.BusinessGauge
+
+	shutdownTracing func(context.Context) error
+}
 
 func StartApplication() {
+	// Yapılandırma okunana kadar varsayılan ayarlı bir logger kullanılır
+	logger := logging.New(logging.Config{}, os.Stdout)
+
 	// Load Environment Variables
 	if err := godotenv.Load(); err != nil {
-		log.Fatalf("Error loading environment: %v", err)
-		return
+		logger.Error("loading environment failed", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	logger.Info(".env file loaded successfully")
+
+	config, err := LoadConfig()
+	if err != nil {
+		logger.Error("loading configuration failed", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	logger = logging.New(config.Logging, os.Stdout)
+	slog.SetDefault(logger)
+
+	app, err := NewApplication(config, logger)
+	if err != nil {
+		logger.Error("initializing application failed", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	// Start Server
+	logger.Info("LMS Service is running", slog.String("address", config.ServerAddress), slog.String("version", Version))
+	runErr := app.Run()
+	if err := app.Close(); err != nil {
+		logger.Error("closing datasources failed", slog.String("error", err.Error()))
+	}
+	if runErr != nil {
+		logger.Error("server stopped", slog.String("error", runErr.Error()))
+		os.Exit(1)
+	}
+}
+
+// NewApplication, veri kaynaklarını açar ve tüm bağımlılıkları verilen ayarlardan oluşturur
+func NewApplication(config *Config, logger *slog.Logger) (*Application, error) {
+	shutdownTracing, err := tracing.NewProvider(context.Background(), config.Tracing, Version)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := datasources.NewDatabase(config.Database, logging.Component(logger, "db"))
+	if err != nil {
+		shutdownTracing(context.Background())
+		return nil, err
+	}
+
+	cache, err := datasources.NewCache(config.Cache, logging.Component(logger, "cache"))
+	if err != nil {
+		closeDatabase(database)
+		shutdownTracing(context.Background())
+		return nil, err
+	}
+
+	app := &Application{
+		config:          config,
+		database:        database,
+		cache:           cache,
+		router:          gin.New(),
+		metrics:         metrics.New(),
+		logger:          logger,
+		shutdownTracing: shutdownTracing,
+	}
+
+	// Setup Tracing
+	if err := app.setupTracing(); err != nil {
+		app.Close()
+		return nil, err
+	}
+
+	// Setup Logging
+	app.setupLogging()
+
+	// Setup Metrics
+	if err := app.setupMetrics(); err != nil {
+		app.Close()
+		return nil, err
 	}
-	log.Println(".env file loaded successfully")
 
 	// Setup CORS
-	setupCORS()
+	app.setupCORS()
+
+	// Setup Request Timeouts
+	app.router.Use(timeoutMiddleware(app.config.RequestTimeout, app.config.RouteTimeouts, logging.Component(logger, "http")))
 
 	// Setup Router
-	addRoutes()
+	app.addRoutes()
 
-	// Start Server
-	log.Println("LMS Service is running on port 8080")
-	router.Run(":8080")
+	return app, nil
+}
+
+// Run, HTTP sunucusunu başlatır ve SIGINT/SIGTERM gelene kadar bekler. Sinyal
+// geldiğinde readiness düşürülür, ShutdownDelay kadar beklenir ve açık istekler
+// ShutdownTimeout süresince tamamlanmaya bırakılır.
+func (app *Application) Run() error {
+	server := &http.Server{
+		Addr:              app.config.ServerAddress,
+		Handler:           app.router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Giden kutusu, webhook gönderimleri ve iş göstergeleri sunucuyla birlikte işlenir; işçiler
+	// veri kaynakları kapanmadan önce durdurulur
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	runs := []func(context.Context){app.eventDispatcher.Run, app.webhookSender.Run}
+	for _, gauge := range app.businessGauges {
+		runs = append(runs, gauge.Run)
+	}
+	for _, run := range runs {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workersCtx)
+		}()
+	}
+	defer func() {
+		stopWorkers()
+		workers.Wait()
+	}()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+	stop()
+
+	app.logger.Info("shutdown signal received, draining connections",
+		slog.Duration("delay", app.config.ShutdownDelay),
+		slog.Duration("timeout", app.config.ShutdownTimeout))
+	app.healthService.MarkShuttingDown()
+	time.Sleep(app.config.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), app.config.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", err)
+	}
+
+	app.logger.Info("server stopped gracefully")
+	return nil
+}
+
+// Handler, uygulamanın rotalarını sunucu açmadan kullanmak için döndürür (ör. entegrasyon testleri)
+func (app *Application) Handler() http.Handler {
+	return app.router
+}
+
+// Close, uygulamanın açtığı veri kaynaklarını kapatır ve bekleyen span'leri gönderir
+func (app *Application) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return errors.Join(app.cache.Close(), closeDatabase(app.database), app.shutdownTracing(ctx))
+}
+
+func closeDatabase(database *gorm.DB) error {
+	sqlDB, err := database.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
 
-func setupCORS() {
+func (app *Application) setupTracing() error {
+	if err := app.database.Use(tracing.GormPlugin()); err != nil {
+		return fmt.Errorf("registering gorm tracing plugin failed: %w", err)
+	}
+	app.cache.AddHook(tracing.RedisHook())
+
+	// Sağlık, metrik ve dokümantasyon uç noktaları izlenmez
+	app.router.Use(otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(func(r *http.Request) bool {
+		switch r.URL.Path {
+		case "/healthz", "/readyz", "/metrics", "/openapi.json", "/docs":
+			return false
+		}
+		return true
+	})))
+	return nil
+}
+
+func (app *Application) setupLogging() {
+	httpLogger := logging.Component(app.logger, "http")
+	app.router.Use(
+		logging.RequestIDMiddleware(),
+		logging.RecoveryMiddleware(httpLogger),
+		logging.AccessLogMiddleware(httpLogger),
+	)
+}
+
+func (app *Application) setupMetrics() error {
+	if err := app.database.Use(app.metrics.GormPlugin()); err != nil {
+		return fmt.Errorf("registering gorm metrics plugin failed: %w", err)
+	}
+
+	sqlDB, err := app.database.DB()
+	if err != nil {
+		return err
+	}
+	app.metrics.RegisterDBStats(sqlDB)
+
+	app.router.Use(app.metrics.GinMiddleware())
+	return nil
+}
+
+func (app *Application) setupCORS() {
 	corsConfig := cors.DefaultConfig()
-	corsConfig.AllowOrigins = []string{"http://localhost:5173"} // Update as per your frontend's origin
+	corsConfig.AllowOrigins = app.config.CORSOrigins
 	corsConfig.AllowCredentials = true
 	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Token"}
-	router.Use(cors.New(corsConfig))
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "Accept-Language", "X-Token", logging.RequestIDHeader}
+	corsConfig.ExposeHeaders = []string{logging.RequestIDHeader, "Deprecation", "Link"}
+	app.router.Use(cors.New(corsConfig))
 }
 
-func addRoutes() {
+func (app *Application) addRoutes() {
 	// #region Initialize repositories and services
-	systemUserRepo := repositories.NewSystemUserRepository(datasources.Database)
-	systemUserService := services.NewSystemUserService(systemUserRepo)
+	healthRepo := repositories.NewHealthRepository(app.database, app.cache)
+	app.healthService = services.NewHealthService(healthRepo, Version)
+
+	systemUserSettingRepo := repositories.NewSystemUserSettingRepository(app.database)
+	cacheRepo := repositories.NewCacheRepository(app.cache, systemUserSettingRepo, app.metrics, logging.Component(app.logger, "cache"))
+	cacheService := services.NewCacheService(cacheRepo)
+
+	transactor := repositories.NewTransactor(app.database)
+	outboxRepo := repositories.NewOutboxRepository(app.database)
+	eventService := services.NewEventService(transactor, outboxRepo)
+	app.eventDispatcher = services.NewEventDispatcher(outboxRepo, app.config.Events, logging.Component(app.logger, "events"))
 
-	systemUserSettingRepo := repositories.NewSystemUserSettingRepository(datasources.Database)
-	systemUserSettingService := services.NewSystemUserSettingService(systemUserSettingRepo)
+	systemUserSettingService := services.NewSystemUserSettingService(systemUserSettingRepo, cacheService, eventService)
 
-	clientRepo := repositories.NewClientRepository(datasources.Database)
-	clientService := services.NewClientService(clientRepo)
+	systemUserRepo := repositories.NewSystemUserRepository(app.database)
+	systemUserService := services.NewSystemUserService(systemUserRepo, systemUserSettingService, cacheService, eventService, app.metrics)
 
-	clientProjectRepo := repositories.NewClientProjectRepository(datasources.Database)
-	clientProjectService := services.NewClientProjectService(clientProjectRepo)
+	clientRepo := repositories.NewClientRepository(app.database)
+	clientService := services.NewClientService(clientRepo, transactor, cacheService, eventService)
 
-	timingRepo := repositories.NewTimingRepository(datasources.Database)
-	timingService := services.NewTimingService(timingRepo)
+	clientContactRepo := repositories.NewClientContactRepository(app.database)
+	clientContactService := services.NewClientContactService(clientContactRepo, clientRepo, cacheService, eventService)
+
+	clientProjectRepo := repositories.NewClientProjectRepository(app.database)
+	clientProjectService := services.NewClientProjectService(clientProjectRepo, clientRepo, transactor, cacheService, eventService)
+
+	clientProjectMemberRepo := repositories.NewClientProjectMemberRepository(app.database)
+	clientProjectMemberService := services.NewClientProjectMemberService(clientProjectMemberRepo, clientProjectRepo, systemUserRepo, cacheService, eventService)
+
+	projectTaskRepo := repositories.NewProjectTaskRepository(app.database)
+	projectTaskService := services.NewProjectTaskService(projectTaskRepo, clientProjectRepo, cacheService, eventService)
+
+	tagRepo := repositories.NewTagRepository(app.database)
+	tagService := services.NewTagService(tagRepo, cacheService, eventService)
+
+	timingRepo := repositories.NewTimingRepository(app.database)
+	budgetAlerter := services.NewLogBudgetAlerter(logging.Component(app.logger, "budget"), app.metrics)
+	budgetService := services.NewBudgetService(clientProjectRepo, timingRepo, clientProjectMemberRepo, cacheService, eventService, budgetAlerter, app.config.BudgetAlerts)
+	timingService := services.NewTimingService(timingRepo, clientProjectRepo, clientRepo, clientProjectMemberRepo, projectTaskRepo, tagRepo, budgetService, transactor, cacheService, eventService)
+
+	searchRepo := repositories.NewSearchRepository(app.database)
+	searchService := services.NewSearchService(searchRepo, cacheService)
+
+	reportService := services.NewReportService(timingRepo, clientProjectRepo, cacheService)
+
+	webhookRepo := repositories.NewWebhookRepository(app.database)
+	webhookDeliveryRepo := repositories.NewWebhookDeliveryRepository(app.database)
+	webhookService := services.NewWebhookService(webhookRepo, webhookDeliveryRepo, cacheService)
+	app.webhookSender = services.NewWebhookSender(webhookDeliveryRepo, webhookRepo, services.NewWebhookClient(), app.config.Webhooks, logging.Component(app.logger, "webhooks"))
 
 	// #endregion Initialize repositories and services
 
+	// #region Subscribe Event Handlers
+	app.eventDispatcher.Subscribe(&services.SystemUserEventHandlerSessions{CacheService: cacheService}, services.SystemUserSessionEvents...)
+	app.eventDispatcher.Subscribe(&services.WebhookEventHandlerDeliveries{WebhookRepository: webhookRepo, WebhookDeliveryRepository: webhookDeliveryRepo})
+	// #endregion Subscribe Event Handlers
+
+	// #region Register Business Gauges
+	// Göstergeler her scrape'te değil GaugeInterval aralıklarla hesaplanır
+	timingsRunning := app.metrics.RegisterGauge("timings_running", "Number of timings currently in Started status.", app.config.GaugeInterval, func(ctx context.Context) float64 {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		result := timingRepo.CountByStatus(models.NewSystemContext(ctx), enum.StatusStarted)
+		if !result.IsSuccess() {
+			return math.NaN()
+		}
+		return float64(result.ReturnObject.(int64))
+	})
+	app.businessGauges = append(app.businessGauges, timingsRunning)
+	// #endregion Register Business Gauges
+
 	// #region Add Routes
+	// Hata mesajları Accept-Language veya kullanıcının dil ayarına göre çevrilir
+	app.router.Use(controllers.LanguageMiddleware())
+
 	// Korumasız rotalar
-	openRoutes := router.Group("/")
+	openRoutes := app.router.Group("/")
+	routers.HealthRoutes(openRoutes, app.healthService)
+	routers.MetricsRoutes(openRoutes, app.metrics.Handler())
 	routers.NonProtectedRoutes(openRoutes, systemUserService)
+	routers.DocsRoutes(openRoutes, routers.OpenAPIDocument(Version))
 
 	// Korunan rotalar
-	protectedRoutes := router.Group("/")
-	protectedRoutes.Use(authenticationMiddleware())
+	protectedRoutes := app.router.Group("/")
+	protectedRoutes.Use(authenticationMiddleware(cacheService, logging.Component(app.logger, "auth")), controllers.UserLanguageMiddleware(cacheService))
 	routers.SystemUserRoutes(protectedRoutes, systemUserService)
 	routers.SystemUserSettingRoutes(protectedRoutes, systemUserSettingService)
 	routers.ClientRoutes(protectedRoutes, clientService)
 	routers.ClientProjectRoutes(protectedRoutes, clientProjectService)
 	routers.TimingRoutes(protectedRoutes, timingService)
+	routers.SearchRoutes(protectedRoutes, searchService)
+
+	// /api/v1 rotaları: REST kaynakları, HTTP durum kodları ve Problem Details hataları
+	v1Routes := app.router.Group("/api/v1", controllers.ProblemDetailsMiddleware())
+	routers.NonProtectedRoutesV1(v1Routes, systemUserService)
+
+	v1ProtectedRoutes := v1Routes.Group("/")
+	v1ProtectedRoutes.Use(authenticationMiddleware(cacheService, logging.Component(app.logger, "auth")), controllers.UserLanguageMiddleware(cacheService))
+	routers.SystemUserRoutesV1(v1ProtectedRoutes, systemUserService)
+	routers.SystemUserSettingRoutesV1(v1ProtectedRoutes, systemUserSettingService)
+	routers.ClientRoutesV1(v1ProtectedRoutes, clientService)
+	routers.ClientContactRoutesV1(v1ProtectedRoutes, clientContactService)
+	routers.ClientProjectRoutesV1(v1ProtectedRoutes, clientProjectService)
+	routers.ClientProjectMemberRoutesV1(v1ProtectedRoutes, clientProjectMemberService)
+	routers.ProjectTaskRoutesV1(v1ProtectedRoutes, projectTaskService)
+	routers.BudgetRoutesV1(v1ProtectedRoutes, budgetService)
+	routers.TimingRoutesV1(v1ProtectedRoutes, timingService)
+	routers.TagRoutesV1(v1ProtectedRoutes, tagService)
+	routers.SearchRoutesV1(v1ProtectedRoutes, searchService)
+	routers.ReportRoutesV1(v1ProtectedRoutes, reportService)
+	routers.WebhookRoutesV1(v1ProtectedRoutes, webhookService)
 	// #endregion Add Routes
 }
 
-func authenticationMiddleware() gin.HandlerFunc {
+// #region Middleware
+
+func authenticationMiddleware(cacheService services.CacheService, logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
 		// #region Get User Token
 		userToken := c.GetHeader("X-Token")
-		log.Println("Gelen Token:", userToken)
 
 		if len(userToken) == 0 {
-			log.Println("HATA: Token Header boş.")
-			c.JSON(http.StatusUnauthorized, lgo.NewAuthError())
-			c.Abort()
-			return
-		}
-
-		authResult := services.CacheService.AuthenticateSystemUser(userToken)
-		if authResult == nil || !authResult.IsSuccess() {
-			log.Println("HATA: AuthenticateSystemUser başarısız.")
-			c.JSON(http.StatusUnauthorized, lgo.NewAuthError())
-			c.Abort()
+			logger.WarnContext(ctx, "authentication failed: token header is empty")
+			controllers.AbortWithResult(c, http.StatusUnauthorized, lgo.NewAuthError())
 			return
 		}
+		// #endregion Get User Token
 
-		if !authResult.ReturnObject.(bool) {
-			log.Println("HATA: Token geçersiz.")
-			c.JSON(http.StatusUnauthorized, lgo.NewAuthError())
-			c.Abort()
+		// #region Get Principal
+		spanCtx, span := tracing.Start(ctx, "authenticationMiddleware.GetSystemUserCredential")
+		lookupContext := models.NewContext(c).WithContext(spanCtx)
+		lookupContext.Token = userToken
+		credentialResult := cacheService.GetSystemUserCredential(lookupContext, userToken)
+		span.End()
+		if credentialResult == nil || !credentialResult.IsSuccess() {
+			logger.WarnContext(ctx, "authentication failed: token is invalid, expired or could not be resolved")
+			controllers.AbortWithResult(c, http.StatusUnauthorized, lgo.NewAuthError())
 			return
 		}
-		// #endregion Get User Token
+		// #endregion Get Principal
 
 		// #region Set Context
-		c.Set("usertoken", userToken)
-		log.Println("Başarılı Token:", userToken)
+		c.Set(models.TokenKey, userToken)
+		c.Set(models.PrincipalKey, credentialResult.ReturnObject.(*mvc.SystemUserCredential))
+		logger.DebugContext(ctx, "authentication succeeded")
 		// #endregion Set Context
 
 		c.Next()
@@ -0,0 +1,249 @@
+package application
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"lms-web-services-main/database/datasources"
+	"lms-web-services-main/logging"
+	"lms-web-services-main/services"
+	"lms-web-services-main/tracing"
+)
+
+type Config struct {
+	ServerAddress   string
+	CORSOrigins     []string
+	ShutdownDelay   time.Duration // Readiness düştükten sonra yeni istekleri kesmeden önce beklenecek süre
+	ShutdownTimeout time.Duration // Açık isteklerin tamamlanması için tanınan azami süre
+	RequestTimeout  time.Duration // Rotaya özel süre tanımlanmamış isteklerin azami süresi
+	RouteTimeouts   map[string]time.Duration
+	BudgetAlerts    []int         // Proje bütçesi uyarı eşikleri (yüzde), örn. 80 ve 100
+	GaugeInterval   time.Duration // Veritabanından hesaplanan iş göstergelerinin yenilenme aralığı
+	Events          services.EventDispatcherConfig
+	Webhooks        services.WebhookSenderConfig
+	Logging         logging.Config
+	Tracing         tracing.Config
+	Database        datasources.DatabaseConfig
+	Cache           datasources.CacheConfig
+}
+
+// LoadConfig, uygulama ayarlarını ortam değişkenlerinden okur
+func LoadConfig() (*Config, error) {
+	cacheDB, err := strconv.Atoi(getEnv("LMS_REDIS_DB", "0"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LMS_REDIS_DB: %w", err)
+	}
+
+	shutdownDelay, err := time.ParseDuration(getEnv("LMS_SHUTDOWN_DELAY", "0s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LMS_SHUTDOWN_DELAY: %w", err)
+	}
+
+	shutdownTimeout, err := time.ParseDuration(getEnv("LMS_SHUTDOWN_TIMEOUT", "15s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LMS_SHUTDOWN_TIMEOUT: %w", err)
+	}
+
+	requestTimeout, err := time.ParseDuration(getEnv("LMS_REQUEST_TIMEOUT", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LMS_REQUEST_TIMEOUT: %w", err)
+	}
+
+	routeTimeouts, err := parseRouteTimeouts(os.Getenv("LMS_ROUTE_TIMEOUTS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LMS_ROUTE_TIMEOUTS: %w", err)
+	}
+
+	budgetAlerts, err := parseBudgetAlerts(getEnv("LMS_BUDGET_ALERT_THRESHOLDS", "80,100"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LMS_BUDGET_ALERT_THRESHOLDS: %w", err)
+	}
+
+	var gaugeInterval time.Duration
+	if err := loadDurations([]durationSetting{{"LMS_METRICS_GAUGE_INTERVAL", "30s", &gaugeInterval}}); err != nil {
+		return nil, err
+	}
+
+	eventsConfig, err := loadEventDispatcherConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	webhooksConfig, err := loadWebhookSenderConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	loggingConfig, err := logging.ParseConfig(
+		getEnv("LMS_LOG_FORMAT", "json"),
+		getEnv("LMS_LOG_LEVEL", "info"),
+		getEnv("LMS_LOG_LEVELS", "db=warn"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tracingExporters, err := tracing.ParseExporters(getEnv("LMS_TRACING_EXPORTERS", "none"))
+	if err != nil {
+		return nil, err
+	}
+
+	tracingSampleRatio, err := strconv.ParseFloat(getEnv("LMS_TRACING_SAMPLE_RATIO", "1"), 64)
+	if err != nil || tracingSampleRatio < 0 || tracingSampleRatio > 1 {
+		return nil, fmt.Errorf("invalid LMS_TRACING_SAMPLE_RATIO: must be between 0 and 1")
+	}
+
+	config := &Config{
+		ServerAddress:   getEnv("LMS_HTTP_ADDRESS", ":8080"),
+		CORSOrigins:     strings.Split(getEnv("LMS_CORS_ORIGINS", "http://localhost:5173"), ","),
+		ShutdownDelay:   shutdownDelay,
+		ShutdownTimeout: shutdownTimeout,
+		RequestTimeout:  requestTimeout,
+		RouteTimeouts:   routeTimeouts,
+		BudgetAlerts:    budgetAlerts,
+		GaugeInterval:   gaugeInterval,
+		Events:          eventsConfig,
+		Webhooks:        webhooksConfig,
+		Logging:         loggingConfig,
+		Tracing: tracing.Config{
+			Exporters:   tracingExporters,
+			SampleRatio: tracingSampleRatio,
+		},
+		Database: datasources.DatabaseConfig{
+			Host:     os.Getenv("LMS_DB_HOST"),
+			User:     os.Getenv("LMS_DB_USER"),
+			Password: os.Getenv("LMS_DB_PASSWORD"),
+			Name:     os.Getenv("LMS_DB_NAME"),
+			Port:     os.Getenv("LMS_DB_PORT"),
+			SSLMode:  os.Getenv("LMS_DB_SSLMODE"),
+		},
+		Cache: datasources.CacheConfig{
+			Address:  os.Getenv("LMS_REDIS_ADDRESS"),
+			Password: os.Getenv("LMS_REDIS_PASSWORD"),
+			DB:       cacheDB,
+		},
+	}
+
+	if config.Database.Host == "" || config.Database.Name == "" {
+		return nil, fmt.Errorf("LMS_DB_HOST and LMS_DB_NAME must be set")
+	}
+	if config.Cache.Address == "" {
+		return nil, fmt.Errorf("LMS_REDIS_ADDRESS must be set")
+	}
+
+	return config, nil
+}
+
+func getEnv(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// loadEventDispatcherConfig, giden kutusu dağıtım ayarlarını LMS_EVENTS_* değişkenlerinden okur
+func loadEventDispatcherConfig() (services.EventDispatcherConfig, error) {
+	config := services.EventDispatcherConfig{}
+
+	err := loadDurations([]durationSetting{
+		{"LMS_EVENTS_POLL_INTERVAL", "1s", &config.PollInterval},
+		{"LMS_EVENTS_RETRY_DELAY", "5s", &config.RetryDelay},
+		{"LMS_EVENTS_MAX_RETRY_DELAY", "10m", &config.MaxRetryDelay},
+		{"LMS_EVENTS_LEASE", "1m", &config.Lease},
+	})
+	if err != nil {
+		return config, err
+	}
+
+	err = loadCounts([]countSetting{
+		{"LMS_EVENTS_BATCH_SIZE", "100", &config.BatchSize},
+		{"LMS_EVENTS_MAX_ATTEMPTS", "10", &config.MaxAttempts},
+	})
+	return config, err
+}
+
+// loadWebhookSenderConfig, webhook gönderim ayarlarını LMS_WEBHOOKS_* değişkenlerinden okur.
+// Varsayılanlarla başarısız bir gönderim yaklaşık bir gün boyunca denenir.
+func loadWebhookSenderConfig() (services.WebhookSenderConfig, error) {
+	config := services.WebhookSenderConfig{}
+
+	err := loadDurations([]durationSetting{
+		{"LMS_WEBHOOKS_POLL_INTERVAL", "1s", &config.PollInterval},
+		{"LMS_WEBHOOKS_RETRY_DELAY", "30s", &config.RetryDelay},
+		{"LMS_WEBHOOKS_MAX_RETRY_DELAY", "6h", &config.MaxRetryDelay},
+		{"LMS_WEBHOOKS_LEASE", "2m", &config.Lease},
+		{"LMS_WEBHOOKS_TIMEOUT", "10s", &config.Timeout},
+	})
+	if err != nil {
+		return config, err
+	}
+
+	err = loadCounts([]countSetting{
+		{"LMS_WEBHOOKS_BATCH_SIZE", "50", &config.BatchSize},
+		{"LMS_WEBHOOKS_MAX_ATTEMPTS", "12", &config.MaxAttempts},
+	})
+	if err != nil {
+		return config, err
+	}
+
+	// Parti eşzamanlı gönderildiği için en uzun sürecek parti bir isteğin süre aşımı kadardır;
+	// bu süre dolmadan gönderimler başka bir işçiye verilmemelidir
+	if config.Lease <= config.Timeout {
+		return config, fmt.Errorf("invalid LMS_WEBHOOKS_LEASE: must be longer than LMS_WEBHOOKS_TIMEOUT")
+	}
+	return config, nil
+}
+
+// durationSetting ve countSetting, pozitif olması gereken süre ve sayı ayarlarıdır
+type durationSetting struct {
+	key      string
+	fallback string
+	target   *time.Duration
+}
+
+type countSetting struct {
+	key      string
+	fallback string
+	target   *int
+}
+
+func loadDurations(settings []durationSetting) error {
+	for _, setting := range settings {
+		value, err := time.ParseDuration(getEnv(setting.key, setting.fallback))
+		if err != nil || value <= 0 {
+			return fmt.Errorf("invalid %s: expected a positive duration", setting.key)
+		}
+		*setting.target = value
+	}
+	return nil
+}
+
+func loadCounts(settings []countSetting) error {
+	for _, setting := range settings {
+		value, err := strconv.Atoi(getEnv(setting.key, setting.fallback))
+		if err != nil || value <= 0 {
+			return fmt.Errorf("invalid %s: expected a positive number", setting.key)
+		}
+		*setting.target = value
+	}
+	return nil
+}
+
+// parseBudgetAlerts, virgülle ayrılmış yüzde eşiklerini okur ("80,100"). "none" uyarıları kapatır.
+func parseBudgetAlerts(value string) ([]int, error) {
+	var thresholds []int
+	if strings.TrimSpace(value) == "none" {
+		return thresholds, nil
+	}
+	for _, entry := range strings.Split(value, ",") {
+		threshold, err := strconv.Atoi(strings.TrimSpace(entry))
+		if err != nil || threshold <= 0 {
+			return nil, fmt.Errorf("invalid threshold %q: expected a positive percent", entry)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	return thresholds, nil
+}
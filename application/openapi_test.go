@@ -0,0 +1,89 @@
+package application
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lms-web-services-main/metrics"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/routers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRoutesOnlyApplication, veritabanı ve Redis bağlantısı olmadan yalnızca rotaları kaydeder
+func newRoutesOnlyApplication(t *testing.T) *Application {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	app := &Application{
+		config:  &Config{},
+		router:  gin.New(),
+		metrics: metrics.New(),
+		logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	app.addRoutes()
+	return app
+}
+
+func TestOpenAPIDocumentCoversAllRoutes(t *testing.T) {
+	app := newRoutesOnlyApplication(t)
+	doc := routers.OpenAPIDocument(Version)
+
+	registered := map[string]bool{}
+	for _, route := range app.router.Routes() {
+		registered[route.Method+" "+openapi.PathOf(route.Path)] = true
+		if !doc.HasRoute(route.Method, route.Path) {
+			t.Errorf("route %s %s is not documented in the OpenAPI document", route.Method, route.Path)
+		}
+	}
+
+	for _, route := range doc.Routes() {
+		if !registered[route] {
+			t.Errorf("OpenAPI document describes %s but no such gin route is registered", route)
+		}
+	}
+}
+
+func TestOpenAPIDocumentIsServed(t *testing.T) {
+	app := newRoutesOnlyApplication(t)
+
+	recorder := httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json returned %d", recorder.Code)
+	}
+
+	var spec struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas         map[string]json.RawMessage `json:"schemas"`
+			SecuritySchemes map[string]struct {
+				In   string `json:"in"`
+				Name string `json:"name"`
+			} `json:"securitySchemes"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if spec.OpenAPI == "" {
+		t.Error("openapi version is missing")
+	}
+	if _, ok := spec.Components.Schemas["OperationResult"]; !ok {
+		t.Error("OperationResult envelope schema is missing")
+	}
+	if scheme := spec.Components.SecuritySchemes[openapi.TokenSecurityScheme]; scheme.In != "header" || scheme.Name != "X-Token" {
+		t.Errorf("X-Token security scheme = %+v", scheme)
+	}
+
+	recorder = httptest.NewRecorder()
+	app.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /docs returned %d", recorder.Code)
+	}
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// timeoutMiddleware, isteğin context'ine rotaya özgü bir son tarih ekler. Süre dolduğunda
+// context iptal edilir ve devam eden veritabanı ve Redis çağrıları kesilir. routeTimeouts
+// anahtarları "METHOD /rota/:param" biçimindedir; eşleşmeyen rotalar defaultTimeout kullanır.
+func timeoutMiddleware(defaultTimeout time.Duration, routeTimeouts map[string]time.Duration, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		timeout, ok := routeTimeouts[route]
+		if !ok {
+			timeout = defaultTimeout
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			logger.WarnContext(ctx, "request deadline exceeded", slog.String("route", route), slog.Duration("timeout", timeout))
+		}
+	}
+}
+
+// parseRouteTimeouts, "GET /timings/all=60s,POST /login=5s" biçimindeki değeri çözümler
+func parseRouteTimeouts(value string) (map[string]time.Duration, error) {
+	routeTimeouts := map[string]time.Duration{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, duration, found := strings.Cut(entry, "=")
+		method, path, hasPath := strings.Cut(strings.TrimSpace(route), " ")
+		if !found || !hasPath {
+			return nil, fmt.Errorf("invalid route timeout %q: expected \"METHOD /path=duration\"", entry)
+		}
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil {
+			return nil, fmt.Errorf("invalid route timeout %q: %w", entry, err)
+		}
+		routeTimeouts[strings.ToUpper(method)+" "+strings.TrimSpace(path)] = timeout
+	}
+	return routeTimeouts, nil
+}
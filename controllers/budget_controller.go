@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BudgetController struct {
+	service services.BudgetService
+}
+
+func NewBudgetController(service services.BudgetService) *BudgetController {
+	return &BudgetController{service: service}
+}
+
+// #region Get Budget Status
+func (ctrl *BudgetController) GetStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetStatus(id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Budget Status
@@ -0,0 +1,56 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+)
+
+func TestBudgetControllerV1(t *testing.T) {
+	tests := []struct {
+		name string
+		deny string
+		path string // {id}, bütçeli projenin kimliğiyle değiştirilir
+		want response
+	}{
+		{name: "get", path: "/api/v1/client-projects/{id}/budget-status", want: response{status: http.StatusOK}},
+		{name: "get missing", path: "/api/v1/client-projects/99/budget-status", want: response{http.StatusNotFound, i18n.ClientProjectNotFound}},
+		{name: "get invalid id", path: "/api/v1/client-projects/abc/budget-status", want: response{http.StatusBadRequest, i18n.InvalidIdFormat}},
+		{name: "get forbidden", deny: datamodels.CLIENTPROJECTS_VIEW, path: "/api/v1/client-projects/{id}/budget-status", want: response{http.StatusForbidden, i18n.Forbidden}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, datamodels.CLIENTPROJECTS_VIEW)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			timing := s.addTiming(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+
+			test.want.check(t, s.do(t, http.MethodGet, withId(test.path, timing.ClientProjectId), nil))
+		})
+	}
+}
+
+func TestBudgetControllerV1Status(t *testing.T) {
+	s := newServer(t, datamodels.CLIENTPROJECTS_VIEW, datamodels.TIMINGS_ADD)
+	timing := s.addTiming(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	project := s.repos.ClientProjects.GetById(nil, timing.ClientProjectId).ReturnObject.(*datamodels.ClientProject)
+	project.BudgetHours = 1.5
+	project.EnforceBudget = true
+	s.repos.ClientProjects.Update(nil, project)
+
+	var status mvc.BudgetStatus
+	decode(t, s.do(t, http.MethodGet, withId("/api/v1/client-projects/{id}/budget-status", project.Id), nil), &status)
+	if status.ConsumedHours != 1 || status.RemainingHours != 0.5 || status.ConsumedPercent != 66.67 || status.Exhausted {
+		t.Fatalf("want one of 1.5 hours consumed, got %+v", status)
+	}
+
+	start := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+	entry := map[string]any{"cpid": project.Id, "suid": s.user.Id, "t": "Toplantı", "sdt": start, "edt": start.Add(time.Hour)}
+	response{http.StatusConflict, i18n.ProjectBudgetExceeded}.check(t, s.do(t, http.MethodPost, "/api/v1/timings", entry))
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// #region Client Contact Controller Definition
+
+// ClientContactController, /clients/:id/contacts rotalarını karşılar. Müşteri ID'si her
+// zaman yoldan alınır; gövdedeki "cid" yok sayılır.
+type ClientContactController struct {
+	service services.ClientContactService
+}
+
+func NewClientContactController(service services.ClientContactService) *ClientContactController {
+	return &ClientContactController{service: service}
+}
+
+//#endregion Client Contact Controller Definition
+
+// #region Create Client Contact
+func (ctrl *ClientContactController) Create(c *gin.Context) {
+	var contact data.ClientContact
+	if err := c.ShouldBindJSON(&contact); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	contact.Id = 0
+	if !bindClientId(c, &contact.ClientId) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Create(&contact, context)
+	writeResult(c, http.StatusCreated, result)
+}
+
+//#endregion Create Client Contact
+
+// #region Update Client Contact
+func (ctrl *ClientContactController) Update(c *gin.Context) {
+	var contact data.ClientContact
+	if err := c.ShouldBindJSON(&contact); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	if !bindClientId(c, &contact.ClientId) || !bindContactId(c, &contact.Id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Update(&contact, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Update Client Contact
+
+// #region Delete Client Contact
+func (ctrl *ClientContactController) Delete(c *gin.Context) {
+	var clientId, id int
+	if !bindClientId(c, &clientId) || !bindContactId(c, &id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Delete(clientId, id, context)
+	writeResult(c, http.StatusNoContent, result)
+}
+
+//#endregion Delete Client Contact
+
+// #region Get Client Contact By Id
+func (ctrl *ClientContactController) GetById(c *gin.Context) {
+	var clientId, id int
+	if !bindClientId(c, &clientId) || !bindContactId(c, &id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetById(clientId, id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Client Contact By Id
+
+// #region Get Client Contacts By ClientId
+func (ctrl *ClientContactController) GetByClientId(c *gin.Context) {
+	var clientId int
+	if !bindClientId(c, &clientId) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetByClientId(clientId, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Client Contacts By ClientId
+
+// bindClientId, müşteri ID'sini /clients/:id yolundan okur
+func bindClientId(c *gin.Context, clientId *int) bool {
+	value, err := strconv.Atoi(c.Param("id"))
+	if err != nil || value <= 0 {
+		writeBadRequest(c, i18n.InvalidClientIdFormat)
+		return false
+	}
+	*clientId = value
+	return true
+}
+
+// bindContactId, yetkili ID'sini /clients/:id/contacts/:contactId yolundan okur
+func bindContactId(c *gin.Context, id *int) bool {
+	value, err := strconv.Atoi(c.Param("contactId"))
+	if err != nil || value <= 0 {
+		writeBadRequest(c, i18n.InvalidContactIdFormat)
+		return false
+	}
+	*id = value
+	return true
+}
@@ -0,0 +1,56 @@
+package controllers_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+)
+
+func TestClientContactControllerV1(t *testing.T) {
+	permissions := []string{datamodels.CLIENTS_VIEW, datamodels.CLIENTS_UPDATE}
+	valid := map[string]any{"n": "Can Demir", "r": "Muhasebe", "e": "can@example.com", "ph": "+90 216 000 00 00"}
+
+	tests := []struct {
+		name   string
+		deny   string
+		method string
+		path   string // {id} müşterinin, {contactId} yetkilinin kimliğiyle değiştirilir
+		body   any
+		want   response
+	}{
+		{name: "create", method: http.MethodPost, path: "/api/v1/clients/{id}/contacts", body: valid, want: response{status: http.StatusCreated}},
+		{name: "create invalid", method: http.MethodPost, path: "/api/v1/clients/{id}/contacts", body: map[string]any{"n": "", "e": "can"}, want: response{http.StatusUnprocessableEntity, i18n.InvalidFields}},
+		{name: "create for a missing client", method: http.MethodPost, path: "/api/v1/clients/99/contacts", body: valid, want: response{http.StatusNotFound, i18n.ClientNotFound}},
+		{name: "create forbidden", deny: datamodels.CLIENTS_UPDATE, method: http.MethodPost, path: "/api/v1/clients/{id}/contacts", body: valid, want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "list", method: http.MethodGet, path: "/api/v1/clients/{id}/contacts", want: response{status: http.StatusOK}},
+		{name: "list invalid client id", method: http.MethodGet, path: "/api/v1/clients/abc/contacts", want: response{http.StatusBadRequest, i18n.InvalidClientIdFormat}},
+		{name: "get", method: http.MethodGet, path: "/api/v1/clients/{id}/contacts/{contactId}", want: response{status: http.StatusOK}},
+		{name: "get invalid id", method: http.MethodGet, path: "/api/v1/clients/{id}/contacts/abc", want: response{http.StatusBadRequest, i18n.InvalidContactIdFormat}},
+		{name: "get through another client", method: http.MethodGet, path: "/api/v1/clients/{other}/contacts/{contactId}", want: response{http.StatusNotFound, i18n.ClientContactNotFound}},
+		{name: "update", method: http.MethodPut, path: "/api/v1/clients/{id}/contacts/{contactId}", body: valid, want: response{status: http.StatusOK}},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/clients/{id}/contacts/{contactId}", want: response{status: http.StatusNoContent}},
+		{name: "delete forbidden", deny: datamodels.CLIENTS_UPDATE, method: http.MethodDelete, path: "/api/v1/clients/{id}/contacts/{contactId}", want: response{http.StatusForbidden, i18n.Forbidden}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			client := &datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş."}
+			s.repos.Clients.Create(nil, client)
+			other := &datamodels.Client{ShortTitle: "GLOBEX", Title: "Globex"}
+			s.repos.Clients.Create(nil, other)
+			contact := &datamodels.ClientContact{ClientId: client.Id, Name: "Ada Yılmaz"}
+			s.repos.ClientContacts.Create(nil, contact)
+
+			path := strings.NewReplacer("{id}", fmt.Sprint(client.Id), "{other}", fmt.Sprint(other.Id), "{contactId}", fmt.Sprint(contact.Id)).Replace(test.path)
+			test.want.check(t, s.do(t, test.method, path, test.body))
+		})
+	}
+}
@@ -4,12 +4,12 @@ import (
 	"net/http"
 	"strconv"
 
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
 	"lms-web-services-main/models/data"
 	"lms-web-services-main/models/mvc"
 	"lms-web-services-main/services"
 
-	"github.com/LGYtech/lgo"
 	"github.com/gin-gonic/gin"
 )
 
@@ -29,13 +29,13 @@ func (ctrl *ClientController) Create(c *gin.Context) {
 
 	var client data.Client
 	if err := c.ShouldBindJSON(&client); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Create(&client, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusCreated, result)
 }
 
 //#endregion Create Client
@@ -45,13 +45,16 @@ func (ctrl *ClientController) Update(c *gin.Context) {
 
 	var client data.Client
 	if err := c.ShouldBindJSON(&client); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	if !bindPathId(c, &client.Id) {
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Update(&client, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Update Client
@@ -61,13 +64,22 @@ func (ctrl *ClientController) Delete(c *gin.Context) {
 
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+	// force=true, bağlı kayıtları da siler ve silinenlerin sayılarını döndürür
+	force, ok := bindForce(c)
+	if !ok {
 		return
 	}
 
 	context := models.NewContext(c)
+	if force {
+		writeResult(c, http.StatusOK, ctrl.service.ForceDelete(id, context))
+		return
+	}
 	result := ctrl.service.Delete(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusNoContent, result)
 }
 
 //#endregion Delete Client
@@ -77,13 +89,13 @@ func (ctrl *ClientController) GetById(c *gin.Context) {
 
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetById(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get Client By Id
@@ -92,14 +104,50 @@ func (ctrl *ClientController) GetById(c *gin.Context) {
 func (ctrl *ClientController) GetAll(c *gin.Context) {
 	var query mvc.QueryModel
 	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
 		return
 	}
+	// archived, arşivlenmiş müşterilerin listeye girip girmeyeceğidir; varsayılan olarak gizlenirler
+	archived, ok := bindArchivedFilter(c)
+	if !ok {
+		return
+	}
+	query.Archived = archived
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetAll(&query, context)
 
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get All Clients
+
+// #region Archive Client
+func (ctrl *ClientController) Archive(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Archive(id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Archive Client
+
+// #region Unarchive Client
+func (ctrl *ClientController) Unarchive(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Unarchive(id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Unarchive Client
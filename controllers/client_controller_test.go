@@ -0,0 +1,106 @@
+package controllers_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+)
+
+func TestClientControllerV1(t *testing.T) {
+	permissions := []string{datamodels.CLIENTS_VIEW, datamodels.CLIENTS_ADD, datamodels.CLIENTS_UPDATE, datamodels.CLIENTS_DELETE, datamodels.CLIENTS_FORCE_DELETE}
+	valid := map[string]any{"st": "ACME", "t": "ACME A.Ş."}
+
+	tests := []struct {
+		name   string
+		deny   string
+		method string
+		path   string // {id}, kayıtlı müşterinin kimliğiyle değiştirilir
+		body   any
+		want   response
+	}{
+		{name: "create", method: http.MethodPost, path: "/api/v1/clients", body: valid, want: response{status: http.StatusCreated}},
+		{name: "create invalid", method: http.MethodPost, path: "/api/v1/clients", body: map[string]any{"st": ""}, want: response{http.StatusUnprocessableEntity, i18n.InvalidFields}},
+		{name: "create malformed", method: http.MethodPost, path: "/api/v1/clients", body: "[", want: response{http.StatusBadRequest, i18n.InvalidRequest}},
+		{name: "create forbidden", deny: datamodels.CLIENTS_ADD, method: http.MethodPost, path: "/api/v1/clients", body: valid, want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "list", method: http.MethodGet, path: "/api/v1/clients?pn=1&rpp=10", want: response{status: http.StatusOK}},
+		{name: "list archived", method: http.MethodGet, path: "/api/v1/clients?pn=1&rpp=10&archived=all", want: response{status: http.StatusOK}},
+		{name: "list invalid archived", method: http.MethodGet, path: "/api/v1/clients?pn=1&rpp=10&archived=yes", want: response{http.StatusBadRequest, i18n.InvalidArchivedFilter}},
+		{name: "list invalid page", method: http.MethodGet, path: "/api/v1/clients?pn=0&rpp=10", want: response{http.StatusBadRequest, i18n.QueryInvalidPage}},
+		{name: "get", method: http.MethodGet, path: "/api/v1/clients/{id}", want: response{status: http.StatusOK}},
+		{name: "get missing", method: http.MethodGet, path: "/api/v1/clients/99", want: response{http.StatusNotFound, i18n.ClientNotFound}},
+		{name: "get invalid id", method: http.MethodGet, path: "/api/v1/clients/abc", want: response{http.StatusBadRequest, i18n.InvalidIdFormat}},
+		{name: "update", method: http.MethodPut, path: "/api/v1/clients/{id}", body: valid, want: response{status: http.StatusOK}},
+		{name: "update missing", method: http.MethodPut, path: "/api/v1/clients/99", body: valid, want: response{http.StatusNotFound, i18n.ClientNotFound}},
+		{name: "archive", method: http.MethodPost, path: "/api/v1/clients/{id}/archive", want: response{status: http.StatusOK}},
+		{name: "archive missing", method: http.MethodPost, path: "/api/v1/clients/99/archive", want: response{http.StatusNotFound, i18n.ClientNotFound}},
+		{name: "archive forbidden", deny: datamodels.CLIENTS_UPDATE, method: http.MethodPost, path: "/api/v1/clients/{id}/archive", want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "unarchive", method: http.MethodPost, path: "/api/v1/clients/{id}/unarchive", want: response{status: http.StatusOK}},
+		{name: "unarchive invalid id", method: http.MethodPost, path: "/api/v1/clients/abc/unarchive", want: response{http.StatusBadRequest, i18n.InvalidIdFormat}},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/clients/{id}", want: response{status: http.StatusNoContent}},
+		{name: "force delete", method: http.MethodDelete, path: "/api/v1/clients/{id}?force=true", want: response{status: http.StatusOK}},
+		{name: "force delete forbidden", deny: datamodels.CLIENTS_FORCE_DELETE, method: http.MethodDelete, path: "/api/v1/clients/{id}?force=true", want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "delete invalid force", method: http.MethodDelete, path: "/api/v1/clients/{id}?force=maybe", want: response{http.StatusBadRequest, i18n.InvalidRequest}},
+		{name: "delete forbidden", deny: datamodels.CLIENTS_DELETE, method: http.MethodDelete, path: "/api/v1/clients/{id}", want: response{http.StatusForbidden, i18n.Forbidden}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			client := &datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş."}
+			s.repos.Clients.Create(nil, client)
+
+			test.want.check(t, s.do(t, test.method, withId(test.path, client.Id), test.body))
+		})
+	}
+}
+
+func TestClientControllerV1ReportsInvalidFields(t *testing.T) {
+	s := newServer(t, datamodels.CLIENTS_ADD)
+
+	details := problem(t, s.do(t, http.MethodPost, "/api/v1/clients", map[string]any{"st": "ACME"}))
+	if _, ok := details.Errors["t"]; !ok || len(details.Errors) != 1 {
+		t.Fatalf("want a single error for \"t\", got %v", details.Errors)
+	}
+}
+
+func TestClientControllerV1DeleteListsReferences(t *testing.T) {
+	s := newServer(t, datamodels.CLIENTS_DELETE, datamodels.CLIENTS_FORCE_DELETE)
+	client := &datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş.", IsActive: true}
+	s.repos.Clients.Create(nil, client)
+	s.repos.ClientProjects.Create(nil, &datamodels.ClientProject{ClientId: client.Id, Name: "Web sitesi", IsActive: true})
+	s.repos.ClientProjects.Create(nil, &datamodels.ClientProject{ClientId: client.Id, Name: "Mobil uygulama", IsActive: true})
+
+	recorder := s.do(t, http.MethodDelete, withId("/api/v1/clients/{id}", client.Id), nil)
+	response{http.StatusConflict, i18n.ClientHasReferences}.check(t, recorder)
+	if details := problem(t, recorder); len(details.Errors) != 1 || details.Errors["cps"] == "" {
+		t.Fatalf("want the projects listed, got %v", details.Errors)
+	}
+
+	var references mvc.ForeignReferences
+	decode(t, s.do(t, http.MethodDelete, withId("/api/v1/clients/{id}?force=true", client.Id), nil), &references)
+	if references.ClientProjects != 2 {
+		t.Fatalf("want the removed projects counted, got %+v", references)
+	}
+}
+
+func TestClientControllerLegacy(t *testing.T) {
+	s := newServer(t, datamodels.CLIENTS_VIEW)
+
+	result := legacyResult(t, s.do(t, http.MethodGet, "/clients/99", nil))
+	if result["ec"] != float64(2) || result["em"] == "" {
+		t.Fatalf("want a not found error with a message, got %v", result)
+	}
+}
+
+// withId, yoldaki {id} yer tutucusunu kimlikle değiştirir
+func withId(path string, id any) string {
+	return strings.Replace(path, "{id}", fmt.Sprint(id), 1)
+}
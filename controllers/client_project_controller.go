@@ -4,12 +4,12 @@ import (
 	"net/http"
 	"strconv"
 
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	"lms-web-services-main/models/mvc"
 	"lms-web-services-main/services"
 
-	"github.com/LGYtech/lgo"
 	"github.com/gin-gonic/gin"
 )
 
@@ -25,13 +25,13 @@ func NewClientProjectController(service services.ClientProjectService) *ClientPr
 func (ctrl *ClientProjectController) Create(c *gin.Context) {
 	var clientProject datamodels.ClientProject
 	if err := c.ShouldBindJSON(&clientProject); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Create(&clientProject, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusCreated, result)
 }
 
 //#endregion Create ClientProject
@@ -40,13 +40,16 @@ func (ctrl *ClientProjectController) Create(c *gin.Context) {
 func (ctrl *ClientProjectController) Update(c *gin.Context) {
 	var clientProject datamodels.ClientProject
 	if err := c.ShouldBindJSON(&clientProject); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	if !bindPathId(c, &clientProject.Id) {
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Update(&clientProject, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Update ClientProject
@@ -55,13 +58,22 @@ func (ctrl *ClientProjectController) Update(c *gin.Context) {
 func (ctrl *ClientProjectController) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+	// force=true, bağlı kayıtları da siler ve silinenlerin sayılarını döndürür
+	force, ok := bindForce(c)
+	if !ok {
 		return
 	}
 
 	context := models.NewContext(c)
+	if force {
+		writeResult(c, http.StatusOK, ctrl.service.ForceDelete(id, context))
+		return
+	}
 	result := ctrl.service.Delete(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusNoContent, result)
 }
 
 //#endregion Delete ClientProject
@@ -70,13 +82,13 @@ func (ctrl *ClientProjectController) Delete(c *gin.Context) {
 func (ctrl *ClientProjectController) GetById(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetById(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get ClientProject By Id
@@ -86,12 +98,33 @@ func (ctrl *ClientProjectController) GetAll(c *gin.Context) {
 	// İstekten QueryModel'i oluştur
 	var query mvc.QueryModel
 	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	// mine=true, listeyi oturum açmış kullanıcının üyesi olduğu projelerle sınırlar
+	mine := false
+	if value := c.Query("mine"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			writeBadRequest(c, i18n.InvalidRequest, err.Error())
+			return
+		}
+		mine = parsed
+	}
+	// archived, arşivlenmiş projelerin listeye girip girmeyeceğidir; varsayılan olarak gizlenirler
+	archived, ok := bindArchivedFilter(c)
+	if !ok {
 		return
 	}
+	query.Archived = archived
+
 	context := models.NewContext(c)
+	if mine {
+		writeResult(c, http.StatusOK, ctrl.service.GetMine(&query, context))
+		return
+	}
 	result := ctrl.service.GetAll(&query, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get All ClientProjects
@@ -100,13 +133,47 @@ func (ctrl *ClientProjectController) GetAll(c *gin.Context) {
 func (ctrl *ClientProjectController) GetByClientId(c *gin.Context) {
 	clientId, err := strconv.Atoi(c.Param("clientId"))
 	if err != nil || clientId <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz Client ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidClientIdFormat)
+		return
+	}
+	archived, ok := bindArchivedFilter(c)
+	if !ok {
 		return
 	}
 
 	context := models.NewContext(c)
-	result := ctrl.service.GetByClientId(clientId, context)
-	c.JSON(http.StatusOK, result)
+	result := ctrl.service.GetByClientId(clientId, archived, context)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get ClientProjects By ClientId
+
+// #region Archive ClientProject
+func (ctrl *ClientProjectController) Archive(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Archive(id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Archive ClientProject
+
+// #region Unarchive ClientProject
+func (ctrl *ClientProjectController) Unarchive(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Unarchive(id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Unarchive ClientProject
@@ -0,0 +1,130 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+)
+
+func TestClientProjectControllerV1(t *testing.T) {
+	permissions := []string{datamodels.CLIENTPROJECTS_VIEW, datamodels.CLIENTPROJECTS_ADD, datamodels.CLIENTPROJECTS_UPDATE, datamodels.CLIENTPROJECTS_DELETE, datamodels.CLIENTPROJECTS_FORCE_DELETE}
+
+	tests := []struct {
+		name   string
+		deny   string
+		method string
+		path   string // {id}, kayıtlı projenin kimliğiyle değiştirilir
+		body   func(project *datamodels.ClientProject) any
+		want   response
+	}{
+		{
+			name: "create", method: http.MethodPost, path: "/api/v1/client-projects",
+			body: func(project *datamodels.ClientProject) any {
+				return map[string]any{"cid": project.ClientId, "n": "Mobil"}
+			},
+			want: response{status: http.StatusCreated},
+		},
+		{
+			name: "create without client", method: http.MethodPost, path: "/api/v1/client-projects",
+			body: func(*datamodels.ClientProject) any { return map[string]any{"n": "Mobil"} },
+			want: response{http.StatusUnprocessableEntity, i18n.InvalidFields},
+		},
+		{
+			name: "update", method: http.MethodPut, path: "/api/v1/client-projects/{id}",
+			body: func(*datamodels.ClientProject) any { return map[string]any{"n": "Yeni ad", "ia": true} },
+			want: response{status: http.StatusOK},
+		},
+		{
+			name: "update forbidden", deny: datamodels.CLIENTPROJECTS_UPDATE, method: http.MethodPut, path: "/api/v1/client-projects/{id}",
+			body: func(*datamodels.ClientProject) any { return map[string]any{"n": "Yeni ad"} },
+			want: response{http.StatusForbidden, i18n.Forbidden},
+		},
+		{name: "get", method: http.MethodGet, path: "/api/v1/client-projects/{id}", want: response{status: http.StatusOK}},
+		{name: "get missing", method: http.MethodGet, path: "/api/v1/client-projects/99", want: response{http.StatusNotFound, i18n.ClientProjectNotFound}},
+		{name: "list", method: http.MethodGet, path: "/api/v1/client-projects?pn=1&rpp=5", want: response{status: http.StatusOK}},
+		{name: "list mine", method: http.MethodGet, path: "/api/v1/client-projects?mine=true&pn=1&rpp=5", want: response{status: http.StatusOK}},
+		{name: "list mine invalid", method: http.MethodGet, path: "/api/v1/client-projects?mine=maybe", want: response{http.StatusBadRequest, i18n.InvalidRequest}},
+		{name: "list archived", method: http.MethodGet, path: "/api/v1/client-projects?archived=true&pn=1&rpp=5", want: response{status: http.StatusOK}},
+		{name: "list invalid archived", method: http.MethodGet, path: "/api/v1/client-projects?archived=maybe", want: response{http.StatusBadRequest, i18n.InvalidArchivedFilter}},
+		{name: "list by client", method: http.MethodGet, path: "/api/v1/clients/1/projects", want: response{status: http.StatusOK}},
+		{name: "list by client invalid archived", method: http.MethodGet, path: "/api/v1/clients/1/projects?archived=maybe", want: response{http.StatusBadRequest, i18n.InvalidArchivedFilter}},
+		{name: "archive", method: http.MethodPost, path: "/api/v1/client-projects/{id}/archive", want: response{status: http.StatusOK}},
+		{name: "archive missing", method: http.MethodPost, path: "/api/v1/client-projects/99/archive", want: response{http.StatusNotFound, i18n.ClientProjectNotFound}},
+		{name: "unarchive", method: http.MethodPost, path: "/api/v1/client-projects/{id}/unarchive", want: response{status: http.StatusOK}},
+		{name: "list by invalid client", method: http.MethodGet, path: "/api/v1/clients/x/projects", want: response{http.StatusBadRequest, i18n.InvalidClientIdFormat}},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/client-projects/{id}", want: response{status: http.StatusNoContent}},
+		{name: "force delete", method: http.MethodDelete, path: "/api/v1/client-projects/{id}?force=true", want: response{status: http.StatusOK}},
+		{name: "force delete forbidden", deny: datamodels.CLIENTPROJECTS_FORCE_DELETE, method: http.MethodDelete, path: "/api/v1/client-projects/{id}?force=1", want: response{http.StatusForbidden, i18n.Forbidden}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			client := &datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş.", IsActive: true}
+			s.repos.Clients.Create(nil, client)
+			project := &datamodels.ClientProject{ClientId: client.Id, Name: "Web sitesi", IsActive: true}
+			s.repos.ClientProjects.Create(nil, project)
+
+			var body any
+			if test.body != nil {
+				body = test.body(project)
+			}
+			test.want.check(t, s.do(t, test.method, withId(test.path, project.Id), body))
+		})
+	}
+}
+
+func TestClientProjectControllerV1ListsMine(t *testing.T) {
+	s := newServer(t, datamodels.CLIENTPROJECTS_VIEW)
+	client := &datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş."}
+	s.repos.Clients.Create(nil, client)
+	mine := &datamodels.ClientProject{ClientId: client.Id, Name: "Web sitesi", IsActive: true}
+	s.repos.ClientProjects.Create(nil, mine)
+	s.repos.ClientProjects.Create(nil, &datamodels.ClientProject{ClientId: client.Id, Name: "Mobil uygulama", IsActive: true})
+	s.repos.ClientProjectMembers.Create(nil, &datamodels.ClientProjectMember{ClientProjectId: mine.Id, SystemUserId: s.user.Id})
+
+	var page mvc.PagedResult[*datamodels.ClientProject]
+	decode(t, s.do(t, http.MethodGet, "/api/v1/client-projects?mine=true&pn=1&rpp=10", nil), &page)
+	if page.TotalCount != 1 || page.Items[0].Id != mine.Id {
+		t.Fatalf("want only the project the user is a member of, got %+v", page.Items)
+	}
+}
+
+func TestClientProjectControllerV1UnarchiveUnderArchivedClient(t *testing.T) {
+	s := newServer(t, datamodels.CLIENTPROJECTS_UPDATE)
+	client := &datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş.", IsActive: true}
+	s.repos.Clients.Create(nil, client)
+	project := &datamodels.ClientProject{ClientId: client.Id, Name: "Web sitesi", IsActive: true}
+	s.repos.ClientProjects.Create(nil, project)
+	s.repos.Clients.Archive(nil, client.Id, time.Now())
+
+	response{http.StatusConflict, i18n.ClientArchived}.check(t, s.do(t, http.MethodPost, withId("/api/v1/client-projects/{id}/unarchive", project.Id), nil))
+}
+
+func TestClientProjectControllerV1DeleteListsReferences(t *testing.T) {
+	s := newServer(t, datamodels.CLIENTPROJECTS_DELETE)
+	project := s.addTiming(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)).ClientProjectId
+
+	recorder := s.do(t, http.MethodDelete, withId("/api/v1/client-projects/{id}", project), nil)
+	response{http.StatusConflict, i18n.ClientProjectHasReferences}.check(t, recorder)
+	details := problem(t, recorder)
+	if len(details.Errors) != 2 || details.Errors["tms"] == "" || details.Errors["cpms"] == "" {
+		t.Fatalf("want the timing and the membership listed, got %v", details.Errors)
+	}
+}
+
+func TestClientProjectControllerLegacy(t *testing.T) {
+	s := newServer(t, datamodels.CLIENTPROJECTS_ADD)
+
+	result := legacyResult(t, s.do(t, http.MethodPost, "/client-projects/create", map[string]any{"n": "Mobil"}))
+	if result["ec"] != float64(1) {
+		t.Fatalf("want a validation error, got %v", result)
+	}
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// #region Client Project Member Controller Definition
+
+// ClientProjectMemberController, /client-projects/:id/members rotalarını karşılar. Proje ID'si
+// her zaman yoldan alınır; gövdedeki "cpid" yok sayılır.
+type ClientProjectMemberController struct {
+	service services.ClientProjectMemberService
+}
+
+func NewClientProjectMemberController(service services.ClientProjectMemberService) *ClientProjectMemberController {
+	return &ClientProjectMemberController{service: service}
+}
+
+//#endregion Client Project Member Controller Definition
+
+// #region Create Client Project Member
+func (ctrl *ClientProjectMemberController) Create(c *gin.Context) {
+	var member data.ClientProjectMember
+	if err := c.ShouldBindJSON(&member); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	member.Id = 0
+	if !bindClientProjectId(c, &member.ClientProjectId) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Create(&member, context)
+	writeResult(c, http.StatusCreated, result)
+}
+
+//#endregion Create Client Project Member
+
+// #region Update Client Project Member
+func (ctrl *ClientProjectMemberController) Update(c *gin.Context) {
+	var member data.ClientProjectMember
+	if err := c.ShouldBindJSON(&member); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	if !bindClientProjectId(c, &member.ClientProjectId) || !bindMemberId(c, &member.Id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Update(&member, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Update Client Project Member
+
+// #region Delete Client Project Member
+func (ctrl *ClientProjectMemberController) Delete(c *gin.Context) {
+	var clientProjectId, id int
+	if !bindClientProjectId(c, &clientProjectId) || !bindMemberId(c, &id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Delete(clientProjectId, id, context)
+	writeResult(c, http.StatusNoContent, result)
+}
+
+//#endregion Delete Client Project Member
+
+// #region Get Client Project Member By Id
+func (ctrl *ClientProjectMemberController) GetById(c *gin.Context) {
+	var clientProjectId, id int
+	if !bindClientProjectId(c, &clientProjectId) || !bindMemberId(c, &id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetById(clientProjectId, id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Client Project Member By Id
+
+// #region Get Client Project Members By ClientProjectId
+func (ctrl *ClientProjectMemberController) GetByClientProjectId(c *gin.Context) {
+	var clientProjectId int
+	if !bindClientProjectId(c, &clientProjectId) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetByClientProjectId(clientProjectId, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Client Project Members By ClientProjectId
+
+// bindClientProjectId, proje ID'sini /client-projects/:id yolundan okur
+func bindClientProjectId(c *gin.Context, clientProjectId *int) bool {
+	value, err := strconv.Atoi(c.Param("id"))
+	if err != nil || value <= 0 {
+		writeBadRequest(c, i18n.InvalidClientProjectIdFormat)
+		return false
+	}
+	*clientProjectId = value
+	return true
+}
+
+// bindMemberId, üyelik ID'sini /client-projects/:id/members/:memberId yolundan okur
+func bindMemberId(c *gin.Context, id *int) bool {
+	value, err := strconv.Atoi(c.Param("memberId"))
+	if err != nil || value <= 0 {
+		writeBadRequest(c, i18n.InvalidMemberIdFormat)
+		return false
+	}
+	*id = value
+	return true
+}
@@ -0,0 +1,90 @@
+package controllers_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+)
+
+func TestClientProjectMemberControllerV1(t *testing.T) {
+	permissions := []string{datamodels.CLIENTPROJECTS_VIEW, datamodels.CLIENTPROJECTS_UPDATE}
+
+	tests := []struct {
+		name   string
+		deny   string
+		method string
+		path   string // {id} projenin, {other} başka bir projenin, {memberId} üyeliğin kimliğiyle değiştirilir
+		body   func(s *server, grace *datamodels.SystemUser) any
+		want   response
+	}{
+		{
+			name: "create", method: http.MethodPost, path: "/api/v1/client-projects/{id}/members",
+			body: func(_ *server, grace *datamodels.SystemUser) any {
+				return map[string]any{"suid": grace.Id, "r": "Geliştirici", "hr": 150}
+			},
+			want: response{status: http.StatusCreated},
+		},
+		{
+			name: "create invalid", method: http.MethodPost, path: "/api/v1/client-projects/{id}/members",
+			body: func(_ *server, grace *datamodels.SystemUser) any { return map[string]any{"suid": grace.Id, "hr": -1} },
+			want: response{http.StatusUnprocessableEntity, i18n.InvalidFields},
+		},
+		{
+			name: "create an existing member", method: http.MethodPost, path: "/api/v1/client-projects/{id}/members",
+			body: func(s *server, _ *datamodels.SystemUser) any { return map[string]any{"suid": s.user.Id} },
+			want: response{http.StatusConflict, i18n.MemberExists},
+		},
+		{
+			name: "create for a missing project", method: http.MethodPost, path: "/api/v1/client-projects/99/members",
+			body: func(_ *server, grace *datamodels.SystemUser) any { return map[string]any{"suid": grace.Id} },
+			want: response{http.StatusNotFound, i18n.ClientProjectNotFound},
+		},
+		{
+			name: "create forbidden", deny: datamodels.CLIENTPROJECTS_UPDATE, method: http.MethodPost, path: "/api/v1/client-projects/{id}/members",
+			body: func(_ *server, grace *datamodels.SystemUser) any { return map[string]any{"suid": grace.Id} },
+			want: response{http.StatusForbidden, i18n.Forbidden},
+		},
+		{name: "list", method: http.MethodGet, path: "/api/v1/client-projects/{id}/members", want: response{status: http.StatusOK}},
+		{name: "list invalid project id", method: http.MethodGet, path: "/api/v1/client-projects/abc/members", want: response{http.StatusBadRequest, i18n.InvalidClientProjectIdFormat}},
+		{name: "get", method: http.MethodGet, path: "/api/v1/client-projects/{id}/members/{memberId}", want: response{status: http.StatusOK}},
+		{name: "get invalid id", method: http.MethodGet, path: "/api/v1/client-projects/{id}/members/abc", want: response{http.StatusBadRequest, i18n.InvalidMemberIdFormat}},
+		{name: "get through another project", method: http.MethodGet, path: "/api/v1/client-projects/{other}/members/{memberId}", want: response{http.StatusNotFound, i18n.MemberNotFound}},
+		{
+			name: "update", method: http.MethodPut, path: "/api/v1/client-projects/{id}/members/{memberId}",
+			body: func(*server, *datamodels.SystemUser) any { return map[string]any{"r": "Proje yöneticisi", "hr": 200} },
+			want: response{status: http.StatusOK},
+		},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/client-projects/{id}/members/{memberId}", want: response{status: http.StatusNoContent}},
+		{name: "delete forbidden", deny: datamodels.CLIENTPROJECTS_UPDATE, method: http.MethodDelete, path: "/api/v1/client-projects/{id}/members/{memberId}", want: response{http.StatusForbidden, i18n.Forbidden}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			client := &datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş."}
+			s.repos.Clients.Create(nil, client)
+			project := &datamodels.ClientProject{ClientId: client.Id, Name: "Web sitesi"}
+			s.repos.ClientProjects.Create(nil, project)
+			other := &datamodels.ClientProject{ClientId: client.Id, Name: "Mobil uygulama"}
+			s.repos.ClientProjects.Create(nil, other)
+			member := &datamodels.ClientProjectMember{ClientProjectId: project.Id, SystemUserId: s.user.Id}
+			s.repos.ClientProjectMembers.Create(nil, member)
+			grace := &datamodels.SystemUser{Name: "Grace", Surname: "Hopper", Email: "grace@example.com", Password: "hash", PasswordSalt: "salt", IsActive: true}
+			s.repos.SystemUsers.Create(nil, grace)
+
+			var body any
+			if test.body != nil {
+				body = test.body(s, grace)
+			}
+			path := strings.NewReplacer("{id}", fmt.Sprint(project.Id), "{other}", fmt.Sprint(other.Id), "{memberId}", fmt.Sprint(member.Id)).Replace(test.path)
+			test.want.check(t, s.do(t, test.method, path, body))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"lms-web-services-main/models"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const readinessTimeout = 2 * time.Second
+
+type HealthController struct {
+	service services.HealthService
+}
+
+func NewHealthController(service services.HealthService) *HealthController {
+	return &HealthController{service: service}
+}
+
+// #region Liveness
+func (ctrl *HealthController) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, ctrl.service.Liveness())
+}
+
+//#endregion Liveness
+
+// #region Readiness
+func (ctrl *HealthController) Readiness(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	result := ctrl.service.Readiness(models.NewContext(c).WithContext(ctx))
+	if !result.IsSuccess() {
+		c.JSON(http.StatusServiceUnavailable, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+//#endregion Readiness
@@ -0,0 +1,48 @@
+package controllers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthController(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		databaseError error
+		want          int
+	}{
+		{name: "liveness", path: "/healthz", want: http.StatusOK},
+		{name: "readiness", path: "/readyz", want: http.StatusOK},
+		{name: "readiness without database", path: "/readyz", databaseError: errors.New("connection refused"), want: http.StatusServiceUnavailable},
+		{name: "liveness without database", path: "/healthz", databaseError: errors.New("connection refused"), want: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t)
+			s.repos.Health.DatabaseError = test.databaseError
+
+			// Sağlık uçları kimlik doğrulaması istemez; istek X-Token olmadan gönderilir
+			recorder := httptest.NewRecorder()
+			s.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.path, nil))
+			if recorder.Code != test.want {
+				t.Fatalf("want %d, got %d: %s", test.want, recorder.Code, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestProtectedRoutesRequireToken(t *testing.T) {
+	s := newServer(t)
+
+	for _, path := range []string{"/api/v1/clients?pn=1&rpp=10", "/api/v1/timings/1", "/api/v1/search?q=acme"} {
+		recorder := httptest.NewRecorder()
+		s.router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("%s: want 401, got %d: %s", path, recorder.Code, recorder.Body)
+		}
+	}
+}
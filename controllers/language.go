@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/services"
+
+	"github.com/LGYtech/lgo"
+	"github.com/gin-gonic/gin"
+)
+
+// Hata mesajlarının dilini belirleyen gin anahtarları
+const (
+	languageKey     = "language"
+	userLanguageKey = "userlanguage"
+)
+
+// userLanguageResolver, kullanıcının dil ayarını okur. Ayar yalnızca bir hata mesajı
+// çevrileceğinde okunur; başarılı isteklerde önbelleğe gidilmez.
+type userLanguageResolver func() (i18n.Language, bool)
+
+// LanguageMiddleware, Accept-Language başlığındaki desteklenen dili isteğe kaydeder
+func LanguageMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if language, ok := i18n.FromAcceptLanguage(c.GetHeader("Accept-Language")); ok {
+			c.Set(languageKey, language)
+		}
+		c.Next()
+	}
+}
+
+// UserLanguageMiddleware, oturum açmış kullanıcının "system.language" ayarını
+// Accept-Language başlığından öncelikli kılar. Kimlik doğrulamadan sonra eklenmelidir.
+func UserLanguageMiddleware(cacheService services.CacheService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			resolved bool
+			language i18n.Language
+			found    bool
+		)
+		c.Set(userLanguageKey, userLanguageResolver(func() (i18n.Language, bool) {
+			if !resolved {
+				resolved = true
+				result := cacheService.GetSystemUserSetting(models.NewContext(c), data.SYSTEM_LANGUAGE)
+				if result.IsSuccess() {
+					if value, ok := result.ReturnObject.(string); ok {
+						language, found = i18n.Parse(value)
+					}
+				}
+			}
+			return language, found
+		}))
+		c.Next()
+	}
+}
+
+// requestLanguage, isteğin dilini sırasıyla kullanıcı ayarından, Accept-Language
+// başlığından veya varsayılan dilden belirler
+func requestLanguage(c *gin.Context) i18n.Language {
+	if value, ok := c.Get(userLanguageKey); ok {
+		if language, found := value.(userLanguageResolver)(); found {
+			return language
+		}
+	}
+	if value, ok := c.Get(languageKey); ok {
+		return value.(i18n.Language)
+	}
+	return i18n.Default
+}
+
+// localize, hatalı sonucun mesajını isteğin diline çevirir ve mesaj kodunu ReturnObject
+// içinde taşır. Sonucun kendisi değiştirilmez; çevrilmiş bir kopya döner.
+func localize(c *gin.Context, result *lgo.OperationResult) *lgo.OperationResult {
+	if result.IsSuccess() {
+		return result
+	}
+
+	localized := *result
+	switch result.Result {
+	case mvc.ResultAuthError:
+		localized.ReturnObject = i18n.New(i18n.Unauthenticated)
+	case mvc.ResultAutoError:
+		// Eski istemciler eksik yetkinin anahtarını "em" alanından okur
+		localized.ReturnObject = i18n.New(i18n.Forbidden, result.ErrorMessage)
+		c.Header("Content-Language", string(requestLanguage(c)))
+		return &localized
+	}
+
+	message, ok := localized.ReturnObject.(*i18n.Message)
+	if !ok {
+		return result
+	}
+	language := requestLanguage(c)
+	localized.ErrorMessage = message.Text(language)
+	c.Header("Content-Language", string(language))
+	return &localized
+}
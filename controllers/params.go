@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"strconv"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// bindPathId, /api/v1 güncelleme rotalarında (PUT /clients/:id) kaydın ID'sini yoldan alır;
+// gövdedeki ID yok sayılır. Yolda ID yoksa (eski rotalar) gövdedeki değer kullanılır.
+func bindPathId(c *gin.Context, id *int) bool {
+	raw := c.Param("id")
+	if raw == "" {
+		return true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return false
+	}
+	*id = value
+	return true
+}
+
+// bindPathUUID, bindPathId'nin UUID kimlikli kayıtlar için olanıdır
+func bindPathUUID(c *gin.Context, id *uuid.UUID) bool {
+	raw := c.Param("id")
+	if raw == "" {
+		return true
+	}
+	value, err := uuid.Parse(raw)
+	if err != nil {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return false
+	}
+	*id = value
+	return true
+}
+
+// bindArchivedFilter, müşteri ve proje listelerindeki "archived" parametresini okur
+func bindArchivedFilter(c *gin.Context) (mvc.ArchivedFilter, bool) {
+	archived, ok := mvc.ParseArchivedFilter(c.Query("archived"))
+	if !ok {
+		writeBadRequest(c, i18n.InvalidArchivedFilter)
+		return "", false
+	}
+	return archived, true
+}
+
+// bindForce, silme rotalarındaki "force" parametresini okur. force=true, kaydın bağlı
+// kayıtlarıyla birlikte silinmesini ister.
+func bindForce(c *gin.Context) (bool, bool) {
+	value := c.Query("force")
+	if value == "" {
+		return false, true
+	}
+	force, err := strconv.ParseBool(value)
+	if err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return false, false
+	}
+	return force, true
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// #region Project Task Controller Definition
+
+// ProjectTaskController, /client-projects/:id/tasks rotalarını karşılar. Proje ID'si
+// her zaman yoldan alınır; gövdedeki "cpid" yok sayılır.
+type ProjectTaskController struct {
+	service services.ProjectTaskService
+}
+
+func NewProjectTaskController(service services.ProjectTaskService) *ProjectTaskController {
+	return &ProjectTaskController{service: service}
+}
+
+//#endregion Project Task Controller Definition
+
+// #region Create Project Task
+func (ctrl *ProjectTaskController) Create(c *gin.Context) {
+	var task data.ProjectTask
+	if err := c.ShouldBindJSON(&task); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	task.Id = 0
+	if !bindClientProjectId(c, &task.ClientProjectId) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Create(&task, context)
+	writeResult(c, http.StatusCreated, result)
+}
+
+//#endregion Create Project Task
+
+// #region Update Project Task
+func (ctrl *ProjectTaskController) Update(c *gin.Context) {
+	var task data.ProjectTask
+	if err := c.ShouldBindJSON(&task); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	if !bindClientProjectId(c, &task.ClientProjectId) || !bindTaskId(c, &task.Id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Update(&task, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Update Project Task
+
+// #region Delete Project Task
+func (ctrl *ProjectTaskController) Delete(c *gin.Context) {
+	var clientProjectId, id int
+	if !bindClientProjectId(c, &clientProjectId) || !bindTaskId(c, &id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Delete(clientProjectId, id, context)
+	writeResult(c, http.StatusNoContent, result)
+}
+
+//#endregion Delete Project Task
+
+// #region Get Project Task By Id
+func (ctrl *ProjectTaskController) GetById(c *gin.Context) {
+	var clientProjectId, id int
+	if !bindClientProjectId(c, &clientProjectId) || !bindTaskId(c, &id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetById(clientProjectId, id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Project Task By Id
+
+// #region Get Project Tasks By ClientProjectId
+func (ctrl *ProjectTaskController) GetByClientProjectId(c *gin.Context) {
+	var clientProjectId int
+	if !bindClientProjectId(c, &clientProjectId) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetByClientProjectId(clientProjectId, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Project Tasks By ClientProjectId
+
+// bindTaskId, görev ID'sini /client-projects/:id/tasks/:taskId yolundan okur
+func bindTaskId(c *gin.Context, id *int) bool {
+	value, err := strconv.Atoi(c.Param("taskId"))
+	if err != nil || value <= 0 {
+		writeBadRequest(c, i18n.InvalidTaskIdFormat)
+		return false
+	}
+	*id = value
+	return true
+}
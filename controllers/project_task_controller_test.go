@@ -0,0 +1,59 @@
+package controllers_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+)
+
+func TestProjectTaskControllerV1(t *testing.T) {
+	permissions := []string{datamodels.CLIENTPROJECTS_VIEW, datamodels.CLIENTPROJECTS_UPDATE}
+
+	tests := []struct {
+		name   string
+		deny   string
+		method string
+		path   string // {id} projenin, {other} başka bir projenin, {taskId} görevin kimliğiyle değiştirilir
+		body   any
+		want   response
+	}{
+		{name: "create", method: http.MethodPost, path: "/api/v1/client-projects/{id}/tasks", body: map[string]any{"n": "Backend", "eh": 16}, want: response{status: http.StatusCreated}},
+		{name: "create invalid", method: http.MethodPost, path: "/api/v1/client-projects/{id}/tasks", body: map[string]any{"n": "Backend", "eh": -1}, want: response{http.StatusUnprocessableEntity, i18n.InvalidFields}},
+		{name: "create an existing name", method: http.MethodPost, path: "/api/v1/client-projects/{id}/tasks", body: map[string]any{"n": "Tasarım"}, want: response{http.StatusConflict, i18n.TaskExists}},
+		{name: "create for a missing project", method: http.MethodPost, path: "/api/v1/client-projects/99/tasks", body: map[string]any{"n": "Backend"}, want: response{http.StatusNotFound, i18n.ClientProjectNotFound}},
+		{name: "create forbidden", deny: datamodels.CLIENTPROJECTS_UPDATE, method: http.MethodPost, path: "/api/v1/client-projects/{id}/tasks", body: map[string]any{"n": "Backend"}, want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "list", method: http.MethodGet, path: "/api/v1/client-projects/{id}/tasks", want: response{status: http.StatusOK}},
+		{name: "list invalid project id", method: http.MethodGet, path: "/api/v1/client-projects/abc/tasks", want: response{http.StatusBadRequest, i18n.InvalidClientProjectIdFormat}},
+		{name: "get", method: http.MethodGet, path: "/api/v1/client-projects/{id}/tasks/{taskId}", want: response{status: http.StatusOK}},
+		{name: "get invalid id", method: http.MethodGet, path: "/api/v1/client-projects/{id}/tasks/abc", want: response{http.StatusBadRequest, i18n.InvalidTaskIdFormat}},
+		{name: "get through another project", method: http.MethodGet, path: "/api/v1/client-projects/{other}/tasks/{taskId}", want: response{http.StatusNotFound, i18n.TaskNotFound}},
+		{name: "update", method: http.MethodPut, path: "/api/v1/client-projects/{id}/tasks/{taskId}", body: map[string]any{"n": "Tasarım", "eh": 24, "st": 1}, want: response{status: http.StatusOK}},
+		{name: "update invalid status", method: http.MethodPut, path: "/api/v1/client-projects/{id}/tasks/{taskId}", body: map[string]any{"n": "Tasarım", "st": 9}, want: response{http.StatusUnprocessableEntity, i18n.InvalidFields}},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/client-projects/{id}/tasks/{taskId}", want: response{status: http.StatusNoContent}},
+		{name: "delete forbidden", deny: datamodels.CLIENTPROJECTS_UPDATE, method: http.MethodDelete, path: "/api/v1/client-projects/{id}/tasks/{taskId}", want: response{http.StatusForbidden, i18n.Forbidden}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			client := &datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş."}
+			s.repos.Clients.Create(nil, client)
+			project := &datamodels.ClientProject{ClientId: client.Id, Name: "Web sitesi"}
+			s.repos.ClientProjects.Create(nil, project)
+			other := &datamodels.ClientProject{ClientId: client.Id, Name: "Mobil uygulama"}
+			s.repos.ClientProjects.Create(nil, other)
+			task := &datamodels.ProjectTask{ClientProjectId: project.Id, Name: "Tasarım"}
+			s.repos.ProjectTasks.Create(nil, task)
+
+			path := strings.NewReplacer("{id}", fmt.Sprint(project.Id), "{other}", fmt.Sprint(other.Id), "{taskId}", fmt.Sprint(task.Id)).Replace(test.path)
+			test.want.check(t, s.do(t, test.method, path, test.body))
+		})
+	}
+}
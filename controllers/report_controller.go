@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportController struct {
+	service services.ReportService
+}
+
+func NewReportController(service services.ReportService) *ReportController {
+	return &ReportController{service: service}
+}
+
+// #region Get Task Report
+func (ctrl *ReportController) GetTaskReport(c *gin.Context) {
+	var query mvc.ReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetTaskReport(&query, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Task Report
+
+// #region Get Tag Report
+func (ctrl *ReportController) GetTagReport(c *gin.Context) {
+	var query mvc.ReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetTagReport(&query, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Tag Report
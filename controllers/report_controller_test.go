@@ -0,0 +1,73 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+)
+
+func TestReportControllerV1(t *testing.T) {
+	tests := []struct {
+		name string
+		deny string
+		path string // {id}, kaydı olan projenin kimliğiyle değiştirilir
+		want response
+	}{
+		{name: "tasks", path: "/api/v1/reports/tasks?cpid={id}", want: response{status: http.StatusOK}},
+		{name: "tasks in a range", path: "/api/v1/reports/tasks?cpid={id}&startDate=2024-03-01T00:00:00Z&endDate=2024-04-01T00:00:00Z", want: response{status: http.StatusOK}},
+		{name: "tasks without a project", path: "/api/v1/reports/tasks", want: response{http.StatusBadRequest, i18n.InvalidClientProjectId}},
+		{name: "tasks with an invalid date", path: "/api/v1/reports/tasks?cpid={id}&startDate=yesterday", want: response{http.StatusBadRequest, i18n.InvalidRequest}},
+		{name: "tasks for a missing project", path: "/api/v1/reports/tasks?cpid=99", want: response{http.StatusNotFound, i18n.ClientProjectNotFound}},
+		{name: "tasks forbidden", deny: datamodels.TIMINGS_VIEW, path: "/api/v1/reports/tasks?cpid={id}", want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "tags", path: "/api/v1/reports/tags", want: response{status: http.StatusOK}},
+		{name: "tags of a project", path: "/api/v1/reports/tags?cpid={id}", want: response{status: http.StatusOK}},
+		{name: "tags for a missing project", path: "/api/v1/reports/tags?cpid=99", want: response{http.StatusNotFound, i18n.ClientProjectNotFound}},
+		{name: "tags forbidden", deny: datamodels.TIMINGS_VIEW, path: "/api/v1/reports/tags", want: response{http.StatusForbidden, i18n.Forbidden}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, datamodels.TIMINGS_VIEW)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			timing := s.addTiming(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+
+			test.want.check(t, s.do(t, http.MethodGet, withId(test.path, timing.ClientProjectId), nil))
+		})
+	}
+}
+
+func TestReportControllerV1TaskReport(t *testing.T) {
+	s := newServer(t, datamodels.TIMINGS_VIEW, datamodels.TIMINGS_ADD)
+	timing := s.addTiming(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	task := &datamodels.ProjectTask{ClientProjectId: timing.ClientProjectId, Name: "Backend", EstimateHours: 4}
+	s.repos.ProjectTasks.Create(nil, task)
+
+	start := time.Date(2024, 3, 1, 13, 0, 0, 0, time.UTC)
+	entry := map[string]any{"cpid": timing.ClientProjectId, "suid": s.user.Id, "tid": task.Id, "t": "Geliştirme", "sdt": start, "edt": start.Add(2 * time.Hour)}
+	response{status: http.StatusCreated}.check(t, s.do(t, http.MethodPost, "/api/v1/timings", entry))
+
+	var report mvc.TaskReport
+	decode(t, s.do(t, http.MethodGet, withId("/api/v1/reports/tasks?cpid={id}", timing.ClientProjectId), nil), &report)
+	if len(report.Tasks) != 1 || report.Tasks[0].ActualHours != 2 || report.Tasks[0].ConsumedPercent != 50 || report.UnassignedHours != 1 {
+		t.Fatalf("want 2 of 4 hours on the task and 1 unassigned, got %+v", report)
+	}
+}
+
+func TestReportControllerV1TagReport(t *testing.T) {
+	s := newServer(t, datamodels.TIMINGS_VIEW, datamodels.TIMINGS_UPDATE)
+	timing := s.addTiming(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	tag := s.addTag(t, "Toplantı")
+	response{status: http.StatusOK}.check(t, s.do(t, http.MethodPut, withId("/api/v1/timings/{id}/tags", timing.Id), map[string]any{"tgs": []int{tag.Id}}))
+
+	var report mvc.TagReport
+	decode(t, s.do(t, http.MethodGet, "/api/v1/reports/tags", nil), &report)
+	if len(report.Tags) != 1 || report.Tags[0].Timings != 1 || report.Tags[0].Hours != 1 || report.UntaggedHours != 0 {
+		t.Fatalf("want the hour on the tag, got %+v", report)
+	}
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/logging"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"github.com/gin-gonic/gin"
+)
+
+// problemDetailsKey, isteğin /api/v1 kurallarıyla yanıtlanacağını belirten gin anahtarıdır
+const problemDetailsKey = "problemdetails"
+
+// ProblemDetailsMiddleware, gruptaki isteklerin REST kurallarıyla yanıtlanmasını sağlar:
+// başarılı yanıtlar ReturnObject'i doğrudan, hatalar RFC 9457 Problem Details olarak döner.
+func ProblemDetailsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(problemDetailsKey, true)
+		c.Next()
+	}
+}
+
+// writeResult, sonucu isteğin sürümüne göre yazar. Eski rotalarda OperationResult her
+// zaman 200 ile döner; /api/v1 rotalarında başarılı sonuç status ile, hata ise
+// sonucun türüne uygun durum koduyla döner.
+func writeResult(c *gin.Context, status int, result *lgo.OperationResult) {
+	if !c.GetBool(problemDetailsKey) {
+		c.JSON(http.StatusOK, localize(c, result))
+		return
+	}
+
+	if !result.IsSuccess() {
+		writeProblem(c, result)
+		return
+	}
+	if status == http.StatusNoContent {
+		c.Status(status)
+		return
+	}
+	c.JSON(status, result.ReturnObject)
+}
+
+// writeBadRequest, çözülemeyen istek gövdesi veya parametreleri için verilen mesaj koduyla
+// 400 döndürür
+func writeBadRequest(c *gin.Context, code string, args ...any) {
+	AbortWithResult(c, http.StatusBadRequest, mvc.NewLogicError(code, args...))
+}
+
+// AbortWithResult, isteği sonlandırır. Eski rotalarda OperationResult verilen status ile,
+// /api/v1 rotalarında Problem Details olarak yazılır.
+func AbortWithResult(c *gin.Context, status int, result *lgo.OperationResult) {
+	if !c.GetBool(problemDetailsKey) {
+		c.AbortWithStatusJSON(status, localize(c, result))
+		return
+	}
+	writeProblemWithStatus(c, status, result)
+	c.Abort()
+}
+
+func writeProblem(c *gin.Context, result *lgo.OperationResult) {
+	writeProblemWithStatus(c, StatusOf(result), result)
+}
+
+func writeProblemWithStatus(c *gin.Context, status int, result *lgo.OperationResult) {
+	problem := &mvc.ProblemDetails{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Instance:  c.Request.URL.Path,
+		Code:      i18n.Unexpected,
+		RequestID: c.GetString(logging.GinRequestIDKey),
+	}
+	if status >= http.StatusInternalServerError {
+		// Sistem hatalarının ayrıntısı (örn. veritabanı mesajları) istemciye gösterilmez
+		language := requestLanguage(c)
+		problem.Detail = i18n.Translate(language, i18n.Unexpected)
+		c.Header("Content-Language", string(language))
+	} else {
+		localized := localize(c, result)
+		problem.Detail = localized.ErrorMessage
+		if message, ok := localized.ReturnObject.(*i18n.Message); ok {
+			language := requestLanguage(c)
+			problem.Code = message.Code
+			problem.Detail = i18n.Translate(language, message.Code, message.Args...)
+			problem.Errors = message.FieldTexts(language)
+		}
+	}
+	c.Header("Content-Type", mvc.ProblemDetailsContentType)
+	c.JSON(status, problem)
+}
+
+// StatusOf, başarısız bir OperationResult'a karşılık gelen HTTP durum kodunu döndürür
+func StatusOf(result *lgo.OperationResult) int {
+	switch result.Result {
+	case mvc.ResultAuthError:
+		return http.StatusUnauthorized
+	case mvc.ResultAutoError:
+		return http.StatusForbidden
+	case mvc.ResultFailure:
+		return http.StatusInternalServerError
+	case mvc.ResultLogicError:
+		switch result.ErrorCode {
+		case mvc.ErrorCodeValidation:
+			return http.StatusUnprocessableEntity
+		case mvc.ErrorCodeNotFound:
+			return http.StatusNotFound
+		case mvc.ErrorCodeConflict:
+			return http.StatusConflict
+		}
+		return http.StatusBadRequest
+	}
+	return http.StatusOK
+}
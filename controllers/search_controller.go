@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchController struct {
+	service services.SearchService
+}
+
+func NewSearchController(service services.SearchService) *SearchController {
+	return &SearchController{service: service}
+}
+
+// #region Search
+func (ctrl *SearchController) Search(c *gin.Context) {
+	var query mvc.SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Search(&query, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Search
@@ -0,0 +1,45 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+)
+
+func TestSearchControllerV1(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		path        string
+		want        response
+		wantHits    int
+	}{
+		{name: "every permitted type", permissions: []string{datamodels.CLIENTS_VIEW, datamodels.CLIENTPROJECTS_VIEW, datamodels.TIMINGS_VIEW}, path: "/api/v1/search?q=acme", want: response{status: http.StatusOK}, wantHits: 1},
+		{name: "only permitted types", permissions: []string{datamodels.CLIENTPROJECTS_VIEW}, path: "/api/v1/search?q=acme", want: response{status: http.StatusOK}, wantHits: 0},
+		{name: "projects", permissions: []string{datamodels.CLIENTPROJECTS_VIEW}, path: "/api/v1/search?q=web&types=client_project", want: response{status: http.StatusOK}, wantHits: 1},
+		{name: "unknown type", permissions: []string{datamodels.CLIENTS_VIEW}, path: "/api/v1/search?q=acme&types=invoice", want: response{http.StatusUnprocessableEntity, i18n.SearchInvalidType}},
+		{name: "short term", permissions: []string{datamodels.CLIENTS_VIEW}, path: "/api/v1/search?q=a", want: response{http.StatusUnprocessableEntity, i18n.SearchTermTooShort}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, test.permissions...)
+			s.addTiming(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+
+			recorder := s.do(t, http.MethodGet, test.path, nil)
+			test.want.check(t, recorder)
+			if recorder.Code != http.StatusOK {
+				return
+			}
+			var hits []mvc.SearchHit
+			decode(t, recorder, &hits)
+			if len(hits) != test.wantHits {
+				t.Fatalf("want %d hits, got %+v", test.wantHits, hits)
+			}
+		})
+	}
+}
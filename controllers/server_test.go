@@ -0,0 +1,190 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/metrics"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories/memory"
+	"lms-web-services-main/routers"
+	"lms-web-services-main/services"
+
+	"github.com/LGYtech/lgo"
+	"github.com/gin-gonic/gin"
+)
+
+const testToken = "session-token"
+
+// server, bellekteki depolarla kurulmuş ve application.addRoutes'taki rotaları kaydeden
+// test sunucusudur. X-Token başlığı testToken olan istekler user olarak yetkilendirilir.
+type server struct {
+	repos  *memory.Repositories
+	router *gin.Engine
+	user   *datamodels.SystemUser
+}
+
+func newServer(t *testing.T, permissions ...string) *server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	repos := memory.NewRepositories()
+	s := &server{repos: repos, router: gin.New()}
+
+	s.user = &datamodels.SystemUser{Name: "Ada", Surname: "Yılmaz", Email: "ada@example.com", Password: "hash", PasswordSalt: "salt", IsActive: true}
+	repos.SystemUsers.Create(nil, s.user)
+	repos.Settings.Grant(s.user.Id, permissions...)
+	repos.Cache.RegisterSystemUserCredential(nil, testToken, s.user)
+
+	cacheService := services.NewCacheService(repos.Cache)
+	eventService := services.NewEventService(repos.Transactor, repos.Outbox)
+	settingService := services.NewSystemUserSettingService(repos.Settings, cacheService, eventService)
+	systemUserService := services.NewSystemUserService(repos.SystemUsers, settingService, cacheService, eventService, metrics.New())
+	clientService := services.NewClientService(repos.Clients, repos.Transactor, cacheService, eventService)
+	clientContactService := services.NewClientContactService(repos.ClientContacts, repos.Clients, cacheService, eventService)
+	clientProjectService := services.NewClientProjectService(repos.ClientProjects, repos.Clients, repos.Transactor, cacheService, eventService)
+	clientProjectMemberService := services.NewClientProjectMemberService(repos.ClientProjectMembers, repos.ClientProjects, repos.SystemUsers, cacheService, eventService)
+	projectTaskService := services.NewProjectTaskService(repos.ProjectTasks, repos.ClientProjects, cacheService, eventService)
+	tagService := services.NewTagService(repos.Tags, cacheService, eventService)
+	budgetAlerter := services.NewLogBudgetAlerter(slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.New())
+	budgetService := services.NewBudgetService(repos.ClientProjects, repos.Timings, repos.ClientProjectMembers, cacheService, eventService, budgetAlerter, []int{80, 100})
+	timingService := services.NewTimingService(repos.Timings, repos.ClientProjects, repos.Clients, repos.ClientProjectMembers, repos.ProjectTasks, repos.Tags, budgetService, repos.Transactor, cacheService, eventService)
+	searchService := services.NewSearchService(repos.Search, cacheService)
+	reportService := services.NewReportService(repos.Timings, repos.ClientProjects, cacheService)
+	webhookService := services.NewWebhookService(repos.Webhooks, repos.WebhookDeliveries, cacheService)
+	healthService := services.NewHealthService(repos.Health, "test")
+
+	s.router.Use(controllers.LanguageMiddleware())
+
+	openRoutes := s.router.Group("/")
+	routers.HealthRoutes(openRoutes, healthService)
+	routers.NonProtectedRoutes(openRoutes, systemUserService)
+
+	protectedRoutes := s.router.Group("/")
+	protectedRoutes.Use(authenticate(cacheService), controllers.UserLanguageMiddleware(cacheService))
+	routers.SystemUserRoutes(protectedRoutes, systemUserService)
+	routers.SystemUserSettingRoutes(protectedRoutes, settingService)
+	routers.ClientRoutes(protectedRoutes, clientService)
+	routers.ClientProjectRoutes(protectedRoutes, clientProjectService)
+	routers.TimingRoutes(protectedRoutes, timingService)
+	routers.SearchRoutes(protectedRoutes, searchService)
+
+	v1Routes := s.router.Group("/api/v1", controllers.ProblemDetailsMiddleware())
+	routers.NonProtectedRoutesV1(v1Routes, systemUserService)
+
+	v1ProtectedRoutes := v1Routes.Group("/")
+	v1ProtectedRoutes.Use(authenticate(cacheService), controllers.UserLanguageMiddleware(cacheService))
+	routers.SystemUserRoutesV1(v1ProtectedRoutes, systemUserService)
+	routers.SystemUserSettingRoutesV1(v1ProtectedRoutes, settingService)
+	routers.ClientRoutesV1(v1ProtectedRoutes, clientService)
+	routers.ClientContactRoutesV1(v1ProtectedRoutes, clientContactService)
+	routers.ClientProjectRoutesV1(v1ProtectedRoutes, clientProjectService)
+	routers.ClientProjectMemberRoutesV1(v1ProtectedRoutes, clientProjectMemberService)
+	routers.ProjectTaskRoutesV1(v1ProtectedRoutes, projectTaskService)
+	routers.BudgetRoutesV1(v1ProtectedRoutes, budgetService)
+	routers.TimingRoutesV1(v1ProtectedRoutes, timingService)
+	routers.TagRoutesV1(v1ProtectedRoutes, tagService)
+	routers.SearchRoutesV1(v1ProtectedRoutes, searchService)
+	routers.ReportRoutesV1(v1ProtectedRoutes, reportService)
+	routers.WebhookRoutesV1(v1ProtectedRoutes, webhookService)
+
+	return s
+}
+
+// authenticate, uygulamadaki kimlik doğrulama middleware'inin sadeleştirilmiş halidir
+func authenticate(cacheService services.CacheService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader("X-Token")
+		result := cacheService.GetSystemUserCredential(models.NewContext(c), token)
+		if token == "" || !result.IsSuccess() {
+			controllers.AbortWithResult(c, http.StatusUnauthorized, lgo.NewAuthError())
+			return
+		}
+		c.Set(models.TokenKey, token)
+		c.Set(models.PrincipalKey, result.ReturnObject.(*mvc.SystemUserCredential))
+		c.Next()
+	}
+}
+
+// deny, yetkiyi "0" değeriyle kaydeder; yetki yok sayılmaz, açıkça reddedilir
+func (s *server) deny(permission string) {
+	s.repos.Settings.Set(nil, &datamodels.SystemUserSetting{SystemUserId: s.user.Id, Key: permission, Value: "0"})
+}
+
+// do, isteği oturum açmış kullanıcı adına gönderir. body nil değilse JSON olarak kodlanır.
+func (s *server) do(t *testing.T, method string, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	request := httptest.NewRequest(method, path, reader)
+	request.Header.Set("X-Token", testToken)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept-Language", "en")
+
+	recorder := httptest.NewRecorder()
+	s.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// problem, /api/v1 hata yanıtını çözer
+func problem(t *testing.T, recorder *httptest.ResponseRecorder) *mvc.ProblemDetails {
+	t.Helper()
+	if contentType := recorder.Header().Get("Content-Type"); contentType != mvc.ProblemDetailsContentType {
+		t.Fatalf("want %s, got %q: %s", mvc.ProblemDetailsContentType, contentType, recorder.Body)
+	}
+	var details mvc.ProblemDetails
+	decode(t, recorder, &details)
+	return &details
+}
+
+// legacyResult, eski rotaların her zaman 200 ile dönen OperationResult yanıtını çözer
+func legacyResult(t *testing.T, recorder *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("legacy routes always answer 200, got %d: %s", recorder.Code, recorder.Body)
+	}
+	var result map[string]any
+	decode(t, recorder, &result)
+	return result
+}
+
+func decode(t *testing.T, recorder *httptest.ResponseRecorder, target any) {
+	t.Helper()
+	if err := json.Unmarshal(recorder.Body.Bytes(), target); err != nil {
+		t.Fatalf("decoding %q: %v", recorder.Body, err)
+	}
+}
+
+// response, bir tablo satırının beklediği yanıttır
+type response struct {
+	status int
+	code   string // Problem Details "code"; yalnızca hata durumlarında kontrol edilir
+}
+
+func (want response) check(t *testing.T, recorder *httptest.ResponseRecorder) {
+	t.Helper()
+	if recorder.Code != want.status {
+		t.Fatalf("want %d, got %d: %s", want.status, recorder.Code, recorder.Body)
+	}
+	if want.status >= http.StatusBadRequest && want.code != "" {
+		if details := problem(t, recorder); details.Code != want.code {
+			t.Fatalf("want problem code %q, got %q (%s)", want.code, details.Code, details.Detail)
+		}
+	}
+}
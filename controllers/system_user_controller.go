@@ -3,12 +3,12 @@ package controllers
 import (
 	"net/http"
 
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	mvc "lms-web-services-main/models/mvc"
 	"lms-web-services-main/services"
 
-	"github.com/LGYtech/lgo"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -25,13 +25,13 @@ func NewSystemUserController(service services.SystemUserService) *SystemUserCont
 func (ctrl *SystemUserController) Create(c *gin.Context) {
 	var systemUser datamodels.SystemUser
 	if err := c.ShouldBindJSON(&systemUser); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Create(&systemUser, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusCreated, result)
 }
 
 //#endregion Create System User
@@ -40,13 +40,16 @@ func (ctrl *SystemUserController) Create(c *gin.Context) {
 func (ctrl *SystemUserController) Update(c *gin.Context) {
 	var systemUser datamodels.SystemUser
 	if err := c.ShouldBindJSON(&systemUser); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	if !bindPathUUID(c, &systemUser.Id) {
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Update(&systemUser, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Update System User
@@ -55,13 +58,13 @@ func (ctrl *SystemUserController) Update(c *gin.Context) {
 func (ctrl *SystemUserController) Delete(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil || id == uuid.Nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Delete(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusNoContent, result)
 }
 
 //#endregion Delete System User
@@ -70,13 +73,13 @@ func (ctrl *SystemUserController) Delete(c *gin.Context) {
 func (ctrl *SystemUserController) GetById(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil || id == uuid.Nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetById(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get System User By Id
@@ -85,13 +88,13 @@ func (ctrl *SystemUserController) GetById(c *gin.Context) {
 func (ctrl *SystemUserController) GetAll(c *gin.Context) {
 	var query mvc.QueryModel
 	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetAll(&query, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get All System Users
@@ -100,12 +103,13 @@ func (ctrl *SystemUserController) GetAll(c *gin.Context) {
 func (ctrl *SystemUserController) GetByEmail(c *gin.Context) {
 	email := c.Query("email")
 	if email == "" {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("E-posta adresi zorunludur.", nil))
+		writeBadRequest(c, i18n.Required, i18n.New(i18n.FieldEmail))
 		return
 	}
 
-	result := ctrl.service.GetByEmail(email)
-	c.JSON(http.StatusOK, result)
+	context := models.NewContext(c)
+	result := ctrl.service.GetByEmail(email, context)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get System User By Email
@@ -114,27 +118,27 @@ func (ctrl *SystemUserController) GetByEmail(c *gin.Context) {
 func (ctrl *SystemUserController) Login(c *gin.Context) {
 	var loginRequest mvc.SystemUserLoginRequest
 	if err := c.ShouldBindJSON(&loginRequest); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Giriş verisi doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidLoginRequest, err.Error())
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Login(context, &loginRequest)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusCreated, result)
 }
 
 //#endregion Login
 
 // #region Logout
 func (ctrl *SystemUserController) Logout(c *gin.Context) {
-	token := c.GetHeader("X-Token")
-	if token == "" {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Token eksik.", nil))
+	context := models.NewContext(c)
+	if context.Token == "" {
+		writeBadRequest(c, i18n.TokenMissing)
 		return
 	}
 
-	result := ctrl.service.Logout(token)
-	c.JSON(http.StatusOK, result)
+	result := ctrl.service.Logout(context)
+	writeResult(c, http.StatusNoContent, result)
 }
 
 //#endregion Logout
@@ -0,0 +1,89 @@
+package controllers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/google/uuid"
+)
+
+func TestSystemUserControllerV1(t *testing.T) {
+	permissions := []string{datamodels.SYSTEM_USERS_VIEW, datamodels.SYSTEM_USERS_UPDATE, datamodels.SYSTEM_USERS_DELETE, datamodels.SYSTEM_SETTINGS_ADD}
+	newUser := map[string]any{"n": "Can", "sn": "Demir", "e": "can@example.com", "p": "Parola.123", "ia": true}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string // {id}, oturum açmış kullanıcının kimliğiyle değiştirilir
+		body   any
+		want   response
+	}{
+		{name: "create", method: http.MethodPost, path: "/api/v1/system-users", body: newUser, want: response{status: http.StatusCreated}},
+		{name: "create with a used email", method: http.MethodPost, path: "/api/v1/system-users", body: map[string]any{"n": "Can", "sn": "Demir", "e": "ada@example.com", "p": "Parola.123"}, want: response{http.StatusConflict, i18n.SystemUserEmailExists}},
+		{name: "get", method: http.MethodGet, path: "/api/v1/system-users/{id}", want: response{status: http.StatusOK}},
+		{name: "get invalid id", method: http.MethodGet, path: "/api/v1/system-users/1", want: response{http.StatusBadRequest, i18n.InvalidIdFormat}},
+		{name: "get missing", method: http.MethodGet, path: "/api/v1/system-users/" + uuid.NewString(), want: response{http.StatusNotFound, i18n.SystemUserNotFound}},
+		{name: "get by email", method: http.MethodGet, path: "/api/v1/system-users/by-email?email=ada@example.com", want: response{status: http.StatusOK}},
+		{name: "list", method: http.MethodGet, path: "/api/v1/system-users?pn=1&rpp=10", want: response{status: http.StatusOK}},
+		{name: "update", method: http.MethodPut, path: "/api/v1/system-users/{id}", body: map[string]any{"n": "Ada", "sn": "Kaya", "e": "ada@example.com", "ia": true}, want: response{status: http.StatusOK}},
+		{name: "delete a user with settings", method: http.MethodDelete, path: "/api/v1/system-users/{id}", want: response{http.StatusConflict, i18n.SystemUserHasSettings}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			test.want.check(t, s.do(t, test.method, withId(test.path, s.user.Id), test.body))
+		})
+	}
+}
+
+func TestSystemUserControllerListOmitsPasswords(t *testing.T) {
+	s := newServer(t, datamodels.SYSTEM_USERS_VIEW)
+
+	var page mvc.PagedResult[map[string]any]
+	decode(t, s.do(t, http.MethodGet, "/api/v1/system-users?pn=1&rpp=10", nil), &page)
+	if len(page.Items) != 1 || page.Items[0]["p"] != "" || page.Items[0]["ps"] != "" {
+		t.Fatalf("want one user without password fields, got %v", page.Items)
+	}
+}
+
+func TestSystemUserControllerSessions(t *testing.T) {
+	s := newServer(t, datamodels.SYSTEM_USERS_VIEW, datamodels.SYSTEM_SETTINGS_ADD)
+	s.do(t, http.MethodPost, "/api/v1/system-users", map[string]any{"n": "Can", "sn": "Demir", "e": "can@example.com", "p": "Parola.123", "ia": true})
+
+	tests := []struct {
+		name     string
+		password string
+		want     response
+	}{
+		{name: "login", password: "Parola.123", want: response{status: http.StatusCreated}},
+		{name: "wrong password", password: "yanlış", want: response{http.StatusBadRequest, i18n.InvalidCredentials}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := s.do(t, http.MethodPost, "/api/v1/sessions", map[string]any{"e": "can@example.com", "p": test.password})
+			test.want.check(t, recorder)
+		})
+	}
+
+	t.Run("logout ends the session", func(t *testing.T) {
+		var session map[string]string
+		decode(t, s.do(t, http.MethodPost, "/api/v1/sessions", map[string]any{"e": "can@example.com", "p": "Parola.123"}), &session)
+
+		logout := httptest.NewRequest(http.MethodPost, "/system-user/logout", nil)
+		logout.Header.Set("X-Token", session["t"])
+		s.router.ServeHTTP(httptest.NewRecorder(), logout)
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/system-users?pn=1&rpp=10", nil)
+		request.Header.Set("X-Token", session["t"])
+		recorder := httptest.NewRecorder()
+		s.router.ServeHTTP(recorder, request)
+		response{http.StatusUnauthorized, i18n.Unauthenticated}.check(t, recorder)
+	})
+}
@@ -4,11 +4,11 @@ import (
 	"net/http"
 	"strconv"
 
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	"lms-web-services-main/services"
 
-	"github.com/LGYtech/lgo"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -25,13 +25,13 @@ func NewSystemUserSettingController(service services.SystemUserSettingService) *
 func (ctrl *SystemUserSettingController) GetByUserId(c *gin.Context) {
 	userId, err := uuid.Parse(c.Param("userId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz kullanıcı ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidUserIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetByUserId(userId, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion GetByUserId
@@ -40,13 +40,13 @@ func (ctrl *SystemUserSettingController) GetByUserId(c *gin.Context) {
 func (ctrl *SystemUserSettingController) GetById(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetById(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion GetById
@@ -55,13 +55,13 @@ func (ctrl *SystemUserSettingController) GetById(c *gin.Context) {
 func (ctrl *SystemUserSettingController) Set(c *gin.Context) {
 	var setting datamodels.SystemUserSetting
 	if err := c.ShouldBindJSON(&setting); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Set(&setting, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Set
@@ -70,13 +70,13 @@ func (ctrl *SystemUserSettingController) Set(c *gin.Context) {
 func (ctrl *SystemUserSettingController) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Delete(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusNoContent, result)
 }
 
 //#endregion Delete
@@ -85,19 +85,19 @@ func (ctrl *SystemUserSettingController) Delete(c *gin.Context) {
 func (ctrl *SystemUserSettingController) GetValue(c *gin.Context) {
 	userId, err := uuid.Parse(c.Param("userId"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz kullanıcı ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidUserIdFormat)
 		return
 	}
 
 	key := c.Query("key")
 	if key == "" {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Anahtar (key) zorunludur.", nil))
+		writeBadRequest(c, i18n.Required, i18n.New(i18n.FieldKey))
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetValue(userId, key, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion GetValue
@@ -0,0 +1,58 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+)
+
+func TestSystemUserSettingControllerV1(t *testing.T) {
+	permissions := []string{datamodels.SYSTEM_SETTINGS_VIEW, datamodels.SYSTEM_SETTINGS_ADD, datamodels.SYSTEM_SETTINGS_UPDATE, datamodels.SYSTEM_SETTINGS_DELETE}
+
+	tests := []struct {
+		name   string
+		deny   string
+		method string
+		path   string // {id}, kayıtlı ayarın kimliğiyle değiştirilir
+		body   func(s *server) any
+		want   response
+	}{
+		{
+			name: "set", method: http.MethodPut, path: "/api/v1/system-user-settings",
+			body: func(s *server) any { return map[string]any{"suid": s.user.Id, "key": "theme", "val": "dark"} },
+			want: response{status: http.StatusOK},
+		},
+		{
+			name: "set without value", method: http.MethodPut, path: "/api/v1/system-user-settings",
+			body: func(s *server) any { return map[string]any{"suid": s.user.Id, "key": "theme"} },
+			want: response{http.StatusBadRequest, i18n.Required},
+		},
+		{name: "get", method: http.MethodGet, path: "/api/v1/system-user-settings/{id}", want: response{status: http.StatusOK}},
+		{name: "get missing", method: http.MethodGet, path: "/api/v1/system-user-settings/999", want: response{http.StatusNotFound, i18n.SettingNotFound}},
+		{name: "get forbidden", deny: datamodels.SYSTEM_SETTINGS_VIEW, method: http.MethodGet, path: "/api/v1/system-user-settings/{id}", want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "list by user", method: http.MethodGet, path: "/api/v1/system-users/{user}/settings", want: response{status: http.StatusOK}},
+		{name: "list by invalid user", method: http.MethodGet, path: "/api/v1/system-users/1/settings", want: response{http.StatusBadRequest, i18n.InvalidUserIdFormat}},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/system-user-settings/{id}", want: response{status: http.StatusNoContent}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			setting := &datamodels.SystemUserSetting{SystemUserId: s.user.Id, Key: "theme", Value: "light"}
+			s.repos.Settings.Set(nil, setting)
+
+			var body any
+			if test.body != nil {
+				body = test.body(s)
+			}
+			path := strings.Replace(withId(test.path, setting.Id), "{user}", s.user.Id.String(), 1)
+			test.want.check(t, s.do(t, test.method, path, body))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// #region Tag Controller Definition
+type TagController struct {
+	service services.TagService
+}
+
+func NewTagController(service services.TagService) *TagController {
+	return &TagController{service: service}
+}
+
+//#endregion Tag Controller Definition
+
+// #region Create Tag
+func (ctrl *TagController) Create(c *gin.Context) {
+	var tag data.Tag
+	if err := c.ShouldBindJSON(&tag); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	tag.Id = 0
+
+	context := models.NewContext(c)
+	result := ctrl.service.Create(&tag, context)
+	writeResult(c, http.StatusCreated, result)
+}
+
+//#endregion Create Tag
+
+// #region Update Tag
+func (ctrl *TagController) Update(c *gin.Context) {
+	var tag data.Tag
+	if err := c.ShouldBindJSON(&tag); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	if !bindPathId(c, &tag.Id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Update(&tag, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Update Tag
+
+// #region Delete Tag
+func (ctrl *TagController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Delete(id, context)
+	writeResult(c, http.StatusNoContent, result)
+}
+
+//#endregion Delete Tag
+
+// #region Get Tag By Id
+func (ctrl *TagController) GetById(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetById(id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Tag By Id
+
+// #region Get All Tags
+func (ctrl *TagController) GetAll(c *gin.Context) {
+	var query mvc.QueryModel
+	if err := c.ShouldBindQuery(&query); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetAll(&query, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get All Tags
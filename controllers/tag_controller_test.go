@@ -0,0 +1,46 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+)
+
+func TestTagControllerV1(t *testing.T) {
+	permissions := []string{datamodels.TIMINGS_VIEW, datamodels.SYSTEM_SETTINGS_UPDATE}
+
+	tests := []struct {
+		name   string
+		deny   string
+		method string
+		path   string // {id}, kayıtlı etiketin kimliğiyle değiştirilir
+		body   any
+		want   response
+	}{
+		{name: "create", method: http.MethodPost, path: "/api/v1/tags", body: map[string]any{"n": "Destek", "c": "#43A047"}, want: response{status: http.StatusCreated}},
+		{name: "create invalid color", method: http.MethodPost, path: "/api/v1/tags", body: map[string]any{"n": "Destek", "c": "green"}, want: response{http.StatusUnprocessableEntity, i18n.InvalidFields}},
+		{name: "create an existing name", method: http.MethodPost, path: "/api/v1/tags", body: map[string]any{"n": "toplantı", "c": "#43A047"}, want: response{http.StatusConflict, i18n.TagExists}},
+		{name: "create forbidden", deny: datamodels.SYSTEM_SETTINGS_UPDATE, method: http.MethodPost, path: "/api/v1/tags", body: map[string]any{"n": "Destek", "c": "#43A047"}, want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "list", method: http.MethodGet, path: "/api/v1/tags?pn=1&rpp=10", want: response{status: http.StatusOK}},
+		{name: "get", method: http.MethodGet, path: "/api/v1/tags/{id}", want: response{status: http.StatusOK}},
+		{name: "get missing", method: http.MethodGet, path: "/api/v1/tags/99", want: response{http.StatusNotFound, i18n.TagNotFound}},
+		{name: "get invalid id", method: http.MethodGet, path: "/api/v1/tags/abc", want: response{http.StatusBadRequest, i18n.InvalidIdFormat}},
+		{name: "update", method: http.MethodPut, path: "/api/v1/tags/{id}", body: map[string]any{"n": "Toplantı", "c": "#E53935", "desc": "İç ve dış toplantılar"}, want: response{status: http.StatusOK}},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/tags/{id}", want: response{status: http.StatusNoContent}},
+		{name: "delete forbidden", deny: datamodels.SYSTEM_SETTINGS_UPDATE, method: http.MethodDelete, path: "/api/v1/tags/{id}", want: response{http.StatusForbidden, i18n.Forbidden}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			tag := s.addTag(t, "Toplantı")
+
+			test.want.check(t, s.do(t, test.method, withId(test.path, tag.Id), test.body))
+		})
+	}
+}
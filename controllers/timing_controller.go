@@ -5,12 +5,12 @@ import (
 	"strconv"
 	"time"
 
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	"lms-web-services-main/models/mvc"
 	"lms-web-services-main/services"
 
-	"github.com/LGYtech/lgo"
 	"github.com/gin-gonic/gin"
 )
 
@@ -26,13 +26,13 @@ func NewTimingController(service services.TimingService) *TimingController {
 func (ctrl *TimingController) Create(c *gin.Context) {
 	var timing datamodels.Timing
 	if err := c.ShouldBindJSON(&timing); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Create(&timing, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusCreated, result)
 }
 
 //#endregion Create Timing
@@ -41,13 +41,16 @@ func (ctrl *TimingController) Create(c *gin.Context) {
 func (ctrl *TimingController) Update(c *gin.Context) {
 	var timing datamodels.Timing
 	if err := c.ShouldBindJSON(&timing); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	if !bindPathId(c, &timing.Id) {
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Update(&timing, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Update Timing
@@ -56,13 +59,13 @@ func (ctrl *TimingController) Update(c *gin.Context) {
 func (ctrl *TimingController) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.Delete(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusNoContent, result)
 }
 
 //#endregion Delete Timing
@@ -71,13 +74,13 @@ func (ctrl *TimingController) Delete(c *gin.Context) {
 func (ctrl *TimingController) GetById(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetById(id, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get Timing By Id
@@ -87,7 +90,7 @@ func (ctrl *TimingController) GetAll(c *gin.Context) {
 	// İstekten QueryModel'i oluştur
 	var query mvc.QueryModel
 	if err := c.ShouldBindQuery(&query); err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Veri doğrulama hatası: "+err.Error(), nil))
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
 		return
 	}
 
@@ -96,7 +99,7 @@ func (ctrl *TimingController) GetAll(c *gin.Context) {
 	result := ctrl.service.GetAll(&query, context)
 
 	// Sonuç döndür
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get All Timings
@@ -105,13 +108,13 @@ func (ctrl *TimingController) GetAll(c *gin.Context) {
 func (ctrl *TimingController) GetByClientProjectId(c *gin.Context) {
 	clientProjectId, err := strconv.Atoi(c.Param("clientProjectId"))
 	if err != nil || clientProjectId <= 0 {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz ClientProject ID formatı.", nil))
+		writeBadRequest(c, i18n.InvalidClientProjectIdFormat)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetByClientProjectId(clientProjectId, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get Timings By ClientProjectId
@@ -123,19 +126,52 @@ func (ctrl *TimingController) GetByDateRange(c *gin.Context) {
 
 	startDate, err := time.Parse(time.RFC3339, startDateStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz başlangıç tarihi formatı.", nil))
+		writeBadRequest(c, i18n.InvalidStartDate)
 		return
 	}
 
 	endDate, err := time.Parse(time.RFC3339, endDateStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, lgo.NewLogicError("Geçersiz bitiş tarihi formatı.", nil))
+		writeBadRequest(c, i18n.InvalidEndDate)
 		return
 	}
 
 	context := models.NewContext(c)
 	result := ctrl.service.GetByDateRange(startDate, endDate, context)
-	c.JSON(http.StatusOK, result)
+	writeResult(c, http.StatusOK, result)
 }
 
 //#endregion Get Timings By Date Range
+
+// #region Get Timing Tags
+func (ctrl *TimingController) GetTags(c *gin.Context) {
+	var id int
+	if !bindPathId(c, &id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetTags(id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Timing Tags
+
+// #region Set Timing Tags
+func (ctrl *TimingController) SetTags(c *gin.Context) {
+	var tags mvc.TimingTags
+	if err := c.ShouldBindJSON(&tags); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	var id int
+	if !bindPathId(c, &id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.SetTags(id, &tags, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Set Timing Tags
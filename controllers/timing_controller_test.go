@@ -0,0 +1,180 @@
+package controllers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/google/uuid"
+)
+
+func TestTimingControllerV1(t *testing.T) {
+	permissions := []string{datamodels.TIMINGS_VIEW, datamodels.TIMINGS_ADD, datamodels.TIMINGS_UPDATE, datamodels.TIMINGS_DELETE, datamodels.TIMINGS_ADD_FOR_OTHERS}
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string // {id}, kayıtlı zamanlamanın kimliğiyle değiştirilir
+		body   func(s *server, timing *datamodels.Timing) any
+		want   response
+	}{
+		{
+			name: "create", method: http.MethodPost, path: "/api/v1/timings",
+			body: func(s *server, timing *datamodels.Timing) any {
+				return map[string]any{"cpid": timing.ClientProjectId, "suid": s.user.Id, "t": "Toplantı", "sdt": start, "edt": start.Add(time.Hour), "st": 2}
+			},
+			want: response{status: http.StatusCreated},
+		},
+		{
+			name: "create ending before it starts", method: http.MethodPost, path: "/api/v1/timings",
+			body: func(s *server, timing *datamodels.Timing) any {
+				return map[string]any{"cpid": timing.ClientProjectId, "suid": s.user.Id, "t": "Toplantı", "sdt": start, "edt": start.Add(-time.Hour)}
+			},
+			want: response{http.StatusUnprocessableEntity, i18n.InvalidFields},
+		},
+		{
+			name: "create for another user", method: http.MethodPost, path: "/api/v1/timings",
+			body: func(s *server, timing *datamodels.Timing) any {
+				return map[string]any{"cpid": timing.ClientProjectId, "suid": uuid.New(), "t": "Toplantı", "sdt": start, "edt": start.Add(time.Hour)}
+			},
+			want: response{http.StatusUnprocessableEntity, i18n.InvalidFields},
+		},
+		{
+			name: "create as a non-member for a member", method: http.MethodPost, path: "/api/v1/timings",
+			body: func(s *server, _ *datamodels.Timing) any {
+				project, member := s.addProjectWithoutUser(t)
+				return map[string]any{"cpid": project.Id, "suid": member.Id, "t": "Toplantı", "sdt": start, "edt": start.Add(time.Hour)}
+			},
+			want: response{http.StatusUnprocessableEntity, i18n.InvalidFields},
+		},
+		{
+			name: "update", method: http.MethodPut, path: "/api/v1/timings/{id}",
+			body: func(*server, *datamodels.Timing) any {
+				return map[string]any{"t": "Analiz", "sdt": start, "edt": start.Add(time.Hour), "st": 3}
+			},
+			want: response{status: http.StatusOK},
+		},
+		{name: "get", method: http.MethodGet, path: "/api/v1/timings/{id}", want: response{status: http.StatusOK}},
+		{name: "get missing", method: http.MethodGet, path: "/api/v1/timings/99", want: response{http.StatusNotFound, i18n.TimingNotFound}},
+		{name: "list", method: http.MethodGet, path: "/api/v1/timings?pn=1&rpp=10", want: response{status: http.StatusOK}},
+		{name: "list by project", method: http.MethodGet, path: "/api/v1/client-projects/1/timings", want: response{status: http.StatusOK}},
+		{name: "list by invalid project", method: http.MethodGet, path: "/api/v1/client-projects/0/timings", want: response{http.StatusBadRequest, i18n.InvalidClientProjectIdFormat}},
+		{name: "date range", method: http.MethodGet, path: "/api/v1/timings/date-range?startDate=2024-03-01T00:00:00Z&endDate=2024-03-02T00:00:00Z", want: response{status: http.StatusOK}},
+		{name: "date range without start", method: http.MethodGet, path: "/api/v1/timings/date-range?endDate=2024-03-02T00:00:00Z", want: response{http.StatusBadRequest, i18n.InvalidStartDate}},
+		{name: "date range reversed", method: http.MethodGet, path: "/api/v1/timings/date-range?startDate=2024-03-02T00:00:00Z&endDate=2024-03-01T00:00:00Z", want: response{http.StatusBadRequest, i18n.EndBeforeStart}},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/timings/{id}", want: response{status: http.StatusNoContent}},
+		{
+			name: "set tags", method: http.MethodPut, path: "/api/v1/timings/{id}/tags",
+			body: func(s *server, _ *datamodels.Timing) any {
+				return map[string]any{"tgs": []int{s.addTag(t, "Toplantı").Id}}
+			},
+			want: response{status: http.StatusOK},
+		},
+		{
+			name: "set a missing tag", method: http.MethodPut, path: "/api/v1/timings/{id}/tags",
+			body: func(*server, *datamodels.Timing) any { return map[string]any{"tgs": []int{99}} },
+			want: response{http.StatusUnprocessableEntity, i18n.InvalidFields},
+		},
+		{
+			name: "set tags of a missing timing", method: http.MethodPut, path: "/api/v1/timings/99/tags",
+			body: func(*server, *datamodels.Timing) any { return map[string]any{"tgs": []int{}} },
+			want: response{http.StatusNotFound, i18n.TimingNotFound},
+		},
+		{name: "get tags", method: http.MethodGet, path: "/api/v1/timings/{id}/tags", want: response{status: http.StatusOK}},
+		{name: "get tags invalid id", method: http.MethodGet, path: "/api/v1/timings/abc/tags", want: response{http.StatusBadRequest, i18n.InvalidIdFormat}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			timing := s.addTiming(t, start)
+
+			var body any
+			if test.body != nil {
+				body = test.body(s, timing)
+			}
+			test.want.check(t, s.do(t, test.method, withId(test.path, timing.Id), body))
+		})
+	}
+}
+
+func TestTimingControllerV1ForbidsLoggingForOthers(t *testing.T) {
+	s := newServer(t, datamodels.TIMINGS_ADD)
+	s.deny(datamodels.TIMINGS_ADD_FOR_OTHERS)
+	project, member := s.addProjectWithoutUser(t)
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	body := map[string]any{"cpid": project.Id, "suid": member.Id, "t": "Toplantı", "sdt": start, "edt": start.Add(time.Hour)}
+	response{http.StatusForbidden, i18n.Forbidden}.check(t, s.do(t, http.MethodPost, "/api/v1/timings", body))
+}
+
+func TestTimingControllerV1ListsNames(t *testing.T) {
+	s := newServer(t, datamodels.TIMINGS_VIEW)
+	s.addTiming(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+
+	recorder := s.do(t, http.MethodGet, "/api/v1/timings?pn=1&rpp=10", nil)
+	var page mvc.PagedResult[mvc.TimingViewModel]
+	decode(t, recorder, &page)
+	if page.TotalCount != 1 || page.Items[0].Client != "ACME A.Ş." || page.Items[0].ClientProject != "Web sitesi" {
+		t.Fatalf("want the timing with its client and project names, got %+v", page)
+	}
+}
+
+func TestTimingControllerV1ListsTags(t *testing.T) {
+	s := newServer(t, datamodels.TIMINGS_VIEW, datamodels.TIMINGS_UPDATE)
+	timing := s.addTiming(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	tag := s.addTag(t, "Toplantı")
+	response{status: http.StatusOK}.check(t, s.do(t, http.MethodPut, withId("/api/v1/timings/{id}/tags", timing.Id), map[string]any{"tgs": []int{tag.Id}}))
+
+	var page mvc.PagedResult[mvc.TimingViewModel]
+	decode(t, s.do(t, http.MethodGet, "/api/v1/timings?pn=1&rpp=10", nil), &page)
+	if page.TotalCount != 1 || len(page.Items[0].Tags) != 1 || page.Items[0].Tags[0].Name != "Toplantı" {
+		t.Fatalf("want the timing with its tag, got %+v", page)
+	}
+}
+
+// addTiming, kullanıcıyı ACME müşterisinin "Web sitesi" projesine üye yapar ve projeye bir
+// saatlik zamanlama ekler
+func (s *server) addTiming(t *testing.T, start time.Time) *datamodels.Timing {
+	t.Helper()
+	client := &datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş.", IsActive: true}
+	s.repos.Clients.Create(nil, client)
+	project := &datamodels.ClientProject{ClientId: client.Id, Name: "Web sitesi", IsActive: true}
+	s.repos.ClientProjects.Create(nil, project)
+	s.repos.ClientProjectMembers.Create(nil, &datamodels.ClientProjectMember{ClientProjectId: project.Id, SystemUserId: s.user.Id})
+	timing := &datamodels.Timing{ClientProjectId: project.Id, SystemUserId: s.user.Id, Title: "Analiz", StartDateTime: start, EndDateTime: start.Add(time.Hour)}
+	if result := s.repos.Timings.Create(nil, timing); !result.IsSuccess() {
+		t.Fatalf("setup failed: %s", result.ErrorMessage)
+	}
+	return timing
+}
+
+// addProjectWithoutUser, oturum açmış kullanıcının üye olmadığı bir proje ve o projenin
+// üyesi olan başka bir kullanıcı ekler
+func (s *server) addProjectWithoutUser(t *testing.T) (*datamodels.ClientProject, *datamodels.SystemUser) {
+	t.Helper()
+	client := &datamodels.Client{ShortTitle: "GLOBEX", Title: "Globex Danışmanlık", IsActive: true}
+	s.repos.Clients.Create(nil, client)
+	project := &datamodels.ClientProject{ClientId: client.Id, Name: "Mobil uygulama", IsActive: true}
+	s.repos.ClientProjects.Create(nil, project)
+	member := &datamodels.SystemUser{Name: "Grace", Surname: "Hopper", Email: "grace@example.com", Password: "hash", PasswordSalt: "salt", IsActive: true}
+	if result := s.repos.SystemUsers.Create(nil, member); !result.IsSuccess() {
+		t.Fatalf("setup failed: %s", result.ErrorMessage)
+	}
+	s.repos.ClientProjectMembers.Create(nil, &datamodels.ClientProjectMember{ClientProjectId: project.Id, SystemUserId: member.Id})
+	return project, member
+}
+
+func (s *server) addTag(t *testing.T, name string) *datamodels.Tag {
+	t.Helper()
+	tag := &datamodels.Tag{Name: name, Color: "#1E88E5"}
+	if result := s.repos.Tags.Create(nil, tag); !result.IsSuccess() {
+		t.Fatalf("setup failed: %s", result.ErrorMessage)
+	}
+	return tag
+}
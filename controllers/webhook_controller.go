@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// #region Webhook Controller Definition
+type WebhookController struct {
+	service services.WebhookService
+}
+
+func NewWebhookController(service services.WebhookService) *WebhookController {
+	return &WebhookController{service: service}
+}
+
+//#endregion Webhook Controller Definition
+
+// #region Create Webhook
+func (ctrl *WebhookController) Create(c *gin.Context) {
+	var subscription data.WebhookSubscription
+	if err := c.ShouldBindJSON(&subscription); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	subscription.Id = 0
+
+	context := models.NewContext(c)
+	result := ctrl.service.Create(&subscription, context)
+	writeResult(c, http.StatusCreated, result)
+}
+
+//#endregion Create Webhook
+
+// #region Update Webhook
+func (ctrl *WebhookController) Update(c *gin.Context) {
+	var subscription data.WebhookSubscription
+	if err := c.ShouldBindJSON(&subscription); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+	if !bindPathId(c, &subscription.Id) {
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Update(&subscription, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Update Webhook
+
+// #region Delete Webhook
+func (ctrl *WebhookController) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Delete(id, context)
+	writeResult(c, http.StatusNoContent, result)
+}
+
+//#endregion Delete Webhook
+
+// #region Get Webhook By Id
+func (ctrl *WebhookController) GetById(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetById(id, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Webhook By Id
+
+// #region Get All Webhooks
+func (ctrl *WebhookController) GetAll(c *gin.Context) {
+	var query mvc.QueryModel
+	if err := c.ShouldBindQuery(&query); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetAll(&query, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get All Webhooks
+
+// #region Get Webhook Deliveries
+func (ctrl *WebhookController) GetDeliveries(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+	var query mvc.QueryModel
+	if err := c.ShouldBindQuery(&query); err != nil {
+		writeBadRequest(c, i18n.InvalidRequest, err.Error())
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.GetDeliveries(id, &query, context)
+	writeResult(c, http.StatusOK, result)
+}
+
+//#endregion Get Webhook Deliveries
+
+// #region Redeliver Webhook
+// Redeliver, gönderim kuyruğa eklendiği için 202 döner; sonucu gönderim günlüğünden izlenir
+func (ctrl *WebhookController) Redeliver(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		writeBadRequest(c, i18n.InvalidIdFormat)
+		return
+	}
+	deliveryId, err := strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil || deliveryId <= 0 {
+		writeBadRequest(c, i18n.InvalidDeliveryIdFormat)
+		return
+	}
+
+	context := models.NewContext(c)
+	result := ctrl.service.Redeliver(id, deliveryId, context)
+	writeResult(c, http.StatusAccepted, result)
+}
+
+//#endregion Redeliver Webhook
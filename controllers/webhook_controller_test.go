@@ -0,0 +1,87 @@
+package controllers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+)
+
+func TestWebhookControllerV1(t *testing.T) {
+	permissions := []string{datamodels.WEBHOOKS_VIEW, datamodels.WEBHOOKS_ADD, datamodels.WEBHOOKS_UPDATE, datamodels.WEBHOOKS_DELETE}
+	subscription := map[string]any{"url": "https://hooks.example.com/lms", "evts": []string{"timing.created"}, "sec": "0123456789abcdef", "ia": true}
+
+	tests := []struct {
+		name   string
+		deny   string
+		method string
+		path   string // {id}, kayıtlı aboneliğin kimliğiyle değiştirilir
+		body   any
+		want   response
+	}{
+		{name: "create", method: http.MethodPost, path: "/api/v1/webhooks", body: subscription, want: response{status: http.StatusCreated}},
+		{name: "create with an unknown event type", method: http.MethodPost, path: "/api/v1/webhooks", body: map[string]any{"url": "https://hooks.example.com/lms", "evts": []string{"timing.exploded"}, "sec": "0123456789abcdef"}, want: response{http.StatusUnprocessableEntity, i18n.InvalidFields}},
+		{name: "create with a short secret", method: http.MethodPost, path: "/api/v1/webhooks", body: map[string]any{"url": "https://hooks.example.com/lms", "evts": []string{"timing.created"}, "sec": "kisa"}, want: response{http.StatusUnprocessableEntity, i18n.InvalidFields}},
+		{name: "create forbidden", deny: datamodels.WEBHOOKS_ADD, method: http.MethodPost, path: "/api/v1/webhooks", body: subscription, want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "list", method: http.MethodGet, path: "/api/v1/webhooks?pn=1&rpp=10", want: response{status: http.StatusOK}},
+		{name: "get", method: http.MethodGet, path: "/api/v1/webhooks/{id}", want: response{status: http.StatusOK}},
+		{name: "get missing", method: http.MethodGet, path: "/api/v1/webhooks/99", want: response{http.StatusNotFound, i18n.WebhookNotFound}},
+		{name: "update keeping the secret", method: http.MethodPut, path: "/api/v1/webhooks/{id}", body: map[string]any{"url": "https://hooks.example.com/v2", "evts": []string{"client.updated"}, "ia": true}, want: response{status: http.StatusOK}},
+		{name: "delete", method: http.MethodDelete, path: "/api/v1/webhooks/{id}", want: response{status: http.StatusNoContent}},
+		{name: "deliveries", method: http.MethodGet, path: "/api/v1/webhooks/{id}/deliveries?pn=1&rpp=10", want: response{status: http.StatusOK}},
+		{name: "deliveries of a missing webhook", method: http.MethodGet, path: "/api/v1/webhooks/99/deliveries?pn=1&rpp=10", want: response{http.StatusNotFound, i18n.WebhookNotFound}},
+		{name: "deliveries forbidden", deny: datamodels.WEBHOOKS_VIEW, method: http.MethodGet, path: "/api/v1/webhooks/{id}/deliveries?pn=1&rpp=10", want: response{http.StatusForbidden, i18n.Forbidden}},
+		{name: "redeliver", method: http.MethodPost, path: "/api/v1/webhooks/{id}/deliveries/1/redeliver", want: response{status: http.StatusAccepted}},
+		{name: "redeliver a missing delivery", method: http.MethodPost, path: "/api/v1/webhooks/{id}/deliveries/99/redeliver", want: response{http.StatusNotFound, i18n.DeliveryNotFound}},
+		{name: "redeliver an invalid delivery id", method: http.MethodPost, path: "/api/v1/webhooks/{id}/deliveries/abc/redeliver", want: response{http.StatusBadRequest, i18n.InvalidDeliveryIdFormat}},
+		{name: "redeliver forbidden", deny: datamodels.WEBHOOKS_UPDATE, method: http.MethodPost, path: "/api/v1/webhooks/{id}/deliveries/1/redeliver", want: response{http.StatusForbidden, i18n.Forbidden}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, permissions...)
+			if test.deny != "" {
+				s.deny(test.deny)
+			}
+			webhook := s.addWebhook(t)
+
+			test.want.check(t, s.do(t, test.method, withId(test.path, webhook.Id), test.body))
+		})
+	}
+}
+
+func TestWebhookControllerV1HidesTheSecret(t *testing.T) {
+	s := newServer(t, datamodels.WEBHOOKS_VIEW)
+	webhook := s.addWebhook(t)
+
+	recorder := s.do(t, http.MethodGet, withId("/api/v1/webhooks/{id}", webhook.Id), nil)
+	response{status: http.StatusOK}.check(t, recorder)
+	if strings.Contains(recorder.Body.String(), webhook.Secret) || strings.Contains(recorder.Body.String(), `"sec"`) {
+		t.Fatalf("want the secret left out of the response, got %s", recorder.Body)
+	}
+
+	var page mvc.PagedResult[map[string]any]
+	decode(t, s.do(t, http.MethodGet, withId("/api/v1/webhooks/{id}/deliveries?pn=1&rpp=10", webhook.Id), nil), &page)
+	if page.TotalCount != 1 || page.Items[0]["st"] != float64(enum.DeliveryStatusFailed) {
+		t.Fatalf("want the failed delivery in the log, got %+v", page)
+	}
+}
+
+// addWebhook, servis kurallarını atlayarak bir abonelik ve başarısız olmuş bir gönderimini ekler
+func (s *server) addWebhook(t *testing.T) *datamodels.WebhookSubscription {
+	t.Helper()
+	webhook := &datamodels.WebhookSubscription{Url: "https://hooks.example.com/lms", EventTypes: []string{"timing.created"}, Secret: "gizli-anahtar-0123456789", IsActive: true}
+	if result := s.repos.Webhooks.Create(nil, webhook); !result.IsSuccess() {
+		t.Fatalf("setup failed: %s", result.ErrorMessage)
+	}
+	s.repos.WebhookDeliveries.Add(nil, &datamodels.WebhookDelivery{
+		SubscriptionId: webhook.Id, EventId: 1, EventType: "timing.created", Payload: `{"id":1}`,
+		Status: enum.DeliveryStatusFailed, Attempts: 12, ResponseStatus: http.StatusInternalServerError, CreatedAt: time.Now(),
+	})
+	return webhook
+}
@@ -1,21 +1,39 @@
 package datasources
 
 import (
-	"os"
+	"context"
+	"fmt"
+	"log/slog"
 
-	"github.com/go-redis/redis"
+	"github.com/redis/go-redis/v9"
 )
 
-var Cache *redis.Client
+type CacheConfig struct {
+	Address  string
+	Password string
+	DB       int
+}
+
+// LogValue, CacheConfig loglandığında parolanın yazılmasını engeller
+func (cfg CacheConfig) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("address", cfg.Address),
+		slog.String("password", "[REDACTED]"),
+		slog.Int("db", cfg.DB),
+	)
+}
 
-func init() {
-	Cache = redis.NewClient(&redis.Options{
-		Addr:     os.Getenv("LMS_REDIS_ADDRESS"),
-		Password: "",
-		DB:       0,
+// NewCache, verilen ayarlarla Redis istemcisini oluşturur ve bağlantıyı doğrular
+func NewCache(cfg CacheConfig, logger *slog.Logger) (*redis.Client, error) {
+	cache := redis.NewClient(&redis.Options{
+		Addr:     cfg.Address,
+		Password: cfg.Password,
+		DB:       cfg.DB,
 	})
-	err := Cache.Ping().Err()
-	if err != nil {
-		panic(err)
+	if err := cache.Ping(context.Background()).Err(); err != nil {
+		cache.Close()
+		return nil, fmt.Errorf("cache connection failed (address=%s): %w", cfg.Address, err)
 	}
+	logger.Info("cache connection established", slog.Any("config", cfg))
+	return cache, nil
 }
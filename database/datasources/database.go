@@ -1,44 +1,55 @@
 package datasources
 
 import (
-	"log"
-	"os"
+	"fmt"
+	"log/slog"
 	"time"
 
+	"lms-web-services-main/logging"
+
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
-var Database *gorm.DB
+type DatabaseConfig struct {
+	Host     string
+	User     string
+	Password string
+	Name     string
+	Port     string
+	SSLMode  string
+}
 
-func init() {
-	dsn := "host=" + os.Getenv("LMS_DB_HOST") +
-		" user=" + os.Getenv("LMS_DB_USER") +
-		" password=" + os.Getenv("LMS_DB_PASSWORD") +
-		" dbname=" + os.Getenv("LMS_DB_NAME") +
-		" port=" + os.Getenv("LMS_DB_PORT") +
-		" sslmode=" + os.Getenv("LMS_DB_SSLMODE")
+// LogValue, DatabaseConfig loglandığında parolanın yazılmasını engeller
+func (cfg DatabaseConfig) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("host", cfg.Host),
+		slog.String("user", cfg.User),
+		slog.String("password", "[REDACTED]"),
+		slog.String("dbname", cfg.Name),
+		slog.String("port", cfg.Port),
+		slog.String("sslmode", cfg.SSLMode),
+	)
+}
 
-	log.Println("DSN:", dsn)
+func (cfg DatabaseConfig) DSN() string {
+	return "host=" + cfg.Host +
+		" user=" + cfg.User +
+		" password=" + cfg.Password +
+		" dbname=" + cfg.Name +
+		" port=" + cfg.Port +
+		" sslmode=" + cfg.SSLMode
+}
 
-	var err error
-	Database, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// NewDatabase, verilen ayarlarla Postgres bağlantısını açar ve bağlantıyı doğrular
+func NewDatabase(cfg DatabaseConfig, logger *slog.Logger) (*gorm.DB, error) {
+	database, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{
+		Logger: logging.NewGormLogger(logger, time.Second),
+	})
 	if err != nil {
-		log.Printf("Database connection failed: %s", err.Error())
-		log.Println("Make sure the database is running and the connection details are correct.")
-		return // Database initialization fails but program continues to run
+		return nil, fmt.Errorf("database connection failed (host=%s dbname=%s): %s", cfg.Host, cfg.Name, logging.RedactDSN(err.Error()))
 	}
 
-	Database.Logger = logger.New(
-		log.New(os.Stdout, "\r\n", log.LstdFlags), // io.Writer for log output
-		logger.Config{
-			SlowThreshold:             time.Second, // Threshold for logging slow queries
-			LogLevel:                  logger.Info, // Log level for database operations
-			IgnoreRecordNotFoundError: true,        // Do not log error for record not found
-			Colorful:                  false,       // Disable colorful logs
-		},
-	)
-
-	log.Println("Database connection successfully established.")
+	logger.Info("database connection established", slog.Any("config", cfg))
+	return database, nil
 }
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_timings_searchvector;
+ALTER TABLE "Timings" DROP COLUMN IF EXISTS "SearchVector";
+
+DROP INDEX IF EXISTS idx_clientprojects_searchvector;
+ALTER TABLE "ClientProjects" DROP COLUMN IF EXISTS "SearchVector";
+
+DROP INDEX IF EXISTS idx_clients_searchvector;
+ALTER TABLE "Clients" DROP COLUMN IF EXISTS "SearchVector";
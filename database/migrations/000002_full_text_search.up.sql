@@ -0,0 +1,28 @@
+-- Tam metin arama için Türkçe kök bulma yapan, ağırlıklı tsvector sütunları.
+-- Sütunlar generated olduğundan uygulama tarafından yazılmaz.
+
+-- BEGIN CLIENTS
+ALTER TABLE "Clients" ADD COLUMN "SearchVector" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('turkish', coalesce("Title", '')), 'A') ||
+    setweight(to_tsvector('turkish', coalesce("Notes", '')), 'B')
+) STORED;
+
+CREATE INDEX idx_clients_searchvector ON "Clients" USING GIN ("SearchVector");
+-- END CLIENTS
+
+-- BEGIN CLIENTPROJECTS
+ALTER TABLE "ClientProjects" ADD COLUMN "SearchVector" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('turkish', coalesce("Name", '')), 'A')
+) STORED;
+
+CREATE INDEX idx_clientprojects_searchvector ON "ClientProjects" USING GIN ("SearchVector");
+-- END CLIENTPROJECTS
+
+-- BEGIN TIMINGS
+ALTER TABLE "Timings" ADD COLUMN "SearchVector" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('turkish', coalesce("Title", '')), 'A') ||
+    setweight(to_tsvector('turkish', coalesce("Description", '')), 'B')
+) STORED;
+
+CREATE INDEX idx_timings_searchvector ON "Timings" USING GIN ("SearchVector");
+-- END TIMINGS
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "ClientContacts";
+
+ALTER TABLE "Clients"
+    DROP COLUMN IF EXISTS "PaymentTermDays",
+    DROP COLUMN IF EXISTS "Currency",
+    DROP COLUMN IF EXISTS "BillingAddress",
+    DROP COLUMN IF EXISTS "TaxNumber",
+    DROP COLUMN IF EXISTS "TaxOffice";
@@ -0,0 +1,32 @@
+-- Müşteri fatura bilgileri ve yetkili kişiler.
+-- Vergi numarası (VKN/TCKN) kontrol basamakları uygulama tarafından doğrulanır.
+
+-- BEGIN CLIENTS
+ALTER TABLE "Clients"
+    ADD COLUMN "TaxOffice" varchar(100),
+    ADD COLUMN "TaxNumber" varchar(11),
+    ADD COLUMN "BillingAddress" text,
+    ADD COLUMN "Currency" varchar(3) NOT NULL DEFAULT 'TRY',
+    ADD COLUMN "PaymentTermDays" integer NOT NULL DEFAULT 0;
+-- END CLIENTS
+
+-- BEGIN CLIENTCONTACTS
+CREATE TABLE "ClientContacts" (
+    "Id" serial PRIMARY KEY,
+    "ClientId" integer NOT NULL,
+    "Name" varchar(100) NOT NULL,
+    "Role" varchar(100),
+    "Email" varchar(255),
+    "Phone" varchar(30),
+    "IsPrimary" boolean NOT NULL DEFAULT false,
+    CONSTRAINT fk_clientcontacts_clientid FOREIGN KEY ("ClientId") REFERENCES "Clients" ("Id") ON DELETE CASCADE
+);
+
+CREATE INDEX idx_clientcontacts_clientid ON "ClientContacts" ("ClientId");
+
+-- Bir müşterinin en fazla bir birincil yetkilisi olabilir
+CREATE UNIQUE INDEX uix_clientcontacts_clientid_primary ON "ClientContacts" ("ClientId")
+WHERE "IsPrimary" = true;
+
+ALTER TABLE "ClientContacts" OWNER TO postgres;
+-- END CLIENTCONTACTS
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_timings_clientprojectid_startdatetime;
+
+ALTER TABLE "ClientProjects"
+    DROP CONSTRAINT IF EXISTS chk_clientprojects_budget_dates,
+    DROP CONSTRAINT IF EXISTS chk_clientprojects_budget_nonnegative,
+    DROP COLUMN IF EXISTS "EnforceBudget",
+    DROP COLUMN IF EXISTS "BudgetEndDate",
+    DROP COLUMN IF EXISTS "BudgetStartDate",
+    DROP COLUMN IF EXISTS "HourlyRate",
+    DROP COLUMN IF EXISTS "BudgetAmount",
+    DROP COLUMN IF EXISTS "BudgetHours";
@@ -0,0 +1,19 @@
+-- Proje bütçeleri. Tutar bütçesi harcanan saatlerin saatlik ücretle çarpımıyla izlenir;
+-- sıfır değerler o türde bütçe olmadığı anlamına gelir.
+
+-- BEGIN CLIENTPROJECTS
+ALTER TABLE "ClientProjects"
+    ADD COLUMN "BudgetHours" numeric(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN "BudgetAmount" numeric(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN "HourlyRate" numeric(12, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN "BudgetStartDate" date,
+    ADD COLUMN "BudgetEndDate" date,
+    ADD COLUMN "EnforceBudget" boolean NOT NULL DEFAULT false,
+    ADD CONSTRAINT chk_clientprojects_budget_nonnegative CHECK ("BudgetHours" >= 0 AND "BudgetAmount" >= 0 AND "HourlyRate" >= 0),
+    ADD CONSTRAINT chk_clientprojects_budget_dates CHECK ("BudgetEndDate" IS NULL OR "BudgetStartDate" IS NULL OR "BudgetEndDate" >= "BudgetStartDate");
+-- END CLIENTPROJECTS
+
+-- BEGIN TIMINGS
+-- Bütçe durumu projenin zaman kayıtlarını başlangıç zamanına göre toplar
+CREATE INDEX IF NOT EXISTS idx_timings_clientprojectid_startdatetime ON "Timings" ("ClientProjectId", "StartDateTime");
+-- END TIMINGS
@@ -0,0 +1,26 @@
+-- Proje üyelikleri. Kullanıcılar yalnızca üyesi oldukları projelere zaman kaydı girebilir.
+
+-- BEGIN CLIENTPROJECTMEMBERS
+CREATE TABLE "ClientProjectMembers" (
+    "Id" serial PRIMARY KEY,
+    "ClientProjectId" integer NOT NULL,
+    "SystemUserId" uuid NOT NULL,
+    "Role" varchar(50),
+    "HourlyRate" numeric(12, 2),
+    CONSTRAINT fk_clientprojectmembers_clientprojectid FOREIGN KEY ("ClientProjectId") REFERENCES "ClientProjects" ("Id") ON DELETE CASCADE,
+    CONSTRAINT fk_clientprojectmembers_systemuserid FOREIGN KEY ("SystemUserId") REFERENCES "SystemUsers" ("Id") ON DELETE CASCADE,
+    CONSTRAINT chk_clientprojectmembers_hourlyrate CHECK ("HourlyRate" IS NULL OR "HourlyRate" >= 0)
+);
+
+-- Bir kullanıcı bir projeye bir kez üye olabilir; "projelerim" sorgusu kullanıcıya göre arar
+CREATE UNIQUE INDEX uix_clientprojectmembers_project_user ON "ClientProjectMembers" ("ClientProjectId", "SystemUserId");
+CREATE INDEX idx_clientprojectmembers_systemuserid ON "ClientProjectMembers" ("SystemUserId");
+
+ALTER TABLE "ClientProjectMembers" OWNER TO postgres;
+-- END CLIENTPROJECTMEMBERS
+
+-- BEGIN BACKFILL
+-- Mevcut zaman kayıtlarının sahipleri, kayıt girdikleri projelere üye yapılır
+INSERT INTO "ClientProjectMembers" ("ClientProjectId", "SystemUserId")
+SELECT DISTINCT "ClientProjectId", "SystemUserId" FROM "Timings";
+-- END BACKFILL
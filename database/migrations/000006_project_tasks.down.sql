@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_timings_taskid;
+
+ALTER TABLE "Timings"
+    DROP CONSTRAINT IF EXISTS fk_timings_taskid,
+    DROP COLUMN IF EXISTS "TaskId";
+
+DROP TABLE IF EXISTS "ProjectTasks";
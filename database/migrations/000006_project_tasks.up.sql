@@ -0,0 +1,28 @@
+-- Proje görevleri. Zaman kayıtları isteğe bağlı olarak projelerinin bir görevine bağlanır.
+
+-- BEGIN PROJECTTASKS
+CREATE TABLE "ProjectTasks" (
+    "Id" serial PRIMARY KEY,
+    "ClientProjectId" integer NOT NULL,
+    "Name" varchar(100) NOT NULL,
+    "Description" text,
+    "EstimateHours" numeric(10, 2) NOT NULL DEFAULT 0,
+    "Status" integer NOT NULL DEFAULT 0,
+    CONSTRAINT fk_projecttasks_clientprojectid FOREIGN KEY ("ClientProjectId") REFERENCES "ClientProjects" ("Id") ON DELETE CASCADE,
+    CONSTRAINT chk_projecttasks_estimatehours CHECK ("EstimateHours" >= 0)
+);
+
+-- Görev adları proje içinde benzersizdir
+CREATE UNIQUE INDEX uix_projecttasks_project_name ON "ProjectTasks" ("ClientProjectId", LOWER("Name"));
+
+ALTER TABLE "ProjectTasks" OWNER TO postgres;
+-- END PROJECTTASKS
+
+-- BEGIN TIMINGS
+-- Görev silindiğinde kayıtlar projede görevsiz olarak kalır
+ALTER TABLE "Timings"
+    ADD COLUMN "TaskId" integer,
+    ADD CONSTRAINT fk_timings_taskid FOREIGN KEY ("TaskId") REFERENCES "ProjectTasks" ("Id") ON DELETE SET NULL;
+
+CREATE INDEX idx_timings_taskid ON "Timings" ("TaskId");
+-- END TIMINGS
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "TimingTags";
+
+DROP TABLE IF EXISTS "Tags";
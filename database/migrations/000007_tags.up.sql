@@ -0,0 +1,30 @@
+-- Etiketler. Zaman kayıtları TimingTags üzerinden birden fazla etikete bağlanır.
+
+-- BEGIN TAGS
+CREATE TABLE "Tags" (
+    "Id" serial PRIMARY KEY,
+    "Name" varchar(50) NOT NULL,
+    "Color" varchar(9) NOT NULL,
+    "Description" varchar(200) NOT NULL DEFAULT ''
+);
+
+-- Etiket adları büyük/küçük harf duyarsız benzersizdir
+CREATE UNIQUE INDEX uix_tags_name ON "Tags" (LOWER("Name"));
+
+ALTER TABLE "Tags" OWNER TO postgres;
+-- END TAGS
+
+-- BEGIN TIMINGTAGS
+CREATE TABLE "TimingTags" (
+    "TimingId" integer NOT NULL,
+    "TagId" integer NOT NULL,
+    CONSTRAINT pk_timingtags PRIMARY KEY ("TimingId", "TagId"),
+    CONSTRAINT fk_timingtags_timingid FOREIGN KEY ("TimingId") REFERENCES "Timings" ("Id") ON DELETE CASCADE,
+    CONSTRAINT fk_timingtags_tagid FOREIGN KEY ("TagId") REFERENCES "Tags" ("Id") ON DELETE CASCADE
+);
+
+-- Etikete göre filtre ve rapor sorguları için
+CREATE INDEX idx_timingtags_tagid ON "TimingTags" ("TagId");
+
+ALTER TABLE "TimingTags" OWNER TO postgres;
+-- END TIMINGTAGS
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_clientprojects_clientid_archivedat;
+
+ALTER TABLE "ClientProjects" DROP COLUMN IF EXISTS "ArchivedAt";
+ALTER TABLE "Clients" DROP COLUMN IF EXISTS "ArchivedAt";
@@ -0,0 +1,14 @@
+-- Müşteri ve proje arşivleme. Arşivleme IsActive = false ile yapılır; ArchivedAt arşivlenme
+-- zamanıdır. Müşteriyle birlikte arşivlenen projelere müşterinin ArchivedAt değeri yazılır,
+-- müşteri arşivden çıkarıldığında yalnızca bu projeler geri açılır.
+
+-- BEGIN CLIENTS
+ALTER TABLE "Clients" ADD COLUMN "ArchivedAt" timestamptz;
+UPDATE "Clients" SET "ArchivedAt" = now() WHERE NOT "IsActive";
+-- END CLIENTS
+
+-- BEGIN CLIENTPROJECTS
+ALTER TABLE "ClientProjects" ADD COLUMN "ArchivedAt" timestamptz;
+UPDATE "ClientProjects" SET "ArchivedAt" = now() WHERE NOT "IsActive";
+CREATE INDEX IF NOT EXISTS idx_clientprojects_clientid_archivedat ON "ClientProjects" ("ClientId", "ArchivedAt");
+-- END CLIENTPROJECTS
@@ -0,0 +1,22 @@
+-- Alan olayları için giden kutusu (transactional outbox). Servisler olayları değişikliğin
+-- kendisiyle aynı işlemde buraya yazar; dağıtıcı bekleyen olayları sırayla abonelere iletir.
+-- DispatchedAt iletilme zamanıdır. NextAttemptAt, olayın en erken ne zaman (yeniden)
+-- deneneceğidir; deneme hakkı biten olaylarda boştur.
+
+-- BEGIN OUTBOXEVENTS
+CREATE TABLE "OutboxEvents" (
+    "Id" bigserial PRIMARY KEY,
+    "Type" varchar(100) NOT NULL,
+    "Payload" jsonb NOT NULL,
+    "OccurredAt" timestamptz NOT NULL,
+    "Attempts" integer NOT NULL DEFAULT 0,
+    "NextAttemptAt" timestamptz,
+    "DispatchedAt" timestamptz,
+    "LastError" text NOT NULL DEFAULT ''
+);
+
+-- Dağıtıcının bekleyen olayları araması için
+CREATE INDEX idx_outboxevents_nextattemptat ON "OutboxEvents" ("NextAttemptAt") WHERE "DispatchedAt" IS NULL;
+
+ALTER TABLE "OutboxEvents" OWNER TO postgres;
+-- END OUTBOXEVENTS
@@ -0,0 +1,51 @@
+-- Giden webhook'lar. Abonelikler dinledikleri olay türlerini EventTypes'ta JSON dizisi olarak
+-- tutar. Her olay, dinleyen her etkin aboneliğe bir WebhookDeliveries kaydıyla gönderilir;
+-- gönderim kayıtları aynı zamanda gönderim günlüğüdür.
+
+-- BEGIN WEBHOOKSUBSCRIPTIONS
+CREATE TABLE "WebhookSubscriptions" (
+    "Id" serial PRIMARY KEY,
+    "Url" varchar(2048) NOT NULL,
+    "EventTypes" jsonb NOT NULL DEFAULT '[]',
+    "Secret" varchar(200) NOT NULL,
+    "IsActive" boolean NOT NULL DEFAULT true,
+    "Description" varchar(200) NOT NULL DEFAULT ''
+);
+
+-- Olayı dinleyen aboneliklerin aranması ("EventTypes" @> '["timing.created"]') için
+CREATE INDEX idx_webhooksubscriptions_eventtypes ON "WebhookSubscriptions" USING gin ("EventTypes");
+
+ALTER TABLE "WebhookSubscriptions" OWNER TO postgres;
+-- END WEBHOOKSUBSCRIPTIONS
+
+-- BEGIN WEBHOOKDELIVERIES
+CREATE TABLE "WebhookDeliveries" (
+    "Id" bigserial PRIMARY KEY,
+    "SubscriptionId" integer NOT NULL,
+    "EventId" bigint NOT NULL,
+    "EventType" varchar(100) NOT NULL,
+    "RedeliveryOf" bigint,
+    "Payload" jsonb NOT NULL,
+    "Status" integer NOT NULL DEFAULT 0,
+    "Attempts" integer NOT NULL DEFAULT 0,
+    "NextAttemptAt" timestamptz,
+    "LastAttemptAt" timestamptz,
+    "ResponseStatus" integer NOT NULL DEFAULT 0,
+    "LastError" text NOT NULL DEFAULT '',
+    "CreatedAt" timestamptz NOT NULL,
+    "DeliveredAt" timestamptz,
+    CONSTRAINT fk_webhookdeliveries_subscriptionid FOREIGN KEY ("SubscriptionId") REFERENCES "WebhookSubscriptions" ("Id") ON DELETE CASCADE,
+    CONSTRAINT fk_webhookdeliveries_redeliveryof FOREIGN KEY ("RedeliveryOf") REFERENCES "WebhookDeliveries" ("Id") ON DELETE SET NULL
+);
+
+-- Olay yeniden dağıtılsa da bir aboneliğe bir kez gönderilir; yeniden gönderimler hariç
+CREATE UNIQUE INDEX uix_webhookdeliveries_subscriptionid_eventid ON "WebhookDeliveries" ("SubscriptionId", "EventId") WHERE "RedeliveryOf" IS NULL;
+
+-- Gönderim günlüğünün aboneliğe göre listelenmesi için
+CREATE INDEX idx_webhookdeliveries_subscriptionid ON "WebhookDeliveries" ("SubscriptionId", "Id" DESC);
+
+-- Gönderim işçisinin bekleyen gönderimleri araması için
+CREATE INDEX idx_webhookdeliveries_nextattemptat ON "WebhookDeliveries" ("NextAttemptAt") WHERE "Status" = 0;
+
+ALTER TABLE "WebhookDeliveries" OWNER TO postgres;
+-- END WEBHOOKDELIVERIES
@@ -0,0 +1,2 @@
+-- Verilmemiş (varsayılan değerdeki) yetkiler kaldırılır; sonradan verilen yetkiler korunur
+DELETE FROM "SystemUserSettings" WHERE "Key" = 'timings.add_for_others' AND "Value" = '0';
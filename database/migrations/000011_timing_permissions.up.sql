@@ -0,0 +1,13 @@
+-- Varsayılan yetkiler kullanıcı oluşturulurken atanır. timings.add_for_others yetkisi
+-- eklenmeden önce oluşturulan kullanıcılarda bu ayar olmadığı için yetki kontrolü
+-- "yetki bulunamadı" hatası döner; bu kullanıcılara varsayılan değeriyle eklenir.
+
+-- BEGIN BACKFILL
+INSERT INTO "SystemUserSettings" ("SystemUserId", "Key", "Value", "Description")
+SELECT u."Id", 'timings.add_for_others', '0', 'Başka kullanıcılar adına zamanlama ekleme yetkisi'
+FROM "SystemUsers" AS u
+WHERE NOT EXISTS (
+    SELECT 1 FROM "SystemUserSettings" AS s
+    WHERE s."SystemUserId" = u."Id" AND s."Key" = 'timings.add_for_others'
+);
+-- END BACKFILL
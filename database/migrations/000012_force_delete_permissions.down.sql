@@ -0,0 +1,3 @@
+-- Verilmemiş (varsayılan değerdeki) yetkiler kaldırılır; sonradan verilen yetkiler korunur
+DELETE FROM "SystemUserSettings"
+WHERE "Key" IN ('clients.force_delete', 'clientprojects.force_delete') AND "Value" = '0';
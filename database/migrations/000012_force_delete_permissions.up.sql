@@ -0,0 +1,17 @@
+-- Varsayılan yetkiler kullanıcı oluşturulurken atanır. Bağlı kayıtlarla silme yetkileri
+-- eklenmeden önce oluşturulan kullanıcılarda bu ayarlar olmadığı için yetki kontrolü
+-- "yetki bulunamadı" hatası döner; bu kullanıcılara varsayılan değerleriyle eklenir.
+
+-- BEGIN BACKFILL
+INSERT INTO "SystemUserSettings" ("SystemUserId", "Key", "Value", "Description")
+SELECT u."Id", p."Key", '0', p."Description"
+FROM "SystemUsers" AS u
+CROSS JOIN (VALUES
+    ('clients.force_delete', 'Müşterileri bağlı kayıtlarıyla birlikte silme yetkisi'),
+    ('clientprojects.force_delete', 'Müşteri projelerini bağlı kayıtlarıyla birlikte silme yetkisi')
+) AS p ("Key", "Description")
+WHERE NOT EXISTS (
+    SELECT 1 FROM "SystemUserSettings" AS s
+    WHERE s."SystemUserId" = u."Id" AND s."Key" = p."Key"
+);
+-- END BACKFILL
@@ -0,0 +1,3 @@
+-- Verilmemiş (varsayılan değerdeki) yetkiler kaldırılır; sonradan verilen yetkiler korunur
+DELETE FROM "SystemUserSettings"
+WHERE "Key" IN ('webhooks.view', 'webhooks.add', 'webhooks.update', 'webhooks.delete') AND "Value" = '0';
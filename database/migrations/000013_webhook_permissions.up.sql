@@ -0,0 +1,19 @@
+-- Varsayılan yetkiler kullanıcı oluşturulurken atanır. Webhook yetkileri eklenmeden önce
+-- oluşturulan kullanıcılarda bu ayarlar olmadığı için yetki kontrolü "yetki bulunamadı"
+-- hatası döner; bu kullanıcılara varsayılan değerleriyle eklenir.
+
+-- BEGIN BACKFILL
+INSERT INTO "SystemUserSettings" ("SystemUserId", "Key", "Value", "Description")
+SELECT u."Id", p."Key", '0', p."Description"
+FROM "SystemUsers" AS u
+CROSS JOIN (VALUES
+    ('webhooks.view', 'Webhook aboneliklerini ve gönderim günlüğünü görüntüleme yetkisi'),
+    ('webhooks.add', 'Webhook aboneliği ekleme yetkisi'),
+    ('webhooks.update', 'Webhook aboneliklerini güncelleme ve gönderimleri yeniden gönderme yetkisi'),
+    ('webhooks.delete', 'Webhook aboneliklerini silme yetkisi')
+) AS p ("Key", "Description")
+WHERE NOT EXISTS (
+    SELECT 1 FROM "SystemUserSettings" AS s
+    WHERE s."SystemUserId" = u."Id" AND s."Key" = p."Key"
+);
+-- END BACKFILL
@@ -0,0 +1,23 @@
+ALTER TABLE "TimingTags"
+    DROP CONSTRAINT fk_timingtags_timingid,
+    ADD CONSTRAINT fk_timingtags_timingid FOREIGN KEY ("TimingId") REFERENCES "Timings" ("Id") ON DELETE CASCADE;
+
+ALTER TABLE "ProjectTasks"
+    DROP CONSTRAINT fk_projecttasks_clientprojectid,
+    ADD CONSTRAINT fk_projecttasks_clientprojectid FOREIGN KEY ("ClientProjectId") REFERENCES "ClientProjects" ("Id") ON DELETE CASCADE;
+
+ALTER TABLE "ClientProjectMembers"
+    DROP CONSTRAINT fk_clientprojectmembers_clientprojectid,
+    ADD CONSTRAINT fk_clientprojectmembers_clientprojectid FOREIGN KEY ("ClientProjectId") REFERENCES "ClientProjects" ("Id") ON DELETE CASCADE;
+
+ALTER TABLE "Timings"
+    DROP CONSTRAINT fk_timings_clientprojectid,
+    ADD CONSTRAINT fk_timings_clientprojectid FOREIGN KEY ("ClientProjectId") REFERENCES "ClientProjects" ("Id") ON DELETE CASCADE;
+
+ALTER TABLE "ClientContacts"
+    DROP CONSTRAINT fk_clientcontacts_clientid,
+    ADD CONSTRAINT fk_clientcontacts_clientid FOREIGN KEY ("ClientId") REFERENCES "Clients" ("Id") ON DELETE CASCADE;
+
+ALTER TABLE "ClientProjects"
+    DROP CONSTRAINT fk_clientprojects_clientid,
+    ADD CONSTRAINT fk_clientprojects_clientid FOREIGN KEY ("ClientId") REFERENCES "Clients" ("Id") ON DELETE CASCADE;
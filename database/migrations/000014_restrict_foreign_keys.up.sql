@@ -0,0 +1,41 @@
+-- Müşteri ve proje silinirken bağlı kayıtlar artık veritabanında kendiliğinden silinmez.
+-- Bağlı kaydı olan bir müşteri veya proje yalnızca force=true ile silinebilir; ForceDelete
+-- bağlı kayıtları aynı işlemde açıkça siler. Sayım ile silme arasında eklenen bir kayıt
+-- silmeyi foreign key ihlaliyle (SQLSTATE 23503) durdurur ve istek 409 ile döner.
+
+-- BEGIN CLIENTPROJECTS
+ALTER TABLE "ClientProjects"
+    DROP CONSTRAINT fk_clientprojects_clientid,
+    ADD CONSTRAINT fk_clientprojects_clientid FOREIGN KEY ("ClientId") REFERENCES "Clients" ("Id") ON DELETE RESTRICT;
+-- END CLIENTPROJECTS
+
+-- BEGIN CLIENTCONTACTS
+ALTER TABLE "ClientContacts"
+    DROP CONSTRAINT fk_clientcontacts_clientid,
+    ADD CONSTRAINT fk_clientcontacts_clientid FOREIGN KEY ("ClientId") REFERENCES "Clients" ("Id") ON DELETE RESTRICT;
+-- END CLIENTCONTACTS
+
+-- BEGIN TIMINGS
+ALTER TABLE "Timings"
+    DROP CONSTRAINT fk_timings_clientprojectid,
+    ADD CONSTRAINT fk_timings_clientprojectid FOREIGN KEY ("ClientProjectId") REFERENCES "ClientProjects" ("Id") ON DELETE RESTRICT;
+-- END TIMINGS
+
+-- BEGIN CLIENTPROJECTMEMBERS
+ALTER TABLE "ClientProjectMembers"
+    DROP CONSTRAINT fk_clientprojectmembers_clientprojectid,
+    ADD CONSTRAINT fk_clientprojectmembers_clientprojectid FOREIGN KEY ("ClientProjectId") REFERENCES "ClientProjects" ("Id") ON DELETE RESTRICT;
+-- END CLIENTPROJECTMEMBERS
+
+-- BEGIN PROJECTTASKS
+ALTER TABLE "ProjectTasks"
+    DROP CONSTRAINT fk_projecttasks_clientprojectid,
+    ADD CONSTRAINT fk_projecttasks_clientprojectid FOREIGN KEY ("ClientProjectId") REFERENCES "ClientProjects" ("Id") ON DELETE RESTRICT;
+-- END PROJECTTASKS
+
+-- BEGIN TIMINGTAGS
+-- Zaman kaydı silinirken etiket bağlantıları depo tarafından aynı işlemde silinir
+ALTER TABLE "TimingTags"
+    DROP CONSTRAINT fk_timingtags_timingid,
+    ADD CONSTRAINT fk_timingtags_timingid FOREIGN KEY ("TimingId") REFERENCES "Timings" ("Id") ON DELETE RESTRICT;
+-- END TIMINGTAGS
@@ -0,0 +1,395 @@
+// Package events, servislerin yayımladığı alan olaylarıdır. Olaylar değişikliğin kendisiyle
+// aynı veritabanı işleminde Outbox tablosuna JSON olarak yazılır; dağıtıcı bunları Decode ile
+// türlerine geri çevirerek abonelere iletir. Bir olay birden fazla kez iletilebileceği için
+// aboneler aynı olayı tekrar işlemeye dayanıklı olmalıdır.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	datamodels "lms-web-services-main/models/data"
+
+	"github.com/google/uuid"
+)
+
+// Event, Outbox'a yazılabilen bir alan olayıdır. EventType, olayın kalıcı adıdır ve
+// değiştirilmemelidir.
+type Event interface {
+	EventType() string
+}
+
+// Olay türleri
+const (
+	TypeClientCreated    = "client.created"
+	TypeClientUpdated    = "client.updated"
+	TypeClientDeleted    = "client.deleted"
+	TypeClientArchived   = "client.archived"
+	TypeClientUnarchived = "client.unarchived"
+
+	TypeClientProjectCreated    = "client_project.created"
+	TypeClientProjectUpdated    = "client_project.updated"
+	TypeClientProjectDeleted    = "client_project.deleted"
+	TypeClientProjectArchived   = "client_project.archived"
+	TypeClientProjectUnarchived = "client_project.unarchived"
+
+	// TypeClientProjectBudgetThresholdCrossed, projenin harcanan bütçesi yapılandırılmış bir
+	// eşiği ilk kez aştığında yayımlanır
+	TypeClientProjectBudgetThresholdCrossed = "client_project.budget_threshold_crossed"
+
+	TypeClientContactCreated = "client_contact.created"
+	TypeClientContactUpdated = "client_contact.updated"
+	TypeClientContactDeleted = "client_contact.deleted"
+
+	TypeClientProjectMemberAdded   = "client_project_member.added"
+	TypeClientProjectMemberRemoved = "client_project_member.removed"
+
+	TypeProjectTaskCreated = "project_task.created"
+	TypeProjectTaskUpdated = "project_task.updated"
+	TypeProjectTaskDeleted = "project_task.deleted"
+
+	TypeTimingCreated = "timing.created"
+	TypeTimingUpdated = "timing.updated"
+	TypeTimingDeleted = "timing.deleted"
+	// TypeTimingCompleted, kayıt "Completed" durumuna geçtiğinde timing.updated yerine yayımlanır
+	TypeTimingCompleted = "timing.completed"
+	TypeTimingTagsSet   = "timing.tags_set"
+
+	TypeTagCreated = "tag.created"
+	TypeTagUpdated = "tag.updated"
+	TypeTagDeleted = "tag.deleted"
+
+	TypeSystemUserCreated     = "system_user.created"
+	TypeSystemUserActivated   = "system_user.activated"
+	TypeSystemUserDeactivated = "system_user.deactivated"
+	TypeSystemUserDeleted     = "system_user.deleted"
+
+	TypeSystemUserSettingSet     = "system_user_setting.set"
+	TypeSystemUserSettingDeleted = "system_user_setting.deleted"
+)
+
+// registry, Decode'un olay türünden boş olayı üretmesi içindir
+var registry = map[string]func() Event{
+	TypeClientCreated:    func() Event { return &ClientCreated{} },
+	TypeClientUpdated:    func() Event { return &ClientUpdated{} },
+	TypeClientDeleted:    func() Event { return &ClientDeleted{} },
+	TypeClientArchived:   func() Event { return &ClientArchived{} },
+	TypeClientUnarchived: func() Event { return &ClientUnarchived{} },
+
+	TypeClientProjectCreated:    func() Event { return &ClientProjectCreated{} },
+	TypeClientProjectUpdated:    func() Event { return &ClientProjectUpdated{} },
+	TypeClientProjectDeleted:    func() Event { return &ClientProjectDeleted{} },
+	TypeClientProjectArchived:   func() Event { return &ClientProjectArchived{} },
+	TypeClientProjectUnarchived: func() Event { return &ClientProjectUnarchived{} },
+
+	TypeClientProjectBudgetThresholdCrossed: func() Event { return &ClientProjectBudgetThresholdCrossed{} },
+
+	TypeClientContactCreated: func() Event { return &ClientContactCreated{} },
+	TypeClientContactUpdated: func() Event { return &ClientContactUpdated{} },
+	TypeClientContactDeleted: func() Event { return &ClientContactDeleted{} },
+
+	TypeClientProjectMemberAdded:   func() Event { return &ClientProjectMemberAdded{} },
+	TypeClientProjectMemberRemoved: func() Event { return &ClientProjectMemberRemoved{} },
+
+	TypeProjectTaskCreated: func() Event { return &ProjectTaskCreated{} },
+	TypeProjectTaskUpdated: func() Event { return &ProjectTaskUpdated{} },
+	TypeProjectTaskDeleted: func() Event { return &ProjectTaskDeleted{} },
+
+	TypeTimingCreated:   func() Event { return &TimingCreated{} },
+	TypeTimingUpdated:   func() Event { return &TimingUpdated{} },
+	TypeTimingDeleted:   func() Event { return &TimingDeleted{} },
+	TypeTimingCompleted: func() Event { return &TimingCompleted{} },
+	TypeTimingTagsSet:   func() Event { return &TimingTagsSet{} },
+
+	TypeTagCreated: func() Event { return &TagCreated{} },
+	TypeTagUpdated: func() Event { return &TagUpdated{} },
+	TypeTagDeleted: func() Event { return &TagDeleted{} },
+
+	TypeSystemUserCreated:     func() Event { return &SystemUserCreated{} },
+	TypeSystemUserActivated:   func() Event { return &SystemUserActivated{} },
+	TypeSystemUserDeactivated: func() Event { return &SystemUserDeactivated{} },
+	TypeSystemUserDeleted:     func() Event { return &SystemUserDeleted{} },
+
+	TypeSystemUserSettingSet:     func() Event { return &SystemUserSettingSet{} },
+	TypeSystemUserSettingDeleted: func() Event { return &SystemUserSettingDeleted{} },
+}
+
+// Types, bilinen olay türlerini alfabetik sırayla döndürür
+func Types() []string {
+	types := make([]string, 0, len(registry))
+	for eventType := range registry {
+		types = append(types, eventType)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// Decode, Outbox'taki bir olayı türüne göre çözer
+func Decode(eventType string, payload []byte) (Event, error) {
+	newEvent, ok := registry[eventType]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", eventType)
+	}
+	event := newEvent()
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("decoding %s event failed: %w", eventType, err)
+	}
+	return event, nil
+}
+
+// Envelope, abonelere iletilen olaydır. Id, olayın Outbox'taki kimliğidir; aynı olayın
+// yeniden iletimlerinde değişmez ve abonelerin tekrarları ayıklaması için kullanılabilir.
+type Envelope struct {
+	Id         int64     `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       Event     `json:"data"`
+}
+
+// #region Client Events
+type ClientCreated struct {
+	Client datamodels.Client `json:"client"`
+}
+
+func (*ClientCreated) EventType() string { return TypeClientCreated }
+
+type ClientUpdated struct {
+	Client datamodels.Client `json:"client"`
+}
+
+func (*ClientUpdated) EventType() string { return TypeClientUpdated }
+
+// ClientDeleted, müşteri bağlı kayıtlarıyla birlikte silindiğinde de yayımlanır; silinen
+// projeler ve zamanlamalar için ayrıca ClientProjectDeleted ve TimingDeleted yayımlanır
+type ClientDeleted struct {
+	ClientId int `json:"client_id"`
+}
+
+func (*ClientDeleted) EventType() string { return TypeClientDeleted }
+
+// ClientArchived, müşteriyle birlikte arşivlenen projeler için ayrıca ClientProjectArchived
+// yayımlanır
+type ClientArchived struct {
+	ClientId int `json:"client_id"`
+}
+
+func (*ClientArchived) EventType() string { return TypeClientArchived }
+
+// ClientUnarchived, müşteriyle birlikte arşivden çıkan projeler için ayrıca
+// ClientProjectUnarchived yayımlanır
+type ClientUnarchived struct {
+	ClientId int `json:"client_id"`
+}
+
+func (*ClientUnarchived) EventType() string { return TypeClientUnarchived }
+
+//#endregion Client Events
+
+// #region ClientProject Events
+type ClientProjectCreated struct {
+	ClientProject datamodels.ClientProject `json:"client_project"`
+}
+
+func (*ClientProjectCreated) EventType() string { return TypeClientProjectCreated }
+
+type ClientProjectUpdated struct {
+	ClientProject datamodels.ClientProject `json:"client_project"`
+}
+
+func (*ClientProjectUpdated) EventType() string { return TypeClientProjectUpdated }
+
+// ClientProjectDeleted, proje bağlı kayıtlarıyla birlikte silindiğinde de yayımlanır; silinen
+// zamanlamalar için ayrıca TimingDeleted yayımlanır
+type ClientProjectDeleted struct {
+	ClientProjectId int `json:"client_project_id"`
+}
+
+func (*ClientProjectDeleted) EventType() string { return TypeClientProjectDeleted }
+
+type ClientProjectArchived struct {
+	ClientProjectId int `json:"client_project_id"`
+}
+
+func (*ClientProjectArchived) EventType() string { return TypeClientProjectArchived }
+
+type ClientProjectUnarchived struct {
+	ClientProjectId int `json:"client_project_id"`
+}
+
+func (*ClientProjectUnarchived) EventType() string { return TypeClientProjectUnarchived }
+
+type ClientProjectBudgetThresholdCrossed struct {
+	ClientProjectId int     `json:"client_project_id"`
+	Threshold       int     `json:"threshold"`
+	ConsumedPercent float64 `json:"consumed_percent"`
+}
+
+func (*ClientProjectBudgetThresholdCrossed) EventType() string {
+	return TypeClientProjectBudgetThresholdCrossed
+}
+
+//#endregion ClientProject Events
+
+// #region ClientContact Events
+type ClientContactCreated struct {
+	Contact datamodels.ClientContact `json:"contact"`
+}
+
+func (*ClientContactCreated) EventType() string { return TypeClientContactCreated }
+
+type ClientContactUpdated struct {
+	Contact datamodels.ClientContact `json:"contact"`
+}
+
+func (*ClientContactUpdated) EventType() string { return TypeClientContactUpdated }
+
+type ClientContactDeleted struct {
+	ContactId int `json:"contact_id"`
+}
+
+func (*ClientContactDeleted) EventType() string { return TypeClientContactDeleted }
+
+//#endregion ClientContact Events
+
+// #region ClientProjectMember Events
+type ClientProjectMemberAdded struct {
+	Member datamodels.ClientProjectMember `json:"member"`
+}
+
+func (*ClientProjectMemberAdded) EventType() string { return TypeClientProjectMemberAdded }
+
+type ClientProjectMemberRemoved struct {
+	MemberId int `json:"member_id"`
+}
+
+func (*ClientProjectMemberRemoved) EventType() string { return TypeClientProjectMemberRemoved }
+
+//#endregion ClientProjectMember Events
+
+// #region ProjectTask Events
+type ProjectTaskCreated struct {
+	Task datamodels.ProjectTask `json:"task"`
+}
+
+func (*ProjectTaskCreated) EventType() string { return TypeProjectTaskCreated }
+
+type ProjectTaskUpdated struct {
+	Task datamodels.ProjectTask `json:"task"`
+}
+
+func (*ProjectTaskUpdated) EventType() string { return TypeProjectTaskUpdated }
+
+type ProjectTaskDeleted struct {
+	TaskId int `json:"task_id"`
+}
+
+func (*ProjectTaskDeleted) EventType() string { return TypeProjectTaskDeleted }
+
+//#endregion ProjectTask Events
+
+// #region Timing Events
+type TimingCreated struct {
+	Timing datamodels.Timing `json:"timing"`
+}
+
+func (*TimingCreated) EventType() string { return TypeTimingCreated }
+
+type TimingUpdated struct {
+	Timing datamodels.Timing `json:"timing"`
+}
+
+func (*TimingUpdated) EventType() string { return TypeTimingUpdated }
+
+type TimingDeleted struct {
+	TimingId int `json:"timing_id"`
+}
+
+func (*TimingDeleted) EventType() string { return TypeTimingDeleted }
+
+type TimingCompleted struct {
+	Timing datamodels.Timing `json:"timing"`
+}
+
+func (*TimingCompleted) EventType() string { return TypeTimingCompleted }
+
+// TimingTagsSet, kaydın etiketlerinin tamamıdır; eski etiketlerin yerini alır
+type TimingTagsSet struct {
+	TimingId int   `json:"timing_id"`
+	TagIds   []int `json:"tag_ids"`
+}
+
+func (*TimingTagsSet) EventType() string { return TypeTimingTagsSet }
+
+//#endregion Timing Events
+
+// #region Tag Events
+type TagCreated struct {
+	Tag datamodels.Tag `json:"tag"`
+}
+
+func (*TagCreated) EventType() string { return TypeTagCreated }
+
+type TagUpdated struct {
+	Tag datamodels.Tag `json:"tag"`
+}
+
+func (*TagUpdated) EventType() string { return TypeTagUpdated }
+
+type TagDeleted struct {
+	TagId int `json:"tag_id"`
+}
+
+func (*TagDeleted) EventType() string { return TypeTagDeleted }
+
+//#endregion Tag Events
+
+// #region SystemUser Events
+// SystemUser olayları şifre alanlarını taşımaz
+type SystemUserCreated struct {
+	SystemUserId uuid.UUID `json:"system_user_id"`
+	Email        string    `json:"email"`
+}
+
+func (*SystemUserCreated) EventType() string { return TypeSystemUserCreated }
+
+type SystemUserActivated struct {
+	SystemUserId uuid.UUID `json:"system_user_id"`
+}
+
+func (*SystemUserActivated) EventType() string { return TypeSystemUserActivated }
+
+type SystemUserDeactivated struct {
+	SystemUserId uuid.UUID `json:"system_user_id"`
+}
+
+func (*SystemUserDeactivated) EventType() string { return TypeSystemUserDeactivated }
+
+type SystemUserDeleted struct {
+	SystemUserId uuid.UUID `json:"system_user_id"`
+}
+
+func (*SystemUserDeleted) EventType() string { return TypeSystemUserDeleted }
+
+//#endregion SystemUser Events
+
+// #region SystemUserSetting Events
+// SystemUserSettingSet, ayar eklendiğinde de değeri değiştiğinde de yayımlanır. Yetkiler de
+// ayar olarak saklandığı için yetki değişiklikleri bu olayla izlenebilir.
+type SystemUserSettingSet struct {
+	SystemUserId uuid.UUID `json:"system_user_id"`
+	Key          string    `json:"key"`
+	Value        string    `json:"value"`
+}
+
+func (*SystemUserSettingSet) EventType() string { return TypeSystemUserSettingSet }
+
+type SystemUserSettingDeleted struct {
+	SystemUserId uuid.UUID `json:"system_user_id"`
+	Key          string    `json:"key"`
+}
+
+func (*SystemUserSettingDeleted) EventType() string { return TypeSystemUserSettingDeleted }
+
+//#endregion SystemUserSetting Events
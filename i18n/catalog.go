@@ -0,0 +1,177 @@
+package i18n
+
+// catalog, mesaj kodlarını dillere göre metinlere eşler. Yeni bir kod eklendiğinde
+// desteklenen tüm dillerde karşılığı yazılmalıdır.
+var catalog = map[string]map[Language]string{
+	// Genel
+	Unexpected:         {Turkish: "Beklenmeyen bir hata oluştu.", English: "An unexpected error occurred."},
+	DatabaseError:      {Turkish: "Veritabanı işlemi başarısız: %v", English: "Database operation failed: %v"},
+	DuplicateRecord:    {Turkish: "Kayıt mevcut bir kayıtla çakışıyor.", English: "The record conflicts with an existing record."},
+	ReferenceViolation: {Turkish: "Kayıt ilişkili bir kayıtla çakışıyor: bağlı kayıtları olan bir kayıt silinemez, olmayan bir kayda bağlanamaz.", English: "The record conflicts with a related record: a record with dependents cannot be deleted and a record cannot refer to a missing one."},
+	ValidationFailed:   {Turkish: "Veri doğrulama hatası: %v", English: "Validation failed: %v"},
+
+	// İstek
+	InvalidRequest:               {Turkish: "Veri doğrulama hatası: %v", English: "The request could not be parsed: %v"},
+	InvalidLoginRequest:          {Turkish: "Giriş verisi doğrulama hatası: %v", English: "The login request could not be parsed: %v"},
+	InvalidIdFormat:              {Turkish: "Geçersiz ID formatı.", English: "Invalid ID format."},
+	InvalidUserIdFormat:          {Turkish: "Geçersiz kullanıcı ID formatı.", English: "Invalid user ID format."},
+	InvalidClientIdFormat:        {Turkish: "Geçersiz müşteri ID formatı.", English: "Invalid client ID format."},
+	InvalidClientProjectIdFormat: {Turkish: "Geçersiz proje ID formatı.", English: "Invalid project ID format."},
+	InvalidContactIdFormat:       {Turkish: "Geçersiz yetkili ID formatı.", English: "Invalid contact ID format."},
+	InvalidMemberIdFormat:        {Turkish: "Geçersiz üye ID formatı.", English: "Invalid member ID format."},
+	InvalidTaskIdFormat:          {Turkish: "Geçersiz görev ID formatı.", English: "Invalid task ID format."},
+	InvalidDeliveryIdFormat:      {Turkish: "Geçersiz gönderim ID formatı.", English: "Invalid delivery ID format."},
+	InvalidArchivedFilter:        {Turkish: "archived parametresi true, false veya all olmalıdır.", English: "The archived parameter must be true, false or all."},
+	InvalidStartDate:             {Turkish: "Geçersiz başlangıç tarihi formatı.", English: "Invalid start date format."},
+	InvalidEndDate:               {Turkish: "Geçersiz bitiş tarihi formatı.", English: "Invalid end date format."},
+	TokenMissing:                 {Turkish: "Token eksik.", English: "Token is missing."},
+
+	// Kimlik doğrulama ve yetkilendirme
+	Unauthenticated:       {Turkish: "Oturum açmanız gerekiyor.", English: "Authentication is required."},
+	Forbidden:             {Turkish: "Bu işlem için gerekli yetkiniz yok: %v", English: "You do not have the required permission: %v"},
+	InvalidCredentials:    {Turkish: "Email veya Şifre hatalı.", English: "Email or password is incorrect."},
+	LoginPasswordRequired: {Turkish: "Giriş yapabilmeniz için Parolanızı girmeniz gereklidir.", English: "Password is required to sign in."},
+	LoginEmailRequired:    {Turkish: "Giriş yapabilmeniz için Email girmeniz gereklidir.", English: "Email is required to sign in."},
+	LoginEmailInvalid:     {Turkish: "Email adresi doğrulanamadı.", English: "Email address is not valid."},
+	PermissionNotFound:    {Turkish: "Gerekli yetki bulunamadı.", English: "The required permission was not found."},
+	InvalidSettingType:    {Turkish: "Hatalı veri türü.", English: "Invalid setting value type."},
+
+	// Doğrulama
+	Required:               {Turkish: "%v alanı zorunludur.", English: "%v is required."},
+	MaxLength:              {Turkish: "%v %d karakterden uzun olamaz.", English: "%v cannot be longer than %d characters."},
+	InvalidEmail:           {Turkish: "Geçerli bir e-posta adresi giriniz.", English: "Please enter a valid email address."},
+	InvalidId:              {Turkish: "Geçersiz ID.", English: "Invalid ID."},
+	InvalidUserId:          {Turkish: "Geçersiz kullanıcı ID.", English: "Invalid user ID."},
+	InvalidClientId:        {Turkish: "Geçersiz müşteri ID.", English: "Invalid client ID."},
+	InvalidClientProjectId: {Turkish: "Geçersiz proje ID.", English: "Invalid project ID."},
+	DateRangeRequired:      {Turkish: "Başlangıç ve bitiş tarihleri zorunludur.", English: "Start and end dates are required."},
+	EndBeforeStart:         {Turkish: "Bitiş zamanı, başlangıç zamanından önce olamaz.", English: "End time cannot be before start time."},
+	InvalidStatus:          {Turkish: "Geçersiz durum değeri.", English: "Invalid status value."},
+	InvalidFields:          {Turkish: "Gönderilen verilerde hatalı alanlar var.", English: "Some fields are invalid."},
+	MinLength:              {Turkish: "%v en az %d karakter olmalıdır.", English: "%v must be at least %d characters long."},
+	InvalidValue:           {Turkish: "%v alanının değeri geçersiz.", English: "%v has an invalid value."},
+	NotBefore:              {Turkish: "%v, %v alanından önce olamaz.", English: "%v cannot be before %v."},
+	InvalidTaxNumber:       {Turkish: "Geçerli bir vergi kimlik numarası (VKN) veya T.C. kimlik numarası giriniz.", English: "Please enter a valid tax number (VKN) or Turkish identity number (TCKN)."},
+	InvalidCurrency:        {Turkish: "Geçerli bir ISO 4217 para birimi kodu giriniz (örn. TRY).", English: "Please enter a valid ISO 4217 currency code (e.g. TRY)."},
+	NotProjectMember:       {Turkish: "Kullanıcı bu projenin üyesi değil.", English: "The user is not a member of this project."},
+	TaskNotInProject:       {Turkish: "Görev bu projeye ait değil.", English: "The task does not belong to this project."},
+	MaxItems:               {Turkish: "%v en fazla %d öğe içerebilir.", English: "%v can contain at most %d items."},
+	InvalidEventType:       {Turkish: "Bilinmeyen olay türü: %v", English: "Unknown event type: %v"},
+	PrivateWebhookUrl:      {Turkish: "Webhook adresi yerel veya özel bir ağı gösteremez.", English: "The webhook address cannot point to a local or private network."},
+
+	// Alan adları (validation.* mesajlarının parametreleri)
+	FieldShortTitle:      {Turkish: "Kısa başlık", English: "Short title"},
+	FieldTitle:           {Turkish: "Başlık", English: "Title"},
+	FieldClient:          {Turkish: "Müşteri", English: "Client"},
+	FieldClientProject:   {Turkish: "Proje", English: "Project"},
+	FieldProjectName:     {Turkish: "Proje adı", English: "Project name"},
+	FieldSystemUser:      {Turkish: "Kullanıcı", English: "User"},
+	FieldName:            {Turkish: "Ad", English: "Name"},
+	FieldSurname:         {Turkish: "Soyad", English: "Surname"},
+	FieldEmail:           {Turkish: "E-posta", English: "Email"},
+	FieldPassword:        {Turkish: "Şifre", English: "Password"},
+	FieldPasswordSalt:    {Turkish: "Şifre tuzu (salt)", English: "Password salt"},
+	FieldKey:             {Turkish: "Anahtar (key)", English: "Key"},
+	FieldValue:           {Turkish: "Değer (value)", English: "Value"},
+	FieldDescription:     {Turkish: "Açıklama", English: "Description"},
+	FieldStartDateTime:   {Turkish: "Başlangıç zamanı", English: "Start time"},
+	FieldEndDateTime:     {Turkish: "Bitiş zamanı", English: "End time"},
+	FieldStatus:          {Turkish: "Durum", English: "Status"},
+	FieldTaxOffice:       {Turkish: "Vergi dairesi", English: "Tax office"},
+	FieldTaxNumber:       {Turkish: "Vergi numarası", English: "Tax number"},
+	FieldBillingAddress:  {Turkish: "Fatura adresi", English: "Billing address"},
+	FieldCurrency:        {Turkish: "Para birimi", English: "Currency"},
+	FieldPaymentTermDays: {Turkish: "Ödeme vadesi (gün)", English: "Payment term (days)"},
+	FieldContactRole:     {Turkish: "Görev", English: "Role"},
+	FieldPhone:           {Turkish: "Telefon", English: "Phone"},
+	FieldBudgetHours:     {Turkish: "Saat bütçesi", English: "Hour budget"},
+	FieldBudgetAmount:    {Turkish: "Tutar bütçesi", English: "Amount budget"},
+	FieldHourlyRate:      {Turkish: "Saatlik ücret", English: "Hourly rate"},
+	FieldBudgetStartDate: {Turkish: "Bütçe başlangıç tarihi", English: "Budget start date"},
+	FieldBudgetEndDate:   {Turkish: "Bütçe bitiş tarihi", English: "Budget end date"},
+	FieldMemberRole:      {Turkish: "Proje rolü", English: "Project role"},
+	FieldTask:            {Turkish: "Görev", English: "Task"},
+	FieldTaskName:        {Turkish: "Görev adı", English: "Task name"},
+	FieldEstimateHours:   {Turkish: "Tahmini süre (saat)", English: "Estimated hours"},
+	FieldTagName:         {Turkish: "Etiket adı", English: "Tag name"},
+	FieldColor:           {Turkish: "Renk", English: "Color"},
+	FieldTags:            {Turkish: "Etiketler", English: "Tags"},
+	FieldUrl:             {Turkish: "Adres (URL)", English: "URL"},
+	FieldEventTypes:      {Turkish: "Olay türleri", English: "Event types"},
+	FieldSecret:          {Turkish: "İmza anahtarı", English: "Signing secret"},
+
+	// Kayıtlar
+	ClientNotFound:        {Turkish: "Müşteri bulunamadı.", English: "Client not found."},
+	ClientProjectNotFound: {Turkish: "Proje bulunamadı.", English: "Project not found."},
+	ClientContactNotFound: {Turkish: "Müşteri yetkilisi bulunamadı.", English: "Client contact not found."},
+	ClientProjectInactive: {Turkish: "Proje aktif olmadığı için zaman kaydı girilemez.", English: "The project is inactive; time cannot be logged on it."},
+	ClientInactive:        {Turkish: "Müşteri aktif olmadığı için zaman kaydı girilemez.", English: "The client is inactive; time cannot be logged on its projects."},
+	ClientArchived:        {Turkish: "Müşteri arşivde; önce müşteri arşivden çıkarılmalıdır.", English: "The client is archived; unarchive the client first."},
+	TimingProjectArchived: {Turkish: "Projesi arşivde olan zaman kaydı değiştirilemez.", English: "The timing belongs to an archived project and cannot be changed."},
+	MemberNotFound:        {Turkish: "Proje üyesi bulunamadı.", English: "Project member not found."},
+	MemberExists:          {Turkish: "Kullanıcı bu projenin zaten üyesi.", English: "The user is already a member of this project."},
+	TaskNotFound:          {Turkish: "Görev bulunamadı.", English: "Task not found."},
+	TaskExists:            {Turkish: "Projede bu adla bir görev zaten var.", English: "The project already has a task with this name."},
+	TagNotFound:           {Turkish: "Etiket bulunamadı.", English: "Tag not found."},
+	TagExists:             {Turkish: "Bu adla bir etiket zaten var.", English: "A tag with this name already exists."},
+	WebhookNotFound:       {Turkish: "Webhook aboneliği bulunamadı.", English: "Webhook subscription not found."},
+	DeliveryNotFound:      {Turkish: "Webhook gönderimi bulunamadı.", English: "Webhook delivery not found."},
+	ProjectBudgetExceeded: {Turkish: "Bu kayıt projenin bütçesini aşıyor (kalan %.2f saat).", English: "This entry exceeds the project budget (%.2f hours left)."},
+	TimingNotFound:        {Turkish: "Zaman kaydı bulunamadı.", English: "Timing not found."},
+	SystemUserNotFound:    {Turkish: "Kullanıcı bulunamadı.", English: "User not found."},
+	SystemUserEmailExists: {Turkish: "Bu e-posta adresiyle kayıtlı bir kullanıcı zaten var.", English: "A user with this email address already exists."},
+	SystemUserHasSettings: {Turkish: "Kullanıcının ayarları olduğu için silinemez.", English: "The user has settings and cannot be deleted."},
+	SystemUserHasTimings:  {Turkish: "Kullanıcının zaman kayıtları olduğu için silinemez.", English: "The user has timings and cannot be deleted."},
+	SettingNotFound:       {Turkish: "Kayıt bulunamadı.", English: "Setting not found."},
+	SettingUpdateFailed:   {Turkish: "Kayıt güncellenemedi.", English: "The setting could not be updated."},
+
+	// Silmeyle birlikte silinecek bağlı kayıtlar
+	ClientHasReferences:        {Turkish: "Müşteri silinirse bağlı kayıtları da silinir. Yine de silmek için force=true kullanılmalıdır.", English: "Deleting the client also deletes its dependent records. Use force=true to delete it anyway."},
+	ClientProjectHasReferences: {Turkish: "Proje silinirse bağlı kayıtları da silinir. Yine de silmek için force=true kullanılmalıdır.", English: "Deleting the project also deletes its dependent records. Use force=true to delete it anyway."},
+	ReferencedClientProjects:   {Turkish: "%d proje", English: "%d projects"},
+	ReferencedClientContacts:   {Turkish: "%d yetkili", English: "%d contacts"},
+	ReferencedMembers:          {Turkish: "%d proje üyeliği", English: "%d project memberships"},
+	ReferencedTasks:            {Turkish: "%d görev", English: "%d tasks"},
+	ReferencedTimings:          {Turkish: "%d zaman kaydı", English: "%d timings"},
+	ReferencedTimingTags:       {Turkish: "%d etiket bağlantısı", English: "%d tag links"},
+
+	// Sorgu (QueryModel)
+	QueryCursorWithPage:       {Turkish: "İmleç ile sayfa numarası birlikte kullanılamaz.", English: "Cursor and page number cannot be used together."},
+	QueryInvalidPage:          {Turkish: "Geçersiz sayfa numarası. Sayfa numarası 1 veya daha büyük olmalıdır.", English: "Invalid page number. Page number must be 1 or greater."},
+	QueryInvalidPageSize:      {Turkish: "Gösterilecek veri yoktur.", English: "Records per page must be at least 1."},
+	QueryPageSizeTooLarge:     {Turkish: "Sayfa başına en fazla %d kayıt istenebilir.", English: "At most %d records can be requested per page."},
+	QueryUnknownField:         {Turkish: "Bilinmeyen alan: '%v'. Kullanılabilir alanlar: %v", English: "Unknown field: '%v'. Available fields: %v"},
+	QueryInvalidValue:         {Turkish: "'%v' alanı için geçersiz değer: '%v'", English: "Invalid value for field '%v': '%v'"},
+	QueryFieldNotSearchable:   {Turkish: "'%v' alanında arama yapılamaz.", English: "Field '%v' is not searchable."},
+	QueryFieldNotSortable:     {Turkish: "'%v' alanına göre sıralanamaz.", English: "Results cannot be sorted by field '%v'."},
+	QueryInvalidSortDirection: {Turkish: "Geçersiz sıralama yönü: %d (0: artan, 1: azalan)", English: "Invalid sort direction: %d (0: ascending, 1: descending)"},
+	QueryCursorNotSupported:   {Turkish: "Bu sıralama ile imleç kullanılamaz; sayfa numarası kullanın.", English: "A cursor cannot be used with this sorting; use a page number."},
+	QueryInvalidCursor:        {Turkish: "Geçersiz imleç.", English: "Invalid cursor."},
+	QueryCursorSortMismatch:   {Turkish: "İmleç farklı bir sıralama ile oluşturulmuş.", English: "The cursor was created with a different sorting."},
+
+	// Filtre ifadesi
+	FilterTooLong:             {Turkish: "Filtre ifadesi en fazla %d karakter olabilir.", English: "The filter can be at most %d characters long."},
+	FilterTooDeep:             {Turkish: "Filtre ifadesi en fazla %d seviye iç içe parantez içerebilir.", English: "The filter can contain at most %d levels of nested parentheses."},
+	FilterTooManyConditions:   {Turkish: "Filtre ifadesi en fazla %d koşul içerebilir.", English: "The filter can contain at most %d conditions."},
+	FilterTooManyValues:       {Turkish: "'in' listesi en fazla %d değer içerebilir.", English: "An 'in' list can contain at most %d values."},
+	FilterLikeRequiresText:    {Turkish: "'like' yalnızca metin alanlarında kullanılabilir: '%v'", English: "'like' can only be used on text fields: '%v'"},
+	FilterOperatorNotAllowed:  {Turkish: "'%v' operatörü '%v' alanında kullanılamaz.", English: "Operator '%v' cannot be used on field '%v'."},
+	FilterSyntax:              {Turkish: "Geçersiz filtre ifadesi (konum %d): %v", English: "Invalid filter (position %d): %v"},
+	FilterUseNotEquals:        {Turkish: "'!' yerine '!=' kullanın", English: "use '!=' instead of '!'"},
+	FilterUnclosedQuote:       {Turkish: "kapanmamış tırnak", English: "unclosed quote"},
+	FilterUnclosedParen:       {Turkish: "kapanmamış parantez", English: "unclosed parenthesis"},
+	FilterUnexpectedToken:     {Turkish: "beklenmeyen '%v'", English: "unexpected '%v'"},
+	FilterFieldExpected:       {Turkish: "alan adı bekleniyor", English: "field name expected"},
+	FilterNullExpected:        {Turkish: "'IS' sonrasında 'NULL' veya 'NOT NULL' bekleniyor", English: "'NULL' or 'NOT NULL' expected after 'IS'"},
+	FilterOperatorExpected:    {Turkish: "'%v' alanından sonra geçerli bir operatör bekleniyor (=, !=, <, <=, >, >=, in, between, like, is null)", English: "a valid operator is expected after '%v' (=, !=, <, <=, >, >=, in, between, like, is null)"},
+	FilterUnknownOperator:     {Turkish: "bilinmeyen operatör '%v'", English: "unknown operator '%v'"},
+	FilterInParenExpected:     {Turkish: "'in' sonrasında '(' bekleniyor", English: "'(' expected after 'in'"},
+	FilterInSeparatorExpected: {Turkish: "'in' listesinde ',' veya ')' bekleniyor", English: "',' or ')' expected in 'in' list"},
+	FilterBetweenAndExpected:  {Turkish: "'between' değerleri arasında 'and' bekleniyor", English: "'and' expected between 'between' values"},
+	FilterValueExpected:       {Turkish: "'%v' alanı için değer bekleniyor", English: "a value is expected for field '%v'"},
+
+	// Arama
+	SearchTermTooShort: {Turkish: "Arama ifadesi en az %d karakter olmalıdır.", English: "The search term must be at least %d characters long."},
+	SearchTermTooLong:  {Turkish: "Arama ifadesi en fazla %d karakter olabilir.", English: "The search term can be at most %d characters long."},
+	SearchInvalidType:  {Turkish: "Geçersiz arama türü: '%v'", English: "Invalid search type: '%v'"},
+}
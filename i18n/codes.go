@@ -0,0 +1,177 @@
+package i18n
+
+// Mesaj kodları. Kodlar istemcilerle yapılan sözleşmenin parçasıdır; değiştirilmemeli,
+// kaldırılmamalı, yalnızca yenileri eklenmelidir.
+const (
+	// Genel
+	Unexpected         = "error.unexpected"
+	DatabaseError      = "error.database"
+	DuplicateRecord    = "error.duplicate"
+	ReferenceViolation = "error.reference"
+	ValidationFailed   = "validation.failed"
+
+	// İstek
+	InvalidRequest               = "request.invalid"
+	InvalidLoginRequest          = "request.invalid_login"
+	InvalidIdFormat              = "request.invalid_id"
+	InvalidUserIdFormat          = "request.invalid_user_id"
+	InvalidClientIdFormat        = "request.invalid_client_id"
+	InvalidClientProjectIdFormat = "request.invalid_client_project_id"
+	InvalidContactIdFormat       = "request.invalid_contact_id"
+	InvalidMemberIdFormat        = "request.invalid_member_id"
+	InvalidTaskIdFormat          = "request.invalid_task_id"
+	InvalidDeliveryIdFormat      = "request.invalid_delivery_id"
+	InvalidArchivedFilter        = "request.invalid_archived"
+	InvalidStartDate             = "request.invalid_start_date"
+	InvalidEndDate               = "request.invalid_end_date"
+	TokenMissing                 = "request.token_missing"
+
+	// Kimlik doğrulama ve yetkilendirme
+	Unauthenticated       = "auth.unauthenticated"
+	Forbidden             = "auth.forbidden"
+	InvalidCredentials    = "auth.invalid_credentials"
+	LoginPasswordRequired = "auth.password_required"
+	LoginEmailRequired    = "auth.email_required"
+	LoginEmailInvalid     = "auth.email_invalid"
+	PermissionNotFound    = "auth.permission_not_found"
+	InvalidSettingType    = "auth.invalid_setting_type"
+
+	// Doğrulama
+	Required               = "validation.required"
+	MaxLength              = "validation.max_length"
+	InvalidEmail           = "validation.email"
+	InvalidId              = "validation.invalid_id"
+	InvalidUserId          = "validation.invalid_user_id"
+	InvalidClientId        = "validation.invalid_client_id"
+	InvalidClientProjectId = "validation.invalid_client_project_id"
+	DateRangeRequired      = "validation.date_range_required"
+	EndBeforeStart         = "validation.end_before_start"
+	InvalidStatus          = "validation.invalid_status"
+	InvalidFields          = "validation.invalid_fields"
+	MinLength              = "validation.min_length"
+	InvalidValue           = "validation.invalid_value"
+	NotBefore              = "validation.not_before"
+	InvalidTaxNumber       = "validation.tax_number"
+	InvalidCurrency        = "validation.currency"
+	NotProjectMember       = "validation.not_project_member"
+	TaskNotInProject       = "validation.task_not_in_project"
+	MaxItems               = "validation.max_items"
+	InvalidEventType       = "validation.event_type"
+	PrivateWebhookUrl      = "validation.private_webhook_url"
+
+	// Alan adları (validation.* mesajlarının parametreleri)
+	FieldShortTitle      = "field.short_title"
+	FieldTitle           = "field.title"
+	FieldClient          = "field.client"
+	FieldClientProject   = "field.client_project"
+	FieldProjectName     = "field.project_name"
+	FieldSystemUser      = "field.system_user"
+	FieldName            = "field.name"
+	FieldSurname         = "field.surname"
+	FieldEmail           = "field.email"
+	FieldPassword        = "field.password"
+	FieldPasswordSalt    = "field.password_salt"
+	FieldKey             = "field.key"
+	FieldValue           = "field.value"
+	FieldDescription     = "field.description"
+	FieldStartDateTime   = "field.start_date_time"
+	FieldEndDateTime     = "field.end_date_time"
+	FieldStatus          = "field.status"
+	FieldTaxOffice       = "field.tax_office"
+	FieldTaxNumber       = "field.tax_number"
+	FieldBillingAddress  = "field.billing_address"
+	FieldCurrency        = "field.currency"
+	FieldPaymentTermDays = "field.payment_term_days"
+	FieldContactRole     = "field.contact_role"
+	FieldPhone           = "field.phone"
+	FieldBudgetHours     = "field.budget_hours"
+	FieldBudgetAmount    = "field.budget_amount"
+	FieldHourlyRate      = "field.hourly_rate"
+	FieldBudgetStartDate = "field.budget_start_date"
+	FieldBudgetEndDate   = "field.budget_end_date"
+	FieldMemberRole      = "field.member_role"
+	FieldTask            = "field.task"
+	FieldTaskName        = "field.task_name"
+	FieldEstimateHours   = "field.estimate_hours"
+	FieldTagName         = "field.tag_name"
+	FieldColor           = "field.color"
+	FieldTags            = "field.tags"
+	FieldUrl             = "field.url"
+	FieldEventTypes      = "field.event_types"
+	FieldSecret          = "field.secret"
+
+	// Kayıtlar
+	ClientNotFound        = "client.not_found"
+	ClientProjectNotFound = "client_project.not_found"
+	ClientContactNotFound = "client_contact.not_found"
+	ProjectBudgetExceeded = "client_project.budget_exceeded"
+	ClientProjectInactive = "client_project.inactive"
+	ClientInactive        = "client.inactive"
+	ClientArchived        = "client.archived"
+	TimingProjectArchived = "timing.project_archived"
+	MemberNotFound        = "client_project_member.not_found"
+	MemberExists          = "client_project_member.exists"
+	TaskNotFound          = "project_task.not_found"
+	TaskExists            = "project_task.exists"
+	TagNotFound           = "tag.not_found"
+	TagExists             = "tag.exists"
+	WebhookNotFound       = "webhook.not_found"
+	DeliveryNotFound      = "webhook_delivery.not_found"
+	TimingNotFound        = "timing.not_found"
+	SystemUserNotFound    = "system_user.not_found"
+	SystemUserEmailExists = "system_user.email_exists"
+	SystemUserHasSettings = "system_user.referenced_by_settings"
+	SystemUserHasTimings  = "system_user.referenced_by_timings"
+	SettingNotFound       = "setting.not_found"
+	SettingUpdateFailed   = "setting.update_failed"
+
+	// Silmeyle birlikte silinecek bağlı kayıtlar
+	ClientHasReferences        = "client.has_references"
+	ClientProjectHasReferences = "client_project.has_references"
+	ReferencedClientProjects   = "references.client_projects"
+	ReferencedClientContacts   = "references.client_contacts"
+	ReferencedMembers          = "references.client_project_members"
+	ReferencedTasks            = "references.project_tasks"
+	ReferencedTimings          = "references.timings"
+	ReferencedTimingTags       = "references.timing_tags"
+
+	// Sorgu (QueryModel)
+	QueryCursorWithPage       = "query.cursor_with_page"
+	QueryInvalidPage          = "query.invalid_page"
+	QueryInvalidPageSize      = "query.invalid_page_size"
+	QueryPageSizeTooLarge     = "query.page_size_too_large"
+	QueryUnknownField         = "query.unknown_field"
+	QueryInvalidValue         = "query.invalid_value"
+	QueryFieldNotSearchable   = "query.field_not_searchable"
+	QueryFieldNotSortable     = "query.field_not_sortable"
+	QueryInvalidSortDirection = "query.invalid_sort_direction"
+	QueryCursorNotSupported   = "query.cursor_not_supported"
+	QueryInvalidCursor        = "query.invalid_cursor"
+	QueryCursorSortMismatch   = "query.cursor_sort_mismatch"
+
+	// Filtre ifadesi
+	FilterTooLong             = "filter.too_long"
+	FilterTooDeep             = "filter.too_deep"
+	FilterTooManyConditions   = "filter.too_many_conditions"
+	FilterTooManyValues       = "filter.too_many_values"
+	FilterLikeRequiresText    = "filter.like_requires_text"
+	FilterOperatorNotAllowed  = "filter.operator_not_allowed"
+	FilterSyntax              = "filter.syntax"
+	FilterUseNotEquals        = "filter.syntax.use_not_equals"
+	FilterUnclosedQuote       = "filter.syntax.unclosed_quote"
+	FilterUnclosedParen       = "filter.syntax.unclosed_paren"
+	FilterUnexpectedToken     = "filter.syntax.unexpected"
+	FilterFieldExpected       = "filter.syntax.field_expected"
+	FilterNullExpected        = "filter.syntax.null_expected"
+	FilterOperatorExpected    = "filter.syntax.operator_expected"
+	FilterUnknownOperator     = "filter.syntax.unknown_operator"
+	FilterInParenExpected     = "filter.syntax.in_paren_expected"
+	FilterInSeparatorExpected = "filter.syntax.in_separator_expected"
+	FilterBetweenAndExpected  = "filter.syntax.between_and_expected"
+	FilterValueExpected       = "filter.syntax.value_expected"
+
+	// Arama
+	SearchTermTooShort = "search.term_too_short"
+	SearchTermTooLong  = "search.term_too_long"
+	SearchInvalidType  = "search.invalid_type"
+)
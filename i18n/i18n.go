@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Language, hata mesajlarının gösterileceği dildir
+type Language string
+
+const (
+	Turkish Language = "tr"
+	English Language = "en"
+
+	// Default, dil belirlenemediğinde ve katalogda çevirisi olmayan mesajlarda kullanılır
+	Default = Turkish
+)
+
+// Message, kararlı bir kod ve parametrelerden oluşan, dilden bağımsız mesajdır. Metin,
+// isteğin diline göre yanıt yazılırken üretilir. error arayüzünü uygular; Error()
+// varsayılan dildeki metni döndürür.
+type Message struct {
+	Code   string              `json:"code"`             // Makine tarafından okunabilir kod, örn. "client.not_found"
+	Args   []any               `json:"args,omitempty"`   // Mesaj parametreleri
+	Fields map[string]*Message `json:"fields,omitempty"` // Alan bazlı hatalar; anahtar alanın JSON adıdır
+}
+
+// New, verilen kod ve parametrelerle bir mesaj oluşturur. Parametre olarak verilen
+// *Message değerleri (örn. alan adları) de aynı dilde çevrilir.
+func New(code string, args ...any) *Message {
+	return &Message{Code: code, Args: args}
+}
+
+func (m *Message) Error() string {
+	return m.Text(Default)
+}
+
+// WithField, alan bazlı bir hata ekler ve mesajı döndürür
+func (m *Message) WithField(field string, message *Message) *Message {
+	if m.Fields == nil {
+		m.Fields = map[string]*Message{}
+	}
+	m.Fields[field] = message
+	return m
+}
+
+// Text, mesajı verilen dilde döndürür. Alan bazlı hatalar, alan adına göre sıralanarak
+// mesajın sonuna eklenir.
+func (m *Message) Text(language Language) string {
+	text := Translate(language, m.Code, m.Args...)
+	if len(m.Fields) == 0 {
+		return text
+	}
+
+	fields := m.FieldTexts(language)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := []string{text}
+	for _, name := range names {
+		parts = append(parts, fields[name])
+	}
+	return strings.Join(parts, " ")
+}
+
+// FieldTexts, alan bazlı hataları verilen dilde döndürür
+func (m *Message) FieldTexts(language Language) map[string]string {
+	if len(m.Fields) == 0 {
+		return nil
+	}
+	texts := make(map[string]string, len(m.Fields))
+	for name, message := range m.Fields {
+		texts[name] = message.Text(language)
+	}
+	return texts
+}
+
+// Translate, kodun verilen dildeki karşılığını parametrelerle biçimlendirir. Dilde karşılığı
+// olmayan kodlar için varsayılan dil, katalogda olmayan kodlar için kodun kendisi kullanılır.
+func Translate(language Language, code string, args ...any) string {
+	entry, ok := catalog[code]
+	if !ok {
+		return code
+	}
+	format, ok := entry[language]
+	if !ok {
+		format = entry[Default]
+	}
+	if len(args) == 0 {
+		return format
+	}
+
+	translated := make([]any, len(args))
+	for i, arg := range args {
+		if message, ok := arg.(*Message); ok {
+			translated[i] = message.Text(language)
+		} else {
+			translated[i] = arg
+		}
+	}
+	return fmt.Sprintf(format, translated...)
+}
+
+// Parse, "en", "en-US" veya "tr_TR" biçimindeki dil etiketini desteklenen bir dile çevirir
+func Parse(tag string) (Language, bool) {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	primary, _, _ = strings.Cut(primary, "_")
+	switch Language(primary) {
+	case Turkish:
+		return Turkish, true
+	case English:
+		return English, true
+	}
+	return "", false
+}
+
+// FromAcceptLanguage, Accept-Language başlığındaki desteklenen ve önceliği en yüksek dili seçer
+func FromAcceptLanguage(header string) (Language, bool) {
+	type candidate struct {
+		language Language
+		quality  float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		language, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{language: language, quality: quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].language, true
+}
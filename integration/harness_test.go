@@ -0,0 +1,451 @@
+//go:build integration
+
+// Package integration, uygulamayı geçici Postgres ve Redis süreçlerine karşı uçtan uca
+// test eder. Testler yerel initdb, pg_ctl ve redis-server ikili dosyalarını kullanır;
+// bulunamazlarsa paket atlanır.
+//
+//	go test -tags integration -count=1 ./integration/...
+//
+// İkili dosyalar PATH dışındaysa LMS_TEST_PG_BIN (initdb ve pg_ctl'nin bulunduğu dizin)
+// ve LMS_TEST_REDIS_SERVER (redis-server yolu) ile verilir.
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"lms-web-services-main/application"
+	"lms-web-services-main/database/datasources"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	databaseName = "lms"
+
+	adminEmail    = "admin@example.com"
+	adminPassword = "Parola.123"
+)
+
+// env, TestMain'in başlattığı ortamdır; testler reset ile temiz bir başlangıç alır
+var env *environment
+
+type environment struct {
+	config   *application.Config
+	database *gorm.DB
+	cache    *redis.Client
+	app      *application.Application
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	initdb, pgCtl, redisServer, err := findBinaries()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "skipping integration tests: %v\n", err)
+		return 0
+	}
+
+	dir, err := os.MkdirTemp("", "lms-integration-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	databaseConfig, stopPostgres, err := startPostgres(initdb, pgCtl, filepath.Join(dir, "postgres"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer stopPostgres()
+
+	cacheConfig, stopRedis, err := startRedis(redisServer, filepath.Join(dir, "redis"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer stopRedis()
+
+	env, err = newEnvironment(databaseConfig, cacheConfig)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer env.close()
+
+	return m.Run()
+}
+
+// #region Binaries
+
+func findBinaries() (initdb string, pgCtl string, redisServer string, err error) {
+	lookup := func(name string, dir string) (string, error) {
+		if dir != "" {
+			name = filepath.Join(dir, name)
+		}
+		return exec.LookPath(name)
+	}
+
+	pgBin := os.Getenv("LMS_TEST_PG_BIN")
+	if initdb, err = lookup("initdb", pgBin); err != nil {
+		return "", "", "", err
+	}
+	if pgCtl, err = lookup("pg_ctl", pgBin); err != nil {
+		return "", "", "", err
+	}
+
+	redisServer = os.Getenv("LMS_TEST_REDIS_SERVER")
+	if redisServer == "" {
+		redisServer = "redis-server"
+	}
+	if redisServer, err = exec.LookPath(redisServer); err != nil {
+		return "", "", "", err
+	}
+	return initdb, pgCtl, redisServer, nil
+}
+
+// freePort, işletim sisteminin verdiği boş bir TCP portunu döndürür
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// #endregion Binaries
+
+// #region Postgres
+
+// startPostgres, dir altında yeni bir küme oluşturur, yalnızca 127.0.0.1'i dinleyerek
+// başlatır ve migration'ların beklediği "postgres" rolüyle lms veritabanını açar
+func startPostgres(initdb string, pgCtl string, dir string) (datasources.DatabaseConfig, func(), error) {
+	var config datasources.DatabaseConfig
+
+	output, err := exec.Command(initdb, "-D", dir, "-U", "postgres", "-A", "trust", "-E", "UTF8", "--locale=C").CombinedOutput()
+	if err != nil {
+		return config, nil, fmt.Errorf("initdb failed: %w\n%s", err, output)
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return config, nil, err
+	}
+
+	options := fmt.Sprintf("-p %d -k %s -c listen_addresses=127.0.0.1 -c fsync=off", port, dir)
+	output, err = exec.Command(pgCtl, "-D", dir, "-o", options, "-l", filepath.Join(dir, "postgres.log"), "-w", "start").CombinedOutput()
+	if err != nil {
+		return config, nil, fmt.Errorf("pg_ctl start failed: %w\n%s", err, output)
+	}
+	stop := func() {
+		exec.Command(pgCtl, "-D", dir, "-m", "immediate", "-w", "stop").Run()
+	}
+
+	config = datasources.DatabaseConfig{
+		Host:    "127.0.0.1",
+		User:    "postgres",
+		Name:    "postgres",
+		Port:    strconv.Itoa(port),
+		SSLMode: "disable",
+	}
+
+	admin, err := openDatabase(config)
+	if err != nil {
+		stop()
+		return config, nil, err
+	}
+	err = admin.Exec("CREATE DATABASE " + databaseName).Error
+	closeDatabase(admin)
+	if err != nil {
+		stop()
+		return config, nil, fmt.Errorf("creating database failed: %w", err)
+	}
+
+	config.Name = databaseName
+	return config, stop, nil
+}
+
+func openDatabase(config datasources.DatabaseConfig) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(config.DSN()), &gorm.Config{Logger: logger.Discard})
+}
+
+func closeDatabase(database *gorm.DB) {
+	if sqlDB, err := database.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
+
+// migrate, database/migrations altındaki *.up.sql dosyalarını sırayla uygular
+func migrate(database *gorm.DB) error {
+	files, err := filepath.Glob(filepath.Join("..", "database", "migrations", "*.up.sql"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no migrations found")
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		script, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		// Parametresiz Exec basit sorgu protokolünü kullanır; dosyadaki tüm ifadeler tek seferde çalışır
+		if err := database.Exec(string(script)).Error; err != nil {
+			return fmt.Errorf("migration %s failed: %w", filepath.Base(file), err)
+		}
+	}
+	return nil
+}
+
+// #endregion Postgres
+
+// #region Redis
+
+func startRedis(redisServer string, dir string) (datasources.CacheConfig, func(), error) {
+	var config datasources.CacheConfig
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return config, nil, err
+	}
+	port, err := freePort()
+	if err != nil {
+		return config, nil, err
+	}
+
+	cmd := exec.Command(redisServer, "--port", strconv.Itoa(port), "--bind", "127.0.0.1", "--dir", dir, "--save", "", "--appendonly", "no")
+	if err := cmd.Start(); err != nil {
+		return config, nil, fmt.Errorf("starting redis-server failed: %w", err)
+	}
+	stop := func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}
+
+	config.Address = fmt.Sprintf("127.0.0.1:%d", port)
+	client := redis.NewClient(&redis.Options{Addr: config.Address})
+	defer client.Close()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for client.Ping(context.Background()).Err() != nil {
+		if time.Now().After(deadline) {
+			stop()
+			return config, nil, fmt.Errorf("redis-server did not start on %s", config.Address)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return config, stop, nil
+}
+
+// #endregion Redis
+
+// #region Environment
+
+func newEnvironment(databaseConfig datasources.DatabaseConfig, cacheConfig datasources.CacheConfig) (*environment, error) {
+	gin.SetMode(gin.TestMode)
+
+	database, err := openDatabase(databaseConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(database); err != nil {
+		closeDatabase(database)
+		return nil, err
+	}
+
+	config := &application.Config{
+		ServerAddress:   "127.0.0.1:0",
+		ShutdownTimeout: time.Second,
+		RequestTimeout:  10 * time.Second,
+		Database:        databaseConfig,
+		Cache:           cacheConfig,
+	}
+	app, err := application.NewApplication(config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		closeDatabase(database)
+		return nil, err
+	}
+
+	return &environment{
+		config:   config,
+		database: database,
+		cache:    redis.NewClient(&redis.Options{Addr: cacheConfig.Address}),
+		app:      app,
+	}, nil
+}
+
+func (e *environment) close() {
+	e.app.Close()
+	e.cache.Close()
+	closeDatabase(e.database)
+}
+
+// #endregion Environment
+
+// #region Fixtures
+
+// fixtures, her testin başında yüklenen kayıtlardır
+type fixtures struct {
+	admin   *datamodels.SystemUser
+	client  *datamodels.Client
+	project *datamodels.ClientProject
+	member  *datamodels.ClientProjectMember
+	timing  *datamodels.Timing
+}
+
+// reset, tabloları ve önbelleği boşaltır, fixture'ları yükler ve yönetici olarak oturum açar.
+// Dönen session, isteklere X-Token olarak eklenir.
+func reset(t *testing.T) (*session, *fixtures) {
+	t.Helper()
+
+	err := env.database.Exec(`TRUNCATE "WebhookDeliveries", "WebhookSubscriptions", "OutboxEvents", "TimingTags", "Tags", "Timings", "ProjectTasks", "ClientProjectMembers", "ClientProjects", "ClientContacts", "Clients", "SystemUserSettings", "SystemUsers" RESTART IDENTITY CASCADE`).Error
+	if err != nil {
+		t.Fatalf("truncating tables failed: %v", err)
+	}
+	if err := env.cache.FlushDB(context.Background()).Err(); err != nil {
+		t.Fatalf("flushing cache failed: %v", err)
+	}
+
+	data := seed(t)
+	s := &session{handler: env.app.Handler()}
+	s.login(t, adminEmail, adminPassword)
+	return s, data
+}
+
+func seed(t *testing.T) *fixtures {
+	t.Helper()
+
+	salt := utils.GenerateRandomNumeric(15)
+	data := &fixtures{
+		admin: &datamodels.SystemUser{
+			Id:           uuid.New(),
+			Name:         "Ada",
+			Surname:      "Yılmaz",
+			Email:        adminEmail,
+			Password:     utils.ComputeSHA256(adminPassword, salt),
+			PasswordSalt: salt,
+			IsActive:     true,
+		},
+		client: &datamodels.Client{ShortTitle: "ACME", Title: "ACME Yazılım A.Ş.", Notes: "Kurumsal web projeleri", IsActive: true},
+	}
+	mustCreate(t, data.admin)
+	mustCreate(t, data.client)
+
+	for _, permission := range allPermissions {
+		mustCreate(t, &datamodels.SystemUserSetting{SystemUserId: data.admin.Id, Key: permission, Value: "1"})
+	}
+
+	data.project = &datamodels.ClientProject{ClientId: data.client.Id, Name: "Kurumsal web sitesi", IsActive: true}
+	mustCreate(t, data.project)
+	data.member = &datamodels.ClientProjectMember{ClientProjectId: data.project.Id, SystemUserId: data.admin.Id, Role: "Proje yöneticisi"}
+	mustCreate(t, data.member)
+
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	data.timing = &datamodels.Timing{
+		ClientProjectId: data.project.Id,
+		SystemUserId:    data.admin.Id,
+		Title:           "Analiz toplantısı",
+		StartDateTime:   start,
+		EndDateTime:     start.Add(time.Hour),
+	}
+	mustCreate(t, data.timing)
+	return data
+}
+
+var allPermissions = []string{
+	datamodels.SYSTEM_USERS_VIEW, datamodels.SYSTEM_USERS_ADD, datamodels.SYSTEM_USERS_UPDATE, datamodels.SYSTEM_USERS_DELETE,
+	datamodels.SYSTEM_SETTINGS_VIEW, datamodels.SYSTEM_SETTINGS_ADD, datamodels.SYSTEM_SETTINGS_UPDATE, datamodels.SYSTEM_SETTINGS_DELETE,
+	datamodels.CLIENTS_VIEW, datamodels.CLIENTS_ADD, datamodels.CLIENTS_UPDATE, datamodels.CLIENTS_DELETE, datamodels.CLIENTS_FORCE_DELETE,
+	datamodels.CLIENTPROJECTS_VIEW, datamodels.CLIENTPROJECTS_ADD, datamodels.CLIENTPROJECTS_UPDATE, datamodels.CLIENTPROJECTS_DELETE, datamodels.CLIENTPROJECTS_FORCE_DELETE,
+	datamodels.TIMINGS_VIEW, datamodels.TIMINGS_ADD, datamodels.TIMINGS_UPDATE, datamodels.TIMINGS_DELETE, datamodels.TIMINGS_ADD_FOR_OTHERS,
+	datamodels.WEBHOOKS_VIEW, datamodels.WEBHOOKS_ADD, datamodels.WEBHOOKS_UPDATE, datamodels.WEBHOOKS_DELETE,
+}
+
+func mustCreate(t *testing.T, model any) {
+	t.Helper()
+	if err := env.database.Create(model).Error; err != nil {
+		t.Fatalf("seeding %T failed: %v", model, err)
+	}
+}
+
+// #endregion Fixtures
+
+// #region Session
+
+// session, uygulamaya gerçek bir oturum jetonuyla istek gönderir
+type session struct {
+	handler http.Handler
+	token   string
+}
+
+func (s *session) login(t *testing.T, email string, password string) {
+	t.Helper()
+
+	recorder := s.send(t, http.MethodPost, "/api/v1/sessions", map[string]string{"e": email, "p": password})
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("login failed with %d: %s", recorder.Code, recorder.Body)
+	}
+	var credential map[string]string
+	decode(t, recorder, &credential)
+	s.token = credential["t"]
+}
+
+// send, isteği session'ın jetonuyla gönderir. body nil değilse JSON olarak kodlanır.
+func (s *session) send(t *testing.T, method string, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	request := httptest.NewRequest(method, path, reader)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept-Language", "en")
+	if s.token != "" {
+		request.Header.Set("X-Token", s.token)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func decode(t *testing.T, recorder *httptest.ResponseRecorder, target any) {
+	t.Helper()
+	if err := json.Unmarshal(recorder.Body.Bytes(), target); err != nil {
+		t.Fatalf("decoding %q: %v", recorder.Body, err)
+	}
+}
+
+// #endregion Session
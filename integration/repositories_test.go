@@ -0,0 +1,569 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+func TestClientShortTitleIsUniqueAmongActiveClients(t *testing.T) {
+	s, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	repo := repositories.NewClientRepository(env.database)
+
+	duplicate := &datamodels.Client{ShortTitle: data.client.ShortTitle, Title: "Başka ACME", IsActive: true}
+	if result := repo.Create(c, duplicate); result.IsSuccess() || result.ErrorCode != mvc.ErrorCodeConflict {
+		t.Fatalf("want uix_clients_shorttitle_active to reject a second active client with the same short title as a conflict, got %+v", result)
+	}
+
+	// Pasif müşterinin kısa adı yeniden kullanılabilir
+	data.client.IsActive = false
+	if err := env.database.Save(data.client).Error; err != nil {
+		t.Fatal(err)
+	}
+	duplicate.Id = 0
+	if result := repo.Create(c, duplicate); !result.IsSuccess() {
+		t.Fatalf("want the short title of an inactive client to be reusable, got %s", result.ErrorMessage)
+	}
+
+	// Kısa adı yeniden kullanılan müşteri etkinleştirilemez; ihlal 409 olarak döner
+	recorder := s.send(t, http.MethodPost, fmt.Sprintf("/api/v1/clients/%d/unarchive", data.client.Id), nil)
+	if recorder.Code != http.StatusConflict || !strings.Contains(recorder.Body.String(), i18n.DuplicateRecord) {
+		t.Fatalf("want 409 %s for unarchiving into a used short title, got %d: %s", i18n.DuplicateRecord, recorder.Code, recorder.Body)
+	}
+}
+
+func TestSystemUserEmailIsUniqueAmongActiveUsers(t *testing.T) {
+	s, data := reset(t)
+	user := map[string]any{"n": "Ada", "sn": "Yılmaz", "e": adminEmail, "p": "Gizli.456", "ia": true}
+
+	if recorder := s.send(t, http.MethodPost, "/api/v1/system-users", user); recorder.Code != http.StatusConflict {
+		t.Fatalf("want 409 for an email used by an active user, got %d: %s", recorder.Code, recorder.Body)
+	}
+
+	data.admin.IsActive = false
+	if err := env.database.Save(data.admin).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := env.database.Create(&datamodels.SystemUser{Name: "Ada", Surname: "Yılmaz", Email: adminEmail, Password: "x", PasswordSalt: "x", IsActive: true}).Error; err != nil {
+		t.Fatalf("want uix_systemusers_email_active to allow reusing the email of an inactive user: %v", err)
+	}
+}
+
+func TestTimingGetAll(t *testing.T) {
+	_, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	repo := repositories.NewTimingRepository(env.database)
+
+	other := &datamodels.Client{ShortTitle: "GLOBEX", Title: "Globex Danışmanlık", IsActive: true}
+	mustCreate(t, other)
+	otherProject := &datamodels.ClientProject{ClientId: other.Id, Name: "Mobil uygulama", IsActive: true}
+	mustCreate(t, otherProject)
+	start := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	timings := map[string]*datamodels.Timing{}
+	for _, title := range []string{"Bakım", "Canlıya alım", "Tasarım"} {
+		timings[title] = &datamodels.Timing{ClientProjectId: otherProject.Id, SystemUserId: data.admin.Id, Title: title, StartDateTime: start, EndDateTime: start.Add(time.Hour)}
+		mustCreate(t, timings[title])
+	}
+	meeting := &datamodels.Tag{Name: "Toplantı", Color: "#1E88E5"}
+	mustCreate(t, meeting)
+	support := &datamodels.Tag{Name: "Destek", Color: "#43A047"}
+	mustCreate(t, support)
+	mustSucceed(t, repo.SetTags(c, timings["Bakım"].Id, []int{support.Id}))
+	mustSucceed(t, repo.SetTags(c, timings["Tasarım"].Id, []int{meeting.Id, support.Id}))
+
+	tests := []struct {
+		name       string
+		query      mvc.QueryModel
+		wantTitles []string
+		wantTotal  int64
+	}{
+		{
+			name:       "default sorting by title",
+			query:      mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10},
+			wantTitles: []string{"Analiz toplantısı", "Bakım", "Canlıya alım", "Tasarım"},
+			wantTotal:  4,
+		},
+		{
+			name:       "filter on a joined column",
+			query:      mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10, Filter: "client = 'ACME Yazılım A.Ş.'"},
+			wantTitles: []string{"Analiz toplantısı"},
+			wantTotal:  1,
+		},
+		{
+			name: "sort by client then title descending",
+			query: mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10, SortingOptions: []*mvc.DataSortingOptionItem{
+				{ColumnName: "client", Sorting: 0},
+				{ColumnName: "title", Sorting: 1},
+			}},
+			wantTitles: []string{"Analiz toplantısı", "Tasarım", "Canlıya alım", "Bakım"},
+			wantTotal:  4,
+		},
+		{
+			name:       "search across joined names",
+			query:      mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10, SearchTerm: "mobil"},
+			wantTitles: []string{"Bakım", "Canlıya alım", "Tasarım"},
+			wantTotal:  3,
+		},
+		{
+			name:       "filter with the short field names",
+			query:      mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10, Filter: fmt.Sprintf("cpid = %d and t != 'Bakım'", otherProject.Id)},
+			wantTitles: []string{"Canlıya alım", "Tasarım"},
+			wantTotal:  2,
+		},
+		{
+			name:       "filter on any of the tags",
+			query:      mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10, Filter: fmt.Sprintf("tag_id in (%d, %d)", meeting.Id, support.Id)},
+			wantTitles: []string{"Bakım", "Tasarım"},
+			wantTotal:  2,
+		},
+		{
+			name:       "filter on all of the tags",
+			query:      mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10, Filter: "tag = 'Toplantı' and tag = 'Destek'"},
+			wantTitles: []string{"Tasarım"},
+			wantTotal:  1,
+		},
+		{
+			name:       "second page",
+			query:      mvc.QueryModel{PageNumber: 2, RecordsPerPage: 3},
+			wantTitles: []string{"Tasarım"},
+			wantTotal:  4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := repo.GetAll(c, &test.query)
+			if !result.IsSuccess() {
+				t.Fatalf("GetAll failed: %s", result.ErrorMessage)
+			}
+			page := result.ReturnObject.(*mvc.PagedResult[mvc.TimingViewModel])
+			if page.TotalCount != test.wantTotal {
+				t.Errorf("want %d in total, got %d", test.wantTotal, page.TotalCount)
+			}
+			if titles := timingTitles(page.Items); !equal(titles, test.wantTitles) {
+				t.Errorf("want %v, got %v", test.wantTitles, titles)
+			}
+			for _, timing := range page.Items {
+				if timing.Client == "" || timing.ClientProject == "" || timing.ClientId == 0 {
+					t.Errorf("want client and project names on every row, got %+v", timing)
+				}
+			}
+		})
+	}
+
+	t.Run("tags", func(t *testing.T) {
+		page := repo.GetAll(c, &mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10, Filter: "title = 'Tasarım'"}).ReturnObject.(*mvc.PagedResult[mvc.TimingViewModel])
+		if len(page.Items) != 1 || len(page.Items[0].Tags) != 2 || page.Items[0].Tags[0].Name != "Destek" || page.Items[0].Tags[1].Name != "Toplantı" {
+			t.Fatalf("want both tags sorted by name, got %+v", page.Items)
+		}
+	})
+
+	t.Run("sort by tag", func(t *testing.T) {
+		result := repo.GetAll(c, &mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10, SortingOptions: []*mvc.DataSortingOptionItem{{ColumnName: "tag"}}})
+		if result.IsSuccess() {
+			t.Fatal("want sorting by a tag refused")
+		}
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		first := repo.GetAll(c, &mvc.QueryModel{RecordsPerPage: 2}).ReturnObject.(*mvc.PagedResult[mvc.TimingViewModel])
+		if !first.HasMore || first.NextCursor == "" {
+			t.Fatalf("want a next cursor, got %+v", first)
+		}
+		result := repo.GetAll(c, &mvc.QueryModel{RecordsPerPage: 2, Cursor: first.NextCursor})
+		if !result.IsSuccess() {
+			t.Fatalf("GetAll with cursor failed: %s", result.ErrorMessage)
+		}
+		second := result.ReturnObject.(*mvc.PagedResult[mvc.TimingViewModel])
+		if titles := timingTitles(second.Items); !equal(titles, []string{"Canlıya alım", "Tasarım"}) {
+			t.Errorf("want the last two timings, got %v", titles)
+		}
+	})
+}
+
+func TestTimingLookups(t *testing.T) {
+	_, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	repo := repositories.NewTimingRepository(env.database)
+
+	byProject := repo.GetByClientProjectId(c, data.project.Id)
+	if !byProject.IsSuccess() || len(byProject.ReturnObject.([]*datamodels.Timing)) != 1 {
+		t.Errorf("GetByClientProjectId: want the seeded timing, got %+v", byProject)
+	}
+
+	byDate := repo.GetByDateRange(c, data.timing.StartDateTime.Add(-time.Hour), data.timing.EndDateTime.Add(time.Hour))
+	if !byDate.IsSuccess() || len(byDate.ReturnObject.([]*datamodels.Timing)) != 1 {
+		t.Errorf("GetByDateRange: want the seeded timing, got %+v", byDate)
+	}
+
+	outside := repo.GetByDateRange(c, data.timing.EndDateTime, data.timing.EndDateTime.Add(time.Hour))
+	if !outside.IsSuccess() || len(outside.ReturnObject.([]*datamodels.Timing)) != 0 {
+		t.Errorf("GetByDateRange: want no timings outside the range, got %+v", outside)
+	}
+}
+
+func TestTimingSumHours(t *testing.T) {
+	_, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	repo := repositories.NewTimingRepository(env.database)
+
+	start := data.timing.StartDateTime
+	mustCreate(t, &datamodels.Timing{ClientProjectId: data.project.Id, SystemUserId: data.admin.Id, Title: "Geliştirme", StartDateTime: start.AddDate(0, 0, 1), EndDateTime: start.AddDate(0, 0, 1).Add(90 * time.Minute)})
+	seeded := data.timing.EndDateTime.Sub(start).Hours()
+
+	tests := []struct {
+		name      string
+		from      *time.Time
+		to        *time.Time
+		wantHours float64
+	}{
+		{name: "all timings", wantHours: seeded + 1.5},
+		{name: "from the second day", from: ptr(start.AddDate(0, 0, 1)), wantHours: 1.5},
+		{name: "before the second day", to: ptr(start.AddDate(0, 0, 1)), wantHours: seeded},
+		{name: "empty range", from: ptr(start.AddDate(0, 0, 2)), wantHours: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := repo.SumHours(c, data.project.Id, test.from, test.to)
+			if !result.IsSuccess() {
+				t.Fatalf("SumHours failed: %s", result.ErrorMessage)
+			}
+			hours := result.ReturnObject.(*mvc.TimingHours)
+			if hours.Hours != test.wantHours {
+				t.Errorf("want %v hours, got %v", test.wantHours, hours.Hours)
+			}
+			if (hours.FirstStartDateTime == nil) != (test.wantHours == 0) {
+				t.Errorf("want the first start only when there are timings, got %v", hours.FirstStartDateTime)
+			}
+		})
+	}
+}
+
+// TestTimingSumHoursAmount, tutarın üyenin saatlik ücretiyle, ücreti olmayan üyelerde projenin
+// ücretiyle hesaplandığını doğrular
+func TestTimingSumHoursAmount(t *testing.T) {
+	_, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	repo := repositories.NewTimingRepository(env.database)
+
+	data.project.HourlyRate = 100
+	if err := env.database.Save(data.project).Error; err != nil {
+		t.Fatal(err)
+	}
+	other := &datamodels.SystemUser{Name: "Can", Surname: "Demir", Email: "can@example.com", Password: "x", PasswordSalt: "x", IsActive: true}
+	mustCreate(t, other)
+	mustCreate(t, &datamodels.ClientProjectMember{ClientProjectId: data.project.Id, SystemUserId: other.Id, HourlyRate: ptr(250.0)})
+	start := data.timing.StartDateTime.AddDate(0, 0, 1)
+	mustCreate(t, &datamodels.Timing{ClientProjectId: data.project.Id, SystemUserId: other.Id, Title: "Geliştirme", StartDateTime: start, EndDateTime: start.Add(2 * time.Hour)})
+
+	result := repo.SumHours(c, data.project.Id, nil, nil)
+	if !result.IsSuccess() {
+		t.Fatalf("SumHours failed: %s", result.ErrorMessage)
+	}
+	// Seed'deki bir saat projenin ücretiyle (100), iki saat üyenin ücretiyle (250)
+	if hours := result.ReturnObject.(*mvc.TimingHours); hours.Hours != 3 || hours.Amount != 600 {
+		t.Errorf("want 3 hours for 600, got %+v", hours)
+	}
+}
+
+// TestTimingSumHoursByTask, kaydı olmayan görevlerin de döndüğünü ve aralık dışındaki
+// kayıtların sayılmadığını doğrular
+func TestTimingSumHoursByTask(t *testing.T) {
+	_, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	repo := repositories.NewTimingRepository(env.database)
+
+	design := &datamodels.ProjectTask{ClientProjectId: data.project.Id, Name: "Tasarım", EstimateHours: 4}
+	mustCreate(t, design)
+	mustCreate(t, &datamodels.ProjectTask{ClientProjectId: data.project.Id, Name: "Backend"})
+	start := data.timing.StartDateTime
+	mustCreate(t, &datamodels.Timing{ClientProjectId: data.project.Id, SystemUserId: data.admin.Id, TaskId: &design.Id, Title: "Tasarım", StartDateTime: start.AddDate(0, 0, 1), EndDateTime: start.AddDate(0, 0, 1).Add(2 * time.Hour)})
+	mustCreate(t, &datamodels.Timing{ClientProjectId: data.project.Id, SystemUserId: data.admin.Id, TaskId: &design.Id, Title: "Tasarım", StartDateTime: start.AddDate(0, 0, 5), EndDateTime: start.AddDate(0, 0, 5).Add(time.Hour)})
+
+	result := repo.SumHoursByTask(c, data.project.Id, nil, ptr(start.AddDate(0, 0, 2)))
+	if !result.IsSuccess() {
+		t.Fatalf("SumHoursByTask failed: %s", result.ErrorMessage)
+	}
+	rows := result.ReturnObject.([]*mvc.TaskHours)
+	if len(rows) != 3 {
+		t.Fatalf("want two tasks and the unassigned row, got %d rows", len(rows))
+	}
+	seeded := data.timing.EndDateTime.Sub(start).Hours()
+	if rows[0].Task != "Backend" || rows[0].Hours != 0 ||
+		rows[1].Task != "Tasarım" || rows[1].Hours != 2 || rows[1].EstimateHours != 4 ||
+		rows[2].TaskId != nil || rows[2].Hours != seeded {
+		t.Errorf("want Backend 0, Tasarım 2 and %v unassigned hours, got %+v %+v %+v", seeded, rows[0], rows[1], rows[2])
+	}
+}
+
+// TestTimingSumHoursByTag, birden fazla etiketi olan kaydın her etiketinde sayıldığını ve
+// etiketsiz kayıtların ayrı satırda toplandığını doğrular
+func TestTimingSumHoursByTag(t *testing.T) {
+	_, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	repo := repositories.NewTimingRepository(env.database)
+
+	meeting := &datamodels.Tag{Name: "Toplantı", Color: "#1E88E5"}
+	mustCreate(t, meeting)
+	support := &datamodels.Tag{Name: "Destek", Color: "#43A047"}
+	mustCreate(t, support)
+	mustCreate(t, &datamodels.Tag{Name: "Hata", Color: "#E53935"})
+	start := data.timing.StartDateTime.AddDate(0, 0, 1)
+	tagged := &datamodels.Timing{ClientProjectId: data.project.Id, SystemUserId: data.admin.Id, Title: "Destek toplantısı", StartDateTime: start, EndDateTime: start.Add(2 * time.Hour)}
+	mustCreate(t, tagged)
+	mustSucceed(t, repo.SetTags(c, tagged.Id, []int{meeting.Id, support.Id}))
+	later := &datamodels.Timing{ClientProjectId: data.project.Id, SystemUserId: data.admin.Id, Title: "Toplantı", StartDateTime: start.AddDate(0, 0, 5), EndDateTime: start.AddDate(0, 0, 5).Add(time.Hour)}
+	mustCreate(t, later)
+	mustSucceed(t, repo.SetTags(c, later.Id, []int{meeting.Id}))
+
+	result := repo.SumHoursByTag(c, data.project.Id, nil, ptr(start.AddDate(0, 0, 1)))
+	if !result.IsSuccess() {
+		t.Fatalf("SumHoursByTag failed: %s", result.ErrorMessage)
+	}
+	rows := result.ReturnObject.([]*mvc.TagHours)
+	if len(rows) != 4 {
+		t.Fatalf("want three tags and the untagged row, got %d rows", len(rows))
+	}
+	seeded := data.timing.EndDateTime.Sub(data.timing.StartDateTime).Hours()
+	if rows[0].Tag != "Destek" || rows[0].Hours != 2 || rows[0].Timings != 1 ||
+		rows[1].Tag != "Hata" || rows[1].Hours != 0 || rows[1].Timings != 0 ||
+		rows[2].Tag != "Toplantı" || rows[2].Hours != 2 || rows[2].Color != "#1E88E5" ||
+		rows[3].TagId != nil || rows[3].Hours != seeded || rows[3].Timings != 1 {
+		t.Errorf("want Destek 2, Hata 0, Toplantı 2 and %v untagged hours, got %+v %+v %+v %+v", seeded, rows[0], rows[1], rows[2], rows[3])
+	}
+
+	all := repo.SumHoursByTag(c, 0, nil, nil)
+	if !all.IsSuccess() || all.ReturnObject.([]*mvc.TagHours)[2].Hours != 3 {
+		t.Errorf("want 3 hours on Toplantı without a range, got %+v", all)
+	}
+
+	// Etiketli kayıt silinirken bağlantıları da silinir
+	mustSucceed(t, repo.Delete(c, tagged.Id))
+	if tags := repo.GetTags(c, tagged.Id).ReturnObject.([]*datamodels.Tag); len(tags) != 0 {
+		t.Errorf("want the tag links deleted with the timing, got %+v", tags)
+	}
+}
+
+func TestClientProjectGetAllForMember(t *testing.T) {
+	_, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	repo := repositories.NewClientProjectRepository(env.database)
+	mustCreate(t, &datamodels.ClientProject{ClientId: data.client.Id, Name: "Mobil uygulama", IsActive: true})
+
+	result := repo.GetAllForMember(c, &mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10}, data.admin.Id)
+	if !result.IsSuccess() {
+		t.Fatalf("GetAllForMember failed: %s", result.ErrorMessage)
+	}
+	page := result.ReturnObject.(*mvc.PagedResult[*datamodels.ClientProject])
+	if page.TotalCount != 1 || page.Items[0].Id != data.project.Id {
+		t.Errorf("want only the seeded project, got %+v", page.Items)
+	}
+}
+
+func TestClientArchiveCascades(t *testing.T) {
+	_, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	clients := repositories.NewClientRepository(env.database)
+	projects := repositories.NewClientProjectRepository(env.database)
+	archived := &datamodels.ClientProject{ClientId: data.client.Id, Name: "Eski proje", IsActive: true}
+	mustCreate(t, archived)
+	mustSucceed(t, projects.Archive(c, archived.Id, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	isActive := func(id int) bool {
+		t.Helper()
+		result := projects.GetById(c, id)
+		mustSucceed(t, result)
+		return result.ReturnObject.(*datamodels.ClientProject).IsActive
+	}
+
+	result := clients.Archive(c, data.client.Id, time.Now().UTC().Truncate(time.Microsecond))
+	mustSucceed(t, result)
+	if isActive(data.project.Id) {
+		t.Fatal("want the client's active project archived with it")
+	}
+	if got := result.ReturnObject.([]int); !slices.Equal(got, []int{data.project.Id}) {
+		t.Errorf("want the archived project ids [%d], got %v", data.project.Id, got)
+	}
+	query := &mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10, Archived: mvc.ArchivedExclude}
+	result = clients.GetAll(c, query)
+	mustSucceed(t, result)
+	if page := result.ReturnObject.(*mvc.PagedResult[*datamodels.Client]); page.TotalCount != 0 {
+		t.Errorf("want archived clients hidden by default, got %+v", page.Items)
+	}
+	// İstemcinin filtresi arşiv koşulunu genişletemez
+	query.Filter = "ia = false or id > 0"
+	result = clients.GetAll(c, query)
+	mustSucceed(t, result)
+	if page := result.ReturnObject.(*mvc.PagedResult[*datamodels.Client]); page.TotalCount != 0 {
+		t.Errorf("want the filter unable to widen the archived restriction, got %+v", page.Items)
+	}
+
+	result = clients.Unarchive(c, data.client.Id)
+	mustSucceed(t, result)
+	if !isActive(data.project.Id) || isActive(archived.Id) {
+		t.Error("want only the project archived with the client restored")
+	}
+	if got := result.ReturnObject.([]int); !slices.Equal(got, []int{data.project.Id}) {
+		t.Errorf("want the restored project ids [%d], got %v", data.project.Id, got)
+	}
+	if result := clients.Archive(c, 99999, time.Now()); result.ErrorCode != mvc.ErrorCodeNotFound {
+		t.Errorf("want not found for a missing client, got %+v", result)
+	}
+}
+
+func TestClientForeignReferences(t *testing.T) {
+	_, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	clients := repositories.NewClientRepository(env.database)
+	projects := repositories.NewClientProjectRepository(env.database)
+	mustCreate(t, &datamodels.ClientContact{ClientId: data.client.Id, Name: "Can Demir"})
+	tag := &datamodels.Tag{Name: "Toplantı", Color: "#1E88E5"}
+	mustCreate(t, tag)
+	mustSucceed(t, repositories.NewTimingRepository(env.database).SetTags(c, data.timing.Id, []int{tag.Id}))
+
+	want := mvc.ForeignReferences{ClientProjectMembers: 1, Timings: 1, TimingTags: 1}
+	result := projects.CountForeignReferences(c, data.project.Id)
+	mustSucceed(t, result)
+	if got := *result.ReturnObject.(*mvc.ForeignReferences); got != want {
+		t.Errorf("want the project's references %+v, got %+v", want, got)
+	}
+
+	want.ClientProjects, want.ClientContacts = 1, 1
+	result = clients.CountForeignReferences(c, data.client.Id)
+	mustSucceed(t, result)
+	if got := *result.ReturnObject.(*mvc.ForeignReferences); got != want {
+		t.Errorf("want the client's references %+v, got %+v", want, got)
+	}
+
+	// Foreign key'ler ON DELETE RESTRICT olduğu için bağlı kaydı olan kayıt doğrudan silinemez
+	if result := projects.Delete(c, data.project.Id); result.ErrorCode != mvc.ErrorCodeConflict {
+		t.Errorf("want a conflict for deleting a project with references, got %+v", result)
+	}
+	if result := clients.Delete(c, data.client.Id); result.ErrorCode != mvc.ErrorCodeConflict {
+		t.Errorf("want a conflict for deleting a client with references, got %+v", result)
+	}
+
+	result = clients.ForceDelete(c, data.client.Id)
+	mustSucceed(t, result)
+	deleted := result.ReturnObject.(*repositories.DeletedRecords)
+	if got := *deleted.References; got != want {
+		t.Errorf("want the deleted references %+v, got %+v", want, got)
+	}
+	if !slices.Equal(deleted.ClientProjectIds, []int{data.project.Id}) || !slices.Equal(deleted.TimingIds, []int{data.timing.Id}) {
+		t.Errorf("want the deleted project %d and timing %d, got %+v", data.project.Id, data.timing.Id, deleted)
+	}
+	if result := projects.GetById(c, data.project.Id); result.ErrorCode != mvc.ErrorCodeNotFound {
+		t.Errorf("want the project deleted with the client, got %+v", result)
+	}
+	if result := clients.ForceDelete(c, data.client.Id); result.ErrorCode != mvc.ErrorCodeNotFound {
+		t.Errorf("want not found for a deleted client, got %+v", result)
+	}
+}
+
+func TestOutboxIsWrittenWithTheChange(t *testing.T) {
+	s, data := reset(t)
+	c := models.NewSystemContext(context.Background())
+	transactor := repositories.NewTransactor(env.database)
+	outbox := repositories.NewOutboxRepository(env.database)
+	clients := repositories.NewClientRepository(env.database)
+
+	// Olay yazılamazsa değişiklik de geri alınır
+	result := transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		if result := clients.Create(tx, &datamodels.Client{ShortTitle: "GERI", Title: "Geri Alınan", IsActive: true}); !result.IsSuccess() {
+			return result
+		}
+		return outbox.Add(tx, &datamodels.OutboxEvent{Type: "client.created", Payload: "not json", OccurredAt: time.Now()})
+	})
+	if result.IsSuccess() {
+		t.Fatal("want adding an invalid payload to fail")
+	}
+	var count int64
+	env.database.Model(&datamodels.Client{}).Where("\"ShortTitle\" = ?", "GERI").Count(&count)
+	if count != 0 {
+		t.Fatal("want the client rolled back with the failed outbox write")
+	}
+
+	timing := map[string]any{
+		"cpid": data.project.Id, "suid": data.admin.Id, "t": "Olaylı kayıt",
+		"sdt": "2024-03-01T11:00:00Z", "edt": "2024-03-01T12:00:00Z", "st": 2,
+	}
+	if recorder := s.send(t, http.MethodPost, "/api/v1/timings", timing); recorder.Code != http.StatusCreated {
+		t.Fatalf("want 201, got %d: %s", recorder.Code, recorder.Body)
+	}
+
+	result = outbox.Claim(c, time.Now(), time.Minute, 10)
+	mustSucceed(t, result)
+	claimed := result.ReturnObject.([]*datamodels.OutboxEvent)
+	if len(claimed) != 1 || claimed[0].Type != "timing.created" || claimed[0].Attempts != 1 {
+		t.Fatalf("want the timing.created event claimed once, got %+v", claimed)
+	}
+
+	// Kiralanan olay süre dolana kadar yeniden alınmaz
+	result = outbox.Claim(c, time.Now(), time.Minute, 10)
+	mustSucceed(t, result)
+	if again := result.ReturnObject.([]*datamodels.OutboxEvent); len(again) != 0 {
+		t.Fatalf("want a leased event skipped, got %+v", again)
+	}
+
+	retryAt := time.Now().Add(-time.Second)
+	mustSucceed(t, outbox.MarkFailed(c, claimed[0].Id, "subscriber failed", &retryAt))
+	result = outbox.Claim(c, time.Now(), time.Minute, 10)
+	mustSucceed(t, result)
+	if retried := result.ReturnObject.([]*datamodels.OutboxEvent); len(retried) != 1 || retried[0].Attempts != 2 || retried[0].LastError != "subscriber failed" {
+		t.Fatalf("want the failed event claimed for a second attempt, got %+v", retried)
+	}
+
+	mustSucceed(t, outbox.MarkDispatched(c, claimed[0].Id, time.Now()))
+	result = outbox.Claim(c, time.Now().Add(time.Hour), time.Minute, 10)
+	mustSucceed(t, result)
+	if dispatched := result.ReturnObject.([]*datamodels.OutboxEvent); len(dispatched) != 0 {
+		t.Fatalf("want a dispatched event never claimed again, got %+v", dispatched)
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func mustSucceed(t *testing.T, result *lgo.OperationResult) {
+	t.Helper()
+	if !result.IsSuccess() {
+		t.Fatalf("setup failed: %s", result.ErrorMessage)
+	}
+}
+
+func timingTitles(timings []mvc.TimingViewModel) []string {
+	titles := make([]string, len(timings))
+	for i, timing := range timings {
+		titles[i] = timing.Title
+	}
+	return titles
+}
+
+func equal(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,339 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+
+	"github.com/LGYtech/lgo"
+	"github.com/gin-gonic/gin"
+)
+
+// scenarioState, senaryo boyunca oluşturulan kayıtların kimlikleridir. Yollardaki
+// {ad} yer tutucuları bu değerlerle değiştirilir.
+type scenarioState struct {
+	session *session
+	ids     map[string]string
+}
+
+func (s *scenarioState) expand(value string) string {
+	for name, id := range s.ids {
+		value = strings.ReplaceAll(value, "{"+name+"}", id)
+	}
+	return value
+}
+
+// step, senaryodaki tek bir istektir. route, gin'e kaydedilen "METHOD /yol" biçimidir ve
+// isteğin gerçekten bu rotaya düştüğü doğrulanır.
+type step struct {
+	route   string
+	path    string
+	body    func(s *scenarioState) any
+	status  int    // /api/v1 ve korumasız rotalarda beklenen HTTP durumu
+	legacy  bool   // Eski rotalar 200 ile OperationResult döndürür
+	fails   bool   // Eski rotada OperationResult'ın başarısız olması beklenir
+	capture string // Yanıttaki "id", bu adla state'e kaydedilir
+	after   func(t *testing.T, s *scenarioState)
+}
+
+func TestEveryRouteEndToEnd(t *testing.T) {
+	session, data := reset(t)
+	state := &scenarioState{
+		session: session,
+		ids: map[string]string{
+			"user":    data.admin.Id.String(),
+			"client":  fmt.Sprint(data.client.Id),
+			"project": fmt.Sprint(data.project.Id),
+			"timing":  fmt.Sprint(data.timing.Id),
+		},
+	}
+
+	start := time.Date(2024, 3, 4, 13, 0, 0, 0, time.UTC)
+	dateRange := "startDate=2024-03-01T00:00:00Z&endDate=2024-03-31T00:00:00Z"
+	body := func(value map[string]any) func(*scenarioState) any {
+		return func(s *scenarioState) any {
+			expanded := map[string]any{}
+			for key, field := range value {
+				// Sayısal kimlikler JSON'da sayı olarak gönderilir
+				if text, ok := field.(string); ok {
+					field = s.expand(text)
+					if id, err := strconv.Atoi(field.(string)); err == nil && strings.HasPrefix(text, "{") {
+						field = id
+					}
+				}
+				expanded[key] = field
+			}
+			return expanded
+		}
+	}
+	user := func(email string) func(*scenarioState) any {
+		return body(map[string]any{"n": "Can", "sn": "Demir", "e": email, "p": "Gizli.456", "ia": true})
+	}
+	timing := func(project string, title string, status int) func(*scenarioState) any {
+		return body(map[string]any{"cpid": project, "suid": "{user}", "t": title, "sdt": start, "edt": start.Add(90 * time.Minute), "st": status})
+	}
+
+	steps := []step{
+		// #region Open routes
+		{route: "GET /healthz", path: "/healthz", status: http.StatusOK},
+		{route: "GET /readyz", path: "/readyz", status: http.StatusOK},
+		{route: "GET /metrics", path: "/metrics", status: http.StatusOK},
+		{route: "GET /openapi.json", path: "/openapi.json", status: http.StatusOK},
+		{route: "GET /docs", path: "/docs", status: http.StatusOK},
+		{route: "POST /api/v1/sessions", path: "/api/v1/sessions", body: body(map[string]any{"e": adminEmail, "p": adminPassword}), status: http.StatusCreated},
+		{route: "POST /system-user/login", path: "/system-user/login", body: body(map[string]any{"e": adminEmail, "p": adminPassword}), legacy: true},
+		// #endregion Open routes
+
+		// #region /api/v1 system users and settings
+		{route: "POST /api/v1/system-users", path: "/api/v1/system-users", body: user("can@example.com"), status: http.StatusCreated, capture: "newUser"},
+		{route: "GET /api/v1/system-users", path: "/api/v1/system-users?pn=1&rpp=10", status: http.StatusOK},
+		{route: "GET /api/v1/system-users/by-email", path: "/api/v1/system-users/by-email?email=can@example.com", status: http.StatusOK},
+		{route: "GET /api/v1/system-users/:id", path: "/api/v1/system-users/{newUser}", status: http.StatusOK},
+		{route: "PUT /api/v1/system-users/:id", path: "/api/v1/system-users/{newUser}", body: body(map[string]any{"n": "Can", "sn": "Kaya", "e": "can@example.com", "ia": true}), status: http.StatusOK},
+		{route: "GET /api/v1/system-users/:id/settings", path: "/api/v1/system-users/{newUser}/settings", status: http.StatusOK},
+		{route: "GET /api/v1/system-users/:id/settings/value", path: "/api/v1/system-users/{newUser}/settings/value?key=clients.view", status: http.StatusOK},
+		{route: "PUT /api/v1/system-user-settings", path: "/api/v1/system-user-settings", body: body(map[string]any{"suid": "{newUser}", "key": "system.language", "val": "tr"}), status: http.StatusOK, capture: "setting"},
+		{route: "GET /api/v1/system-user-settings/:id", path: "/api/v1/system-user-settings/{setting}", status: http.StatusOK},
+		{route: "DELETE /api/v1/system-user-settings/:id", path: "/api/v1/system-user-settings/{setting}", status: http.StatusNoContent},
+		// #endregion /api/v1 system users and settings
+
+		// #region /api/v1 clients, projects and timings
+		{route: "POST /api/v1/clients", path: "/api/v1/clients", body: body(map[string]any{"st": "GLOBEX", "t": "Globex Danışmanlık", "ia": true}), status: http.StatusCreated, capture: "newClient"},
+		{route: "GET /api/v1/clients", path: "/api/v1/clients?pn=1&rpp=10", status: http.StatusOK},
+		{route: "GET /api/v1/clients/:id", path: "/api/v1/clients/{newClient}", status: http.StatusOK},
+		{route: "PUT /api/v1/clients/:id", path: "/api/v1/clients/{newClient}", body: body(map[string]any{"st": "GLOBEX", "t": "Globex Danışmanlık Ltd.", "ia": true, "to": "Kadıköy", "tn": "1234567808", "cur": "EUR", "ptd": 30}), status: http.StatusOK},
+		{route: "POST /api/v1/clients/:id/contacts", path: "/api/v1/clients/{newClient}/contacts", body: body(map[string]any{"n": "Can Demir", "r": "Muhasebe", "e": "can@globex.example", "ip": true}), status: http.StatusCreated, capture: "contact"},
+		{route: "GET /api/v1/clients/:id/contacts", path: "/api/v1/clients/{newClient}/contacts", status: http.StatusOK},
+		{route: "GET /api/v1/clients/:id/contacts/:contactId", path: "/api/v1/clients/{newClient}/contacts/{contact}", status: http.StatusOK},
+		{route: "PUT /api/v1/clients/:id/contacts/:contactId", path: "/api/v1/clients/{newClient}/contacts/{contact}", body: body(map[string]any{"n": "Can Demir", "r": "Finans", "ip": true}), status: http.StatusOK},
+		{route: "DELETE /api/v1/clients/:id/contacts/:contactId", path: "/api/v1/clients/{newClient}/contacts/{contact}", status: http.StatusNoContent},
+		{route: "POST /api/v1/client-projects", path: "/api/v1/client-projects", body: body(map[string]any{"cid": "{newClient}", "n": "Mobil uygulama", "ia": true}), status: http.StatusCreated, capture: "newProject"},
+		{route: "GET /api/v1/clients/:id/projects", path: "/api/v1/clients/{newClient}/projects", status: http.StatusOK},
+		{route: "GET /api/v1/client-projects", path: "/api/v1/client-projects?pn=1&rpp=10", status: http.StatusOK},
+		{route: "GET /api/v1/client-projects/:id", path: "/api/v1/client-projects/{newProject}", status: http.StatusOK},
+		{route: "PUT /api/v1/client-projects/:id", path: "/api/v1/client-projects/{newProject}", body: body(map[string]any{"n": "Mobil uygulama v2", "ia": true, "bh": 2, "ba": 3000, "hr": 1500, "bsd": "2024-03-01T00:00:00Z", "bed": "2024-03-31T00:00:00Z", "eb": true}), status: http.StatusOK},
+		{route: "POST /api/v1/client-projects/:id/members", path: "/api/v1/client-projects/{newProject}/members", body: body(map[string]any{"suid": "{user}", "r": "Geliştirici"}), status: http.StatusCreated, capture: "member"},
+		{route: "GET /api/v1/client-projects/:id/members", path: "/api/v1/client-projects/{newProject}/members", status: http.StatusOK},
+		{route: "GET /api/v1/client-projects/:id/members/:memberId", path: "/api/v1/client-projects/{newProject}/members/{member}", status: http.StatusOK},
+		{route: "PUT /api/v1/client-projects/:id/members/:memberId", path: "/api/v1/client-projects/{newProject}/members/{member}", body: body(map[string]any{"r": "Proje yöneticisi", "hr": 1000}), status: http.StatusOK},
+		{route: "GET /api/v1/client-projects", path: "/api/v1/client-projects?mine=true&pn=1&rpp=10", status: http.StatusOK},
+		{route: "POST /api/v1/client-projects/:id/tasks", path: "/api/v1/client-projects/{newProject}/tasks", body: body(map[string]any{"n": "Tasarım", "eh": 4}), status: http.StatusCreated, capture: "task"},
+		{route: "POST /api/v1/client-projects/:id/tasks", path: "/api/v1/client-projects/{newProject}/tasks", body: body(map[string]any{"n": "tasarım"}), status: http.StatusConflict},
+		{route: "GET /api/v1/client-projects/:id/tasks", path: "/api/v1/client-projects/{newProject}/tasks", status: http.StatusOK},
+		{route: "GET /api/v1/client-projects/:id/tasks/:taskId", path: "/api/v1/client-projects/{newProject}/tasks/{task}", status: http.StatusOK},
+		{route: "PUT /api/v1/client-projects/:id/tasks/:taskId", path: "/api/v1/client-projects/{newProject}/tasks/{task}", body: body(map[string]any{"n": "Tasarım", "eh": 6, "st": 1}), status: http.StatusOK},
+		// Üye olmayan kullanıcı adına kayıt girilemez
+		{route: "POST /api/v1/timings", path: "/api/v1/timings", body: body(map[string]any{"cpid": "{newProject}", "suid": "{newUser}", "t": "Tasarım", "sdt": start, "edt": start.Add(time.Hour)}), status: http.StatusUnprocessableEntity},
+		{route: "POST /api/v1/timings", path: "/api/v1/timings", body: timing("{newProject}", "Tasarım", 1), status: http.StatusCreated, capture: "newTiming"},
+		{route: "POST /api/v1/timings", path: "/api/v1/timings", body: timing("{newProject}", "Bütçeyi aşan kayıt", 1), status: http.StatusConflict},
+		{route: "GET /api/v1/client-projects/:id/budget-status", path: "/api/v1/client-projects/{newProject}/budget-status", status: http.StatusOK},
+		{route: "GET /api/v1/client-projects/:id/timings", path: "/api/v1/client-projects/{newProject}/timings", status: http.StatusOK},
+		{route: "GET /api/v1/timings", path: "/api/v1/timings?pn=1&rpp=10", status: http.StatusOK},
+		{route: "GET /api/v1/timings/date-range", path: "/api/v1/timings/date-range?" + dateRange, status: http.StatusOK},
+		{route: "GET /api/v1/timings/:id", path: "/api/v1/timings/{newTiming}", status: http.StatusOK},
+		{route: "PUT /api/v1/timings/:id", path: "/api/v1/timings/{newTiming}", body: timing("{newProject}", "Tasarım incelemesi", 2), status: http.StatusOK},
+		// Başka projenin görevine kayıt bağlanamaz
+		{route: "PUT /api/v1/timings/:id", path: "/api/v1/timings/{timing}", body: body(map[string]any{"t": "Analiz", "tid": "{task}", "sdt": start, "edt": start.Add(time.Hour)}), status: http.StatusUnprocessableEntity},
+		{route: "PUT /api/v1/timings/:id", path: "/api/v1/timings/{newTiming}", body: body(map[string]any{"t": "Tasarım incelemesi", "tid": "{task}", "sdt": start, "edt": start.Add(90 * time.Minute), "st": 2}), status: http.StatusOK},
+		{route: "GET /api/v1/reports/tasks", path: "/api/v1/reports/tasks?cpid={newProject}&" + dateRange, status: http.StatusOK},
+		{route: "POST /api/v1/tags", path: "/api/v1/tags", body: body(map[string]any{"n": "Toplantı", "c": "#1E88E5"}), status: http.StatusCreated, capture: "tag"},
+		{route: "POST /api/v1/tags", path: "/api/v1/tags", body: body(map[string]any{"n": "toplantı", "c": "#43A047"}), status: http.StatusConflict},
+		{route: "GET /api/v1/tags", path: "/api/v1/tags?pn=1&rpp=10", status: http.StatusOK},
+		{route: "GET /api/v1/tags/:id", path: "/api/v1/tags/{tag}", status: http.StatusOK},
+		{route: "PUT /api/v1/tags/:id", path: "/api/v1/tags/{tag}", body: body(map[string]any{"n": "Toplantı", "c": "#E53935", "desc": "İç ve dış toplantılar"}), status: http.StatusOK},
+		{
+			route: "PUT /api/v1/timings/:id/tags", path: "/api/v1/timings/{newTiming}/tags", body: func(s *scenarioState) any {
+				id, _ := strconv.Atoi(s.ids["tag"])
+				return map[string]any{"tgs": []int{id}}
+			}, status: http.StatusOK,
+		},
+		{route: "GET /api/v1/timings/:id/tags", path: "/api/v1/timings/{newTiming}/tags", status: http.StatusOK},
+		{route: "GET /api/v1/timings", path: "/api/v1/timings?pn=1&rpp=10&flt=tag_id+%3D+{tag}", status: http.StatusOK},
+		{route: "GET /api/v1/reports/tags", path: "/api/v1/reports/tags?" + dateRange, status: http.StatusOK},
+		{route: "POST /api/v1/client-projects/:id/archive", path: "/api/v1/client-projects/{newProject}/archive", status: http.StatusOK},
+		// Arşivdeki projenin kayıtları değiştirilemez
+		{route: "PUT /api/v1/timings/:id", path: "/api/v1/timings/{newTiming}", body: timing("{newProject}", "Tasarım incelemesi", 1), status: http.StatusConflict},
+		{route: "POST /api/v1/client-projects/:id/unarchive", path: "/api/v1/client-projects/{newProject}/unarchive", status: http.StatusOK},
+		{route: "POST /api/v1/clients/:id/archive", path: "/api/v1/clients/{newClient}/archive", status: http.StatusOK},
+		{route: "GET /api/v1/clients", path: "/api/v1/clients?pn=1&rpp=10&archived=true", status: http.StatusOK},
+		{route: "POST /api/v1/client-projects/:id/unarchive", path: "/api/v1/client-projects/{newProject}/unarchive", status: http.StatusConflict},
+		{route: "POST /api/v1/clients/:id/unarchive", path: "/api/v1/clients/{newClient}/unarchive", status: http.StatusOK},
+		{route: "GET /api/v1/search", path: "/api/v1/search?q=globex", status: http.StatusOK},
+		// #endregion /api/v1 clients, projects and timings
+
+		// #region /api/v1 webhooks
+		{
+			route: "POST /api/v1/webhooks", path: "/api/v1/webhooks", body: body(map[string]any{"url": "https://hooks.example.com/lms", "evts": []string{"timing.created"}, "sec": "0123456789abcdef", "ia": true}), status: http.StatusCreated, capture: "webhook",
+			after: func(t *testing.T, s *scenarioState) {
+				// Gönderim işçisi senaryoda çalışmadığı için başarısız bir gönderim elle eklenir
+				id, _ := strconv.Atoi(s.ids["webhook"])
+				delivery := &datamodels.WebhookDelivery{SubscriptionId: id, EventId: 1, EventType: "timing.created", Payload: "{}", Status: enum.DeliveryStatusFailed, Attempts: 12, CreatedAt: time.Now()}
+				mustCreate(t, delivery)
+				s.ids["delivery"] = strconv.FormatInt(delivery.Id, 10)
+			},
+		},
+		{route: "POST /api/v1/webhooks", path: "/api/v1/webhooks", body: body(map[string]any{"url": "https://hooks.example.com/lms", "evts": []string{"timing.exploded"}, "sec": "0123456789abcdef"}), status: http.StatusUnprocessableEntity},
+		{route: "GET /api/v1/webhooks", path: "/api/v1/webhooks?pn=1&rpp=10", status: http.StatusOK},
+		{route: "GET /api/v1/webhooks/:id", path: "/api/v1/webhooks/{webhook}", status: http.StatusOK},
+		{route: "PUT /api/v1/webhooks/:id", path: "/api/v1/webhooks/{webhook}", body: body(map[string]any{"url": "https://hooks.example.com/lms", "evts": []string{"timing.created", "timing.completed"}, "ia": true}), status: http.StatusOK},
+		{route: "GET /api/v1/webhooks/:id/deliveries", path: "/api/v1/webhooks/{webhook}/deliveries?pn=1&rpp=10", status: http.StatusOK},
+		{route: "POST /api/v1/webhooks/:id/deliveries/:deliveryId/redeliver", path: "/api/v1/webhooks/{webhook}/deliveries/{delivery}/redeliver", status: http.StatusAccepted},
+		// #endregion /api/v1 webhooks
+
+		// #region Legacy routes
+		{route: "POST /system-user/create", path: "/system-user/create", body: user("deniz@example.com"), legacy: true, capture: "legacyUser"},
+		{route: "GET /system-user/all", path: "/system-user/all?pn=1&rpp=10", legacy: true},
+		{route: "GET /system-user/email", path: "/system-user/email?email=deniz@example.com", legacy: true},
+		{route: "GET /system-user/:id", path: "/system-user/{legacyUser}", legacy: true},
+		{route: "PUT /system-user/update", path: "/system-user/update", body: body(map[string]any{"id": "{legacyUser}", "n": "Deniz", "sn": "Demir", "e": "deniz@example.com", "ia": true}), legacy: true},
+		{route: "POST /system-user-settings/", path: "/system-user-settings/", body: body(map[string]any{"suid": "{user}", "key": "system.language", "val": "en"}), legacy: true, capture: "legacySetting"},
+		{route: "GET /system-user-settings/user/:userId", path: "/system-user-settings/user/{user}", legacy: true},
+		{route: "GET /system-user-settings/value/:userId", path: "/system-user-settings/value/{user}?key=system.language", legacy: true},
+		{route: "GET /system-user-settings/:id", path: "/system-user-settings/{legacySetting}", legacy: true},
+		{route: "DELETE /system-user-settings/:id", path: "/system-user-settings/{legacySetting}", legacy: true},
+		{route: "POST /clients/create", path: "/clients/create", body: body(map[string]any{"st": "INITECH", "t": "Initech Bilişim", "ia": true}), legacy: true, capture: "legacyClient"},
+		{route: "GET /clients/all", path: "/clients/all?pn=1&rpp=10", legacy: true},
+		{route: "GET /clients/:id", path: "/clients/{legacyClient}", legacy: true},
+		{route: "PUT /clients/update", path: "/clients/update", body: body(map[string]any{"id": "{legacyClient}", "st": "INITECH", "t": "Initech Bilişim A.Ş.", "ia": true}), legacy: true},
+		{route: "POST /client-projects/create", path: "/client-projects/create", body: body(map[string]any{"cid": "{legacyClient}", "n": "Müşteri portalı", "ia": true}), legacy: true, capture: "legacyProject"},
+		{route: "GET /client-projects/all", path: "/client-projects/all?pn=1&rpp=10", legacy: true},
+		{route: "GET /client-projects/client/:clientId", path: "/client-projects/client/{legacyClient}", legacy: true},
+		{route: "GET /client-projects/:id", path: "/client-projects/{legacyProject}", legacy: true},
+		{route: "PUT /client-projects/update", path: "/client-projects/update", body: body(map[string]any{"id": "{legacyProject}", "n": "Müşteri portalı v2", "ia": true}), legacy: true},
+		{route: "POST /api/v1/client-projects/:id/members", path: "/api/v1/client-projects/{legacyProject}/members", body: body(map[string]any{"suid": "{user}"}), status: http.StatusCreated},
+		{route: "POST /timings/create", path: "/timings/create", body: timing("{legacyProject}", "Geliştirme", 1), legacy: true, capture: "legacyTiming"},
+		{route: "GET /timings/all", path: "/timings/all?pn=1&rpp=10", legacy: true},
+		{route: "GET /timings/client-project/:clientProjectId", path: "/timings/client-project/{legacyProject}", legacy: true},
+		{route: "GET /timings/date-range", path: "/timings/date-range?" + dateRange, legacy: true},
+		{route: "GET /timings/:id", path: "/timings/{legacyTiming}", legacy: true},
+		{route: "PUT /timings/update", path: "/timings/update", body: body(map[string]any{"id": "{legacyTiming}", "cpid": "{legacyProject}", "suid": "{user}", "t": "Geliştirme ve test", "sdt": start, "edt": start.Add(time.Hour), "st": 2}), legacy: true},
+		{route: "GET /search", path: "/search?q=initech", legacy: true},
+		// #endregion Legacy routes
+
+		// #region Deletes
+		{route: "DELETE /timings/:id", path: "/timings/{legacyTiming}", legacy: true},
+		// Üyesi olan proje ve projesi olan müşteri force olmadan silinmez
+		{route: "DELETE /client-projects/:id", path: "/client-projects/{legacyProject}", legacy: true, fails: true},
+		{route: "DELETE /clients/:id", path: "/clients/{legacyClient}", legacy: true, fails: true},
+		{route: "DELETE /api/v1/clients/:id", path: "/api/v1/clients/{legacyClient}", status: http.StatusConflict},
+		{route: "DELETE /api/v1/clients/:id", path: "/api/v1/clients/{legacyClient}?force=true", status: http.StatusOK},
+		{route: "DELETE /api/v1/timings/:id", path: "/api/v1/timings/{newTiming}", status: http.StatusNoContent},
+		{route: "DELETE /api/v1/tags/:id", path: "/api/v1/tags/{tag}", status: http.StatusNoContent},
+		{route: "DELETE /api/v1/webhooks/:id", path: "/api/v1/webhooks/{webhook}", status: http.StatusNoContent},
+		{route: "DELETE /api/v1/client-projects/:id/tasks/:taskId", path: "/api/v1/client-projects/{newProject}/tasks/{task}", status: http.StatusNoContent},
+		{route: "DELETE /api/v1/client-projects/:id/members/:memberId", path: "/api/v1/client-projects/{newProject}/members/{member}", status: http.StatusNoContent},
+		{route: "DELETE /api/v1/client-projects/:id", path: "/api/v1/client-projects/{newProject}", status: http.StatusNoContent},
+		{route: "DELETE /api/v1/clients/:id", path: "/api/v1/clients/{newClient}", status: http.StatusNoContent},
+		// Yeni kullanıcılara varsayılan yetkiler atandığı için silme engellenir
+		{route: "DELETE /system-user/:id", path: "/system-user/{legacyUser}", legacy: true, fails: true},
+		{route: "DELETE /api/v1/system-users/:id", path: "/api/v1/system-users/{newUser}", status: http.StatusConflict},
+		// #endregion Deletes
+
+		// #region Logout
+		{
+			route: "POST /system-user/logout", path: "/system-user/logout", legacy: true,
+			after: func(t *testing.T, s *scenarioState) { s.session.login(t, adminEmail, adminPassword) },
+		},
+		{route: "DELETE /api/v1/sessions/current", path: "/api/v1/sessions/current", status: http.StatusNoContent},
+		{route: "GET /api/v1/clients", path: "/api/v1/clients?pn=1&rpp=10", status: http.StatusUnauthorized},
+		// #endregion Logout
+	}
+
+	covered := map[string]bool{}
+	for _, step := range steps {
+		covered[step.route] = true
+		if !t.Run(step.route, func(t *testing.T) { state.run(t, step) }) {
+			t.FailNow()
+		}
+	}
+
+	for _, route := range env.app.Handler().(*gin.Engine).Routes() {
+		if !covered[route.Method+" "+route.Path] {
+			t.Errorf("route %s %s is not exercised by the end-to-end scenario", route.Method, route.Path)
+		}
+	}
+}
+
+func (s *scenarioState) run(t *testing.T, step step) {
+	method, pattern, _ := strings.Cut(step.route, " ")
+	path := s.expand(step.path)
+	if strings.Contains(path, "{") {
+		t.Fatalf("unresolved placeholder in %s", path)
+	}
+	if route, _, _ := strings.Cut(path, "?"); !routePattern(pattern).MatchString(route) {
+		t.Fatalf("%s does not match the route %s", path, pattern)
+	}
+
+	var requestBody any
+	if step.body != nil {
+		requestBody = step.body(s)
+	}
+	recorder := s.session.send(t, method, path, requestBody)
+
+	var returnObject map[string]any
+	if step.legacy {
+		var result struct {
+			lgo.OperationResult
+			ReturnObject any `json:"ro"`
+		}
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("legacy routes answer 200, got %d: %s", recorder.Code, recorder.Body)
+		}
+		decode(t, recorder, &result)
+		if result.IsSuccess() == step.fails {
+			t.Fatalf("unexpected result: %s", recorder.Body)
+		}
+		returnObject, _ = result.ReturnObject.(map[string]any)
+	} else {
+		if recorder.Code != step.status {
+			t.Fatalf("want %d, got %d: %s", step.status, recorder.Code, recorder.Body)
+		}
+		if step.capture != "" {
+			decode(t, recorder, &returnObject)
+		}
+	}
+
+	if step.capture != "" {
+		id, ok := returnObject["id"]
+		if !ok {
+			t.Fatalf("response has no id to capture: %s", recorder.Body)
+		}
+		s.ids[step.capture] = fmt.Sprint(id)
+	}
+	if step.after != nil {
+		step.after(t, s)
+	}
+}
+
+// routePattern, gin rota kalıbını (":id") tüm yolu eşleyen bir ifadeye çevirir
+func routePattern(pattern string) *regexp.Regexp {
+	segments := strings.Split(pattern, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "[^/]+"
+		} else {
+			segments[i] = regexp.QuoteMeta(segment)
+		}
+	}
+	return regexp.MustCompile("^" + strings.Join(segments, "/") + "$")
+}
+
+func TestProtectedRoutesRejectMissingToken(t *testing.T) {
+	reset(t)
+	anonymous := &session{handler: env.app.Handler()}
+
+	for _, path := range []string{"/api/v1/clients?pn=1&rpp=10", "/clients/all?pn=1&rpp=10", "/api/v1/search?q=acme"} {
+		if recorder := anonymous.send(t, http.MethodGet, path, nil); recorder.Code != http.StatusUnauthorized {
+			t.Errorf("%s: want 401, got %d: %s", path, recorder.Code, recorder.Body)
+		}
+	}
+}
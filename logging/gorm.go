@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger, gorm kayıtlarını slog'a yönlendirir. SQL metinleri yalnızca DEBUG seviyesinde
+// ve parametreleri olmadan yazılır; böylece parola özetleri gibi değerler loglara düşmez.
+type GormLogger struct {
+	logger        *slog.Logger
+	slowThreshold time.Duration
+}
+
+func NewGormLogger(logger *slog.Logger, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{logger: logger, slowThreshold: slowThreshold}
+}
+
+// LogMode, gorm arayüzü gereği vardır; seviye slog tarafında bileşen ayarıyla belirlenir
+func (l *GormLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return l
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.InfoContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.WarnContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	l.logger.ErrorContext(ctx, fmt.Sprintf(msg, args...))
+}
+
+// ParamsFilter, gorm'un SQL'e parametre değerlerini gömmesini engeller
+func (l *GormLogger) ParamsFilter(_ context.Context, sql string, _ ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.logger.Enabled(ctx, slog.LevelError):
+		sql, rows := fc()
+		l.logger.ErrorContext(ctx, "query failed", queryAttrs(sql, rows, elapsed, slog.String("error", err.Error()))...)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.logger.Enabled(ctx, slog.LevelWarn):
+		sql, rows := fc()
+		l.logger.WarnContext(ctx, "slow query", queryAttrs(sql, rows, elapsed)...)
+	case l.logger.Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		l.logger.DebugContext(ctx, "query", queryAttrs(sql, rows, elapsed)...)
+	}
+}
+
+func queryAttrs(sql string, rows int64, elapsed time.Duration, extra ...any) []any {
+	return append([]any{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("elapsed_ms", float64(elapsed.Microseconds())/1000),
+	}, extra...)
+}
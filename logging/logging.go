@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ComponentKey = "component"
+	RequestIDKey = "request_id"
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+)
+
+// Config, kök logger'ın biçimini, varsayılan seviyesini ve bileşen bazlı seviyelerini tanımlar
+type Config struct {
+	Format          string                // "json" (varsayılan) veya "text"
+	Level           slog.Level            // Bileşen seviyesi tanımlanmamış kayıtlar için seviye
+	ComponentLevels map[string]slog.Level // Örn. {"db": WARN, "http": INFO}
+}
+
+// ParseConfig, "info" ve "db=warn,http=debug" biçimindeki ortam değerlerinden Config üretir
+func ParseConfig(format string, level string, componentLevels string) (Config, error) {
+	config := Config{Format: format, ComponentLevels: map[string]slog.Level{}}
+
+	if level != "" {
+		if err := config.Level.UnmarshalText([]byte(level)); err != nil {
+			return config, fmt.Errorf("invalid log level %q: %w", level, err)
+		}
+	}
+
+	for _, entry := range strings.Split(componentLevels, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		component, value, found := strings.Cut(entry, "=")
+		if !found {
+			return config, fmt.Errorf("invalid component log level %q, expected component=level", entry)
+		}
+		var componentLevel slog.Level
+		if err := componentLevel.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+			return config, fmt.Errorf("invalid log level for component %q: %w", component, err)
+		}
+		config.ComponentLevels[strings.TrimSpace(component)] = componentLevel
+	}
+
+	return config, nil
+}
+
+// New, hassas alanları maskeleyen, request ID'yi ekleyen ve bileşen seviyelerine uyan bir logger oluşturur
+func New(config Config, w io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{
+		Level:       slog.LevelDebug, // Asıl filtreleme componentHandler'da yapılır
+		ReplaceAttr: redactAttr,
+	}
+
+	var inner slog.Handler
+	if config.Format == "text" {
+		inner = slog.NewTextHandler(w, options)
+	} else {
+		inner = slog.NewJSONHandler(w, options)
+	}
+
+	return slog.New(&componentHandler{
+		inner:  inner,
+		config: config,
+		level:  config.Level,
+	})
+}
+
+// Component, logger'ı verilen bileşen adıyla etiketler; bileşenin seviyesi Config'ten alınır
+func Component(logger *slog.Logger, name string) *slog.Logger {
+	return logger.With(ComponentKey, name)
+}
+
+// #region Request ID
+
+type requestIDContextKey struct{}
+
+// WithRequestID, request ID'yi context'e yerleştirir; bu context ile yazılan her log satırı ID'yi taşır
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestID, context'teki request ID'yi döndürür
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// #endregion Request ID
+
+// #region Component Handler
+
+type componentHandler struct {
+	inner  slog.Handler
+	config Config
+	level  slog.Level
+}
+
+func (h *componentHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *componentHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String(RequestIDKey, requestID))
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String(TraceIDKey, spanContext.TraceID().String()),
+			slog.String(SpanIDKey, spanContext.SpanID().String()),
+		)
+	}
+	return h.inner.Handle(ctx, record)
+}
+
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	level := h.level
+	for _, attr := range attrs {
+		if attr.Key != ComponentKey {
+			continue
+		}
+		if componentLevel, ok := h.config.ComponentLevels[attr.Value.String()]; ok {
+			level = componentLevel
+		}
+	}
+	return &componentHandler{inner: h.inner.WithAttrs(attrs), config: h.config, level: level}
+}
+
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	return &componentHandler{inner: h.inner.WithGroup(name), config: h.config, level: h.level}
+}
+
+// #endregion Component Handler
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveKeys, değeri asla loglanmaması gereken attribute anahtarları (küçük harf)
+var sensitiveKeys = map[string]struct{}{
+	"token":         {},
+	"x-token":       {},
+	"usertoken":     {},
+	"authorization": {},
+	"password":      {},
+	"passwordsalt":  {},
+	"secret":        {},
+	"dsn":           {},
+	"p":             {}, // SystemUserLoginRequest.Password JSON etiketi
+	"ps":            {}, // SystemUser.PasswordSalt JSON etiketi
+}
+
+var dsnPasswordPattern = regexp.MustCompile(`(?i)(password=)(\S*)`)
+var urlPasswordPattern = regexp.MustCompile(`(://[^:/@\s]+:)([^@\s]*)(@)`)
+
+// Secret, loglandığında değeri yerine [REDACTED] yazan bir string türüdür
+type Secret string
+
+func (Secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// RedactDSN, "key=value" ve URL biçimindeki bağlantı dizelerindeki parolayı maskeler
+func RedactDSN(dsn string) string {
+	dsn = dsnPasswordPattern.ReplaceAllString(dsn, "${1}"+redacted)
+	return urlPasswordPattern.ReplaceAllString(dsn, "${1}"+redacted+"${3}")
+}
+
+func redactAttr(_ []string, attr slog.Attr) slog.Attr {
+	if _, ok := sensitiveKeys[strings.ToLower(attr.Key)]; ok {
+		return slog.String(attr.Key, redacted)
+	}
+	if attr.Value.Kind() == slog.KindString {
+		value := attr.Value.String()
+		if strings.Contains(strings.ToLower(value), "password=") || strings.Contains(value, "://") {
+			return slog.String(attr.Key, RedactDSN(value))
+		}
+	}
+	return attr
+}
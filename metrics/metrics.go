@@ -0,0 +1,173 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "lms"
+
+// Recorder, servis ve repository katmanlarının iş olaylarını bildirdiği arayüz
+type Recorder interface {
+	CacheLookup(cache string, hit bool)
+	Login(success bool)
+	BudgetAlert(threshold int)
+}
+
+// Metrics, uygulamanın tüm Prometheus ölçümlerini kendi registry'si üzerinde tutar
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	httpInFlight    prometheus.Gauge
+	dbQueryDuration *prometheus.HistogramVec
+	dbQueryErrors   *prometheus.CounterVec
+	cacheLookups    *prometheus.CounterVec
+	loginAttempts   *prometheus.CounterVec
+	budgetAlerts    *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total number of HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method and route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests currently being served.",
+		}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Database query latency by operation and table.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		dbQueryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_errors_total",
+			Help:      "Database queries that returned an error, by operation and table.",
+		}, []string{"operation", "table"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "lookups_total",
+			Help:      "Cache lookups by cache name and result (hit/miss).",
+		}, []string{"cache", "result"}),
+		loginAttempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "logins_total",
+			Help:      "Login attempts by result. Use rate() for logins per minute.",
+		}, []string{"result"}),
+		budgetAlerts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "budget",
+			Name:      "alerts_total",
+			Help:      "Project budget threshold crossings by threshold percent.",
+		}, []string{"threshold"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.httpInFlight,
+		m.dbQueryDuration,
+		m.dbQueryErrors,
+		m.cacheLookups,
+		m.loginAttempts,
+		m.budgetAlerts,
+	)
+	return m
+}
+
+// Handler, /metrics uç noktası için Prometheus exposition handler'ını döndürür
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDBStats, bağlantı havuzu istatistiklerini registry'ye ekler
+func (m *Metrics) RegisterDBStats(db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// BusinessGauge, değeri scrape sırasında değil Run ile belirli aralıklarla hesaplanan bir iş
+// göstergesidir. Veritabanı sorgusuyla hesaplanan göstergeler böylece scrape sıklığından
+// bağımsız olarak aralık başına bir kez sorgulanır. İlk hesaplamaya kadar NaN okunur.
+type BusinessGauge struct {
+	gauge    prometheus.Gauge
+	interval time.Duration
+	value    func(ctx context.Context) float64
+}
+
+// RegisterGauge, interval aralıklarla value ile yenilenen bir iş göstergesi ekler. Gösterge
+// döndürülen BusinessGauge'un Run'ı çalıştırılana kadar yenilenmez.
+func (m *Metrics) RegisterGauge(name string, help string, interval time.Duration, value func(ctx context.Context) float64) *BusinessGauge {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	})
+	gauge.Set(math.NaN())
+	m.registry.MustRegister(gauge)
+	return &BusinessGauge{gauge: gauge, interval: interval, value: value}
+}
+
+// Run, göstergeyi hemen ve ctx iptal edilene kadar her aralıkta yeniden hesaplar
+func (g *BusinessGauge) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	for {
+		g.gauge.Set(g.value(ctx))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Metrics) CacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+func (m *Metrics) Login(success bool) {
+	result := "failure"
+	if success {
+		result = "success"
+	}
+	m.loginAttempts.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) BudgetAlert(threshold int) {
+	m.budgetAlerts.WithLabelValues(strconv.Itoa(threshold)).Inc()
+}
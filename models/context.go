@@ -1,13 +1,56 @@
 package models
 
-import "github.com/gin-gonic/gin"
+import (
+	"context"
 
+	"lms-web-services-main/logging"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Gin context'inde kimlik doğrulama middleware'inin atadığı anahtarlar
+const (
+	TokenKey     = "usertoken"
+	PrincipalKey = "principal"
+)
+
+// Context, bir isteğin servis ve repository katmanlarına taşınan bağlamıdır. Gömülü
+// context.Context sayesinde iptal sinyali, son tarih ve izleme span'leri doğrudan
+// gorm ve Redis çağrılarına aktarılabilir.
 type Context struct {
+	context.Context `json:"-"`
+
 	Token string `json:"t"`
+
+	// RequestID, isteğin X-Request-ID değeridir
+	RequestID string `json:"-"`
+
+	// Principal, kimliği doğrulanmış kullanıcıdır; korumasız rotalarda nil'dir
+	Principal *mvc.SystemUserCredential `json:"-"`
 }
 
 func NewContext(c *gin.Context) *Context {
-	return &Context{
-		Token: c.GetString("usertoken"),
+	ctx := &Context{
+		Context:   c.Request.Context(),
+		Token:     c.GetString(TokenKey),
+		RequestID: logging.RequestID(c.Request.Context()),
 	}
+	if principal, ok := c.Get(PrincipalKey); ok {
+		ctx.Principal, _ = principal.(*mvc.SystemUserCredential)
+	}
+	return ctx
+}
+
+// NewSystemContext, bir istekten gelmeyen işler (metrikler, arka plan görevleri) için
+// kullanıcısız bir Context oluşturur
+func NewSystemContext(ctx context.Context) *Context {
+	return &Context{Context: ctx, RequestID: logging.RequestID(ctx)}
+}
+
+// WithContext, aynı kullanıcı ve istek bilgileriyle ctx'i taşıyan bir kopya döndürür
+func (c *Context) WithContext(ctx context.Context) *Context {
+	copied := *c
+	copied.Context = ctx
+	return &copied
 }
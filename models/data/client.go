@@ -1,47 +1,48 @@
 package data
 
-import "errors"
+import (
+	"time"
+
+	"lms-web-services-main/validation"
+)
 
 type Client struct {
-	Id         int    `gorm:"column:Id;type:serial;primary_key" json:"id"`
-	ShortTitle string `gorm:"column:ShortTitle;type:varchar(50);not null" json:"st"`
-	Title      string `gorm:"column:Title;type:varchar(200);not null" json:"t"`
-	Notes      string `gorm:"column:Notes;type:text" json:"nt"`
-	IsActive   bool   `gorm:"column:IsActive;type:boolean;not null;default:true" json:"ia"`
+	Id         int        `gorm:"column:Id;type:serial;primary_key" json:"id"`
+	ShortTitle string     `gorm:"column:ShortTitle;type:varchar(50);not null" json:"st" validate:"required,max=50" label:"field.short_title"`
+	Title      string     `gorm:"column:Title;type:varchar(200);not null" json:"t" validate:"required,max=200" label:"field.title"`
+	Notes      string     `gorm:"column:Notes;type:text" json:"nt"`
+	IsActive   bool       `gorm:"column:IsActive;type:boolean;not null;default:true" json:"ia" doc:"false ise müşteri arşivdedir; değiştirilmesi projelere de yansır"`
+	ArchivedAt *time.Time `gorm:"column:ArchivedAt;type:timestamptz" json:"aa" doc:"Arşivlenme zamanı (salt okunur)"`
+
+	// Fatura bilgileri
+	TaxOffice       string `gorm:"column:TaxOffice;type:varchar(100)" json:"to" validate:"max=100" label:"field.tax_office"`
+	TaxNumber       string `gorm:"column:TaxNumber;type:varchar(11)" json:"tn" validate:"omitempty,taxnumber" label:"field.tax_number" doc:"10 haneli VKN veya 11 haneli TCKN"`
+	BillingAddress  string `gorm:"column:BillingAddress;type:text" json:"ba" validate:"max=500" label:"field.billing_address"`
+	Currency        string `gorm:"column:Currency;type:varchar(3);not null;default:TRY" json:"cur" validate:"omitempty,iso4217" label:"field.currency" doc:"ISO 4217 para birimi; boşsa TRY"`
+	PaymentTermDays int    `gorm:"column:PaymentTermDays;type:integer;not null;default:0" json:"ptd" validate:"min=0,max=365" label:"field.payment_term_days" doc:"Fatura tarihinden itibaren ödeme vadesi (gün)"`
 }
 
 func (Client) TableName() string {
 	return "Clients"
 }
 
+func (model *Client) IsNew() bool {
+	return model.Id == 0
+}
+
+func (model *Client) GetId() int {
+	return model.Id
+}
+
+func (model *Client) SetId(id int) {
+	model.Id = id
+}
+
 func (model *Client) Validate() error {
-	if model.ShortTitle == "" {
-		return errors.New("kısa başlık alanı zorunludur")
-	}
-	if len(model.ShortTitle) > 50 {
-		return errors.New("kısa başlık 50 karakterden uzun olamaz")
-	}
-	if model.Title == "" {
-		return errors.New("başlık alanı zorunludur")
-	}
-	if len(model.Title) > 200 {
-		return errors.New("başlık 200 karakterden uzun olamaz")
-	}
-	return nil
+	return validation.Struct(model)
 }
 
+// ValidateForUpdate, güncellemede de tüm alanlar aynı kurallarla doğrulanır
 func (model *Client) ValidateForUpdate() error {
-	if model.ShortTitle == "" {
-		return errors.New("kısa başlık alanı zorunludur")
-	}
-	if len(model.ShortTitle) > 50 {
-		return errors.New("kısa başlık 50 karakterden uzun olamaz")
-	}
-	if model.Title == "" {
-		return errors.New("başlık alanı zorunludur")
-	}
-	if len(model.Title) > 200 {
-		return errors.New("başlık 200 karakterden uzun olamaz")
-	}
-	return nil
+	return model.Validate()
 }
@@ -0,0 +1,40 @@
+package data
+
+import "lms-web-services-main/validation"
+
+// ClientContact, müşterinin yetkili kişisidir. Bir müşterinin en fazla bir birincil
+// yetkilisi olabilir.
+type ClientContact struct {
+	Id        int    `gorm:"column:Id;type:serial;primary_key" json:"id"`
+	ClientId  int    `gorm:"column:ClientId;type:integer;not null" json:"cid" validate:"required,gt=0" label:"field.client"`
+	Name      string `gorm:"column:Name;type:varchar(100);not null" json:"n" validate:"required,max=100" label:"field.name"`
+	Role      string `gorm:"column:Role;type:varchar(100)" json:"r" validate:"max=100" label:"field.contact_role"`
+	Email     string `gorm:"column:Email;type:varchar(255)" json:"e" validate:"omitempty,max=255,email" label:"field.email"`
+	Phone     string `gorm:"column:Phone;type:varchar(30)" json:"ph" validate:"max=30" label:"field.phone"`
+	IsPrimary bool   `gorm:"column:IsPrimary;type:boolean;not null;default:false" json:"ip" doc:"Birincil yetkili; işaretlenirse müşterinin önceki birincil yetkilisi kaldırılır"`
+}
+
+func (ClientContact) TableName() string {
+	return "ClientContacts"
+}
+
+func (model *ClientContact) IsNew() bool {
+	return model.Id == 0
+}
+
+func (model *ClientContact) GetId() int {
+	return model.Id
+}
+
+func (model *ClientContact) SetId(id int) {
+	model.Id = id
+}
+
+func (model *ClientContact) Validate() error {
+	return validation.Struct(model)
+}
+
+// ValidateForUpdate, yetkili başka bir müşteriye taşınamadığı için ClientId alanını doğrulamaz
+func (model *ClientContact) ValidateForUpdate() error {
+	return validation.StructExcept(model, "ClientId")
+}
@@ -1,37 +1,55 @@
 package data
 
-import "errors"
+import (
+	"time"
 
+	"lms-web-services-main/validation"
+)
+
+// ClientProject'te bütçe alanları isteğe bağlıdır. BudgetHours veya BudgetAmount sıfırsa o
+// türde bütçe yoktur; tutar bütçesi harcanan saatlerin HourlyRate ile çarpımıyla izlenir.
+// Bütçe tarihleri verilmişse yalnızca bu aralıkta başlayan zaman kayıtları sayılır.
 type ClientProject struct {
-	Id       int    `gorm:"column:Id;type:serial;primary_key" json:"id"`
-	ClientId int    `gorm:"column:ClientId;type:integer;not null" json:"cid"`
-	Name     string `gorm:"column:Name;type:varchar(100);not null" json:"n"`
-	IsActive bool   `gorm:"column:IsActive;type:boolean;not null;default:true" json:"ia"`
+	Id              int        `gorm:"column:Id;type:serial;primary_key" json:"id"`
+	ClientId        int        `gorm:"column:ClientId;type:integer;not null" json:"cid" validate:"required,gt=0" label:"field.client"`
+	Name            string     `gorm:"column:Name;type:varchar(100);not null" json:"n" validate:"required,max=100" label:"field.project_name"`
+	IsActive        bool       `gorm:"column:IsActive;type:boolean;not null;default:true" json:"ia" doc:"false ise proje arşivdedir"`
+	ArchivedAt      *time.Time `gorm:"column:ArchivedAt;type:timestamptz" json:"aa" doc:"Arşivlenme zamanı (salt okunur)"`
+	BudgetHours     float64    `gorm:"column:BudgetHours;type:numeric(10,2);not null;default:0" json:"bh" validate:"min=0" label:"field.budget_hours"`
+	BudgetAmount    float64    `gorm:"column:BudgetAmount;type:numeric(14,2);not null;default:0" json:"ba" validate:"min=0" label:"field.budget_amount"`
+	HourlyRate      float64    `gorm:"column:HourlyRate;type:numeric(12,2);not null;default:0" json:"hr" validate:"min=0" label:"field.hourly_rate"`
+	BudgetStartDate *time.Time `gorm:"column:BudgetStartDate;type:date" json:"bsd" label:"field.budget_start_date"`
+	BudgetEndDate   *time.Time `gorm:"column:BudgetEndDate;type:date" json:"bed" label:"field.budget_end_date"`
+	EnforceBudget   bool       `gorm:"column:EnforceBudget;type:boolean;not null;default:false" json:"eb" doc:"Bütçe tükendiğinde yeni zaman kaydı girilmesini engeller"`
 }
 
 func (ClientProject) TableName() string {
 	return "ClientProjects"
 }
 
+func (model *ClientProject) IsNew() bool {
+	return model.Id == 0
+}
+
+func (model *ClientProject) GetId() int {
+	return model.Id
+}
+
+func (model *ClientProject) SetId(id int) {
+	model.Id = id
+}
+
 func (model *ClientProject) Validate() error {
-	if model.ClientId <= 0 {
-		return errors.New("client_id alanı zorunludur")
-	}
-	if model.Name == "" {
-		return errors.New("name alanı zorunludur")
-	}
-	if len(model.Name) > 100 {
-		return errors.New("name alanı 100 karakterden uzun olamaz")
-	}
-	return nil
+	return validation.Struct(model)
+}
+
+// HasBudget, projede saat veya tutar bütçesi tanımlı olup olmadığını döndürür
+func (model *ClientProject) HasBudget() bool {
+	return model.BudgetHours > 0 || model.BudgetAmount > 0
 }
 
+// ValidateForUpdate, projenin bağlı olduğu müşteri güncellemede değiştirilmediği için
+// ClientId alanını doğrulamaz
 func (model *ClientProject) ValidateForUpdate() error {
-	if model.Name == "" {
-		return errors.New("name alanı zorunludur")
-	}
-	if len(model.Name) > 100 {
-		return errors.New("name alanı 100 karakterden uzun olamaz")
-	}
-	return nil
+	return validation.StructExcept(model, "ClientId")
 }
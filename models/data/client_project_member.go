@@ -0,0 +1,43 @@
+package data
+
+import (
+	"lms-web-services-main/validation"
+
+	"github.com/google/uuid"
+)
+
+// ClientProjectMember, kullanıcının projeye zaman kaydı girebilmesini sağlayan üyeliktir.
+// HourlyRate verilmezse projenin saatlik ücreti geçerlidir.
+type ClientProjectMember struct {
+	Id              int       `gorm:"column:Id;type:serial;primary_key" json:"id"`
+	ClientProjectId int       `gorm:"column:ClientProjectId;type:integer;not null" json:"cpid" validate:"required,gt=0" label:"field.client_project"`
+	SystemUserId    uuid.UUID `gorm:"column:SystemUserId;type:uuid;not null" json:"suid" validate:"required" label:"field.system_user"`
+	Role            string    `gorm:"column:Role;type:varchar(50)" json:"r" validate:"max=50" label:"field.member_role"`
+	HourlyRate      *float64  `gorm:"column:HourlyRate;type:numeric(12,2)" json:"hr,omitempty" validate:"omitempty,min=0" label:"field.hourly_rate"`
+}
+
+func (ClientProjectMember) TableName() string {
+	return "ClientProjectMembers"
+}
+
+func (model *ClientProjectMember) IsNew() bool {
+	return model.Id == 0
+}
+
+func (model *ClientProjectMember) GetId() int {
+	return model.Id
+}
+
+func (model *ClientProjectMember) SetId(id int) {
+	model.Id = id
+}
+
+func (model *ClientProjectMember) Validate() error {
+	return validation.Struct(model)
+}
+
+// ValidateForUpdate, üyeliğin projesi ve kullanıcısı güncellemede değiştirilmediği için
+// ClientProjectId ve SystemUserId alanlarını doğrulamaz
+func (model *ClientProjectMember) ValidateForUpdate() error {
+	return validation.StructExcept(model, "ClientProjectId", "SystemUserId")
+}
@@ -0,0 +1,30 @@
+package data
+
+import "time"
+
+// OutboxEvent, servislerin yayımladığı bir alan olayının Outbox tablosundaki kaydıdır. Olay,
+// onu doğuran değişiklikle aynı veritabanı işleminde yazılır ve dağıtıcı tarafından
+// aboneler başarıyla çalışana kadar yeniden denenir. NextAttemptAt boş ve DispatchedAt
+// boşsa olay deneme hakkını bitirmiştir.
+type OutboxEvent struct {
+	Id            int64      `gorm:"column:Id;type:bigserial;primary_key" json:"id"`
+	Type          string     `gorm:"column:Type;type:varchar(100);not null" json:"t"`
+	Payload       string     `gorm:"column:Payload;type:jsonb;not null" json:"p"`
+	OccurredAt    time.Time  `gorm:"column:OccurredAt;type:timestamptz;not null" json:"oa"`
+	Attempts      int        `gorm:"column:Attempts;type:integer;not null;default:0" json:"a"`
+	NextAttemptAt *time.Time `gorm:"column:NextAttemptAt;type:timestamptz" json:"naa"`
+	DispatchedAt  *time.Time `gorm:"column:DispatchedAt;type:timestamptz" json:"da"`
+	LastError     string     `gorm:"column:LastError;type:text;not null;default:''" json:"le"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "OutboxEvents"
+}
+
+func (model *OutboxEvent) GetId() int64 {
+	return model.Id
+}
+
+func (model *OutboxEvent) SetId(id int64) {
+	model.Id = id
+}
@@ -0,0 +1,43 @@
+package data
+
+import (
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/validation"
+)
+
+// ProjectTask, projenin altındaki iş kalemidir (ör. "Tasarım", "Backend", "Test"). Zaman
+// kayıtları isteğe bağlı olarak bir göreve bağlanır; EstimateHours sıfırsa tahmin yoktur.
+type ProjectTask struct {
+	Id              int                 `gorm:"column:Id;type:serial;primary_key" json:"id"`
+	ClientProjectId int                 `gorm:"column:ClientProjectId;type:integer;not null" json:"cpid" validate:"required,gt=0" label:"field.client_project"`
+	Name            string              `gorm:"column:Name;type:varchar(100);not null" json:"n" validate:"required,max=100" label:"field.task_name"`
+	Description     string              `gorm:"column:Description;type:text" json:"desc"`
+	EstimateHours   float64             `gorm:"column:EstimateHours;type:numeric(10,2);not null;default:0" json:"eh" validate:"min=0" label:"field.estimate_hours"`
+	Status          enum.TaskStatusEnum `gorm:"column:Status;type:integer;not null;default:0" json:"st" validate:"enum" label:"field.status" doc:"0: Todo, 1: InProgress, 2: Done"`
+}
+
+func (ProjectTask) TableName() string {
+	return "ProjectTasks"
+}
+
+func (model *ProjectTask) IsNew() bool {
+	return model.Id == 0
+}
+
+func (model *ProjectTask) GetId() int {
+	return model.Id
+}
+
+func (model *ProjectTask) SetId(id int) {
+	model.Id = id
+}
+
+func (model *ProjectTask) Validate() error {
+	return validation.Struct(model)
+}
+
+// ValidateForUpdate, görevin projesi güncellemede değiştirilmediği için ClientProjectId
+// alanını doğrulamaz
+func (model *ProjectTask) ValidateForUpdate() error {
+	return validation.StructExcept(model, "ClientProjectId")
+}
@@ -1,41 +1,120 @@
 package data
 
 import (
-	"errors"
+	"lms-web-services-main/validation"
 
 	"github.com/google/uuid"
 )
 
 type SystemUserSetting struct {
 	Id           int       `gorm:"column:Id;type:integer;primary_key" json:"id"`
-	SystemUserId uuid.UUID `gorm:"column:SystemUserId;type:uuid;not null" json:"suid"`
-	Key          string    `gorm:"column:Key;type:varchar(50);not null" json:"key"`
-	Value        string    `gorm:"column:Value;type:varchar(200);not null" json:"val"`
-	Description  string    `gorm:"column:Description;type:varchar(200);not null" json:"desc"`
+	SystemUserId uuid.UUID `gorm:"column:SystemUserId;type:uuid;not null" json:"suid" validate:"required" label:"field.system_user"`
+	Key          string    `gorm:"column:Key;type:varchar(50);not null" json:"key" validate:"required,max=50" label:"field.key"`
+	Value        string    `gorm:"column:Value;type:varchar(200);not null" json:"val" validate:"required,max=200" label:"field.value"`
+	Description  string    `gorm:"column:Description;type:varchar(200);not null" json:"desc" validate:"max=200" label:"field.description"`
 }
 
 func (SystemUserSetting) TableName() string {
 	return "SystemUserSettings"
 }
 
+func (model *SystemUserSetting) IsNew() bool {
+	return model.Id == 0
+}
+
+func (model *SystemUserSetting) GetId() int {
+	return model.Id
+}
+
+func (model *SystemUserSetting) SetId(id int) {
+	model.Id = id
+}
+
 func (model *SystemUserSetting) Validate() error {
-	if model.SystemUserId == uuid.Nil {
-		return errors.New("system_user_id alanı zorunludur")
+	return validation.Struct(model)
+}
+
+// PermissionSet, bir varlık üzerindeki işlemlerin yetki anahtarlarıdır. Boş anahtar,
+// işlemin yetki kontrolü gerektirmediği anlamına gelir.
+type PermissionSet struct {
+	View   string
+	Add    string
+	Update string
+	Delete string
+}
+
+// Varlıkların yetki anahtarları
+var (
+	// Kullanıcı ekleme yetki kontrolü gerektirmez
+	SystemUserPermissions = PermissionSet{
+		View:   SYSTEM_USERS_VIEW,
+		Update: SYSTEM_USERS_UPDATE,
+		Delete: SYSTEM_USERS_DELETE,
 	}
-	if len(model.Key) == 0 {
-		return errors.New("key alanı zorunludur")
+	SystemUserSettingPermissions = PermissionSet{
+		View:   SYSTEM_SETTINGS_VIEW,
+		Add:    SYSTEM_SETTINGS_ADD,
+		Update: SYSTEM_SETTINGS_UPDATE,
+		Delete: SYSTEM_SETTINGS_DELETE,
 	}
-	if len(model.Key) > 50 {
-		return errors.New("key alanı 50 karakterden uzun olamaz")
+	ClientPermissions = PermissionSet{
+		View:   CLIENTS_VIEW,
+		Add:    CLIENTS_ADD,
+		Update: CLIENTS_UPDATE,
+		Delete: CLIENTS_DELETE,
 	}
-	if len(model.Value) == 0 {
-		return errors.New("value alanı zorunludur")
+	// Yetkililer müşteri kaydının parçası sayılır; eklemek, değiştirmek ve silmek müşteriyi
+	// güncelleme yetkisi gerektirir
+	ClientContactPermissions = PermissionSet{
+		View:   CLIENTS_VIEW,
+		Add:    CLIENTS_UPDATE,
+		Update: CLIENTS_UPDATE,
+		Delete: CLIENTS_UPDATE,
 	}
-	if len(model.Value) > 200 {
-		return errors.New("value alanı 200 karakterden uzun olamaz")
+	ClientProjectPermissions = PermissionSet{
+		View:   CLIENTPROJECTS_VIEW,
+		Add:    CLIENTPROJECTS_ADD,
+		Update: CLIENTPROJECTS_UPDATE,
+		Delete: CLIENTPROJECTS_DELETE,
 	}
-	return nil
-}
+	// Proje üyelikleri projenin parçası sayılır; üyeleri yönetmek projeyi güncelleme
+	// yetkisi gerektirir
+	ClientProjectMemberPermissions = PermissionSet{
+		View:   CLIENTPROJECTS_VIEW,
+		Add:    CLIENTPROJECTS_UPDATE,
+		Update: CLIENTPROJECTS_UPDATE,
+		Delete: CLIENTPROJECTS_UPDATE,
+	}
+	// Görevler de projenin parçası sayılır
+	ProjectTaskPermissions = PermissionSet{
+		View:   CLIENTPROJECTS_VIEW,
+		Add:    CLIENTPROJECTS_UPDATE,
+		Update: CLIENTPROJECTS_UPDATE,
+		Delete: CLIENTPROJECTS_UPDATE,
+	}
+	// Etiket listesi kurulumun ortak ayarı sayılır; herkes görebilir, yalnızca sistem
+	// ayarlarını güncelleyebilenler değiştirebilir. Kayıtlara etiket bağlamak kaydı
+	// güncelleme yetkisiyle yapılır (bkz. TimingService.SetTags).
+	TagPermissions = PermissionSet{
+		View:   TIMINGS_VIEW,
+		Add:    SYSTEM_SETTINGS_UPDATE,
+		Update: SYSTEM_SETTINGS_UPDATE,
+		Delete: SYSTEM_SETTINGS_UPDATE,
+	}
+	TimingPermissions = PermissionSet{
+		View:   TIMINGS_VIEW,
+		Add:    TIMINGS_ADD,
+		Update: TIMINGS_UPDATE,
+		Delete: TIMINGS_DELETE,
+	}
+	// Webhook abonelikleri ve gönderim günlüğü; yeniden gönderim Update yetkisi gerektirir
+	WebhookPermissions = PermissionSet{
+		View:   WEBHOOKS_VIEW,
+		Add:    WEBHOOKS_ADD,
+		Update: WEBHOOKS_UPDATE,
+		Delete: WEBHOOKS_DELETE,
+	}
+)
 
 const (
 	// System Users
@@ -55,16 +134,31 @@ const (
 	CLIENTS_ADD    = "clients.add"
 	CLIENTS_UPDATE = "clients.update"
 	CLIENTS_DELETE = "clients.delete"
+	// Bağlı kayıtları olan müşteriyi kayıtlarıyla birlikte silme (DELETE ?force=true)
+	CLIENTS_FORCE_DELETE = "clients.force_delete"
 
 	// Client Projects
 	CLIENTPROJECTS_VIEW   = "clientprojects.view"
 	CLIENTPROJECTS_ADD    = "clientprojects.add"
 	CLIENTPROJECTS_UPDATE = "clientprojects.update"
 	CLIENTPROJECTS_DELETE = "clientprojects.delete"
+	// Bağlı kayıtları olan projeyi kayıtlarıyla birlikte silme (DELETE ?force=true)
+	CLIENTPROJECTS_FORCE_DELETE = "clientprojects.force_delete"
 
 	// Timings
 	TIMINGS_VIEW   = "timings.view"
 	TIMINGS_ADD    = "timings.add"
 	TIMINGS_UPDATE = "timings.update"
 	TIMINGS_DELETE = "timings.delete"
+	// Başka bir kullanıcı adına zamanlama ekleme
+	TIMINGS_ADD_FOR_OTHERS = "timings.add_for_others"
+
+	// Webhooks
+	WEBHOOKS_VIEW   = "webhooks.view"
+	WEBHOOKS_ADD    = "webhooks.add"
+	WEBHOOKS_UPDATE = "webhooks.update"
+	WEBHOOKS_DELETE = "webhooks.delete"
+
+	// Preferences
+	SYSTEM_LANGUAGE = "system.language" // Hata mesajlarının dili ("tr", "en")
 )
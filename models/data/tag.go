@@ -0,0 +1,51 @@
+package data
+
+import (
+	"lms-web-services-main/validation"
+)
+
+// Tag, zaman kayıtlarını işin türüne göre (ör. "Toplantı", "Hata düzeltme", "Destek")
+// sınıflandıran etikettir. Etiket listesi kurulumun tamamında ortaktır; bir kayda birden
+// fazla etiket bağlanabilir.
+type Tag struct {
+	Id          int    `gorm:"column:Id;type:serial;primary_key" json:"id"`
+	Name        string `gorm:"column:Name;type:varchar(50);not null" json:"n" validate:"required,max=50" label:"field.tag_name"`
+	Color       string `gorm:"column:Color;type:varchar(9);not null" json:"c" validate:"required,hexcolor" label:"field.color" doc:"Onaltılık renk kodu, örn. #1E88E5"`
+	Description string `gorm:"column:Description;type:varchar(200);not null" json:"desc" validate:"max=200" label:"field.description"`
+}
+
+func (Tag) TableName() string {
+	return "Tags"
+}
+
+func (model *Tag) IsNew() bool {
+	return model.Id == 0
+}
+
+func (model *Tag) GetId() int {
+	return model.Id
+}
+
+func (model *Tag) SetId(id int) {
+	model.Id = id
+}
+
+func (model *Tag) Validate() error {
+	return validation.Struct(model)
+}
+
+// ValidateForUpdate, güncellemede de tüm alanlar aynı kurallarla doğrulanır
+func (model *Tag) ValidateForUpdate() error {
+	return model.Validate()
+}
+
+// TimingTag, zaman kaydı ile etiket arasındaki bağlantıdır. Bağlantılar API'de ayrı bir
+// kaynak olarak görünmez; /timings/:id/tags ile topluca değiştirilir.
+type TimingTag struct {
+	TimingId int `gorm:"column:TimingId;type:integer;primary_key"`
+	TagId    int `gorm:"column:TagId;type:integer;primary_key"`
+}
+
+func (TimingTag) TableName() string {
+	return "TimingTags"
+}
@@ -1,63 +1,49 @@
 package data
 
 import (
-	"errors"
 	"time"
 
 	"lms-web-services-main/models/enum"
+	"lms-web-services-main/validation"
 
 	"github.com/google/uuid"
 )
 
+// Timing'de TaskId isteğe bağlıdır; verilirse kaydın projesinin görevlerinden biri olmalıdır
 type Timing struct {
 	Id              int             `gorm:"column:Id;type:serial;primary_key" json:"id"`
-	ClientProjectId int             `gorm:"column:ClientProjectId;type:integer;not null" json:"cpid"`
-	SystemUserId    uuid.UUID       `gorm:"column:SystemUserId;type:uuid;not null" json:"suid"`
-	Title           string          `gorm:"column:Title;type:varchar(100);not null" json:"t"`
+	ClientProjectId int             `gorm:"column:ClientProjectId;type:integer;not null" json:"cpid" validate:"required,gt=0" label:"field.client_project"`
+	SystemUserId    uuid.UUID       `gorm:"column:SystemUserId;type:uuid;not null" json:"suid" validate:"required" label:"field.system_user"`
+	TaskId          *int            `gorm:"column:TaskId;type:integer" json:"tid,omitempty" validate:"omitempty,gt=0" label:"field.task" doc:"İsteğe bağlı; kaydın projesine ait bir görev olmalıdır"`
+	Title           string          `gorm:"column:Title;type:varchar(100);not null" json:"t" validate:"required,max=100" label:"field.title"`
 	Description     string          `gorm:"column:Description;type:text" json:"desc"`
-	StartDateTime   time.Time       `gorm:"column:StartDateTime;type:timestamptz;not null" json:"sdt"`
-	EndDateTime     time.Time       `gorm:"column:EndDateTime;type:timestamptz;not null" json:"edt"`
-	Status          enum.StatusEnum `gorm:"column:Status;type:integer;not null" json:"st"`
+	StartDateTime   time.Time       `gorm:"column:StartDateTime;type:timestamptz;not null" json:"sdt" label:"field.start_date_time"`
+	EndDateTime     time.Time       `gorm:"column:EndDateTime;type:timestamptz;not null" json:"edt" validate:"gtefield=StartDateTime" label:"field.end_date_time"`
+	Status          enum.StatusEnum `gorm:"column:Status;type:integer;not null" json:"st" validate:"enum" label:"field.status" doc:"0: Paused, 1: Started, 2: Stopped, 3: Completed"`
 }
 
 func (Timing) TableName() string {
 	return "Timings"
 }
 
+func (model *Timing) IsNew() bool {
+	return model.Id == 0
+}
+
+func (model *Timing) GetId() int {
+	return model.Id
+}
+
+func (model *Timing) SetId(id int) {
+	model.Id = id
+}
+
 func (model *Timing) Validate() error {
-	if model.ClientProjectId <= 0 {
-		return errors.New("client_project_id alanı zorunludur")
-	}
-	if model.SystemUserId == uuid.Nil {
-		return errors.New("system_user_id alanı zorunludur")
-	}
-	if model.Title == "" {
-		return errors.New("başlık alanı zorunludur")
-	}
-	if len(model.Title) > 100 {
-		return errors.New("başlık 100 karakterden uzun olamaz")
-	}
-	if model.EndDateTime.Before(model.StartDateTime) {
-		return errors.New("bitiş zamanı, başlangıç zamanından önce olamaz")
-	}
-	if !model.Status.IsValid() {
-		return errors.New("geçersiz durum değeri")
-	}
-	return nil
+	return validation.Struct(model)
 }
 
+// ValidateForUpdate, kaydın projesi ve sahibi güncellemede değiştirilmediği için
+// ClientProjectId ve SystemUserId alanlarını doğrulamaz
 func (model *Timing) ValidateForUpdate() error {
-	if model.Title == "" {
-		return errors.New("başlık alanı zorunludur")
-	}
-	if len(model.Title) > 100 {
-		return errors.New("başlık 100 karakterden uzun olamaz")
-	}
-	if model.EndDateTime.Before(model.StartDateTime) {
-		return errors.New("bitiş zamanı, başlangıç zamanından önce olamaz")
-	}
-	if !model.Status.IsValid() {
-		return errors.New("geçersiz durum değeri")
-	}
-	return nil
+	return validation.StructExcept(model, "ClientProjectId", "SystemUserId")
 }
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/validation"
+)
+
+// StringList, jsonb sütunda JSON dizisi olarak saklanan metin listesidir
+type StringList []string
+
+func (list StringList) Value() (driver.Value, error) {
+	if list == nil {
+		return "[]", nil
+	}
+	value, err := json.Marshal([]string(list))
+	return string(value), err
+}
+
+func (list *StringList) Scan(value any) error {
+	switch value := value.(type) {
+	case []byte:
+		return json.Unmarshal(value, list)
+	case string:
+		return json.Unmarshal([]byte(value), list)
+	case nil:
+		*list = nil
+		return nil
+	}
+	return errors.New("StringList: unsupported column type")
+}
+
+// WebhookSubscription, dış sistemlerin olaylardan haberdar edilmesi için tanımlanan
+// aboneliktir. EventTypes'taki olaylar Url'e POST edilir; gövde Secret ile HMAC-SHA256
+// kullanılarak imzalanır. Secret yalnızca yazılır, yanıtlarda dönmez.
+type WebhookSubscription struct {
+	Id          int        `gorm:"column:Id;type:serial;primary_key" json:"id"`
+	Url         string     `gorm:"column:Url;type:varchar(2048);not null" json:"url" validate:"required,http_url,max=2048" label:"field.url" doc:"Herkese açık bir http(s) adresi; yerel ve özel ağları gösteren adresler reddedilir"`
+	EventTypes  StringList `gorm:"column:EventTypes;type:jsonb;not null" json:"evts" validate:"required,min=1" label:"field.event_types" doc:"Olay türleri, örn. timing.created"`
+	Secret      string     `gorm:"column:Secret;type:varchar(200);not null" json:"sec,omitempty" validate:"required,min=16,max=200" label:"field.secret" doc:"İmza anahtarı; yalnızca yazılır. Güncellemede boş bırakılırsa değişmez."`
+	IsActive    bool       `gorm:"column:IsActive;type:boolean;not null;default:true" json:"ia"`
+	Description string     `gorm:"column:Description;type:varchar(200);not null" json:"desc" validate:"max=200" label:"field.description"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "WebhookSubscriptions"
+}
+
+func (model *WebhookSubscription) IsNew() bool {
+	return model.Id == 0
+}
+
+func (model *WebhookSubscription) GetId() int {
+	return model.Id
+}
+
+func (model *WebhookSubscription) SetId(id int) {
+	model.Id = id
+}
+
+func (model *WebhookSubscription) Validate() error {
+	return validation.Struct(model)
+}
+
+// ValidateForUpdate, boş bırakılan Secret kayıtlı anahtarın korunması anlamına geldiği için
+// bu durumda Secret'ı doğrulamaz
+func (model *WebhookSubscription) ValidateForUpdate() error {
+	if model.Secret == "" {
+		return validation.StructExcept(model, "Secret")
+	}
+	return model.Validate()
+}
+
+// Subscribes, aboneliğin eventType olayını alıp almadığını döndürür
+func (model *WebhookSubscription) Subscribes(eventType string) bool {
+	return slices.Contains(model.EventTypes, eventType)
+}
+
+// MarshalJSON, Secret'ı yanıtlardan çıkarır
+func (model WebhookSubscription) MarshalJSON() ([]byte, error) {
+	type subscription WebhookSubscription
+	model.Secret = ""
+	return json.Marshal(subscription(model))
+}
+
+// WebhookDelivery, bir olayın bir aboneliğe gönderiminin kaydıdır (gönderim günlüğü).
+// Gönderim 2xx yanıt alınana kadar üstel artan beklemeyle yeniden denenir; deneme hakkı
+// bitince Failed durumunda kalır. NextAttemptAt yalnızca bekleyen gönderimlerde doludur.
+// Bir olay bir aboneliğe bir kez gönderilir; yönetici isteğiyle yapılan yeniden gönderimler
+// RedeliveryOf ile asıl gönderime bağlanan yeni kayıtlardır.
+type WebhookDelivery struct {
+	Id             int64                   `gorm:"column:Id;type:bigserial;primary_key" json:"id"`
+	SubscriptionId int                     `gorm:"column:SubscriptionId;type:integer;not null" json:"sid"`
+	EventId        int64                   `gorm:"column:EventId;type:bigint;not null" json:"eid" doc:"Olayın kimliği; yeniden gönderimlerde aynı kalır"`
+	EventType      string                  `gorm:"column:EventType;type:varchar(100);not null" json:"et"`
+	RedeliveryOf   *int64                  `gorm:"column:RedeliveryOf;type:bigint" json:"rdo" doc:"Yeniden gönderimlerde asıl gönderimin ID'si"`
+	Payload        string                  `gorm:"column:Payload;type:jsonb;not null" json:"-"`
+	Status         enum.DeliveryStatusEnum `gorm:"column:Status;type:integer;not null;default:0" json:"st" doc:"0: Pending, 1: Delivered, 2: Failed"`
+	Attempts       int                     `gorm:"column:Attempts;type:integer;not null;default:0" json:"a"`
+	NextAttemptAt  *time.Time              `gorm:"column:NextAttemptAt;type:timestamptz" json:"naa"`
+	LastAttemptAt  *time.Time              `gorm:"column:LastAttemptAt;type:timestamptz" json:"laa"`
+	ResponseStatus int                     `gorm:"column:ResponseStatus;type:integer;not null;default:0" json:"rs" doc:"Son denemede alınan HTTP durum kodu; yanıt alınamadıysa 0"`
+	LastError      string                  `gorm:"column:LastError;type:text;not null;default:''" json:"le"`
+	CreatedAt      time.Time               `gorm:"column:CreatedAt;type:timestamptz;not null" json:"ca"`
+	DeliveredAt    *time.Time              `gorm:"column:DeliveredAt;type:timestamptz" json:"da"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "WebhookDeliveries"
+}
+
+func (model *WebhookDelivery) GetId() int64 {
+	return model.Id
+}
+
+func (model *WebhookDelivery) SetId(id int64) {
+	model.Id = id
+}
@@ -0,0 +1,43 @@
+package enum
+
+import "errors"
+
+// DeliveryStatusEnum, WebhookDelivery için durumları temsil eder
+type DeliveryStatusEnum int
+
+// Enum değerleri
+const (
+	DeliveryStatusPending   DeliveryStatusEnum = iota // 0
+	DeliveryStatusDelivered                           // 1
+	DeliveryStatusFailed                              // 2
+)
+
+// deliveryStatusStrings, DeliveryStatusEnum değerlerinin string karşılıkları
+var deliveryStatusStrings = []string{
+	"Pending",
+	"Delivered",
+	"Failed",
+}
+
+// String, DeliveryStatusEnum için string karşılığını döndürür
+func (s DeliveryStatusEnum) String() string {
+	if s < 0 || int(s) >= len(deliveryStatusStrings) {
+		return "Unknown"
+	}
+	return deliveryStatusStrings[s]
+}
+
+// ParseDeliveryStatus, bir string değeri DeliveryStatusEnum'a dönüştürür
+func ParseDeliveryStatus(value string) (DeliveryStatusEnum, error) {
+	for i, v := range deliveryStatusStrings {
+		if v == value {
+			return DeliveryStatusEnum(i), nil
+		}
+	}
+	return -1, errors.New("geçersiz gönderim durumu")
+}
+
+// IsValid, DeliveryStatusEnum'un geçerli bir değer olup olmadığını kontrol eder
+func (s DeliveryStatusEnum) IsValid() bool {
+	return s >= DeliveryStatusPending && s <= DeliveryStatusFailed
+}
@@ -0,0 +1,43 @@
+package enum
+
+import "errors"
+
+// TaskStatusEnum, ProjectTask için durumları temsil eder
+type TaskStatusEnum int
+
+// Enum değerleri
+const (
+	TaskStatusTodo       TaskStatusEnum = iota // 0
+	TaskStatusInProgress                       // 1
+	TaskStatusDone                             // 2
+)
+
+// taskStatusStrings, TaskStatusEnum değerlerinin string karşılıkları
+var taskStatusStrings = []string{
+	"Todo",
+	"InProgress",
+	"Done",
+}
+
+// String, TaskStatusEnum için string karşılığını döndürür
+func (s TaskStatusEnum) String() string {
+	if s < 0 || int(s) >= len(taskStatusStrings) {
+		return "Unknown"
+	}
+	return taskStatusStrings[s]
+}
+
+// ParseTaskStatus, bir string değeri TaskStatusEnum'a dönüştürür
+func ParseTaskStatus(value string) (TaskStatusEnum, error) {
+	for i, v := range taskStatusStrings {
+		if v == value {
+			return TaskStatusEnum(i), nil
+		}
+	}
+	return -1, errors.New("geçersiz görev durumu")
+}
+
+// IsValid, TaskStatusEnum'un geçerli bir değer olup olmadığını kontrol eder
+func (s TaskStatusEnum) IsValid() bool {
+	return s >= TaskStatusTodo && s <= TaskStatusDone
+}
@@ -0,0 +1,46 @@
+package mvc
+
+// ArchivedFilter, müşteri ve proje listelerinde arşivlenmiş (IsActive = false) kayıtların
+// nasıl ele alınacağıdır. İsteklerde "archived" parametresiyle verilir; varsayılan olarak
+// arşivlenmiş kayıtlar gizlenir.
+type ArchivedFilter string
+
+const (
+	ArchivedExclude ArchivedFilter = "false" // Yalnızca aktif kayıtlar (varsayılan)
+	ArchivedOnly    ArchivedFilter = "true"  // Yalnızca arşivlenmiş kayıtlar
+	ArchivedInclude ArchivedFilter = "all"   // Tüm kayıtlar
+)
+
+// ParseArchivedFilter, "archived" parametresini okur. Boş değer ArchivedExclude'dur.
+func ParseArchivedFilter(value string) (ArchivedFilter, bool) {
+	switch filter := ArchivedFilter(value); filter {
+	case "":
+		return ArchivedExclude, true
+	case ArchivedExclude, ArchivedOnly, ArchivedInclude:
+		return filter, true
+	}
+	return "", false
+}
+
+// Matches, IsActive değeri verilen kaydın listeye girip girmeyeceğini döndürür
+func (f ArchivedFilter) Matches(isActive bool) bool {
+	switch f {
+	case ArchivedOnly:
+		return !isActive
+	case ArchivedInclude:
+		return true
+	}
+	return isActive
+}
+
+// IsActive, filtrenin listeyi daralttığı IsActive değerini döndürür. ArchivedInclude ve boş
+// filtre listeyi daraltmaz; bu durumda restricted false'tur.
+func (f ArchivedFilter) IsActive() (isActive bool, restricted bool) {
+	switch f {
+	case ArchivedExclude:
+		return true, true
+	case ArchivedOnly:
+		return false, true
+	}
+	return false, false
+}
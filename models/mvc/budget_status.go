@@ -0,0 +1,35 @@
+package mvc
+
+import "time"
+
+// TimingHours: Bir projenin zaman kayıtlarının toplam süresi ve saatlik ücretlerle tutarı
+type TimingHours struct {
+	Hours              float64    `json:"h"`
+	Amount             float64    `json:"a"`
+	FirstStartDateTime *time.Time `json:"fsdt,omitempty"` // En erken kaydın başlangıcı; kayıt yoksa nil
+}
+
+// BudgetStatus: /client-projects/:id/budget-status uç noktasının döndürdüğü bütçe durumu.
+// Tanımlı olmayan bütçe türünün (saat veya tutar) alanları sıfırdır.
+type BudgetStatus struct {
+	ClientProjectId     int        `json:"cpid"`
+	StartDate           *time.Time `json:"bsd,omitempty"`
+	EndDate             *time.Time `json:"bed,omitempty"`
+	BudgetHours         float64    `json:"bh"`
+	ConsumedHours       float64    `json:"ch"`
+	RemainingHours      float64    `json:"rh"`
+	BudgetAmount        float64    `json:"ba"`
+	ConsumedAmount      float64    `json:"ca"`
+	RemainingAmount     float64    `json:"ra"`
+	ConsumedPercent     float64    `json:"cpct"`         // Saat ve tutar oranlarından büyük olanı
+	Exhausted           bool       `json:"ex"`           // Bütçelerden biri tükendi
+	ProjectedCompletion *time.Time `json:"pc,omitempty"` // Mevcut harcama hızıyla bütçenin biteceği an
+	ProjectedOverrun    bool       `json:"po"`           // Bütçe, bitiş tarihinden önce tükenecek
+}
+
+// BudgetAlert: Proje bütçesinin bir eşiği (ör. %80) ilk kez aştığını bildiren olay
+type BudgetAlert struct {
+	ClientProjectId int     `json:"cpid"`
+	Threshold       int     `json:"th"`   // Aşılan eşik (yüzde)
+	ConsumedPercent float64 `json:"cpct"` // Kayıttan sonraki harcama oranı
+}
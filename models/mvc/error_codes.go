@@ -0,0 +1,108 @@
+package mvc
+
+import (
+	"errors"
+
+	"lms-web-services-main/i18n"
+
+	"github.com/LGYtech/lgo"
+)
+
+// İş kuralı hatalarının (lgo.NewLogicError) türünü belirten ErrorCode değerleri.
+// /api/v1 rotaları HTTP durum kodunu bu değerlere göre seçer; eski rotalarda yalnızca
+// "ec" alanında döner.
+const (
+	ErrorCodeNone       uint8 = 0 // Sınıflandırılmamış iş kuralı hatası (400)
+	ErrorCodeValidation uint8 = 1 // Model doğrulaması başarısız (422)
+	ErrorCodeNotFound   uint8 = 2 // Kayıt bulunamadı (404)
+	ErrorCodeConflict   uint8 = 3 // Kayıt başka kayıtlarla çakışıyor veya başka kayıtlarca kullanılıyor (409)
+)
+
+// Sonuç türleri lgo'da dışa açık olmadığı için örneklerden okunur. Sonuçların türünü
+// ayırt etmesi gereken katmanlar (HTTP durum eşlemesi, servisler) bu değerleri kullanır.
+var (
+	ResultLogicError = lgo.NewLogicError("", nil).Result
+	ResultFailure    = lgo.NewFailure().Result
+	ResultAuthError  = lgo.NewAuthError().Result
+	ResultAutoError  = lgo.NewAutoError().Result
+)
+
+// IsFailure, sonucun bir sistem hatası (lgo.NewFailure) olup olmadığını döndürür
+func IsFailure(result *lgo.OperationResult) bool {
+	return result.Result == ResultFailure
+}
+
+// Aşağıdaki fonksiyonlar iş kuralı hatalarını bir i18n mesaj koduyla oluşturur. Kod,
+// ReturnObject içinde (*i18n.Message) taşınır; ErrorMessage önce varsayılan dilde yazılır ve
+// yanıt gönderilirken isteğin diline çevrilir.
+
+// NewLogicError, sınıflandırılmamış bir iş kuralı hatası döndürür
+func NewLogicError(code string, args ...any) *lgo.OperationResult {
+	return NewCodedError(ErrorCodeNone, i18n.New(code, args...))
+}
+
+// NewValidationError, model doğrulaması başarısız olduğunda döndürülür
+func NewValidationError(code string, args ...any) *lgo.OperationResult {
+	return NewCodedError(ErrorCodeValidation, i18n.New(code, args...))
+}
+
+// NewNotFoundError, istenen kayıt bulunamadığında döndürülür
+func NewNotFoundError(code string, args ...any) *lgo.OperationResult {
+	return NewCodedError(ErrorCodeNotFound, i18n.New(code, args...))
+}
+
+// NewConflictError, kayıt benzersizlik veya referans kuralıyla çakıştığında döndürülür
+func NewConflictError(code string, args ...any) *lgo.OperationResult {
+	return NewCodedError(ErrorCodeConflict, i18n.New(code, args...))
+}
+
+// Postgres'in çakışma hatasına çevrilen SQLSTATE kodları
+const (
+	uniqueViolation     = "23505" // Benzersiz indeks ihlali
+	foreignKeyViolation = "23503" // Bağlı kaydı olan kaydın silinmesi veya olmayan kayda bağlanma
+)
+
+// NewDatabaseError, benzersiz indeks ve foreign key ihlallerini çakışma hatasına, diğer
+// veritabanı hatalarını sistem hatasına (lgo.NewFailure) çevirir. Sistem hatalarının
+// ayrıntısı /api/v1 yanıtlarında istemciye gösterilmez.
+func NewDatabaseError(err error) *lgo.OperationResult {
+	// Sürücü hatası (pgconn.PgError) SQLSTATE kodunu bu arayüzle verir
+	var sqlError interface{ SQLState() string }
+	if errors.As(err, &sqlError) {
+		switch sqlError.SQLState() {
+		case uniqueViolation:
+			return NewConflictError(i18n.DuplicateRecord)
+		case foreignKeyViolation:
+			return NewConflictError(i18n.ReferenceViolation)
+		}
+	}
+
+	message := i18n.New(i18n.DatabaseError, err.Error())
+	result := lgo.NewFailureWithReturnObject(message)
+	result.ErrorMessage = message.Error()
+	return result
+}
+
+// NewValidationErrorFrom, Validate() fonksiyonlarının döndürdüğü hatayı doğrulama hatasına
+// çevirir. Hata bir *i18n.Message değilse metni validation.failed koduyla taşınır.
+func NewValidationErrorFrom(err error) *lgo.OperationResult {
+	var message *i18n.Message
+	if errors.As(err, &message) {
+		return NewCodedError(ErrorCodeValidation, message)
+	}
+	return NewValidationError(i18n.ValidationFailed, err.Error())
+}
+
+// NewCodedError, verilen türde ve mesajla bir iş kuralı hatası oluşturur
+func NewCodedError(errorCode uint8, message *i18n.Message) *lgo.OperationResult {
+	result := lgo.NewLogicError(message.Error(), message)
+	result.ErrorCode = errorCode
+	return result
+}
+
+// NewForbiddenError, eksik yetkinin anahtarını taşıyan yetkilendirme hatası döndürür
+func NewForbiddenError(permissionKey string) *lgo.OperationResult {
+	result := lgo.NewAutoError()
+	result.ErrorMessage = permissionKey
+	return result
+}
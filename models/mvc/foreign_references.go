@@ -0,0 +1,43 @@
+package mvc
+
+import "lms-web-services-main/i18n"
+
+// ForeignReferences: Bir müşteri veya proje force=true ile silindiğinde onunla birlikte
+// silinecek bağlı kayıtların sayıları. Proje silinirken müşteriye ait alanlar sıfırdır.
+type ForeignReferences struct {
+	ClientProjects       int64 `json:"cps"`
+	ClientContacts       int64 `json:"ccs"`
+	ClientProjectMembers int64 `json:"cpms"`
+	ProjectTasks         int64 `json:"pts"`
+	Timings              int64 `json:"tms"`
+	TimingTags           int64 `json:"tts"` // Silinecek zaman kayıtlarının etiket bağlantıları
+}
+
+// Any, silinecek bağlı kayıt olup olmadığını döndürür
+func (r *ForeignReferences) Any() bool {
+	return r.ClientProjects+r.ClientContacts+r.ClientProjectMembers+r.ProjectTasks+r.Timings+r.TimingTags > 0
+}
+
+// Message, sıfır olmayan sayıları alan olarak taşıyan mesajı döndürür. /api/v1 rotalarında
+// alanlar Problem Details gövdesinin "errors" nesnesinde listelenir.
+func (r *ForeignReferences) Message(code string) *i18n.Message {
+	message := i18n.New(code)
+	counts := []struct {
+		field string
+		code  string
+		count int64
+	}{
+		{"cps", i18n.ReferencedClientProjects, r.ClientProjects},
+		{"ccs", i18n.ReferencedClientContacts, r.ClientContacts},
+		{"cpms", i18n.ReferencedMembers, r.ClientProjectMembers},
+		{"pts", i18n.ReferencedTasks, r.ProjectTasks},
+		{"tms", i18n.ReferencedTimings, r.Timings},
+		{"tts", i18n.ReferencedTimingTags, r.TimingTags},
+	}
+	for _, count := range counts {
+		if count.count > 0 {
+			message.WithField(count.field, i18n.New(count.code, count.count))
+		}
+	}
+	return message
+}
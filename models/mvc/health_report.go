@@ -0,0 +1,24 @@
+package mvc
+
+// HealthReport: Liveness ve readiness uç noktalarının döndürdüğü durum raporu
+type HealthReport struct {
+	Status    string         `json:"status"`           // "ok" veya "unavailable"
+	Version   string         `json:"version"`          // Uygulama sürümü
+	GoVersion string         `json:"go_version"`       // Derleyici sürümü
+	Uptime    string         `json:"uptime"`           // Süreç çalışma süresi
+	Checks    []*HealthCheck `json:"checks,omitempty"` // Bağımlılık kontrolleri (yalnızca readiness)
+}
+
+// HealthCheck: Tek bir bağımlılığın (Postgres, Redis) kontrol sonucu
+type HealthCheck struct {
+	Name      string  `json:"name"`              // Bağımlılık adı
+	Status    string  `json:"status"`            // "ok" veya "unavailable"
+	LatencyMs float64 `json:"latency_ms"`        // Kontrolün sürdüğü süre (ms)
+	Version   string  `json:"version,omitempty"` // Sunucu sürümü
+	Error     string  `json:"error,omitempty"`   // Hata mesajı
+}
+
+const (
+	HealthStatusOk          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
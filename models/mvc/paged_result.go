@@ -0,0 +1,14 @@
+package mvc
+
+// PagedResult, liste uç noktalarının döndürdüğü sayfalı sonuç zarfıdır. NextCursor,
+// sonraki sayfayı anahtar tabanlı (keyset) sayfalamayla almak için QueryModel.Cursor'a
+// verilir; sonraki sayfa yoksa boştur.
+type PagedResult[T any] struct {
+	Items          []T    `json:"itms"`         // Sayfadaki kayıtlar
+	TotalCount     int64  `json:"tc"`           // Filtreye uyan toplam kayıt sayısı
+	PageNumber     int    `json:"pn"`           // İmleçle yapılan isteklerde 0'dır
+	RecordsPerPage int    `json:"rpp"`          // Sayfa başına kayıt
+	TotalPages     int    `json:"tp"`           // Toplam sayfa sayısı
+	HasMore        bool   `json:"hm"`           // Bu sayfadan sonra kayıt var mı
+	NextCursor     string `json:"nc,omitempty"` // Sonraki sayfanın imleci
+}
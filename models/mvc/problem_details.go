@@ -0,0 +1,17 @@
+package mvc
+
+// ProblemDetailsContentType, RFC 9457 hata gövdelerinin içerik türüdür
+const ProblemDetailsContentType = "application/problem+json"
+
+// ProblemDetails, /api/v1 rotalarının hata gövdesidir (RFC 9457)
+type ProblemDetails struct {
+	Type      string `json:"type"`                 // Sorun türü; yalnızca durum kodu anlam taşıyorsa "about:blank"
+	Title     string `json:"title"`                // Sorun türünün kısa açıklaması
+	Status    int    `json:"status"`               // HTTP durum kodu
+	Detail    string `json:"detail,omitempty"`     // Bu isteğe özgü açıklama
+	Instance  string `json:"instance,omitempty"`   // İsteğin yolu
+	Code      string `json:"code"`                 // Kararlı mesaj kodu, örn. "client.not_found"
+	RequestID string `json:"request_id,omitempty"` // Günlüklerde isteği bulmak için
+
+	Errors map[string]string `json:"errors,omitempty"` // Alan bazlı doğrulama hataları; anahtar alanın JSON adıdır
+}
@@ -1,17 +1,27 @@
 package mvc
 
 import (
+	"lms-web-services-main/i18n"
+
 	"github.com/LGYtech/lgo"
 )
 
+// MaxRecordsPerPage, tek istekte döndürülebilecek azami kayıt sayısıdır
+const MaxRecordsPerPage = 100
+
 // QueryModel: Pagination, Sorting, Searching ve Filtering için tek bir model
 type QueryModel struct {
-	PageNumber     int                      `json:"pn" form:"pn"`     // Sayfa numarası
-	RecordsPerPage int                      `json:"rpp" form:"rpp"`   // Sayfa başına kayıt
-	SortingOptions []*DataSortingOptionItem `json:"so" form:"so"`     // Sıralama bilgileri
-	Filter         string                   `json:"flt" form:"flt"`   // Tekil string filtre (örn. "Status=Active")
-	SearchTerm     string                   `json:"src" form:"src"`   // Genel arama terimi
-	SearchFields   []string                 `json:"srcf" form:"srcf"` // Arama yapılacak alanlar
+	PageNumber     int                      `json:"pn" form:"pn" doc:"Sayfa numarası (1'den başlar); cur ile birlikte kullanılmaz"`                                  // Sayfa numarası
+	RecordsPerPage int                      `json:"rpp" form:"rpp" doc:"Sayfa başına kayıt (1-100)"`                                                                 // Sayfa başına kayıt
+	SortingOptions []*DataSortingOptionItem `json:"so" form:"so" doc:"Sıralama; her değer JSON olarak kodlanır, örn. {\"cn\":\"t\",\"s\":1} (s: 0 artan, 1 azalan)"` // Sıralama bilgileri (cn: alan adı)
+	Filter         string                   `json:"flt" form:"flt" doc:"Filtre ifadesi: =, !=, <, <=, >, >=, in, between, like, is [not] null, and/or ve parantez"`  // Filtre ifadesi (örn. "ia = true and (t like 'A%' or id in (1, 2))")
+	SearchTerm     string                   `json:"src" form:"src" doc:"Aranabilir alanlarda ILIKE ile aranacak terim"`                                              // Genel arama terimi
+	SearchFields   []string                 `json:"srcf" form:"srcf" doc:"Aramanın sınırlanacağı alanlar"`                                                           // Aramanın sınırlanacağı alanlar; boşsa tüm aranabilir alanlar
+	Cursor         string                   `json:"cur" form:"cur" doc:"Önceki sayfanın nc (NextCursor) değeri"`                                                     // Önceki sayfanın NextCursor değeri; verilirse PageNumber kullanılmaz
+
+	// Archived, müşteri ve proje listelerini arşiv durumuna göre daraltır. İstekten bağlanmaz;
+	// controller "archived" parametresinden atar. Boşsa liste daraltılmaz.
+	Archived ArchivedFilter `json:"-" form:"-"`
 }
 
 // GetSkip: Atlanacak kayıt sayısını hesaplar
@@ -21,25 +31,24 @@ func (q *QueryModel) GetSkip() int {
 
 // Validate: QueryModel'ın geçerliliğini kontrol eder
 func (q *QueryModel) Validate() *lgo.OperationResult {
-	if q.PageNumber < 1 {
-		return lgo.NewLogicError("Geçersiz sayfa numarası. Sayfa numarası 1 veya daha büyük olmalıdır.", nil)
+	if q.Cursor != "" {
+		if q.PageNumber > 1 {
+			return NewLogicError(i18n.QueryCursorWithPage)
+		}
+	} else if q.PageNumber < 1 {
+		return NewLogicError(i18n.QueryInvalidPage)
 	}
 	if q.RecordsPerPage < 1 {
-		return lgo.NewLogicError("Gösterilecek veri yoktur.", nil)
+		return NewLogicError(i18n.QueryInvalidPageSize)
+	}
+	if q.RecordsPerPage > MaxRecordsPerPage {
+		return NewLogicError(i18n.QueryPageSizeTooLarge, MaxRecordsPerPage)
 	}
 	return lgo.NewSuccess(nil)
 }
 
 // DataSortingOptionItem: Sıralama için sütun bilgileri
 type DataSortingOptionItem struct {
-	ColumnName string `json:"cn" form:"cn"` // Sütun adı
+	ColumnName string `json:"cn" form:"cn"` // Alan adı (API adı; sütuna repository şeması ile çevrilir)
 	Sorting    int8   `json:"s" form:"s"`   // 0: ASC, 1: DESC
 }
-
-// ToGormOrderString: GORM sıralama stringi oluşturur
-func (d *DataSortingOptionItem) ToGormOrderString() string {
-	if d.Sorting == 0 {
-		return d.ColumnName + " ASC"
-	}
-	return d.ColumnName + " DESC"
-}
@@ -0,0 +1,102 @@
+package mvc
+
+import (
+	"time"
+
+	"lms-web-services-main/i18n"
+
+	"github.com/LGYtech/lgo"
+)
+
+// ReportQuery, /reports isteklerinin parametreleridir. Tarihler verilmişse yalnızca
+// [startDate, endDate) aralığında başlayan zaman kayıtları sayılır. Proje, görev raporunda
+// zorunlu, etiket raporunda isteğe bağlıdır. Tarih parametreleri /timings/date-range ile aynı
+// adları taşır.
+type ReportQuery struct {
+	ClientProjectId int       `json:"cpid" form:"cpid" doc:"Proje ID"`
+	StartDate       time.Time `json:"startDate" form:"startDate" time_format:"2006-01-02T15:04:05Z07:00" doc:"Aralığın başlangıcı (RFC 3339, dahil)"`
+	EndDate         time.Time `json:"endDate" form:"endDate" time_format:"2006-01-02T15:04:05Z07:00" doc:"Aralığın sonu (RFC 3339, hariç)"`
+}
+
+// Validate: ReportQuery'nin tarih aralığının geçerliliğini kontrol eder
+func (q *ReportQuery) Validate() *lgo.OperationResult {
+	if !q.StartDate.IsZero() && !q.EndDate.IsZero() && q.EndDate.Before(q.StartDate) {
+		return NewLogicError(i18n.EndBeforeStart)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// Range, verilmeyen sınırları nil olarak döndürür
+func (q *ReportQuery) Range() (*time.Time, *time.Time) {
+	var from, to *time.Time
+	if !q.StartDate.IsZero() {
+		from = &q.StartDate
+	}
+	if !q.EndDate.IsZero() {
+		to = &q.EndDate
+	}
+	return from, to
+}
+
+// TaskHours: Bir görevin zaman kayıtlarının toplam süresi. TaskId nil ise satır projenin
+// göreve bağlanmamış kayıtlarıdır.
+type TaskHours struct {
+	TaskId        *int
+	Task          string
+	Status        int
+	EstimateHours float64
+	Hours         float64
+}
+
+// TaskReport: /reports/tasks uç noktasının döndürdüğü, görevlerin tahmini ve gerçekleşen
+// sürelerinin karşılaştırması
+type TaskReport struct {
+	ClientProjectId int              `json:"cpid"`
+	StartDate       *time.Time       `json:"sd,omitempty"`
+	EndDate         *time.Time       `json:"ed,omitempty"`
+	Tasks           []*TaskReportRow `json:"tsks"`
+	UnassignedHours float64          `json:"uh"` // Göreve bağlanmamış kayıtlar
+	EstimateHours   float64          `json:"eh"`
+	ActualHours     float64          `json:"ah"` // Görevsiz kayıtlar dahil
+}
+
+// TaskReportRow: Bir görevin tahmini ve gerçekleşen süresi
+type TaskReportRow struct {
+	TaskId          int     `json:"tid"`
+	Task            string  `json:"n"`
+	Status          string  `json:"st"`
+	EstimateHours   float64 `json:"eh"`
+	ActualHours     float64 `json:"ah"`
+	VarianceHours   float64 `json:"vh"`   // Gerçekleşen - tahmini; pozitifse tahmin aşıldı
+	ConsumedPercent float64 `json:"cpct"` // Tahminin harcanan yüzdesi; tahmin yoksa sıfır
+	OverEstimate    bool    `json:"oe"`
+}
+
+// TagHours: Bir etiketin zaman kayıtlarının sayısı ve toplam süresi. TagId nil ise satır
+// hiç etiketi olmayan kayıtlardır.
+type TagHours struct {
+	TagId   *int
+	Tag     string
+	Color   string
+	Timings int
+	Hours   float64
+}
+
+// TagReport: /reports/tags uç noktasının döndürdüğü, sürelerin etiketlere dağılımı. Birden
+// fazla etiketi olan bir kayıt her etiketinde sayılır.
+type TagReport struct {
+	ClientProjectId int             `json:"cpid,omitempty"` // Verilmemişse tüm projeler
+	StartDate       *time.Time      `json:"sd,omitempty"`
+	EndDate         *time.Time      `json:"ed,omitempty"`
+	Tags            []*TagReportRow `json:"tgs"`
+	UntaggedHours   float64         `json:"uth"` // Etiketsiz kayıtlar
+}
+
+// TagReportRow: Bir etiketin kayıt sayısı ve toplam süresi
+type TagReportRow struct {
+	TagId   int     `json:"tgid"`
+	Tag     string  `json:"n"`
+	Color   string  `json:"c"`
+	Timings int     `json:"tmc"`
+	Hours   float64 `json:"h"`
+}
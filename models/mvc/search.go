@@ -0,0 +1,88 @@
+package mvc
+
+import (
+	"strings"
+
+	"lms-web-services-main/i18n"
+
+	"github.com/LGYtech/lgo"
+)
+
+// Arama sonuçlarında dönen varlık türleri
+const (
+	SearchTypeClient        = "client"
+	SearchTypeClientProject = "client_project"
+	SearchTypeTiming        = "timing"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 50
+)
+
+// SearchQuery, /search isteğinin parametreleridir
+type SearchQuery struct {
+	Term  string   `json:"q" form:"q" doc:"Aranacak ifade (2-200 karakter)"`                              // Aranacak ifade; tırnak, "or" ve "-" desteklenir
+	Types []string `json:"types" form:"types" doc:"client, client_project, timing; virgülle ayrılabilir"` // Aranacak türler; boşsa yetkili olunan tüm türler
+	Limit int      `json:"limit" form:"limit" doc:"Azami sonuç sayısı (varsayılan 20, en fazla 50)"`      // Azami sonuç sayısı
+}
+
+// Validate: SearchQuery'nin geçerliliğini kontrol eder ve varsayılanları uygular
+func (q *SearchQuery) Validate() *lgo.OperationResult {
+	if len([]rune(q.Term)) < 2 {
+		return NewValidationError(i18n.SearchTermTooShort, 2)
+	}
+	if len([]rune(q.Term)) > 200 {
+		return NewValidationError(i18n.SearchTermTooLong, 200)
+	}
+	// types=client,timing ve types=client&types=timing biçimlerinin ikisi de kabul edilir
+	var types []string
+	for _, value := range q.Types {
+		for _, searchType := range strings.Split(value, ",") {
+			if searchType = strings.TrimSpace(searchType); searchType != "" {
+				types = append(types, searchType)
+			}
+		}
+	}
+	q.Types = types
+
+	for _, searchType := range q.Types {
+		switch searchType {
+		case SearchTypeClient, SearchTypeClientProject, SearchTypeTiming:
+		default:
+			return NewValidationError(i18n.SearchInvalidType, searchType)
+		}
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultSearchLimit
+	}
+	if q.Limit > MaxSearchLimit {
+		q.Limit = MaxSearchLimit
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// HasType, türün aramaya dahil edilip edilmeyeceğini döndürür
+func (q *SearchQuery) HasType(searchType string) bool {
+	if len(q.Types) == 0 {
+		return true
+	}
+	for _, t := range q.Types {
+		if t == searchType {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchHit, tek bir arama sonucudur. Title ve Snippet HTML olarak kaçırılmıştır;
+// eşleşen kelimeler <mark> etiketiyle işaretlenir.
+type SearchHit struct {
+	Type        string  `json:"type"`
+	Id          int     `json:"id"`
+	ParentId    int     `json:"parent_id,omitempty"`    // Projenin müşterisi veya zamanlamanın projesi
+	ParentTitle string  `json:"parent_title,omitempty"` // ParentId'nin adı
+	Title       string  `json:"title"`
+	Snippet     string  `json:"snippet,omitempty"`
+	Rank        float64 `json:"rank"`
+}
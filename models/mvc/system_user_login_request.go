@@ -3,6 +3,8 @@ package mvc
 import (
 	"net/mail"
 
+	"lms-web-services-main/i18n"
+
 	"github.com/LGYtech/lgo"
 )
 
@@ -14,16 +16,16 @@ type SystemUserLoginRequest struct {
 func (model *SystemUserLoginRequest) Validate() *lgo.OperationResult {
 
 	if len(model.Password) == 0 {
-		return lgo.NewLogicError("Giriş yapabilmeniz için Parolanızı girmeniz gereklidir", nil)
+		return NewValidationError(i18n.LoginPasswordRequired)
 	}
 	if len(model.Email) == 0 {
-		return lgo.NewLogicError("Giriş yapabilmeniz için Email girmeniz gereklidir", nil)
+		return NewValidationError(i18n.LoginEmailRequired)
 	}
 
 	// #region Check If Email Valid if Exists
 	_, err := mail.ParseAddress(model.Email)
 	if err != nil {
-		return lgo.NewLogicError("Email adresi doğrulanamadı", nil)
+		return NewValidationError(i18n.LoginEmailInvalid)
 	}
 	// #endregion Check If Email Valid if Exists
 
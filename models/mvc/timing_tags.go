@@ -0,0 +1,38 @@
+package mvc
+
+import (
+	"slices"
+
+	"lms-web-services-main/i18n"
+
+	"github.com/LGYtech/lgo"
+)
+
+// MaxTimingTags, bir zaman kaydına bağlanabilecek azami etiket sayısıdır
+const MaxTimingTags = 20
+
+// TimingTags, PUT /timings/:id/tags isteğinin gövdesidir. Kaydın etiketleri verilenlerle
+// değiştirilir; boş liste tüm etiketleri kaldırır.
+type TimingTags struct {
+	TagIds []int `json:"tgs" doc:"Etiket ID'leri"`
+}
+
+// Validate: Etiket ID'lerinin geçerliliğini kontrol eder ve tekrar edenleri çıkarır
+func (r *TimingTags) Validate() *lgo.OperationResult {
+	for _, id := range r.TagIds {
+		if id <= 0 {
+			return r.invalid(i18n.New(i18n.InvalidValue, i18n.New(i18n.FieldTags)))
+		}
+	}
+
+	slices.Sort(r.TagIds)
+	r.TagIds = slices.Compact(r.TagIds)
+	if len(r.TagIds) > MaxTimingTags {
+		return r.invalid(i18n.New(i18n.MaxItems, i18n.New(i18n.FieldTags), MaxTimingTags))
+	}
+	return lgo.NewSuccess(nil)
+}
+
+func (r *TimingTags) invalid(message *i18n.Message) *lgo.OperationResult {
+	return NewCodedError(ErrorCodeValidation, i18n.New(i18n.InvalidFields).WithField("tgs", message))
+}
@@ -2,15 +2,31 @@ package mvc
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type TimingViewModel struct {
-	Id            int       `json:"id"`
-	ClientProject string    `json:"client_project"`
-	Client        string    `json:"client"`
-	Title         string    `json:"title"`
-	Description   string    `json:"description"`
-	StartDateTime time.Time `json:"start_date_time"`
-	EndDateTime   time.Time `json:"end_date_time"`
-	Status        string    `json:"status"`
+	Id              int       `json:"id"`
+	ClientProjectId int       `json:"client_project_id"`
+	ClientId        int       `json:"client_id"`
+	SystemUserId    uuid.UUID `json:"system_user_id"`
+	TaskId          *int      `json:"task_id,omitempty"`
+	Task            string    `json:"task,omitempty"`
+	ClientProject   string    `json:"client_project"`
+	Client          string    `json:"client"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	StartDateTime   time.Time `json:"start_date_time"`
+	EndDateTime     time.Time `json:"end_date_time"`
+	Status          string    `json:"status"`
+
+	Tags []TagViewModel `json:"tags" gorm:"-"` // Ada göre sıralı; sorgudan sonra ayrıca yüklenir
+}
+
+// TagViewModel, zaman kaydına bağlı bir etiketin listelerde gösterilen alanlarıdır
+type TagViewModel struct {
+	Id    int    `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
 }
@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Document, OpenAPI 3.0 belgesinin bu servisin kullandığı alt kümesidir
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Tags       []Tag                 `json:"tags,omitempty"`
+	Paths      map[string]*PathItem  `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+
+	schemas        *schemaRegistry
+	problem        *Schema
+	problemContent string
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem, HTTP metodunu (küçük harf) işleme eşler
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Tags        []string               `json:"tags,omitempty"`
+	Summary     string                 `json:"summary,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	OperationID string                 `json:"operationId,omitempty"`
+	Parameters  []*Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody           `json:"requestBody,omitempty"`
+	Responses   map[string]*Response   `json:"responses"`
+	Security    *[]SecurityRequirement `json:"security,omitempty"`
+	Deprecated  bool                   `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+	Explode     *bool   `json:"explode,omitempty"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// SecurityRequirement, güvenlik şeması adını kapsamlara eşler
+type SecurityRequirement map[string][]string
+
+// Schema, JSON Schema'nın OpenAPI 3.0'da kullanılan alt kümesidir
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Example              any                `json:"example,omitempty"`
+}
+
+// #region Document Builder
+
+// New, X-Token güvenlik şeması ve OperationResult zarfı tanımlı boş bir belge oluşturur
+func New(title string, description string, version string) *Document {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Description: description, Version: version},
+		Paths:   map[string]*PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]*SecurityScheme{
+				TokenSecurityScheme: {
+					Type:        "apiKey",
+					In:          "header",
+					Name:        "X-Token",
+					Description: "/system-user/login yanıtındaki \"t\" değeri. Oturum 5 saat geçerlidir.",
+				},
+			},
+		},
+		Security: []SecurityRequirement{{TokenSecurityScheme: {}}},
+	}
+	doc.schemas = newSchemaRegistry(doc.Components.Schemas)
+	doc.Components.Schemas[operationResultSchema] = operationResultEnvelope()
+	return doc
+}
+
+// TokenSecurityScheme, X-Token başlığıyla kimlik doğrulamanın şema adıdır
+const TokenSecurityScheme = "XToken"
+
+// UseProblemDetails, Responds ve Problems ile tanımlanan hata yanıtlarının gövde şemasını belirler
+func (d *Document) UseProblemDetails(model any, contentType string) {
+	d.problem = d.schemas.schemaFor(reflect.TypeOf(model))
+	d.problemContent = contentType
+}
+
+// AddTag, etiket açıklamasını belgeye ekler
+func (d *Document) AddTag(name string, description string) {
+	d.Tags = append(d.Tags, Tag{Name: name, Description: description})
+}
+
+// Route, gin biçimindeki rota için (örn. "/clients/:id") bir işlem tanımı başlatır.
+// Yol parametreleri otomatik olarak metin türünde eklenir; PathParam ile türü değiştirilebilir.
+func (d *Document) Route(method string, ginPath string) *OperationBuilder {
+	path, params := convertPath(ginPath)
+	item, ok := d.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+
+	operation := &Operation{Responses: map[string]*Response{}}
+	for _, name := range params {
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"},
+		})
+	}
+	(*item)[strings.ToLower(method)] = operation
+	return &OperationBuilder{doc: d, operation: operation}
+}
+
+// HasRoute, gin biçimindeki rotanın belgede tanımlı olup olmadığını döndürür
+func (d *Document) HasRoute(method string, ginPath string) bool {
+	path, _ := convertPath(ginPath)
+	item, ok := d.Paths[path]
+	if !ok {
+		return false
+	}
+	_, ok = (*item)[strings.ToLower(method)]
+	return ok
+}
+
+// Routes, belgede tanımlı tüm rotaları "METHOD /path" biçiminde sıralı döndürür
+func (d *Document) Routes() []string {
+	var routes []string
+	for path, item := range d.Paths {
+		for method := range *item {
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+// PathOf, gin biçimindeki rotanın OpenAPI yolunu döndürür
+func PathOf(ginPath string) string {
+	path, _ := convertPath(ginPath)
+	return path
+}
+
+// convertPath, gin yolunu OpenAPI yoluna çevirir: /clients/:id -> /clients/{id}
+func convertPath(ginPath string) (string, []string) {
+	var params []string
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// #endregion Document Builder
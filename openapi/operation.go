@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const operationResultSchema = "OperationResult"
+
+// operationResultEnvelope, tüm JSON yanıtlarını saran lgo.OperationResult şemasıdır
+func operationResultEnvelope() *Schema {
+	return &Schema{
+		Type:        "object",
+		Description: "Tüm uç noktaların döndürdüğü lgo.OperationResult zarfı. İşlemin sonucu \"r\" alanındadır; HTTP durum kodu çoğunlukla 200'dür.",
+		Required:    []string{"r", "ec"},
+		Properties: map[string]*Schema{
+			"r": {
+				Type:        "integer",
+				Enum:        []any{0, 1, 2, 3, 4},
+				Description: "Sonuç: 0 başarılı, 1 iş kuralı hatası, 2 sistem hatası, 3 kimlik doğrulama hatası, 4 yetkilendirme hatası",
+			},
+			"ro": {Description: "İşlemin döndürdüğü nesne (başarılı sonuçlarda)"},
+			"em": {Type: "string", Description: "Hata mesajı; yetkilendirme hatalarında eksik yetkinin anahtarı"},
+			"ec": {Type: "integer", Description: "Hata kodu"},
+		},
+	}
+}
+
+// OperationBuilder, bir işlemin açıklamasını zincirleme çağrılarla doldurur
+type OperationBuilder struct {
+	doc       *Document
+	operation *Operation
+}
+
+// Operation, oluşturulan işlemi döndürür
+func (b *OperationBuilder) Operation() *Operation {
+	return b.operation
+}
+
+func (b *OperationBuilder) Tag(tag string) *OperationBuilder {
+	b.operation.Tags = append(b.operation.Tags, tag)
+	return b
+}
+
+func (b *OperationBuilder) Summary(summary string) *OperationBuilder {
+	b.operation.Summary = summary
+	return b
+}
+
+func (b *OperationBuilder) Description(description string) *OperationBuilder {
+	b.operation.Description = description
+	return b
+}
+
+func (b *OperationBuilder) ID(operationID string) *OperationBuilder {
+	b.operation.OperationID = operationID
+	return b
+}
+
+func (b *OperationBuilder) Deprecated() *OperationBuilder {
+	b.operation.Deprecated = true
+	return b
+}
+
+// Public, işlemi X-Token gerektirmeyen olarak işaretler
+func (b *OperationBuilder) Public() *OperationBuilder {
+	b.operation.Security = &[]SecurityRequirement{}
+	return b
+}
+
+// PathParam, yol parametresinin türünü ve açıklamasını belirler
+func (b *OperationBuilder) PathParam(name string, schemaType string, description string) *OperationBuilder {
+	for _, param := range b.operation.Parameters {
+		if param.In == "path" && param.Name == name {
+			param.Schema = schemaForType(schemaType)
+			param.Description = description
+			return b
+		}
+	}
+	panic("openapi: unknown path parameter " + name)
+}
+
+// QueryParam, tek bir sorgu parametresi ekler
+func (b *OperationBuilder) QueryParam(name string, schemaType string, required bool, description string) *OperationBuilder {
+	b.operation.Parameters = append(b.operation.Parameters, &Parameter{
+		Name: name, In: "query", Required: required, Description: description, Schema: schemaForType(schemaType),
+	})
+	return b
+}
+
+// Query, bir yapının "form" etiketli alanlarını sorgu parametreleri olarak ekler
+func (b *OperationBuilder) Query(model any) *OperationBuilder {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		param := &Parameter{Name: name, In: "query", Description: field.Tag.Get("doc")}
+		if field.Type.Kind() == reflect.Slice {
+			// gin yapı elemanlarını JSON olarak çözer: so={"cn":"t","s":1}
+			if elem := indirect(field.Type.Elem()); elem.Kind() == reflect.Struct {
+				param.Schema = &Schema{Type: "array", Items: &Schema{Type: "string", Description: "JSON olarak kodlanmış " + elem.Name()}}
+			}
+			explode := true
+			param.Explode = &explode
+		}
+		if param.Schema == nil {
+			param.Schema = b.doc.schemas.schemaFor(field.Type)
+		}
+		b.operation.Parameters = append(b.operation.Parameters, param)
+	}
+	return b
+}
+
+// Body, JSON istek gövdesinin şemasını belirler
+func (b *OperationBuilder) Body(model any) *OperationBuilder {
+	b.operation.RequestBody = &RequestBody{
+		Required: true,
+		Content:  map[string]*MediaType{"application/json": {Schema: b.doc.schemas.schemaFor(reflect.TypeOf(model))}},
+	}
+	return b
+}
+
+// Returns, başarılı yanıtın OperationResult zarfı içindeki "ro" şemasını belirler.
+// model nil ise "ro" boş döner. Kimlik doğrulama gerektiren işlemlere 401 yanıtı eklenir.
+func (b *OperationBuilder) Returns(model any) *OperationBuilder {
+	envelope := &Schema{Ref: "#/components/schemas/" + operationResultSchema}
+	if model != nil {
+		envelope = &Schema{AllOf: []*Schema{
+			envelope,
+			{Type: "object", Properties: map[string]*Schema{"ro": b.doc.schemas.schemaFor(reflect.TypeOf(model))}},
+		}}
+	}
+
+	b.operation.Responses["200"] = &Response{
+		Description: "İşlem sonucu; başarı \"r\" alanıyla belirtilir",
+		Content:     map[string]*MediaType{"application/json": {Schema: envelope}},
+	}
+	if len(b.operation.Parameters) > 0 || b.operation.RequestBody != nil {
+		b.Response("400", "İstek gövdesi veya parametreleri çözülemedi", nil)
+	}
+	if b.operation.Security == nil {
+		b.Response("401", "X-Token eksik, geçersiz veya süresi dolmuş", nil)
+	}
+	return b
+}
+
+// Response, zarf şemasıyla ek bir yanıt kodu tanımlar; model verilirse "ro" onunla tanımlanır
+func (b *OperationBuilder) Response(status string, description string, model any) *OperationBuilder {
+	schema := &Schema{Ref: "#/components/schemas/" + operationResultSchema}
+	if model != nil {
+		schema = &Schema{AllOf: []*Schema{
+			schema,
+			{Type: "object", Properties: map[string]*Schema{"ro": b.doc.schemas.schemaFor(reflect.TypeOf(model))}},
+		}}
+	}
+	b.operation.Responses[status] = &Response{
+		Description: description,
+		Content:     map[string]*MediaType{"application/json": {Schema: schema}},
+	}
+	return b
+}
+
+// Responds, /api/v1 işlemlerinin başarılı yanıtını tanımlar; gövde zarfsız olarak modelin
+// kendisidir. model nil ise yanıtın gövdesi yoktur (örn. 204). Ortak hata yanıtları
+// (400, 401, 403, 500) Problem Details olarak eklenir.
+func (b *OperationBuilder) Responds(status int, model any) *OperationBuilder {
+	response := &Response{Description: http.StatusText(status)}
+	if model != nil {
+		response.Content = map[string]*MediaType{"application/json": {Schema: b.doc.schemas.schemaFor(reflect.TypeOf(model))}}
+	}
+	b.operation.Responses[strconv.Itoa(status)] = response
+
+	if len(b.operation.Parameters) > 0 || b.operation.RequestBody != nil {
+		b.Problems(http.StatusBadRequest)
+	}
+	if b.operation.Security == nil {
+		b.Problems(http.StatusUnauthorized, http.StatusForbidden)
+	}
+	return b.Problems(http.StatusInternalServerError)
+}
+
+// Problems, verilen durum kodları için Problem Details hata yanıtları ekler
+func (b *OperationBuilder) Problems(statuses ...int) *OperationBuilder {
+	if b.doc.problem == nil {
+		panic("openapi: UseProblemDetails must be called before Problems")
+	}
+	for _, status := range statuses {
+		b.operation.Responses[strconv.Itoa(status)] = &Response{
+			Description: http.StatusText(status),
+			Content:     map[string]*MediaType{b.doc.problemContent: {Schema: b.doc.problem}},
+		}
+	}
+	return b
+}
+
+// RawResponse, OperationResult zarfı kullanmayan yanıtları tanımlar (örn. metrikler)
+func (b *OperationBuilder) RawResponse(status string, description string, contentType string, schema *Schema) *OperationBuilder {
+	b.operation.Responses[status] = &Response{
+		Description: description,
+		Content:     map[string]*MediaType{contentType: {Schema: schema}},
+	}
+	return b
+}
+
+func schemaForType(schemaType string) *Schema {
+	switch schemaType {
+	case "uuid":
+		return &Schema{Type: "string", Format: "uuid"}
+	case "date-time":
+		return &Schema{Type: "string", Format: "date-time"}
+	default:
+		return &Schema{Type: schemaType}
+	}
+}
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// schemaRegistry, Go türlerinden bileşen şemaları üretir. Alan adları "json" etiketinden,
+// açıklamalar "doc" etiketinden okunur.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaRegistry(schemas map[string]*Schema) *schemaRegistry {
+	return &schemaRegistry{schemas: schemas}
+}
+
+func (r *schemaRegistry) schemaFor(t reflect.Type) *Schema {
+	t = indirect(t)
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64, reflect.Uint:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return r.structRef(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// structRef, yapıyı bileşen olarak kaydeder ve ona referans döndürür
+func (r *schemaRegistry) structRef(t reflect.Type) *Schema {
+	name := componentName(t)
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := r.schemas[name]; ok {
+		return ref
+	}
+
+	// Özyinelemeli türler için önce yer tutucu kaydedilir
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	r.schemas[name] = schema
+	r.addFields(schema, t)
+	return ref
+}
+
+func (r *schemaRegistry) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && indirect(field.Type).Kind() == reflect.Struct {
+			r.addFields(schema, indirect(field.Type))
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := r.schemaFor(field.Type)
+		if doc := field.Tag.Get("doc"); doc != "" {
+			if property.Ref != "" {
+				// $ref yanındaki alanlar OpenAPI 3.0'da yok sayıldığından allOf ile sarılır
+				property = &Schema{AllOf: []*Schema{property}, Description: doc}
+			} else {
+				property.Description = doc
+			}
+		}
+		schema.Properties[name] = property
+
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Pointer {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// componentName, genel türlerin adını okunur hale getirir: PagedResult[...data.Client] -> PagedResult_Client
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	start := strings.Index(name, "[")
+	if start < 0 {
+		return name
+	}
+
+	var args []string
+	for _, arg := range strings.Split(name[start+1:len(name)-1], ",") {
+		arg = strings.TrimLeft(strings.TrimSpace(arg), "*[]")
+		if dot := strings.LastIndex(arg, "."); dot >= 0 {
+			arg = arg[dot+1:]
+		}
+		args = append(args, arg)
+	}
+	return name[:start] + "_" + strings.Join(args, "_")
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
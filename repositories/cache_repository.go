@@ -1,51 +1,49 @@
 package repositories
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
-	"lms-web-services-main/database/datasources"
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/metrics"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	mvcmodels "lms-web-services-main/models/mvc"
+	"lms-web-services-main/tracing"
 
 	"github.com/LGYtech/lgo"
-	"github.com/go-redis/redis"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-var (
-	CacheRepository           cacheRepositoryInterface = &cacheRepository{}
-	getSystemUserSettingMutex sync.Mutex
-)
-
-type cacheRepositoryInterface interface {
-	AuthenticateSystemUser(token string) *lgo.OperationResult
-	GetSystemUserCredential(token string) *lgo.OperationResult
-	RegisterSystemUserCredential(token string, systemUser *datamodels.SystemUser) *lgo.OperationResult
-	DeleteSystemUserCredential(token string) *lgo.OperationResult
-	DeleteSystemUserCredentialById(id uuid.UUID) *lgo.OperationResult
+type CacheRepository interface {
+	GetSystemUserCredential(c *models.Context, token string) *lgo.OperationResult
+	RegisterSystemUserCredential(c *models.Context, token string, systemUser *datamodels.SystemUser) *lgo.OperationResult
+	DeleteSystemUserCredential(c *models.Context, token string) *lgo.OperationResult
+	DeleteSystemUserCredentialById(c *models.Context, id uuid.UUID) *lgo.OperationResult
 	GetSystemUserSetting(c *models.Context, setting string) *lgo.OperationResult
-	RemoveSystemUserSetting(systemUserId uuid.UUID, key string) *lgo.OperationResult
+	RemoveSystemUserSetting(c *models.Context, systemUserId uuid.UUID, key string) *lgo.OperationResult
 }
 
-type cacheRepository struct{}
-
-// #region Authenticate System User
-func (r *cacheRepository) AuthenticateSystemUser(token string) *lgo.OperationResult {
-	exists, err := datasources.Cache.Exists("su:" + token).Result()
-	if err != nil {
-		return lgo.NewFailureWithError(err)
-	}
-	return lgo.NewSuccess(exists == 1)
+type cacheRepository struct {
+	cache                     *redis.Client
+	systemUserSettingRepo     SystemUserSettingRepository
+	metrics                   metrics.Recorder
+	logger                    *slog.Logger
+	getSystemUserSettingMutex sync.Mutex
 }
 
-// #endregion Authenticate System User
+func NewCacheRepository(cache *redis.Client, systemUserSettingRepo SystemUserSettingRepository, metrics metrics.Recorder, logger *slog.Logger) CacheRepository {
+	return &cacheRepository{cache: cache, systemUserSettingRepo: systemUserSettingRepo, metrics: metrics, logger: logger}
+}
 
 // #region Get System User Credential
-func (r *cacheRepository) GetSystemUserCredential(token string) *lgo.OperationResult {
-	fields, err := datasources.Cache.HGetAll("su:" + token).Result()
+func (r *cacheRepository) GetSystemUserCredential(c *models.Context, token string) *lgo.OperationResult {
+	fields, err := r.cache.HGetAll(c, "su:"+token).Result()
 	if err != nil {
 		return lgo.NewFailureWithError(err)
 	}
@@ -67,45 +65,19 @@ func (r *cacheRepository) GetSystemUserCredential(token string) *lgo.OperationRe
 // #endregion Get System User Credential
 
 // #region Register System User Credential
-func (r *cacheRepository) RegisterSystemUserCredential(token string, systemUser *datamodels.SystemUser) *lgo.OperationResult {
-	pipe := datasources.Cache.Pipeline()
-
-	result := pipe.HSet("su:"+token, "id", systemUser.Id.String())
-	if result.Err() != nil {
-		return lgo.NewFailureWithError(result.Err())
-	}
-
-	result = pipe.HSet("su:"+token, "n", systemUser.Name)
-	if result.Err() != nil {
-		return lgo.NewFailureWithError(result.Err())
-	}
-
-	result = pipe.HSet("su:"+token, "sn", systemUser.Surname)
-	if result.Err() != nil {
-		return lgo.NewFailureWithError(result.Err())
-	}
-
-	result = pipe.HSet("su:"+token, "e", systemUser.Email)
-	if result.Err() != nil {
-		return lgo.NewFailureWithError(result.Err())
-	}
-
-	result = pipe.Expire("su:"+token, 5*time.Hour)
-	if result.Err() != nil {
-		return lgo.NewFailureWithError(result.Err())
-	}
-
-	listResult := pipe.LPush("su:rev:"+systemUser.Id.String(), token)
-	if listResult.Err() != nil {
-		return lgo.NewFailureWithError(listResult.Err())
-	}
-
-	result = pipe.Expire("su:rev:"+systemUser.Id.String(), 5*time.Hour)
-	if result.Err() != nil {
-		return lgo.NewFailureWithError(result.Err())
-	}
-
-	_, err := pipe.Exec()
+func (r *cacheRepository) RegisterSystemUserCredential(c *models.Context, token string, systemUser *datamodels.SystemUser) *lgo.OperationResult {
+	// Komut hataları pipeline çalıştırılana kadar oluşmaz; Exec hepsini döndürür
+	pipe := r.cache.Pipeline()
+	pipe.HSet(c, "su:"+token,
+		"id", systemUser.Id.String(),
+		"n", systemUser.Name,
+		"sn", systemUser.Surname,
+		"e", systemUser.Email)
+	pipe.Expire(c, "su:"+token, 5*time.Hour)
+	pipe.LPush(c, "su:rev:"+systemUser.Id.String(), token)
+	pipe.Expire(c, "su:rev:"+systemUser.Id.String(), 5*time.Hour)
+
+	_, err := pipe.Exec(c)
 	if err != nil {
 		return lgo.NewFailureWithError(err)
 	}
@@ -116,8 +88,8 @@ func (r *cacheRepository) RegisterSystemUserCredential(token string, systemUser
 // #endregion Register System User Credential
 
 // #region Delete System User Credential
-func (r *cacheRepository) DeleteSystemUserCredential(token string) *lgo.OperationResult {
-	_, err := datasources.Cache.Del("su:" + token).Result()
+func (r *cacheRepository) DeleteSystemUserCredential(c *models.Context, token string) *lgo.OperationResult {
+	_, err := r.cache.Del(c, "su:"+token).Result()
 	if err != nil {
 		return lgo.NewFailureWithError(err)
 	}
@@ -128,19 +100,19 @@ func (r *cacheRepository) DeleteSystemUserCredential(token string) *lgo.Operatio
 // #endregion Delete System User Credential
 
 // #region Delete System User Credential By Id
-func (r *cacheRepository) DeleteSystemUserCredentialById(id uuid.UUID) *lgo.OperationResult {
-	tokens, err := datasources.Cache.LRange("su:rev:"+id.String(), 0, -1).Result()
+func (r *cacheRepository) DeleteSystemUserCredentialById(c *models.Context, id uuid.UUID) *lgo.OperationResult {
+	tokens, err := r.cache.LRange(c, "su:rev:"+id.String(), 0, -1).Result()
 	if err != nil {
 		return lgo.NewFailureWithError(err)
 	}
 
-	pipe := datasources.Cache.Pipeline()
+	pipe := r.cache.Pipeline()
 	for _, token := range tokens {
-		pipe.Del("su:" + token)
+		pipe.Del(c, "su:"+token)
 	}
-	pipe.Del("su:rev:" + id.String())
+	pipe.Del(c, "su:rev:"+id.String())
 
-	_, err = pipe.Exec()
+	_, err = pipe.Exec(c)
 	if err != nil {
 		return lgo.NewFailureWithError(err)
 	}
@@ -151,13 +123,21 @@ func (r *cacheRepository) DeleteSystemUserCredentialById(id uuid.UUID) *lgo.Oper
 // #endregion Delete System User Credential By Id
 
 // #region Get System User Setting
-func (cr *cacheRepository) GetSystemUserSetting(c *models.Context, setting string) *lgo.OperationResult {
+func (r *cacheRepository) GetSystemUserSetting(c *models.Context, setting string) *lgo.OperationResult {
+	ctx, span := tracing.Start(c, "CacheRepository.GetSystemUserSetting", attribute.String("lms.setting", setting))
+	defer span.End()
+	c = c.WithContext(ctx)
+
 	// #region Get SystemUserCredential
-	systemUserCredentialResult := cr.GetSystemUserCredential(c.Token)
-	if !systemUserCredentialResult.IsSuccess() {
-		return systemUserCredentialResult
+	// Kimlik doğrulama middleware'i kullanıcıyı Context'e koyduysa Redis'e tekrar gidilmez
+	systemUserCredential := c.Principal
+	if systemUserCredential == nil {
+		systemUserCredentialResult := r.GetSystemUserCredential(c, c.Token)
+		if !systemUserCredentialResult.IsSuccess() {
+			return systemUserCredentialResult
+		}
+		systemUserCredential = systemUserCredentialResult.ReturnObject.(*mvcmodels.SystemUserCredential)
 	}
-	systemUserCredential := systemUserCredentialResult.ReturnObject.(*mvcmodels.SystemUserCredential)
 	systemUserIdParsed, err := uuid.Parse(systemUserCredential.Id)
 	if err != nil {
 		return lgo.NewFailureWithError(err)
@@ -166,60 +146,58 @@ func (cr *cacheRepository) GetSystemUserSetting(c *models.Context, setting strin
 
 	// #region Try Get From Cache
 	settingValueExists := true
-	settingValue, err := datasources.Cache.Get("sus:" + systemUserCredential.Id + ":" + setting).Result()
-	if err == redis.Nil {
+	settingValue, err := r.cache.Get(c, "sus:"+systemUserCredential.Id+":"+setting).Result()
+	if errors.Is(err, redis.Nil) {
 		settingValueExists = false
 	} else if err != nil {
 		return lgo.NewFailureWithError(err)
 	}
+	r.metrics.CacheLookup("system_user_setting", settingValueExists)
+	span.SetAttributes(attribute.Bool("lms.cache.hit", settingValueExists))
 	// #endregion Try Get From Cache
 
 	// #region Lock and Try Cache Again
 	if !settingValueExists {
-		getSystemUserSettingMutex.Lock()
+		r.getSystemUserSettingMutex.Lock()
+		defer r.getSystemUserSettingMutex.Unlock()
 
 		// region Try Getting From Cache Again
 		settingValueExists = true
-		settingValue, err = datasources.Cache.Get("sus:" + systemUserCredential.Id + ":" + setting).Result()
-		if err == redis.Nil {
+		settingValue, err = r.cache.Get(c, "sus:"+systemUserCredential.Id+":"+setting).Result()
+		if errors.Is(err, redis.Nil) {
 			settingValueExists = false
 		} else if err != nil {
-			getSystemUserSettingMutex.Unlock()
 			return lgo.NewFailureWithError(err)
 		}
 		// endregion Try Getting From Cache Again
 
 		if !settingValueExists {
 			// #region Get From SystemUserSetting Repository
-			var systemUserSettingRepo = NewSystemUserSettingRepository(datasources.Database)
-			systemUserSettingResult := systemUserSettingRepo.GetValue(c, systemUserIdParsed, setting)
+			systemUserSettingResult := r.systemUserSettingRepo.GetValue(c, systemUserIdParsed, setting)
 			if !systemUserSettingResult.IsSuccess() {
-				getSystemUserSettingMutex.Unlock()
 				return systemUserSettingResult
 			}
 			if systemUserSettingResult.ReturnObject == nil {
-				return lgo.NewLogicError("Gerekli yetki bulunamadı.", nil)
+				return mvcmodels.NewLogicError(i18n.PermissionNotFound)
 			}
 
 			// Burada doğru türü alın ve Value alanını kullanın
 			systemUserSetting, ok := systemUserSettingResult.ReturnObject.(*datamodels.SystemUserSetting)
 			if !ok {
-				log.Printf("Hatalı veri türü: %T\n", systemUserSettingResult.ReturnObject)
-				return lgo.NewLogicError("Hatalı veri türü.", nil)
+				r.logger.ErrorContext(c, "unexpected system user setting type", slog.String("type", fmt.Sprintf("%T", systemUserSettingResult.ReturnObject)))
+				return mvcmodels.NewLogicError(i18n.InvalidSettingType)
 			}
 			settingValue = systemUserSetting.Value
 
 			// #endregion Get From SystemUserSetting Repository
 
 			// #region Populate Cache
-			err = datasources.Cache.Set("sus:"+systemUserCredential.Id+":"+setting, settingValue, 0).Err()
+			err = r.cache.Set(c, "sus:"+systemUserCredential.Id+":"+setting, settingValue, 0).Err()
 			if err != nil {
-				log.Println("Cache güncellenemedi!")
+				r.logger.WarnContext(c, "populating permission cache failed", slog.String("setting", setting), slog.String("error", err.Error()))
 			}
 			// #endregion Populate Cache
 		}
-
-		getSystemUserSettingMutex.Unlock()
 	}
 
 	// #endregion Lock and Try Cache Again
@@ -230,9 +208,9 @@ func (cr *cacheRepository) GetSystemUserSetting(c *models.Context, setting strin
 // #endregion Get System User Setting
 
 // #region Remove System User Setting
-func (r *cacheRepository) RemoveSystemUserSetting(systemUserId uuid.UUID, key string) *lgo.OperationResult {
+func (r *cacheRepository) RemoveSystemUserSetting(c *models.Context, systemUserId uuid.UUID, key string) *lgo.OperationResult {
 	cacheKey := "sus:" + systemUserId.String() + ":" + key
-	_, err := datasources.Cache.Del(cacheKey).Result()
+	_, err := r.cache.Del(c, cacheKey).Result()
 	if err != nil {
 		return lgo.NewFailureWithError(err)
 	}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"gorm.io/gorm"
+)
+
+// ClientContactRepository'de Create ve Update, birincil işaretlenen yetkiliden önce
+// müşterinin diğer yetkililerinin birincil işaretini aynı işlem (transaction) içinde kaldırır
+type ClientContactRepository interface {
+	CrudRepository[datamodels.ClientContact, int]
+	GetByClientId(c *models.Context, clientId int) *lgo.OperationResult
+}
+
+// clientContactQuerySchema, GetAll'da filtrelenebilen ve sıralanabilen alanlardır
+var clientContactQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `"Id"`, Type: QueryFieldInt},
+	QueryField{Name: "cid", Alias: "ClientId", Column: `"ClientId"`, Type: QueryFieldInt},
+	QueryField{Name: "n", Alias: "Name", Column: `"Name"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "r", Alias: "Role", Column: `"Role"`, Type: QueryFieldString, Searchable: true, Nullable: true},
+	QueryField{Name: "e", Alias: "Email", Column: `"Email"`, Type: QueryFieldString, Searchable: true, Nullable: true},
+	QueryField{Name: "ip", Alias: "IsPrimary", Column: `"IsPrimary"`, Type: QueryFieldBool},
+).WithDefaultSorting("n", false)
+
+var clientContactOptions = CrudOptions[datamodels.ClientContact]{
+	NotFound: i18n.ClientContactNotFound,
+	Schema:   clientContactQuerySchema,
+	Apply: func(existing *datamodels.ClientContact, contact *datamodels.ClientContact) {
+		existing.Name = contact.Name
+		existing.Role = contact.Role
+		existing.Email = contact.Email
+		existing.Phone = contact.Phone
+		existing.IsPrimary = contact.IsPrimary
+	},
+}
+
+type clientContactRepository struct {
+	CrudRepository[datamodels.ClientContact, int]
+	db         *gorm.DB
+	transactor Transactor
+}
+
+func NewClientContactRepository(db *gorm.DB) ClientContactRepository {
+	return &clientContactRepository{
+		CrudRepository: NewCrudRepository[datamodels.ClientContact, int](db, clientContactOptions),
+		db:             db,
+		transactor:     NewTransactor(db),
+	}
+}
+
+// #region Create
+func (r *clientContactRepository) Create(c *models.Context, contact *datamodels.ClientContact) *lgo.OperationResult {
+	return r.savePrimary(c, contact, func(c *models.Context) *lgo.OperationResult {
+		return r.CrudRepository.Create(c, contact)
+	})
+}
+
+// #endregion Create
+
+// #region Update
+func (r *clientContactRepository) Update(c *models.Context, contact *datamodels.ClientContact) *lgo.OperationResult {
+	return r.savePrimary(c, contact, func(c *models.Context) *lgo.OperationResult {
+		return r.CrudRepository.Update(c, contact)
+	})
+}
+
+// #endregion Update
+
+// savePrimary, yetkili birincil değilse save'i doğrudan çalıştırır. Birincilse müşterinin
+// diğer birincil yetkilisini kaldırır ve save'i aynı işlem içinde çalıştırır.
+func (r *clientContactRepository) savePrimary(c *models.Context, contact *datamodels.ClientContact, save func(c *models.Context) *lgo.OperationResult) *lgo.OperationResult {
+	if !contact.IsPrimary {
+		return save(c)
+	}
+
+	return r.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		err := conn(tx, r.db).Model(&datamodels.ClientContact{}).
+			Where("\"ClientId\" = ? AND \"IsPrimary\" = true AND \"Id\" <> ?", contact.ClientId, contact.Id).
+			Update("IsPrimary", false).Error
+		if err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+		return save(tx)
+	})
+}
+
+// #region Get ClientContacts By ClientId
+func (r *clientContactRepository) GetByClientId(c *models.Context, clientId int) *lgo.OperationResult {
+	var contacts []*datamodels.ClientContact
+	result := conn(c, r.db).Where("\"ClientId\" = ?", clientId).Order("\"IsPrimary\" DESC, \"Name\" ASC").Find(&contacts)
+	if result.Error != nil {
+		return mvc.NewDatabaseError(result.Error)
+	}
+	return lgo.NewSuccess(contacts)
+}
+
+// #endregion Get ClientContacts By ClientId
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"errors"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ClientProjectMemberRepository interface {
+	CrudRepository[datamodels.ClientProjectMember, int]
+	GetByClientProjectId(c *models.Context, clientProjectId int) *lgo.OperationResult
+	// GetByMember, kullanıcının projedeki üyeliğini döndürür; üye değilse MemberNotFound döner
+	GetByMember(c *models.Context, clientProjectId int, systemUserId uuid.UUID) *lgo.OperationResult
+}
+
+// clientProjectMemberQuerySchema, GetAll'da filtrelenebilen ve sıralanabilen alanlardır
+var clientProjectMemberQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `"Id"`, Type: QueryFieldInt},
+	QueryField{Name: "cpid", Alias: "ClientProjectId", Column: `"ClientProjectId"`, Type: QueryFieldInt},
+	QueryField{Name: "suid", Alias: "SystemUserId", Column: `"SystemUserId"`, Type: QueryFieldUUID},
+	QueryField{Name: "r", Alias: "Role", Column: `"Role"`, Type: QueryFieldString, Searchable: true, Nullable: true},
+).WithDefaultSorting("id", false)
+
+type clientProjectMemberRepository struct {
+	CrudRepository[datamodels.ClientProjectMember, int]
+	db *gorm.DB
+}
+
+func NewClientProjectMemberRepository(db *gorm.DB) ClientProjectMemberRepository {
+	return &clientProjectMemberRepository{
+		CrudRepository: NewCrudRepository[datamodels.ClientProjectMember, int](db, CrudOptions[datamodels.ClientProjectMember]{
+			NotFound: i18n.MemberNotFound,
+			Schema:   clientProjectMemberQuerySchema,
+			Apply: func(existing *datamodels.ClientProjectMember, member *datamodels.ClientProjectMember) {
+				existing.Role = member.Role
+				existing.HourlyRate = member.HourlyRate
+			},
+		}),
+		db: db,
+	}
+}
+
+// #region Get Members By ClientProjectId
+func (r *clientProjectMemberRepository) GetByClientProjectId(c *models.Context, clientProjectId int) *lgo.OperationResult {
+	var members []*datamodels.ClientProjectMember
+	result := conn(c, r.db).Where("\"ClientProjectId\" = ?", clientProjectId).Order("\"Id\" ASC").Find(&members)
+	if result.Error != nil {
+		return mvc.NewDatabaseError(result.Error)
+	}
+	return lgo.NewSuccess(members)
+}
+
+// #endregion Get Members By ClientProjectId
+
+// #region Get Member
+func (r *clientProjectMemberRepository) GetByMember(c *models.Context, clientProjectId int, systemUserId uuid.UUID) *lgo.OperationResult {
+	var member datamodels.ClientProjectMember
+	err := conn(c, r.db).Where("\"ClientProjectId\" = ? AND \"SystemUserId\" = ?", clientProjectId, systemUserId).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return mvc.NewNotFoundError(i18n.MemberNotFound)
+	}
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(&member)
+}
+
+// #endregion Get Member
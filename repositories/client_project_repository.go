@@ -1,139 +1,204 @@
 package repositories
 
 import (
-	"errors"
+	"time"
 
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	"lms-web-services-main/models/mvc"
 
 	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type ClientProjectRepository interface {
-	Create(clientProject *datamodels.ClientProject) *lgo.OperationResult
-	Update(clientProject *datamodels.ClientProject) *lgo.OperationResult
-	Delete(id int) *lgo.OperationResult
-	GetById(id int) *lgo.OperationResult
-	GetAll(query *mvc.QueryModel) *lgo.OperationResult
-	GetByClientId(clientId int) *lgo.OperationResult
+	CrudRepository[datamodels.ClientProject, int]
+	GetByClientId(c *models.Context, clientId int) *lgo.OperationResult
+	// GetAllForMember, GetAll'ı kullanıcının üyesi olduğu projelerle sınırlar
+	GetAllForMember(c *models.Context, query *mvc.QueryModel, systemUserId uuid.UUID) *lgo.OperationResult
+	// Archive, projeyi arşivler (IsActive = false)
+	Archive(c *models.Context, id int, archivedAt time.Time) *lgo.OperationResult
+	// Unarchive, projeyi arşivden çıkarır. Müşterinin durumu servis tarafından kontrol edilir.
+	Unarchive(c *models.Context, id int) *lgo.OperationResult
+	// CountForeignReferences, proje silinirse onunla birlikte silinecek kayıtları sayar
+	// (*mvc.ForeignReferences)
+	CountForeignReferences(c *models.Context, id int) *lgo.OperationResult
+	// ForceDelete, projeyi bağlı kayıtlarıyla birlikte siler ve silinen kayıtları
+	// *DeletedRecords olarak döndürür. Sayım ve silme tek işlemde yapılır.
+	ForceDelete(c *models.Context, id int) *lgo.OperationResult
 }
 
+// clientProjectQuerySchema, GetAll'da filtrelenebilen ve sıralanabilen alanlardır
+var clientProjectQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `"Id"`, Type: QueryFieldInt},
+	QueryField{Name: "cid", Alias: "ClientId", Column: `"ClientId"`, Type: QueryFieldInt},
+	QueryField{Name: "n", Alias: "Name", Column: `"Name"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "ia", Alias: "IsActive", Column: `"IsActive"`, Type: QueryFieldBool},
+	QueryField{Name: "aa", Alias: "ArchivedAt", Column: `"ArchivedAt"`, Type: QueryFieldTime, Nullable: true},
+	QueryField{Name: "bed", Alias: "BudgetEndDate", Column: `"BudgetEndDate"`, Type: QueryFieldTime, Nullable: true},
+	QueryField{Name: "eb", Alias: "EnforceBudget", Column: `"EnforceBudget"`, Type: QueryFieldBool},
+).WithDefaultSorting("n", false)
+
 type clientProjectRepository struct {
-	db *gorm.DB
+	CrudRepository[datamodels.ClientProject, int]
+	db         *gorm.DB
+	transactor Transactor
 }
 
 func NewClientProjectRepository(db *gorm.DB) ClientProjectRepository {
-	return &clientProjectRepository{db: db}
-}
-
-// #region Create ClientProject
-func (r *clientProjectRepository) Create(clientProject *datamodels.ClientProject) *lgo.OperationResult {
-	if err := clientProject.Validate(); err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
+	return &clientProjectRepository{
+		CrudRepository: NewCrudRepository[datamodels.ClientProject, int](db, CrudOptions[datamodels.ClientProject]{
+			NotFound: i18n.ClientProjectNotFound,
+			Schema:   clientProjectQuerySchema,
+			Scope:    applyArchivedFilter,
+			Apply: func(existing *datamodels.ClientProject, clientProject *datamodels.ClientProject) {
+				existing.Name = clientProject.Name
+				existing.IsActive = clientProject.IsActive
+				existing.BudgetHours = clientProject.BudgetHours
+				existing.BudgetAmount = clientProject.BudgetAmount
+				existing.HourlyRate = clientProject.HourlyRate
+				existing.BudgetStartDate = clientProject.BudgetStartDate
+				existing.BudgetEndDate = clientProject.BudgetEndDate
+				existing.EnforceBudget = clientProject.EnforceBudget
+			},
+		}),
+		db:         db,
+		transactor: NewTransactor(db),
 	}
+}
 
-	result := r.db.Create(&clientProject)
+// #region Get ClientProjects By ClientId
+func (r *clientProjectRepository) GetByClientId(c *models.Context, clientId int) *lgo.OperationResult {
+	var clientProjects []*datamodels.ClientProject
+	result := conn(c, r.db).Where("\"ClientId\" = ?", clientId).Find(&clientProjects)
 	if result.Error != nil {
-		return lgo.NewLogicError(result.Error.Error(), nil)
+		return mvc.NewDatabaseError(result.Error)
 	}
-	return lgo.NewSuccess(clientProject)
+	return lgo.NewSuccess(clientProjects)
 }
 
-// #endregion Create ClientProject
+// #endregion Get ClientProjects By ClientId
 
-// #region Update ClientProject
-func (r *clientProjectRepository) Update(clientProject *datamodels.ClientProject) *lgo.OperationResult {
-	if err := clientProject.ValidateForUpdate(); err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
+// #region Get ClientProjects For Member
+func (r *clientProjectRepository) GetAllForMember(c *models.Context, query *mvc.QueryModel, systemUserId uuid.UUID) *lgo.OperationResult {
+	var clientProjects []*datamodels.ClientProject
+
+	db := conn(c, r.db).Model(&datamodels.ClientProject{}).
+		Where("\"Id\" IN (SELECT \"ClientProjectId\" FROM \"ClientProjectMembers\" WHERE \"SystemUserId\" = ?)", systemUserId)
+	db, page, result := ApplyQueryModel(applyArchivedFilter(db, query), query, clientProjectQuerySchema)
+	if !result.IsSuccess() {
+		return result
 	}
 
-	existingProject := &datamodels.ClientProject{}
-	if err := r.db.First(&existingProject, clientProject.Id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Proje bulunamadı.", nil)
-		}
-		return lgo.NewLogicError(err.Error(), nil)
+	if err := db.Find(&clientProjects).Error; err != nil {
+		return mvc.NewDatabaseError(err)
 	}
+	return NewPagedResult(page, clientProjects)
+}
 
-	existingProject.Name = clientProject.Name
-	existingProject.IsActive = clientProject.IsActive
+// #endregion Get ClientProjects For Member
 
-	if err := r.db.Save(&existingProject).Error; err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-	return lgo.NewSuccess(existingProject)
+// #region Archive ClientProject
+func (r *clientProjectRepository) Archive(c *models.Context, id int, archivedAt time.Time) *lgo.OperationResult {
+	return r.setArchived(c, id, map[string]any{"IsActive": false, "ArchivedAt": archivedAt})
 }
 
-// #endregion Update ClientProject
+func (r *clientProjectRepository) Unarchive(c *models.Context, id int) *lgo.OperationResult {
+	return r.setArchived(c, id, map[string]any{"IsActive": true, "ArchivedAt": nil})
+}
 
-// #region Delete ClientProject
-func (r *clientProjectRepository) Delete(id int) *lgo.OperationResult {
-	clientProject := &datamodels.ClientProject{}
-	if err := r.db.First(&clientProject, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Proje bulunamadı.", nil)
-		}
-		return lgo.NewLogicError(err.Error(), nil)
+func (r *clientProjectRepository) setArchived(c *models.Context, id int, values map[string]any) *lgo.OperationResult {
+	result := conn(c, r.db).Model(&datamodels.ClientProject{}).Where("\"Id\" = ?", id).Updates(values)
+	if result.Error != nil {
+		return mvc.NewDatabaseError(result.Error)
 	}
-
-	if err := r.db.Delete(&clientProject).Error; err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
+	if result.RowsAffected == 0 {
+		return mvc.NewNotFoundError(i18n.ClientProjectNotFound)
 	}
 	return lgo.NewSuccess(nil)
 }
 
-// #endregion Delete ClientProject
-
-// #region Get ClientProject By Id
-func (r *clientProjectRepository) GetById(id int) *lgo.OperationResult {
-	clientProject := &datamodels.ClientProject{}
-	if err := r.db.First(&clientProject, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Proje bulunamadı.", nil)
-		}
-		return lgo.NewLogicError(err.Error(), nil)
+// #endregion Archive ClientProject
+
+// #region Foreign References
+// clientProjectReferencesQuery, ForceDelete ile projeyle birlikte silinen kayıtları sayar
+const clientProjectReferencesQuery = `
+SELECT
+    (SELECT COUNT(*) FROM "ClientProjectMembers" WHERE "ClientProjectId" = @id) AS "ClientProjectMembers",
+    (SELECT COUNT(*) FROM "ProjectTasks" WHERE "ClientProjectId" = @id) AS "ProjectTasks",
+    (SELECT COUNT(*) FROM "Timings" WHERE "ClientProjectId" = @id) AS "Timings",
+    (SELECT COUNT(*) FROM "TimingTags" AS tt JOIN "Timings" AS t ON tt."TimingId" = t."Id" WHERE t."ClientProjectId" = @id) AS "TimingTags"
+`
+
+func (r *clientProjectRepository) CountForeignReferences(c *models.Context, id int) *lgo.OperationResult {
+	var references mvc.ForeignReferences
+	if err := conn(c, r.db).Raw(clientProjectReferencesQuery, map[string]any{"id": id}).Scan(&references).Error; err != nil {
+		return mvc.NewDatabaseError(err)
 	}
-	return lgo.NewSuccess(clientProject)
+	return lgo.NewSuccess(&references)
 }
 
-// #endregion Get ClientProject By Id
-
-// #region Get All ClientProjects
-func (r *clientProjectRepository) GetAll(query *mvc.QueryModel) *lgo.OperationResult {
-	var clientProjects []*datamodels.ClientProject
-
-	defaultSorting := &mvc.DataSortingOptionItem{
-		ColumnName: "\"Name\"",
-		Sorting:    0, // 0: ASC, 1: DESC
-	}
-
-	searchableColumns := []string{"\"Name\""}
-
-	// QueryModel'i uygula
-	db, result := ApplyQueryModel(r.db, query, searchableColumns, defaultSorting)
-	if !result.IsSuccess() {
-		return lgo.NewLogicError("Sorgu modeli uygulanırken bir hata oluştu: "+result.ErrorMessage, nil)
-	}
+// DeletedRecords, ForceDelete ile silinen bağlı kayıtlardır. Kimlikler, silinen her kayıt
+// için olay yayımlanabilmesi içindir; silinen kaydın kendisini içermez.
+type DeletedRecords struct {
+	References       *mvc.ForeignReferences
+	ClientProjectIds []int
+	TimingIds        []int
+}
 
-	queryResult := db.Find(&clientProjects)
-	if queryResult.Error != nil {
-		return lgo.NewLogicError("Veritabanı sorgusu başarısız: "+queryResult.Error.Error(), nil)
-	}
+func (r *clientProjectRepository) ForceDelete(c *models.Context, id int) *lgo.OperationResult {
+	return r.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		references := r.CountForeignReferences(tx, id)
+		if !references.IsSuccess() {
+			return references
+		}
+		deleted := &DeletedRecords{References: references.ReturnObject.(*mvc.ForeignReferences)}
+		err := conn(tx, r.db).Model(&datamodels.Timing{}).
+			Where("\"ClientProjectId\" = ?", id).
+			Pluck("Id", &deleted.TimingIds).Error
+		if err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+		if err := deleteClientProjectReferences(conn(tx, r.db), []int{id}); err != nil {
+			return mvc.NewDatabaseError(err)
+		}
 
-	return lgo.NewSuccess(clientProjects)
+		result := conn(tx, r.db).Delete(&datamodels.ClientProject{}, id)
+		if result.Error != nil {
+			return mvc.NewDatabaseError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return mvc.NewNotFoundError(i18n.ClientProjectNotFound)
+		}
+		return lgo.NewSuccess(deleted)
+	})
 }
 
-// #endregion Get All ClientProjects
+// clientProjectReferences, projelere bağlı tabloları silinme sırasıyla verir. Foreign key'ler
+// ON DELETE RESTRICT olduğu için bağlı kayıtlar projeden önce açıkça silinir.
+var clientProjectReferences = []struct {
+	model     any
+	condition string
+}{
+	{&datamodels.TimingTag{}, `"TimingId" IN (SELECT "Id" FROM "Timings" WHERE "ClientProjectId" IN ?)`},
+	{&datamodels.Timing{}, `"ClientProjectId" IN ?`},
+	{&datamodels.ClientProjectMember{}, `"ClientProjectId" IN ?`},
+	{&datamodels.ProjectTask{}, `"ClientProjectId" IN ?`},
+}
 
-// #region Get ClientProjects By ClientId
-func (r *clientProjectRepository) GetByClientId(clientId int) *lgo.OperationResult {
-	var clientProjects []*datamodels.ClientProject
-	result := r.db.Where("\"ClientId\" = ?", clientId).Find(&clientProjects)
-	if result.Error != nil {
-		return lgo.NewLogicError(result.Error.Error(), nil)
+// deleteClientProjectReferences, verilen projelerin bağlı kayıtlarını siler; projeleri silmez
+func deleteClientProjectReferences(db *gorm.DB, clientProjectIds []int) error {
+	if len(clientProjectIds) == 0 {
+		return nil
 	}
-	return lgo.NewSuccess(clientProjects)
+	for _, reference := range clientProjectReferences {
+		if err := db.Where(reference.condition, clientProjectIds).Delete(reference.model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// #endregion Get ClientProjects By ClientId
+//#endregion Foreign References
@@ -1,131 +1,199 @@
 package repositories
 
 import (
-	"errors"
+	"time"
 
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	"lms-web-services-main/models/mvc"
 
 	"github.com/LGYtech/lgo"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ClientRepository interface {
-	Create(client *datamodels.Client) *lgo.OperationResult
-	Update(client *datamodels.Client) *lgo.OperationResult
-	Delete(id int) *lgo.OperationResult
-	GetById(id int) *lgo.OperationResult
-	GetAll(query *mvc.QueryModel) *lgo.OperationResult
+	CrudRepository[datamodels.Client, int]
+	// Archive, müşteriyi ve aktif projelerini tek işlemde arşivler. Projelere müşteriyle
+	// aynı ArchivedAt yazılır. Arşivlenen projelerin kimliklerini döndürür ([]int).
+	Archive(c *models.Context, id int, archivedAt time.Time) *lgo.OperationResult
+	// Unarchive, müşteriyi ve onunla birlikte arşivlenmiş projelerini tek işlemde geri açar.
+	// Müşteriden önce ayrıca arşivlenmiş projeler arşivde kalır. Arşivden çıkan projelerin
+	// kimliklerini döndürür ([]int).
+	Unarchive(c *models.Context, id int) *lgo.OperationResult
+	// CountForeignReferences, müşteri silinirse onunla birlikte silinecek kayıtları sayar
+	// (*mvc.ForeignReferences)
+	CountForeignReferences(c *models.Context, id int) *lgo.OperationResult
+	// ForceDelete, müşteriyi bağlı kayıtlarıyla birlikte siler ve silinen kayıtları
+	// *DeletedRecords olarak döndürür. Sayım ve silme tek işlemde yapılır.
+	ForceDelete(c *models.Context, id int) *lgo.OperationResult
 }
 
+// clientQuerySchema, GetAll'da filtrelenebilen ve sıralanabilen alanlardır
+var clientQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `"Id"`, Type: QueryFieldInt},
+	QueryField{Name: "st", Alias: "ShortTitle", Column: `"ShortTitle"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "t", Alias: "Title", Column: `"Title"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "nt", Alias: "Notes", Column: `"Notes"`, Type: QueryFieldString, Nullable: true},
+	QueryField{Name: "ia", Alias: "IsActive", Column: `"IsActive"`, Type: QueryFieldBool},
+	QueryField{Name: "aa", Alias: "ArchivedAt", Column: `"ArchivedAt"`, Type: QueryFieldTime, Nullable: true},
+	QueryField{Name: "to", Alias: "TaxOffice", Column: `"TaxOffice"`, Type: QueryFieldString, Searchable: true, Nullable: true},
+	QueryField{Name: "tn", Alias: "TaxNumber", Column: `"TaxNumber"`, Type: QueryFieldString, Searchable: true, Nullable: true},
+	QueryField{Name: "cur", Alias: "Currency", Column: `"Currency"`, Type: QueryFieldString},
+	QueryField{Name: "ptd", Alias: "PaymentTermDays", Column: `"PaymentTermDays"`, Type: QueryFieldInt},
+).WithDefaultSorting("t", false)
+
 type clientRepository struct {
-	db *gorm.DB
+	CrudRepository[datamodels.Client, int]
+	db         *gorm.DB
+	transactor Transactor
 }
 
 func NewClientRepository(db *gorm.DB) ClientRepository {
-	return &clientRepository{db: db}
-}
-
-// #region Create Client
-func (r *clientRepository) Create(client *datamodels.Client) *lgo.OperationResult {
-	if err := client.Validate(); err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	result := r.db.Create(&client)
-	if result.Error != nil {
-		return lgo.NewLogicError(result.Error.Error(), nil)
+	return &clientRepository{
+		CrudRepository: NewCrudRepository[datamodels.Client, int](db, CrudOptions[datamodels.Client]{
+			NotFound: i18n.ClientNotFound,
+			Schema:   clientQuerySchema,
+			Scope:    applyArchivedFilter,
+			Apply: func(existing *datamodels.Client, client *datamodels.Client) {
+				existing.ShortTitle = client.ShortTitle
+				existing.Title = client.Title
+				existing.Notes = client.Notes
+				existing.IsActive = client.IsActive
+				existing.TaxOffice = client.TaxOffice
+				existing.TaxNumber = client.TaxNumber
+				existing.BillingAddress = client.BillingAddress
+				existing.Currency = client.Currency
+				existing.PaymentTermDays = client.PaymentTermDays
+			},
+		}),
+		db:         db,
+		transactor: NewTransactor(db),
 	}
-	return lgo.NewSuccess(client)
 }
 
-// #endregion Create Client
-
-// #region Update Client
-func (r *clientRepository) Update(client *datamodels.Client) *lgo.OperationResult {
-	if err := client.ValidateForUpdate(); err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	existingClient := &datamodels.Client{}
-	if err := r.db.First(&existingClient, client.Id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Müşteri bulunamadı.", nil)
+// #region Archive Client
+func (r *clientRepository) Archive(c *models.Context, id int, archivedAt time.Time) *lgo.OperationResult {
+	archived := map[string]any{"IsActive": false, "ArchivedAt": archivedAt}
+	return r.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		result := conn(tx, r.db).Model(&datamodels.Client{}).Where("\"Id\" = ?", id).Updates(archived)
+		if result.Error != nil {
+			return mvc.NewDatabaseError(result.Error)
 		}
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	existingClient.ShortTitle = client.ShortTitle
-	existingClient.Title = client.Title
-	existingClient.Notes = client.Notes
-	existingClient.IsActive = client.IsActive
-
-	if err := r.db.Save(&existingClient).Error; err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-	return lgo.NewSuccess(existingClient)
-}
-
-// #endregion Update Client
-
-// #region Delete Client
-func (r *clientRepository) Delete(id int) *lgo.OperationResult {
-	client := &datamodels.Client{}
-	if err := r.db.First(&client, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Müşteri bulunamadı.", nil)
+		if result.RowsAffected == 0 {
+			return mvc.NewNotFoundError(i18n.ClientNotFound)
 		}
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	if err := r.db.Delete(&client).Error; err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-	return lgo.NewSuccess(nil)
+		var projects []datamodels.ClientProject
+		err := conn(tx, r.db).Model(&projects).Clauses(returningId).
+			Where("\"ClientId\" = ? AND \"IsActive\"", id).
+			Updates(archived).Error
+		if err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+		return lgo.NewSuccess(projectIds(projects))
+	})
 }
 
-// #endregion Delete Client
+//#endregion Archive Client
 
-// #region Get Client By Id
-func (r *clientRepository) GetById(id int) *lgo.OperationResult {
-	client := &datamodels.Client{}
-	if err := r.db.First(&client, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Müşteri bulunamadı.", nil)
+// #region Unarchive Client
+func (r *clientRepository) Unarchive(c *models.Context, id int) *lgo.OperationResult {
+	active := map[string]any{"IsActive": true, "ArchivedAt": nil}
+	return r.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		result := r.GetById(tx, id)
+		if !result.IsSuccess() {
+			return result
 		}
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-	return lgo.NewSuccess(client)
+		client := result.ReturnObject.(*datamodels.Client)
+		var projects []datamodels.ClientProject
+		if client.ArchivedAt != nil {
+			err := conn(tx, r.db).Model(&projects).Clauses(returningId).
+				Where("\"ClientId\" = ? AND NOT \"IsActive\" AND \"ArchivedAt\" = ?", id, *client.ArchivedAt).
+				Updates(active).Error
+			if err != nil {
+				return mvc.NewDatabaseError(err)
+			}
+		}
+		if err := conn(tx, r.db).Model(client).Updates(active).Error; err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+		return lgo.NewSuccess(projectIds(projects))
+	})
 }
 
-// #endregion Get Client By Id
+//#endregion Unarchive Client
 
-// #region Get All Clients
-func (r *clientRepository) GetAll(query *mvc.QueryModel) *lgo.OperationResult {
-	var clients []*datamodels.Client
+// returningId, toplu güncellemenin etkilediği kayıtların kimliklerini döndürmesi içindir
+var returningId = clause.Returning{Columns: []clause.Column{{Name: "Id"}}}
 
-	// Varsayılan sıralama
-	defaultSorting := &mvc.DataSortingOptionItem{
-		ColumnName: "\"Title\"",
-		Sorting:    0, // 0: ASC, 1: DESC
+func projectIds(projects []datamodels.ClientProject) []int {
+	ids := make([]int, 0, len(projects))
+	for _, project := range projects {
+		ids = append(ids, project.Id)
 	}
+	return ids
+}
 
-	// Arama yapılacak sütunlar
-	searchableColumns := []string{"\"ShortTitle\"", "\"Title\""}
-
-	// QueryModel'i uygula
-	db, result := ApplyQueryModel(r.db, query, searchableColumns, defaultSorting)
-	if !result.IsSuccess() {
-		return lgo.NewLogicError("Sorgu modeli uygulanırken bir hata oluştu.", nil)
+// #region Foreign References
+// clientReferencesQuery, ForceDelete ile müşteriyle birlikte silinen kayıtları sayar
+const clientReferencesQuery = `
+SELECT
+    (SELECT COUNT(*) FROM "ClientProjects" WHERE "ClientId" = @id) AS "ClientProjects",
+    (SELECT COUNT(*) FROM "ClientContacts" WHERE "ClientId" = @id) AS "ClientContacts",
+    (SELECT COUNT(*) FROM "ClientProjectMembers" AS m JOIN "ClientProjects" AS cp ON m."ClientProjectId" = cp."Id" WHERE cp."ClientId" = @id) AS "ClientProjectMembers",
+    (SELECT COUNT(*) FROM "ProjectTasks" AS pt JOIN "ClientProjects" AS cp ON pt."ClientProjectId" = cp."Id" WHERE cp."ClientId" = @id) AS "ProjectTasks",
+    (SELECT COUNT(*) FROM "Timings" AS t JOIN "ClientProjects" AS cp ON t."ClientProjectId" = cp."Id" WHERE cp."ClientId" = @id) AS "Timings",
+    (SELECT COUNT(*) FROM "TimingTags" AS tt JOIN "Timings" AS t ON tt."TimingId" = t."Id" JOIN "ClientProjects" AS cp ON t."ClientProjectId" = cp."Id" WHERE cp."ClientId" = @id) AS "TimingTags"
+`
+
+func (r *clientRepository) CountForeignReferences(c *models.Context, id int) *lgo.OperationResult {
+	var references mvc.ForeignReferences
+	if err := conn(c, r.db).Raw(clientReferencesQuery, map[string]any{"id": id}).Scan(&references).Error; err != nil {
+		return mvc.NewDatabaseError(err)
 	}
+	return lgo.NewSuccess(&references)
+}
 
-	// Veritabanı sorgusunu çalıştır
-	queryResult := db.Find(&clients)
-	if queryResult.Error != nil {
-		return lgo.NewLogicError(queryResult.Error.Error(), nil)
-	}
+func (r *clientRepository) ForceDelete(c *models.Context, id int) *lgo.OperationResult {
+	return r.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		references := r.CountForeignReferences(tx, id)
+		if !references.IsSuccess() {
+			return references
+		}
+		deleted := &DeletedRecords{References: references.ReturnObject.(*mvc.ForeignReferences)}
+		err := conn(tx, r.db).Model(&datamodels.ClientProject{}).
+			Where("\"ClientId\" = ?", id).
+			Pluck("Id", &deleted.ClientProjectIds).Error
+		if err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+		err = conn(tx, r.db).Model(&datamodels.Timing{}).
+			Where("\"ClientProjectId\" IN (SELECT \"Id\" FROM \"ClientProjects\" WHERE \"ClientId\" = ?)", id).
+			Pluck("Id", &deleted.TimingIds).Error
+		if err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+
+		// Foreign key'ler ON DELETE RESTRICT olduğu için bağlı kayıtlar müşteriden önce silinir
+		if err := deleteClientProjectReferences(conn(tx, r.db), deleted.ClientProjectIds); err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+		for _, model := range []any{&datamodels.ClientProject{}, &datamodels.ClientContact{}} {
+			if err := conn(tx, r.db).Where("\"ClientId\" = ?", id).Delete(model).Error; err != nil {
+				return mvc.NewDatabaseError(err)
+			}
+		}
 
-	return lgo.NewSuccess(clients)
+		result := conn(tx, r.db).Delete(&datamodels.Client{}, id)
+		if result.Error != nil {
+			return mvc.NewDatabaseError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return mvc.NewNotFoundError(i18n.ClientNotFound)
+		}
+		return lgo.NewSuccess(deleted)
+	})
 }
 
-// #endregion Get All Clients
+//#endregion Foreign References
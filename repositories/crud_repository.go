@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"errors"
+
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"gorm.io/gorm"
+)
+
+// CrudRepository, E varlığının K türündeki birincil anahtarla yapılan ortak veri erişim
+// işlemleridir. Varlığa özgü depolar bu arayüzü gömer ve yalnızca ek sorgularını tanımlar.
+type CrudRepository[E any, K comparable] interface {
+	Create(c *models.Context, model *E) *lgo.OperationResult
+	Update(c *models.Context, model *E) *lgo.OperationResult
+	Delete(c *models.Context, id K) *lgo.OperationResult
+	GetById(c *models.Context, id K) *lgo.OperationResult
+	GetAll(c *models.Context, query *mvc.QueryModel) *lgo.OperationResult
+}
+
+// CrudOptions, genel deponun varlığa özgü ayarlarıdır
+type CrudOptions[E any] struct {
+	NotFound string       // Kayıt bulunamadığında dönülecek i18n mesaj kodu
+	Schema   *QuerySchema // GetAll'da filtrelenebilen ve sıralanabilen alanlar
+
+	// Apply, Update'te değiştirilebilen alanları istekteki modelden kayıtlı modele kopyalar
+	Apply func(existing *E, model *E)
+
+	// Scope, verilmişse GetAll'da sorguyu QueryModel uygulanmadan önce daraltır
+	Scope func(db *gorm.DB, query *mvc.QueryModel) *gorm.DB
+}
+
+// Entity, birincil anahtarı GetId ile okunabilen varlıkların işaretçi türüdür
+type Entity[E any, K comparable] interface {
+	*E
+	GetId() K
+}
+
+type crudRepository[E any, K comparable, P Entity[E, K]] struct {
+	db      *gorm.DB
+	options CrudOptions[E]
+}
+
+// NewCrudRepository, E varlığı için genel bir depo oluşturur. Varlık Validate veya
+// ValidateForUpdate metotlarına sahipse Create ve Update öncesinde çağrılır.
+func NewCrudRepository[E any, K comparable, P Entity[E, K]](db *gorm.DB, options CrudOptions[E]) CrudRepository[E, K] {
+	return &crudRepository[E, K, P]{db: db, options: options}
+}
+
+// #region Create
+func (r *crudRepository[E, K, P]) Create(c *models.Context, model *E) *lgo.OperationResult {
+	if validatable, ok := any(model).(interface{ Validate() error }); ok {
+		if err := validatable.Validate(); err != nil {
+			return mvc.NewValidationErrorFrom(err)
+		}
+	}
+
+	if err := conn(c, r.db).Create(model).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(model)
+}
+
+// #endregion Create
+
+// #region Update
+func (r *crudRepository[E, K, P]) Update(c *models.Context, model *E) *lgo.OperationResult {
+	if validatable, ok := any(model).(interface{ ValidateForUpdate() error }); ok {
+		if err := validatable.ValidateForUpdate(); err != nil {
+			return mvc.NewValidationErrorFrom(err)
+		}
+	}
+
+	existing := new(E)
+	if err := conn(c, r.db).First(existing, "\"Id\" = ?", P(model).GetId()).Error; err != nil {
+		return r.notFoundOr(err)
+	}
+
+	r.options.Apply(existing, model)
+
+	if err := conn(c, r.db).Save(existing).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(existing)
+}
+
+// #endregion Update
+
+// #region Delete
+func (r *crudRepository[E, K, P]) Delete(c *models.Context, id K) *lgo.OperationResult {
+	existing := new(E)
+	if err := conn(c, r.db).First(existing, "\"Id\" = ?", id).Error; err != nil {
+		return r.notFoundOr(err)
+	}
+
+	if err := conn(c, r.db).Delete(existing).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// #endregion Delete
+
+// #region Get By Id
+func (r *crudRepository[E, K, P]) GetById(c *models.Context, id K) *lgo.OperationResult {
+	model := new(E)
+	if err := conn(c, r.db).First(model, "\"Id\" = ?", id).Error; err != nil {
+		return r.notFoundOr(err)
+	}
+	return lgo.NewSuccess(model)
+}
+
+// #endregion Get By Id
+
+// #region Get All
+func (r *crudRepository[E, K, P]) GetAll(c *models.Context, query *mvc.QueryModel) *lgo.OperationResult {
+	var rows []*E
+
+	db := conn(c, r.db).Model(new(E))
+	if r.options.Scope != nil {
+		db = r.options.Scope(db, query)
+	}
+
+	// QueryModel'i uygula
+	db, page, result := ApplyQueryModel(db, query, r.options.Schema)
+	if !result.IsSuccess() {
+		return result
+	}
+
+	// Veritabanı sorgusunu çalıştır
+	if err := db.Find(&rows).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return NewPagedResult(page, rows)
+}
+
+// #endregion Get All
+
+func (r *crudRepository[E, K, P]) notFoundOr(err error) *lgo.OperationResult {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return mvc.NewNotFoundError(r.options.NotFound)
+	}
+	return mvc.NewDatabaseError(err)
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"strings"
+
+	"lms-web-services-main/models"
+
+	"github.com/LGYtech/lgo"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type HealthRepository interface {
+	PingDatabase(c *models.Context) *lgo.OperationResult
+	PingCache(c *models.Context) *lgo.OperationResult
+}
+
+type healthRepository struct {
+	db    *gorm.DB
+	cache *redis.Client
+}
+
+func NewHealthRepository(db *gorm.DB, cache *redis.Client) HealthRepository {
+	return &healthRepository{db: db, cache: cache}
+}
+
+// #region Ping Database
+func (r *healthRepository) PingDatabase(c *models.Context) *lgo.OperationResult {
+	var version string
+	if err := conn(c, r.db).Raw("SHOW server_version").Scan(&version).Error; err != nil {
+		return lgo.NewFailureWithError(err)
+	}
+	return lgo.NewSuccess(version)
+}
+
+// #endregion Ping Database
+
+// #region Ping Cache
+func (r *healthRepository) PingCache(c *models.Context) *lgo.OperationResult {
+	info, err := r.cache.Info(c, "server").Result()
+	if err != nil {
+		return lgo.NewFailureWithError(err)
+	}
+
+	for _, line := range strings.Split(info, "\n") {
+		if version, found := strings.CutPrefix(strings.TrimSpace(line), "redis_version:"); found {
+			return lgo.NewSuccess(version)
+		}
+	}
+	return lgo.NewSuccess("")
+}
+
+// #endregion Ping Cache
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"sync"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	mvcmodels "lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+var _ repositories.CacheRepository = (*CacheRepository)(nil)
+
+// CacheRepository, Redis önbelleğinin bellekteki karşılığıdır. Oturumlar token'a göre
+// tutulur; yetki ayarları gerçek depo gibi bir kez SystemUserSettingRepository'den okunur ve
+// RemoveSystemUserSetting çağrılana kadar önbellekte kalır.
+type CacheRepository struct {
+	mutex    sync.Mutex
+	settings repositories.SystemUserSettingRepository
+	sessions map[string]*mvcmodels.SystemUserCredential
+	tokens   map[uuid.UUID][]string
+	values   map[string]string
+}
+
+func NewCacheRepository(settings repositories.SystemUserSettingRepository) *CacheRepository {
+	return &CacheRepository{
+		settings: settings,
+		sessions: map[string]*mvcmodels.SystemUserCredential{},
+		tokens:   map[uuid.UUID][]string{},
+		values:   map[string]string{},
+	}
+}
+
+// #region System User Credential
+func (r *CacheRepository) GetSystemUserCredential(c *models.Context, token string) *lgo.OperationResult {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	credential, ok := r.sessions[token]
+	if !ok {
+		return lgo.NewFailure()
+	}
+	copied := *credential
+	return lgo.NewSuccess(&copied)
+}
+
+func (r *CacheRepository) RegisterSystemUserCredential(c *models.Context, token string, systemUser *datamodels.SystemUser) *lgo.OperationResult {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.sessions[token] = &mvcmodels.SystemUserCredential{
+		Id:      systemUser.Id.String(),
+		Name:    systemUser.Name,
+		Surname: systemUser.Surname,
+		Email:   systemUser.Email,
+	}
+	r.tokens[systemUser.Id] = append(r.tokens[systemUser.Id], token)
+	return lgo.NewSuccess(nil)
+}
+
+func (r *CacheRepository) DeleteSystemUserCredential(c *models.Context, token string) *lgo.OperationResult {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.sessions, token)
+	return lgo.NewSuccess(nil)
+}
+
+func (r *CacheRepository) DeleteSystemUserCredentialById(c *models.Context, id uuid.UUID) *lgo.OperationResult {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, token := range r.tokens[id] {
+		delete(r.sessions, token)
+	}
+	delete(r.tokens, id)
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion System User Credential
+
+// #region System User Setting
+func (r *CacheRepository) GetSystemUserSetting(c *models.Context, setting string) *lgo.OperationResult {
+	credential := c.Principal
+	if credential == nil {
+		credentialResult := r.GetSystemUserCredential(c, c.Token)
+		if !credentialResult.IsSuccess() {
+			return credentialResult
+		}
+		credential = credentialResult.ReturnObject.(*mvcmodels.SystemUserCredential)
+	}
+	systemUserId, err := uuid.Parse(credential.Id)
+	if err != nil {
+		return lgo.NewFailureWithError(err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cacheKey := credential.Id + ":" + setting
+	if value, ok := r.values[cacheKey]; ok {
+		return lgo.NewSuccess(value)
+	}
+
+	settingResult := r.settings.GetValue(c, systemUserId, setting)
+	if !settingResult.IsSuccess() {
+		return settingResult
+	}
+	systemUserSetting, ok := settingResult.ReturnObject.(*datamodels.SystemUserSetting)
+	if !ok {
+		return mvcmodels.NewLogicError(i18n.PermissionNotFound)
+	}
+
+	r.values[cacheKey] = systemUserSetting.Value
+	return lgo.NewSuccess(systemUserSetting.Value)
+}
+
+func (r *CacheRepository) RemoveSystemUserSetting(c *models.Context, systemUserId uuid.UUID, key string) *lgo.OperationResult {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.values, systemUserId.String()+":"+key)
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion System User Setting
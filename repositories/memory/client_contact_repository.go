@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+var _ repositories.ClientContactRepository = (*ClientContactRepository)(nil)
+
+// ClientContactRepository, gerçek depo gibi birincil işaretlenen yetkiliden önce müşterinin
+// diğer birincil yetkilisini kaldırır; işlem bütünlüğü (transaction) taklit edilmez
+type ClientContactRepository struct {
+	*Store[datamodels.ClientContact, int, *datamodels.ClientContact]
+}
+
+func NewClientContactRepository() *ClientContactRepository {
+	return &ClientContactRepository{
+		Store: NewStore[datamodels.ClientContact, int](StoreOptions[datamodels.ClientContact, int]{
+			NotFound: i18n.ClientContactNotFound,
+			NewId:    IntSequence(),
+			Apply: func(existing *datamodels.ClientContact, contact *datamodels.ClientContact) {
+				existing.Name = contact.Name
+				existing.Role = contact.Role
+				existing.Email = contact.Email
+				existing.Phone = contact.Phone
+				existing.IsPrimary = contact.IsPrimary
+			},
+		}),
+	}
+}
+
+func (r *ClientContactRepository) Create(c *models.Context, contact *datamodels.ClientContact) *lgo.OperationResult {
+	r.demote(c, contact)
+	return r.Store.Create(c, contact)
+}
+
+func (r *ClientContactRepository) Update(c *models.Context, contact *datamodels.ClientContact) *lgo.OperationResult {
+	r.demote(c, contact)
+	return r.Store.Update(c, contact)
+}
+
+func (r *ClientContactRepository) demote(c *models.Context, contact *datamodels.ClientContact) {
+	if !contact.IsPrimary {
+		return
+	}
+	for _, other := range r.Find(func(other *datamodels.ClientContact) bool {
+		return other.ClientId == contact.ClientId && other.IsPrimary && other.Id != contact.Id
+	}) {
+		other.IsPrimary = false
+		r.Store.Update(c, other)
+	}
+}
+
+func (r *ClientContactRepository) GetByClientId(c *models.Context, clientId int) *lgo.OperationResult {
+	return lgo.NewSuccess(r.Find(func(contact *datamodels.ClientContact) bool {
+		return contact.ClientId == clientId
+	}))
+}
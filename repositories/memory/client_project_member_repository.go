@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+var _ repositories.ClientProjectMemberRepository = (*ClientProjectMemberRepository)(nil)
+
+type ClientProjectMemberRepository struct {
+	*Store[datamodels.ClientProjectMember, int, *datamodels.ClientProjectMember]
+}
+
+func NewClientProjectMemberRepository() *ClientProjectMemberRepository {
+	return &ClientProjectMemberRepository{
+		Store: NewStore[datamodels.ClientProjectMember, int](StoreOptions[datamodels.ClientProjectMember, int]{
+			NotFound: i18n.MemberNotFound,
+			NewId:    IntSequence(),
+			Apply: func(existing *datamodels.ClientProjectMember, member *datamodels.ClientProjectMember) {
+				existing.Role = member.Role
+				existing.HourlyRate = member.HourlyRate
+			},
+		}),
+	}
+}
+
+func (r *ClientProjectMemberRepository) GetByClientProjectId(c *models.Context, clientProjectId int) *lgo.OperationResult {
+	return lgo.NewSuccess(r.Find(func(member *datamodels.ClientProjectMember) bool {
+		return member.ClientProjectId == clientProjectId
+	}))
+}
+
+func (r *ClientProjectMemberRepository) GetByMember(c *models.Context, clientProjectId int, systemUserId uuid.UUID) *lgo.OperationResult {
+	members := r.Find(func(member *datamodels.ClientProjectMember) bool {
+		return member.ClientProjectId == clientProjectId && member.SystemUserId == systemUserId
+	})
+	if len(members) == 0 {
+		return mvc.NewNotFoundError(i18n.MemberNotFound)
+	}
+	return lgo.NewSuccess(members[0])
+}
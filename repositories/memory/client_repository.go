@@ -0,0 +1,303 @@
+package memory
+
+import (
+	"time"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+var (
+	_ repositories.ClientRepository        = (*ClientRepository)(nil)
+	_ repositories.ClientProjectRepository = (*ClientProjectRepository)(nil)
+)
+
+// #region Client Repository
+
+// ClientRepository, arşivlemeyi yalnızca Projects verilmişse projelere yansıtır. Bağlı
+// kayıtlar da yalnızca verilen depolarda sayılır ve ForceDelete ile silinir.
+type ClientRepository struct {
+	*Store[datamodels.Client, int, *datamodels.Client]
+	Projects *ClientProjectRepository
+	Contacts *ClientContactRepository
+}
+
+func NewClientRepository() *ClientRepository {
+	return &ClientRepository{
+		Store: NewStore[datamodels.Client, int](StoreOptions[datamodels.Client, int]{
+			NotFound: i18n.ClientNotFound,
+			NewId:    IntSequence(),
+			Apply: func(existing *datamodels.Client, client *datamodels.Client) {
+				archivedAt := existing.ArchivedAt
+				*existing = *client
+				existing.ArchivedAt = archivedAt
+			},
+		}),
+	}
+}
+
+// GetAll, gerçek depo gibi listeyi query.Archived'a göre daraltır
+func (r *ClientRepository) GetAll(c *models.Context, query *mvc.QueryModel) *lgo.OperationResult {
+	return NewPagedResult(query, r.Find(func(client *datamodels.Client) bool {
+		return matchesArchived(query, client.IsActive)
+	}))
+}
+
+func (r *ClientRepository) Archive(c *models.Context, id int, archivedAt time.Time) *lgo.OperationResult {
+	result := r.Modify(id, func(client *datamodels.Client) {
+		client.IsActive = false
+		client.ArchivedAt = &archivedAt
+	})
+	if !result.IsSuccess() {
+		return result
+	}
+	projectIds := []int{}
+	if r.Projects != nil {
+		for _, project := range r.Projects.Find(func(project *datamodels.ClientProject) bool {
+			return project.ClientId == id && project.IsActive
+		}) {
+			r.Projects.Archive(c, project.Id, archivedAt)
+			projectIds = append(projectIds, project.Id)
+		}
+	}
+	return lgo.NewSuccess(projectIds)
+}
+
+func (r *ClientRepository) Unarchive(c *models.Context, id int) *lgo.OperationResult {
+	result := r.GetById(c, id)
+	if !result.IsSuccess() {
+		return result
+	}
+	archivedAt := result.ReturnObject.(*datamodels.Client).ArchivedAt
+	projectIds := []int{}
+	if archivedAt != nil && r.Projects != nil {
+		for _, project := range r.Projects.Find(func(project *datamodels.ClientProject) bool {
+			return project.ClientId == id && !project.IsActive && project.ArchivedAt != nil && project.ArchivedAt.Equal(*archivedAt)
+		}) {
+			r.Projects.Unarchive(c, project.Id)
+			projectIds = append(projectIds, project.Id)
+		}
+	}
+	r.Modify(id, func(client *datamodels.Client) {
+		client.IsActive = true
+		client.ArchivedAt = nil
+	})
+	return lgo.NewSuccess(projectIds)
+}
+
+func (r *ClientRepository) CountForeignReferences(c *models.Context, id int) *lgo.OperationResult {
+	references := &mvc.ForeignReferences{}
+	if r.Contacts != nil {
+		references.ClientContacts = r.Contacts.Count(func(contact *datamodels.ClientContact) bool {
+			return contact.ClientId == id
+		})
+	}
+	if r.Projects != nil {
+		for _, project := range r.Projects.Find(func(project *datamodels.ClientProject) bool {
+			return project.ClientId == id
+		}) {
+			projectReferences := r.Projects.countForeignReferences(project.Id)
+			references.ClientProjects++
+			references.ClientProjectMembers += projectReferences.ClientProjectMembers
+			references.ProjectTasks += projectReferences.ProjectTasks
+			references.Timings += projectReferences.Timings
+			references.TimingTags += projectReferences.TimingTags
+		}
+	}
+	return lgo.NewSuccess(references)
+}
+
+// Delete, veritabanındaki ON DELETE RESTRICT gibi bağlı kaydı olan müşteriyi silmez
+func (r *ClientRepository) Delete(c *models.Context, id int) *lgo.OperationResult {
+	if r.CountForeignReferences(c, id).ReturnObject.(*mvc.ForeignReferences).Any() {
+		return mvc.NewConflictError(i18n.ReferenceViolation)
+	}
+	return r.Store.Delete(c, id)
+}
+
+// ForceDelete, gerçek depo gibi bağlı kayıtları müşteriden önce siler
+func (r *ClientRepository) ForceDelete(c *models.Context, id int) *lgo.OperationResult {
+	if result := r.GetById(c, id); !result.IsSuccess() {
+		return result
+	}
+	deleted := &repositories.DeletedRecords{References: r.CountForeignReferences(c, id).ReturnObject.(*mvc.ForeignReferences)}
+	if r.Contacts != nil {
+		for _, contact := range r.Contacts.Find(func(contact *datamodels.ClientContact) bool {
+			return contact.ClientId == id
+		}) {
+			r.Contacts.Delete(c, contact.Id)
+		}
+	}
+	if r.Projects != nil {
+		for _, project := range r.Projects.Find(func(project *datamodels.ClientProject) bool {
+			return project.ClientId == id
+		}) {
+			projectDeleted := r.Projects.ForceDelete(c, project.Id).ReturnObject.(*repositories.DeletedRecords)
+			deleted.ClientProjectIds = append(deleted.ClientProjectIds, project.Id)
+			deleted.TimingIds = append(deleted.TimingIds, projectDeleted.TimingIds...)
+		}
+	}
+	if result := r.Delete(c, id); !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(deleted)
+}
+
+//#endregion Client Repository
+
+// #region Client Project Repository
+
+// ClientProjectRepository'de GetAllForMember yalnızca Members verilmişse üyeliğe göre süzer.
+// Bağlı kayıtlar yalnızca verilen depolarda sayılır ve ForceDelete ile silinir.
+type ClientProjectRepository struct {
+	*Store[datamodels.ClientProject, int, *datamodels.ClientProject]
+	Members *ClientProjectMemberRepository
+	Tasks   *ProjectTaskRepository
+	Timings *TimingRepository
+}
+
+func NewClientProjectRepository() *ClientProjectRepository {
+	return &ClientProjectRepository{
+		Store: NewStore[datamodels.ClientProject, int](StoreOptions[datamodels.ClientProject, int]{
+			NotFound: i18n.ClientProjectNotFound,
+			NewId:    IntSequence(),
+			Apply: func(existing *datamodels.ClientProject, clientProject *datamodels.ClientProject) {
+				existing.Name = clientProject.Name
+				existing.IsActive = clientProject.IsActive
+				existing.BudgetHours = clientProject.BudgetHours
+				existing.BudgetAmount = clientProject.BudgetAmount
+				existing.HourlyRate = clientProject.HourlyRate
+				existing.BudgetStartDate = clientProject.BudgetStartDate
+				existing.BudgetEndDate = clientProject.BudgetEndDate
+				existing.EnforceBudget = clientProject.EnforceBudget
+			},
+		}),
+	}
+}
+
+func (r *ClientProjectRepository) GetByClientId(c *models.Context, clientId int) *lgo.OperationResult {
+	return lgo.NewSuccess(r.Find(func(clientProject *datamodels.ClientProject) bool {
+		return clientProject.ClientId == clientId
+	}))
+}
+
+// GetAll, gerçek depo gibi listeyi query.Archived'a göre daraltır
+func (r *ClientProjectRepository) GetAll(c *models.Context, query *mvc.QueryModel) *lgo.OperationResult {
+	return NewPagedResult(query, r.Find(func(clientProject *datamodels.ClientProject) bool {
+		return matchesArchived(query, clientProject.IsActive)
+	}))
+}
+
+func (r *ClientProjectRepository) GetAllForMember(c *models.Context, query *mvc.QueryModel, systemUserId uuid.UUID) *lgo.OperationResult {
+	return NewPagedResult(query, r.Find(func(clientProject *datamodels.ClientProject) bool {
+		return matchesArchived(query, clientProject.IsActive) &&
+			r.Members != nil && r.Members.GetByMember(c, clientProject.Id, systemUserId).IsSuccess()
+	}))
+}
+
+func (r *ClientProjectRepository) Archive(c *models.Context, id int, archivedAt time.Time) *lgo.OperationResult {
+	result := r.Modify(id, func(clientProject *datamodels.ClientProject) {
+		clientProject.IsActive = false
+		clientProject.ArchivedAt = &archivedAt
+	})
+	if !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(nil)
+}
+
+func (r *ClientProjectRepository) Unarchive(c *models.Context, id int) *lgo.OperationResult {
+	result := r.Modify(id, func(clientProject *datamodels.ClientProject) {
+		clientProject.IsActive = true
+		clientProject.ArchivedAt = nil
+	})
+	if !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(nil)
+}
+
+func (r *ClientProjectRepository) CountForeignReferences(c *models.Context, id int) *lgo.OperationResult {
+	return lgo.NewSuccess(r.countForeignReferences(id))
+}
+
+// Delete, veritabanındaki ON DELETE RESTRICT gibi bağlı kaydı olan projeyi silmez
+func (r *ClientProjectRepository) Delete(c *models.Context, id int) *lgo.OperationResult {
+	if r.countForeignReferences(id).Any() {
+		return mvc.NewConflictError(i18n.ReferenceViolation)
+	}
+	return r.Store.Delete(c, id)
+}
+
+// ForceDelete, gerçek depo gibi bağlı kayıtları projeden önce siler
+func (r *ClientProjectRepository) ForceDelete(c *models.Context, id int) *lgo.OperationResult {
+	if result := r.GetById(c, id); !result.IsSuccess() {
+		return result
+	}
+	deleted := &repositories.DeletedRecords{References: r.countForeignReferences(id)}
+	if r.Members != nil {
+		for _, member := range r.Members.Find(func(member *datamodels.ClientProjectMember) bool {
+			return member.ClientProjectId == id
+		}) {
+			r.Members.Delete(c, member.Id)
+		}
+	}
+	if r.Tasks != nil {
+		for _, task := range r.Tasks.Find(func(task *datamodels.ProjectTask) bool {
+			return task.ClientProjectId == id
+		}) {
+			r.Tasks.Delete(c, task.Id)
+		}
+	}
+	if r.Timings != nil {
+		for _, timing := range r.Timings.Find(func(timing *datamodels.Timing) bool {
+			return timing.ClientProjectId == id
+		}) {
+			r.Timings.SetTags(c, timing.Id, nil)
+			r.Timings.Delete(c, timing.Id)
+			deleted.TimingIds = append(deleted.TimingIds, timing.Id)
+		}
+	}
+	if result := r.Delete(c, id); !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(deleted)
+}
+
+func (r *ClientProjectRepository) countForeignReferences(id int) *mvc.ForeignReferences {
+	references := &mvc.ForeignReferences{}
+	if r.Members != nil {
+		references.ClientProjectMembers = r.Members.Count(func(member *datamodels.ClientProjectMember) bool {
+			return member.ClientProjectId == id
+		})
+	}
+	if r.Tasks != nil {
+		references.ProjectTasks = r.Tasks.Count(func(task *datamodels.ProjectTask) bool {
+			return task.ClientProjectId == id
+		})
+	}
+	if r.Timings != nil {
+		for _, timing := range r.Timings.Find(func(timing *datamodels.Timing) bool {
+			return timing.ClientProjectId == id
+		}) {
+			references.Timings++
+			references.TimingTags += int64(r.Timings.tagCount(timing.Id))
+		}
+	}
+	return references
+}
+
+// matchesArchived, IsActive değeri verilen kaydın query.Archived'a göre listeye girip
+// girmeyeceğini döndürür
+func matchesArchived(query *mvc.QueryModel, isActive bool) bool {
+	want, restricted := query.Archived.IsActive()
+	return !restricted || isActive == want
+}
+
+//#endregion Client Project Repository
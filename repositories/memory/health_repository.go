@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"lms-web-services-main/models"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+var _ repositories.HealthRepository = (*HealthRepository)(nil)
+
+// HealthRepository'de DatabaseError veya CacheError verilirse ilgili kontrol bu hatayla
+// başarısız olur
+type HealthRepository struct {
+	DatabaseError error
+	CacheError    error
+}
+
+func NewHealthRepository() *HealthRepository {
+	return &HealthRepository{}
+}
+
+func (r *HealthRepository) PingDatabase(c *models.Context) *lgo.OperationResult {
+	if r.DatabaseError != nil {
+		return lgo.NewFailureWithError(r.DatabaseError)
+	}
+	return lgo.NewSuccess("memory")
+}
+
+func (r *HealthRepository) PingCache(c *models.Context) *lgo.OperationResult {
+	if r.CacheError != nil {
+		return lgo.NewFailureWithError(r.CacheError)
+	}
+	return lgo.NewSuccess("memory")
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"time"
+
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+var _ repositories.OutboxRepository = (*OutboxRepository)(nil)
+
+// OutboxRepository, Claim'de kayıtları kilitlemez; testlerde tek dağıtıcı çalışır
+type OutboxRepository struct {
+	*Store[datamodels.OutboxEvent, int64, *datamodels.OutboxEvent]
+}
+
+func NewOutboxRepository() *OutboxRepository {
+	next := IntSequence()
+	return &OutboxRepository{
+		Store: NewStore[datamodels.OutboxEvent, int64](StoreOptions[datamodels.OutboxEvent, int64]{
+			NewId: func() int64 { return int64(next()) },
+		}),
+	}
+}
+
+func (r *OutboxRepository) Add(c *models.Context, event *datamodels.OutboxEvent) *lgo.OperationResult {
+	return r.Create(c, event)
+}
+
+func (r *OutboxRepository) Claim(c *models.Context, now time.Time, lease time.Duration, limit int) *lgo.OperationResult {
+	due := r.Find(func(event *datamodels.OutboxEvent) bool {
+		return event.DispatchedAt == nil && event.NextAttemptAt != nil && !event.NextAttemptAt.After(now)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	leaseUntil := now.Add(lease)
+	events := []*datamodels.OutboxEvent{}
+	for _, event := range due {
+		result := r.Modify(event.Id, func(existing *datamodels.OutboxEvent) {
+			existing.Attempts++
+			existing.NextAttemptAt = &leaseUntil
+		})
+		events = append(events, result.ReturnObject.(*datamodels.OutboxEvent))
+	}
+	return lgo.NewSuccess(events)
+}
+
+func (r *OutboxRepository) MarkDispatched(c *models.Context, id int64, dispatchedAt time.Time) *lgo.OperationResult {
+	return r.modify(id, func(existing *datamodels.OutboxEvent) {
+		existing.DispatchedAt = &dispatchedAt
+		existing.NextAttemptAt = nil
+		existing.LastError = ""
+	})
+}
+
+func (r *OutboxRepository) MarkFailed(c *models.Context, id int64, lastError string, nextAttemptAt *time.Time) *lgo.OperationResult {
+	return r.modify(id, func(existing *datamodels.OutboxEvent) {
+		existing.LastError = lastError
+		existing.NextAttemptAt = nextAttemptAt
+	})
+}
+
+// modify, gerçek depodaki UPDATE gibi kayıt yoksa da başarılı döner
+func (r *OutboxRepository) modify(id int64, change func(existing *datamodels.OutboxEvent)) *lgo.OperationResult {
+	r.Modify(id, change)
+	return lgo.NewSuccess(nil)
+}
+
+// Types, giden kutusundaki olayların türlerini yazılma sırasıyla döndürür
+func (r *OutboxRepository) Types() []string {
+	types := []string{}
+	for _, event := range r.Find(nil) {
+		types = append(types, event.Type)
+	}
+	return types
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+var _ repositories.ProjectTaskRepository = (*ProjectTaskRepository)(nil)
+
+// ProjectTaskRepository, görev silindiğinde zaman kayıtlarındaki TaskId'yi boşaltmaz
+// (gerçek veritabanında ON DELETE SET NULL)
+type ProjectTaskRepository struct {
+	*Store[datamodels.ProjectTask, int, *datamodels.ProjectTask]
+}
+
+func NewProjectTaskRepository() *ProjectTaskRepository {
+	return &ProjectTaskRepository{
+		Store: NewStore[datamodels.ProjectTask, int](StoreOptions[datamodels.ProjectTask, int]{
+			NotFound: i18n.TaskNotFound,
+			NewId:    IntSequence(),
+			Apply: func(existing *datamodels.ProjectTask, task *datamodels.ProjectTask) {
+				existing.Name = task.Name
+				existing.Description = task.Description
+				existing.EstimateHours = task.EstimateHours
+				existing.Status = task.Status
+			},
+		}),
+	}
+}
+
+func (r *ProjectTaskRepository) GetByClientProjectId(c *models.Context, clientProjectId int) *lgo.OperationResult {
+	return lgo.NewSuccess(r.Find(func(task *datamodels.ProjectTask) bool {
+		return task.ClientProjectId == clientProjectId
+	}))
+}
@@ -0,0 +1,55 @@
+package memory
+
+// Repositories, birbirine bağlanmış bellekteki depoların tamamıdır. Testler servisleri
+// application.addRoutes'taki gibi bu depolarla kurar.
+type Repositories struct {
+	Clients              *ClientRepository
+	ClientContacts       *ClientContactRepository
+	ClientProjects       *ClientProjectRepository
+	ClientProjectMembers *ClientProjectMemberRepository
+	ProjectTasks         *ProjectTaskRepository
+	Tags                 *TagRepository
+	Timings              *TimingRepository
+	SystemUsers          *SystemUserRepository
+	Settings             *SystemUserSettingRepository
+	Search               *SearchRepository
+	Health               *HealthRepository
+	Cache                *CacheRepository
+	Outbox               *OutboxRepository
+	Webhooks             *WebhookRepository
+	WebhookDeliveries    *WebhookDeliveryRepository
+	Transactor           Transactor
+}
+
+func NewRepositories() *Repositories {
+	r := &Repositories{
+		Clients:              NewClientRepository(),
+		ClientContacts:       NewClientContactRepository(),
+		ClientProjects:       NewClientProjectRepository(),
+		ClientProjectMembers: NewClientProjectMemberRepository(),
+		ProjectTasks:         NewProjectTaskRepository(),
+		Tags:                 NewTagRepository(),
+		Timings:              NewTimingRepository(),
+		SystemUsers:          NewSystemUserRepository(),
+		Settings:             NewSystemUserSettingRepository(),
+		Health:               NewHealthRepository(),
+		Outbox:               NewOutboxRepository(),
+		Webhooks:             NewWebhookRepository(),
+		WebhookDeliveries:    NewWebhookDeliveryRepository(),
+	}
+	r.Timings.Projects = r.ClientProjects
+	r.Timings.Clients = r.Clients
+	r.Timings.Members = r.ClientProjectMembers
+	r.Timings.Tasks = r.ProjectTasks
+	r.Timings.Tags = r.Tags
+	r.Clients.Projects = r.ClientProjects
+	r.Clients.Contacts = r.ClientContacts
+	r.ClientProjects.Members = r.ClientProjectMembers
+	r.ClientProjects.Tasks = r.ProjectTasks
+	r.ClientProjects.Timings = r.Timings
+	r.SystemUsers.Settings = r.Settings
+	r.SystemUsers.Timings = r.Timings
+	r.Search = NewSearchRepository(r.Clients, r.ClientProjects, r.Timings)
+	r.Cache = NewCacheRepository(r.Settings)
+	return r
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"html"
+	"slices"
+	"strings"
+
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+var _ repositories.SearchRepository = (*SearchRepository)(nil)
+
+// SearchRepository, tam metin arama yerine başlıklarda büyük/küçük harf duyarsız alt dize
+// araması yapar. Sonuçlar gerçek depo gibi tür ve kimliğe göre sıralanır; Rank her zaman
+// 1'dir ve eşleşmeler işaretlenmez.
+type SearchRepository struct {
+	Clients  *ClientRepository
+	Projects *ClientProjectRepository
+	Timings  *TimingRepository
+}
+
+func NewSearchRepository(clients *ClientRepository, projects *ClientProjectRepository, timings *TimingRepository) *SearchRepository {
+	return &SearchRepository{Clients: clients, Projects: projects, Timings: timings}
+}
+
+// #region Search
+func (r *SearchRepository) Search(c *models.Context, term string, types []string, limit int) *lgo.OperationResult {
+	hits := []*mvc.SearchHit{}
+	term = strings.ToLower(term)
+	matches := func(text string) bool {
+		return strings.Contains(strings.ToLower(text), term)
+	}
+
+	if slices.Contains(types, mvc.SearchTypeClient) && r.Clients != nil {
+		for _, client := range r.Clients.Find(func(client *datamodels.Client) bool { return matches(client.Title) }) {
+			hits = append(hits, newSearchHit(mvc.SearchTypeClient, client.Id, 0, client.Title))
+		}
+	}
+	if slices.Contains(types, mvc.SearchTypeClientProject) && r.Projects != nil {
+		for _, project := range r.Projects.Find(func(project *datamodels.ClientProject) bool { return matches(project.Name) }) {
+			hits = append(hits, newSearchHit(mvc.SearchTypeClientProject, project.Id, project.ClientId, project.Name))
+		}
+	}
+	if slices.Contains(types, mvc.SearchTypeTiming) && r.Timings != nil {
+		for _, timing := range r.Timings.Find(func(timing *datamodels.Timing) bool { return matches(timing.Title) }) {
+			hits = append(hits, newSearchHit(mvc.SearchTypeTiming, timing.Id, timing.ClientProjectId, timing.Title))
+		}
+	}
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return lgo.NewSuccess(hits)
+}
+
+//#endregion Search
+
+func newSearchHit(searchType string, id int, parentId int, title string) *mvc.SearchHit {
+	return &mvc.SearchHit{Type: searchType, Id: id, ParentId: parentId, Title: html.EscapeString(title), Rank: 1}
+}
@@ -0,0 +1,213 @@
+// Package memory, repositories paketindeki arayüzlerin bellekte çalışan uygulamalarıdır.
+// Servis ve controller testlerinde PostgreSQL ve Redis yerine kullanılır; doğrulama ve
+// "bulunamadı" hataları gerçek depolarla aynı mesaj kodlarıyla döner.
+package memory
+
+import (
+	"math"
+	"sync"
+
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+)
+
+// Entity, bellekteki deponun saklayabildiği varlıkların işaretçi türüdür
+type Entity[E any, K comparable] interface {
+	*E
+	GetId() K
+	SetId(id K)
+}
+
+// StoreOptions, genel deponun varlığa özgü ayarlarıdır
+type StoreOptions[E any, K comparable] struct {
+	NotFound string   // Kayıt bulunamadığında dönülecek i18n mesaj kodu
+	NewId    func() K // Create'te kimliği olmayan kayda verilecek yeni kimlik
+
+	// Apply, Update'te değiştirilebilen alanları istekteki modelden kayıtlı modele kopyalar.
+	// Verilmezse kaydın tamamı değiştirilir.
+	Apply func(existing *E, model *E)
+}
+
+// Store, repositories.CrudRepository'nin bellekte çalışan uygulamasıdır. Kayıtlar kopya
+// olarak saklanır; dönen modellerin değiştirilmesi depoyu etkilemez. GetAll filtre, arama ve
+// sıralama uygulamaz; kayıtları eklenme sırasıyla sayfalar.
+type Store[E any, K comparable, P Entity[E, K]] struct {
+	mutex   sync.RWMutex
+	rows    map[K]E
+	order   []K
+	options StoreOptions[E, K]
+}
+
+func NewStore[E any, K comparable, P Entity[E, K]](options StoreOptions[E, K]) *Store[E, K, P] {
+	return &Store[E, K, P]{rows: map[K]E{}, options: options}
+}
+
+// IntSequence, 1'den başlayan sıralı tamsayı kimlikler üretir (serial sütunlar gibi)
+func IntSequence() func() int {
+	var mutex sync.Mutex
+	last := 0
+	return func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		last++
+		return last
+	}
+}
+
+// #region Create
+func (s *Store[E, K, P]) Create(c *models.Context, model *E) *lgo.OperationResult {
+	if validatable, ok := any(model).(interface{ Validate() error }); ok {
+		if err := validatable.Validate(); err != nil {
+			return mvc.NewValidationErrorFrom(err)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var zero K
+	if P(model).GetId() == zero {
+		P(model).SetId(s.options.NewId())
+	}
+	id := P(model).GetId()
+	if _, exists := s.rows[id]; !exists {
+		s.order = append(s.order, id)
+	}
+	s.rows[id] = *model
+	return lgo.NewSuccess(model)
+}
+
+// #endregion Create
+
+// #region Update
+func (s *Store[E, K, P]) Update(c *models.Context, model *E) *lgo.OperationResult {
+	if validatable, ok := any(model).(interface{ ValidateForUpdate() error }); ok {
+		if err := validatable.ValidateForUpdate(); err != nil {
+			return mvc.NewValidationErrorFrom(err)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	id := P(model).GetId()
+	existing, ok := s.rows[id]
+	if !ok {
+		return mvc.NewNotFoundError(s.options.NotFound)
+	}
+
+	if s.options.Apply != nil {
+		s.options.Apply(&existing, model)
+	} else {
+		existing = *model
+	}
+	s.rows[id] = existing
+	return lgo.NewSuccess(&existing)
+}
+
+// #endregion Update
+
+// #region Modify
+// Modify, kaydı Apply ve doğrulama olmadan yerinde değiştirir. Update ile değiştirilemeyen
+// alanları (örn. arşivlenme zamanı) yazan depo işlemleri içindir.
+func (s *Store[E, K, P]) Modify(id K, change func(existing *E)) *lgo.OperationResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, ok := s.rows[id]
+	if !ok {
+		return mvc.NewNotFoundError(s.options.NotFound)
+	}
+	change(&existing)
+	s.rows[id] = existing
+	return lgo.NewSuccess(&existing)
+}
+
+// #endregion Modify
+
+// #region Delete
+func (s *Store[E, K, P]) Delete(c *models.Context, id K) *lgo.OperationResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.rows[id]; !ok {
+		return mvc.NewNotFoundError(s.options.NotFound)
+	}
+	delete(s.rows, id)
+	for i, key := range s.order {
+		if key == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// #endregion Delete
+
+// #region Get By Id
+func (s *Store[E, K, P]) GetById(c *models.Context, id K) *lgo.OperationResult {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	row, ok := s.rows[id]
+	if !ok {
+		return mvc.NewNotFoundError(s.options.NotFound)
+	}
+	return lgo.NewSuccess(&row)
+}
+
+// #endregion Get By Id
+
+// #region Get All
+func (s *Store[E, K, P]) GetAll(c *models.Context, query *mvc.QueryModel) *lgo.OperationResult {
+	return NewPagedResult(query, s.Find(nil))
+}
+
+// #endregion Get All
+
+// Find, match'e uyan kayıtların kopyalarını eklenme sırasıyla döndürür. match nil ise tüm
+// kayıtlar döner.
+func (s *Store[E, K, P]) Find(match func(model *E) bool) []*E {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	rows := []*E{}
+	for _, id := range s.order {
+		row := s.rows[id]
+		if match == nil || match(&row) {
+			rows = append(rows, &row)
+		}
+	}
+	return rows
+}
+
+// Count, match'e uyan kayıt sayısını döndürür
+func (s *Store[E, K, P]) Count(match func(model *E) bool) int64 {
+	return int64(len(s.Find(match)))
+}
+
+// NewPagedResult, satırları QueryModel'deki sayfa numarası ve sayfa boyutuna göre
+// repositories.NewPagedResult ile aynı zarfta döndürür. İmleçli sayfalama desteklenmez.
+func NewPagedResult[T any](query *mvc.QueryModel, rows []T) *lgo.OperationResult {
+	recordsPerPage := query.RecordsPerPage
+	if recordsPerPage <= 0 || recordsPerPage > mvc.MaxRecordsPerPage {
+		recordsPerPage = mvc.MaxRecordsPerPage
+	}
+	pageNumber := max(query.PageNumber, 1)
+
+	totalCount := len(rows)
+	start := min((pageNumber-1)*recordsPerPage, totalCount)
+	end := min(start+recordsPerPage, totalCount)
+
+	return lgo.NewSuccess(&mvc.PagedResult[T]{
+		Items:          append([]T{}, rows[start:end]...),
+		TotalCount:     int64(totalCount),
+		PageNumber:     query.PageNumber,
+		RecordsPerPage: recordsPerPage,
+		TotalPages:     int(math.Ceil(float64(totalCount) / float64(recordsPerPage))),
+		HasMore:        end < totalCount,
+	})
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+var _ repositories.SystemUserRepository = (*SystemUserRepository)(nil)
+
+// SystemUserRepository'de CheckForeignReferences, yalnızca Settings ve Timings verilmişse
+// bu depolardaki kayıtlara bakar
+type SystemUserRepository struct {
+	*Store[datamodels.SystemUser, uuid.UUID, *datamodels.SystemUser]
+	Settings *SystemUserSettingRepository
+	Timings  *TimingRepository
+}
+
+func NewSystemUserRepository() *SystemUserRepository {
+	return &SystemUserRepository{
+		Store: NewStore[datamodels.SystemUser, uuid.UUID](StoreOptions[datamodels.SystemUser, uuid.UUID]{
+			NotFound: i18n.SystemUserNotFound,
+			NewId:    uuid.New,
+			Apply: func(existing *datamodels.SystemUser, systemUser *datamodels.SystemUser) {
+				existing.Name = systemUser.Name
+				existing.Surname = systemUser.Surname
+				existing.Email = systemUser.Email
+				// Parola yalnızca yenisi verildiğinde değişir
+				if systemUser.Password != "" {
+					existing.Password = systemUser.Password
+					existing.PasswordSalt = systemUser.PasswordSalt
+				}
+				existing.IsActive = systemUser.IsActive
+			},
+		}),
+	}
+}
+
+// GetAll, gerçek depo gibi parola alanlarını döndürmez
+func (r *SystemUserRepository) GetAll(c *models.Context, query *mvc.QueryModel) *lgo.OperationResult {
+	systemUsers := r.Find(nil)
+	for _, systemUser := range systemUsers {
+		systemUser.Password = ""
+		systemUser.PasswordSalt = ""
+	}
+	return NewPagedResult(query, systemUsers)
+}
+
+func (r *SystemUserRepository) GetByEmail(c *models.Context, email string) *lgo.OperationResult {
+	if systemUser := r.findByEmail(email); systemUser != nil {
+		return lgo.NewSuccess(systemUser)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+func (r *SystemUserRepository) CheckForeignReferences(c *models.Context, systemUser *datamodels.SystemUser) *lgo.OperationResult {
+	if r.Settings != nil && r.Settings.Count(func(setting *datamodels.SystemUserSetting) bool {
+		return setting.SystemUserId == systemUser.Id
+	}) > 0 {
+		return mvc.NewConflictError(i18n.SystemUserHasSettings)
+	}
+	if r.Timings != nil && r.Timings.Count(func(timing *datamodels.Timing) bool {
+		return timing.SystemUserId == systemUser.Id
+	}) > 0 {
+		return mvc.NewConflictError(i18n.SystemUserHasTimings)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+func (r *SystemUserRepository) CheckExistingSystemUser(c *models.Context, systemUser *datamodels.SystemUser) *lgo.OperationResult {
+	if existing := r.findByEmail(systemUser.Email); existing != nil {
+		return lgo.NewSuccess(existing)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+func (r *SystemUserRepository) findByEmail(email string) *datamodels.SystemUser {
+	systemUsers := r.Find(func(systemUser *datamodels.SystemUser) bool {
+		return systemUser.Email == email
+	})
+	if len(systemUsers) == 0 {
+		return nil
+	}
+	return systemUsers[0]
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+var _ repositories.SystemUserSettingRepository = (*SystemUserSettingRepository)(nil)
+
+// SystemUserSettingRepository'de ayarlar, gerçek depo gibi kullanıcı ve anahtar çiftine göre
+// Set ile eklenir veya güncellenir
+type SystemUserSettingRepository struct {
+	*Store[datamodels.SystemUserSetting, int, *datamodels.SystemUserSetting]
+}
+
+func NewSystemUserSettingRepository() *SystemUserSettingRepository {
+	return &SystemUserSettingRepository{
+		Store: NewStore[datamodels.SystemUserSetting, int](StoreOptions[datamodels.SystemUserSetting, int]{
+			NotFound: i18n.SettingNotFound,
+			NewId:    IntSequence(),
+		}),
+	}
+}
+
+func (r *SystemUserSettingRepository) GetByUserId(c *models.Context, systemUserId uuid.UUID) *lgo.OperationResult {
+	return lgo.NewSuccess(r.Find(func(setting *datamodels.SystemUserSetting) bool {
+		return setting.SystemUserId == systemUserId
+	}))
+}
+
+func (r *SystemUserSettingRepository) Set(c *models.Context, setting *datamodels.SystemUserSetting) *lgo.OperationResult {
+	if existing := r.find(setting.SystemUserId, setting.Key); existing != nil {
+		existing.Value = setting.Value
+		return r.Update(c, existing)
+	}
+	return r.Create(c, setting)
+}
+
+func (r *SystemUserSettingRepository) GetValue(c *models.Context, systemUserId uuid.UUID, key string) *lgo.OperationResult {
+	if existing := r.find(systemUserId, key); existing != nil {
+		return lgo.NewSuccess(existing)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// Grant, kullanıcıya verilen yetkileri "1" değeriyle ekler. Testlerde oturum açmış
+// kullanıcının yetkilerini hazırlamak içindir.
+func (r *SystemUserSettingRepository) Grant(systemUserId uuid.UUID, keys ...string) {
+	for _, key := range keys {
+		r.Set(nil, &datamodels.SystemUserSetting{SystemUserId: systemUserId, Key: key, Value: "1"})
+	}
+}
+
+func (r *SystemUserSettingRepository) find(systemUserId uuid.UUID, key string) *datamodels.SystemUserSetting {
+	settings := r.Find(func(setting *datamodels.SystemUserSetting) bool {
+		return setting.SystemUserId == systemUserId && setting.Key == key
+	})
+	if len(settings) == 0 {
+		return nil
+	}
+	return settings[0]
+}
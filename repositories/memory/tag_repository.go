@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"slices"
+	"strings"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+var _ repositories.TagRepository = (*TagRepository)(nil)
+
+// TagRepository, etiket silindiğinde zaman kayıtlarındaki bağlantıları kaldırmaz
+// (gerçek veritabanında ON DELETE CASCADE)
+type TagRepository struct {
+	*Store[datamodels.Tag, int, *datamodels.Tag]
+}
+
+func NewTagRepository() *TagRepository {
+	return &TagRepository{
+		Store: NewStore[datamodels.Tag, int](StoreOptions[datamodels.Tag, int]{
+			NotFound: i18n.TagNotFound,
+			NewId:    IntSequence(),
+			Apply: func(existing *datamodels.Tag, tag *datamodels.Tag) {
+				existing.Name = tag.Name
+				existing.Color = tag.Color
+				existing.Description = tag.Description
+			},
+		}),
+	}
+}
+
+func (r *TagRepository) GetByName(c *models.Context, name string) *lgo.OperationResult {
+	tags := r.Find(func(tag *datamodels.Tag) bool {
+		return strings.EqualFold(tag.Name, name)
+	})
+	if len(tags) == 0 {
+		return mvc.NewNotFoundError(i18n.TagNotFound)
+	}
+	return lgo.NewSuccess(tags[0])
+}
+
+func (r *TagRepository) GetByIds(c *models.Context, ids []int) *lgo.OperationResult {
+	tags := r.Find(func(tag *datamodels.Tag) bool {
+		return slices.Contains(ids, tag.Id)
+	})
+	slices.SortFunc(tags, func(a, b *datamodels.Tag) int { return strings.Compare(a.Name, b.Name) })
+	return lgo.NewSuccess(tags)
+}
@@ -0,0 +1,269 @@
+package memory
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+var _ repositories.TimingRepository = (*TimingRepository)(nil)
+
+// TimingRepository'de GetAll, gerçek depo gibi TimingViewModel döndürür. Müşteri ve proje
+// adları yalnızca Projects ve Clients, görev adları Tasks, etiketler Tags verilmişse
+// doldurulur; SumHours'taki tutar da Projects ve Members'taki saatlik ücretlerle hesaplanır.
+type TimingRepository struct {
+	*Store[datamodels.Timing, int, *datamodels.Timing]
+	Projects *ClientProjectRepository
+	Clients  *ClientRepository
+	Members  *ClientProjectMemberRepository
+	Tasks    *ProjectTaskRepository
+	Tags     *TagRepository
+
+	tagMutex sync.RWMutex
+	tagIds   map[int][]int // Kayıt kimliğine göre bağlı etiket kimlikleri
+}
+
+func NewTimingRepository() *TimingRepository {
+	return &TimingRepository{
+		Store: NewStore[datamodels.Timing, int](StoreOptions[datamodels.Timing, int]{
+			NotFound: i18n.TimingNotFound,
+			NewId:    IntSequence(),
+			Apply: func(existing *datamodels.Timing, timing *datamodels.Timing) {
+				existing.Title = timing.Title
+				existing.Description = timing.Description
+				existing.StartDateTime = timing.StartDateTime
+				existing.EndDateTime = timing.EndDateTime
+				existing.Status = timing.Status
+				existing.TaskId = timing.TaskId
+			},
+		}),
+		tagIds: map[int][]int{},
+	}
+}
+
+// #region Get All Timings
+func (r *TimingRepository) GetAll(c *models.Context, query *mvc.QueryModel) *lgo.OperationResult {
+	timings := []mvc.TimingViewModel{}
+	for _, timing := range r.Find(nil) {
+		view := mvc.TimingViewModel{
+			Id:              timing.Id,
+			ClientProjectId: timing.ClientProjectId,
+			SystemUserId:    timing.SystemUserId,
+			TaskId:          timing.TaskId,
+			Title:           timing.Title,
+			Description:     timing.Description,
+			StartDateTime:   timing.StartDateTime,
+			EndDateTime:     timing.EndDateTime,
+			Status:          timing.Status.String(),
+		}
+		if r.Projects != nil {
+			if result := r.Projects.GetById(c, timing.ClientProjectId); result.IsSuccess() {
+				project := result.ReturnObject.(*datamodels.ClientProject)
+				view.ClientId = project.ClientId
+				view.ClientProject = project.Name
+			}
+		}
+		if r.Tasks != nil && timing.TaskId != nil {
+			if result := r.Tasks.GetById(c, *timing.TaskId); result.IsSuccess() {
+				view.Task = result.ReturnObject.(*datamodels.ProjectTask).Name
+			}
+		}
+		if r.Clients != nil && view.ClientId > 0 {
+			if result := r.Clients.GetById(c, view.ClientId); result.IsSuccess() {
+				view.Client = result.ReturnObject.(*datamodels.Client).Title
+			}
+		}
+		view.Tags = []mvc.TagViewModel{}
+		for _, tag := range r.tags(c, timing.Id) {
+			view.Tags = append(view.Tags, mvc.TagViewModel{Id: tag.Id, Name: tag.Name, Color: tag.Color})
+		}
+		timings = append(timings, view)
+	}
+	return NewPagedResult(query, timings)
+}
+
+//#endregion Get All Timings
+
+func (r *TimingRepository) GetByClientProjectId(c *models.Context, clientProjectId int) *lgo.OperationResult {
+	return lgo.NewSuccess(r.Find(func(timing *datamodels.Timing) bool {
+		return timing.ClientProjectId == clientProjectId
+	}))
+}
+
+func (r *TimingRepository) GetByDateRange(c *models.Context, startDate time.Time, endDate time.Time) *lgo.OperationResult {
+	return lgo.NewSuccess(r.Find(func(timing *datamodels.Timing) bool {
+		return !timing.StartDateTime.Before(startDate) && !timing.EndDateTime.After(endDate)
+	}))
+}
+
+func (r *TimingRepository) CountByStatus(c *models.Context, status enum.StatusEnum) *lgo.OperationResult {
+	return lgo.NewSuccess(r.Count(func(timing *datamodels.Timing) bool {
+		return timing.Status == status
+	}))
+}
+
+func (r *TimingRepository) SumHours(c *models.Context, clientProjectId int, from *time.Time, to *time.Time) *lgo.OperationResult {
+	hours := &mvc.TimingHours{}
+	for _, timing := range r.Find(func(timing *datamodels.Timing) bool {
+		return timing.ClientProjectId == clientProjectId &&
+			(from == nil || !timing.StartDateTime.Before(*from)) &&
+			(to == nil || timing.StartDateTime.Before(*to))
+	}) {
+		duration := timing.EndDateTime.Sub(timing.StartDateTime).Hours()
+		hours.Hours += duration
+		hours.Amount += duration * r.hourlyRate(c, timing)
+		if hours.FirstStartDateTime == nil || timing.StartDateTime.Before(*hours.FirstStartDateTime) {
+			start := timing.StartDateTime
+			hours.FirstStartDateTime = &start
+		}
+	}
+	return lgo.NewSuccess(hours)
+}
+
+// SumHoursByTask, Tasks verilmemişse yalnızca göreve bağlanmamış kayıtları döndürür
+func (r *TimingRepository) SumHoursByTask(c *models.Context, clientProjectId int, from *time.Time, to *time.Time) *lgo.OperationResult {
+	hours := map[int]float64{}
+	unassigned := 0.0
+	for _, timing := range r.Find(func(timing *datamodels.Timing) bool {
+		return timing.ClientProjectId == clientProjectId &&
+			(from == nil || !timing.StartDateTime.Before(*from)) &&
+			(to == nil || timing.StartDateTime.Before(*to))
+	}) {
+		duration := timing.EndDateTime.Sub(timing.StartDateTime).Hours()
+		if timing.TaskId == nil {
+			unassigned += duration
+		} else {
+			hours[*timing.TaskId] += duration
+		}
+	}
+
+	tasks := []*mvc.TaskHours{}
+	if r.Tasks != nil {
+		projectTasks := r.Tasks.Find(func(task *datamodels.ProjectTask) bool {
+			return task.ClientProjectId == clientProjectId
+		})
+		slices.SortFunc(projectTasks, func(a, b *datamodels.ProjectTask) int { return strings.Compare(a.Name, b.Name) })
+		for _, task := range projectTasks {
+			tasks = append(tasks, &mvc.TaskHours{
+				TaskId:        &task.Id,
+				Task:          task.Name,
+				Status:        int(task.Status),
+				EstimateHours: task.EstimateHours,
+				Hours:         hours[task.Id],
+			})
+		}
+	}
+	if unassigned > 0 {
+		tasks = append(tasks, &mvc.TaskHours{Hours: unassigned})
+	}
+	return lgo.NewSuccess(tasks)
+}
+
+// SumHoursByTag, clientProjectId sıfırsa tüm projelerin kayıtlarını sayar
+func (r *TimingRepository) SumHoursByTag(c *models.Context, clientProjectId int, from *time.Time, to *time.Time) *lgo.OperationResult {
+	byTag := map[int]*mvc.TagHours{}
+	untagged := &mvc.TagHours{}
+	for _, timing := range r.Find(func(timing *datamodels.Timing) bool {
+		return (clientProjectId == 0 || timing.ClientProjectId == clientProjectId) &&
+			(from == nil || !timing.StartDateTime.Before(*from)) &&
+			(to == nil || timing.StartDateTime.Before(*to))
+	}) {
+		duration := timing.EndDateTime.Sub(timing.StartDateTime).Hours()
+		tags := r.tags(c, timing.Id)
+		if len(tags) == 0 {
+			untagged.Timings++
+			untagged.Hours += duration
+		}
+		for _, tag := range tags {
+			if byTag[tag.Id] == nil {
+				byTag[tag.Id] = &mvc.TagHours{}
+			}
+			byTag[tag.Id].Timings++
+			byTag[tag.Id].Hours += duration
+		}
+	}
+
+	rows := []*mvc.TagHours{}
+	if r.Tags != nil {
+		tags := r.Tags.Find(nil)
+		slices.SortFunc(tags, func(a, b *datamodels.Tag) int { return strings.Compare(a.Name, b.Name) })
+		for _, tag := range tags {
+			row := &mvc.TagHours{TagId: &tag.Id, Tag: tag.Name, Color: tag.Color}
+			if hours := byTag[tag.Id]; hours != nil {
+				row.Timings, row.Hours = hours.Timings, hours.Hours
+			}
+			rows = append(rows, row)
+		}
+	}
+	if untagged.Timings > 0 {
+		rows = append(rows, untagged)
+	}
+	return lgo.NewSuccess(rows)
+}
+
+// Delete, gerçek depo gibi kaydın etiket bağlantılarını da siler
+func (r *TimingRepository) Delete(c *models.Context, id int) *lgo.OperationResult {
+	result := r.Store.Delete(c, id)
+	if result.IsSuccess() {
+		r.tagMutex.Lock()
+		delete(r.tagIds, id)
+		r.tagMutex.Unlock()
+	}
+	return result
+}
+
+func (r *TimingRepository) GetTags(c *models.Context, timingId int) *lgo.OperationResult {
+	return lgo.NewSuccess(r.tags(c, timingId))
+}
+
+func (r *TimingRepository) SetTags(c *models.Context, timingId int, tagIds []int) *lgo.OperationResult {
+	r.tagMutex.Lock()
+	defer r.tagMutex.Unlock()
+	r.tagIds[timingId] = slices.Clone(tagIds)
+	return lgo.NewSuccess(nil)
+}
+
+// tagCount, kayda bağlı etiket sayısını döndürür
+func (r *TimingRepository) tagCount(timingId int) int {
+	r.tagMutex.RLock()
+	defer r.tagMutex.RUnlock()
+	return len(r.tagIds[timingId])
+}
+
+// tags, kayda bağlı etiketleri ada göre sıralı döndürür; Tags verilmemişse boştur
+func (r *TimingRepository) tags(c *models.Context, timingId int) []*datamodels.Tag {
+	r.tagMutex.RLock()
+	ids := r.tagIds[timingId]
+	r.tagMutex.RUnlock()
+	if r.Tags == nil || len(ids) == 0 {
+		return []*datamodels.Tag{}
+	}
+	return r.Tags.GetByIds(c, ids).ReturnObject.([]*datamodels.Tag)
+}
+
+// hourlyRate, kaydı giren üyenin saatlik ücretini, yoksa projenin ücretini döndürür
+func (r *TimingRepository) hourlyRate(c *models.Context, timing *datamodels.Timing) float64 {
+	if r.Members != nil {
+		if result := r.Members.GetByMember(c, timing.ClientProjectId, timing.SystemUserId); result.IsSuccess() {
+			if rate := result.ReturnObject.(*datamodels.ClientProjectMember).HourlyRate; rate != nil {
+				return *rate
+			}
+		}
+	}
+	if r.Projects != nil {
+		if result := r.Projects.GetById(c, timing.ClientProjectId); result.IsSuccess() {
+			return result.ReturnObject.(*datamodels.ClientProject).HourlyRate
+		}
+	}
+	return 0
+}
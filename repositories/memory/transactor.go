@@ -0,0 +1,18 @@
+package memory
+
+import (
+	"lms-web-services-main/models"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+var _ repositories.Transactor = (*Transactor)(nil)
+
+// Transactor, fn'i doğrudan çalıştırır. Bellekteki depolar işlem desteklemediği için fn
+// başarısız olursa o ana kadar yapılan değişiklikler geri alınmaz.
+type Transactor struct{}
+
+func (Transactor) Transaction(c *models.Context, fn func(tx *models.Context) *lgo.OperationResult) *lgo.OperationResult {
+	return fn(c)
+}
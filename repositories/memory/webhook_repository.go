@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+var (
+	_ repositories.WebhookRepository         = (*WebhookRepository)(nil)
+	_ repositories.WebhookDeliveryRepository = (*WebhookDeliveryRepository)(nil)
+)
+
+// #region Webhook Repository
+
+// WebhookRepository, abonelik silindiğinde gönderimlerini silmez (gerçek veritabanında
+// ON DELETE CASCADE)
+type WebhookRepository struct {
+	*Store[datamodels.WebhookSubscription, int, *datamodels.WebhookSubscription]
+}
+
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{
+		Store: NewStore[datamodels.WebhookSubscription, int](StoreOptions[datamodels.WebhookSubscription, int]{
+			NotFound: i18n.WebhookNotFound,
+			NewId:    IntSequence(),
+			Apply:    repositories.ApplyWebhookSubscription,
+		}),
+	}
+}
+
+func (r *WebhookRepository) GetActiveByEventType(c *models.Context, eventType string) *lgo.OperationResult {
+	return lgo.NewSuccess(r.Find(func(subscription *datamodels.WebhookSubscription) bool {
+		return subscription.IsActive && subscription.Subscribes(eventType)
+	}))
+}
+
+//#endregion Webhook Repository
+
+// #region Webhook Delivery Repository
+
+// WebhookDeliveryRepository, Claim'de kayıtları kilitlemez; testlerde tek işçi çalışır.
+// GetBySubscriptionId filtre uygulamaz.
+type WebhookDeliveryRepository struct {
+	*Store[datamodels.WebhookDelivery, int64, *datamodels.WebhookDelivery]
+}
+
+func NewWebhookDeliveryRepository() *WebhookDeliveryRepository {
+	next := IntSequence()
+	return &WebhookDeliveryRepository{
+		Store: NewStore[datamodels.WebhookDelivery, int64](StoreOptions[datamodels.WebhookDelivery, int64]{
+			NotFound: i18n.DeliveryNotFound,
+			NewId:    func() int64 { return int64(next()) },
+		}),
+	}
+}
+
+func (r *WebhookDeliveryRepository) Add(c *models.Context, delivery *datamodels.WebhookDelivery) *lgo.OperationResult {
+	if delivery.RedeliveryOf == nil && r.Count(func(existing *datamodels.WebhookDelivery) bool {
+		return existing.RedeliveryOf == nil && existing.SubscriptionId == delivery.SubscriptionId && existing.EventId == delivery.EventId
+	}) > 0 {
+		return lgo.NewSuccess(delivery)
+	}
+	return r.Create(c, delivery)
+}
+
+func (r *WebhookDeliveryRepository) GetBySubscriptionId(c *models.Context, subscriptionId int, query *mvc.QueryModel) *lgo.OperationResult {
+	deliveries := r.Find(func(delivery *datamodels.WebhookDelivery) bool {
+		return delivery.SubscriptionId == subscriptionId
+	})
+	slices.SortFunc(deliveries, func(a, b *datamodels.WebhookDelivery) int { return cmp.Compare(b.Id, a.Id) })
+	return NewPagedResult(query, deliveries)
+}
+
+func (r *WebhookDeliveryRepository) Claim(c *models.Context, now time.Time, lease time.Duration, limit int) *lgo.OperationResult {
+	due := r.Find(func(delivery *datamodels.WebhookDelivery) bool {
+		return delivery.Status == enum.DeliveryStatusPending && delivery.NextAttemptAt != nil && !delivery.NextAttemptAt.After(now)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	leaseUntil := now.Add(lease)
+	deliveries := []*datamodels.WebhookDelivery{}
+	for _, delivery := range due {
+		result := r.Modify(delivery.Id, func(existing *datamodels.WebhookDelivery) {
+			existing.Attempts++
+			existing.NextAttemptAt = &leaseUntil
+		})
+		deliveries = append(deliveries, result.ReturnObject.(*datamodels.WebhookDelivery))
+	}
+	return lgo.NewSuccess(deliveries)
+}
+
+func (r *WebhookDeliveryRepository) MarkDelivered(c *models.Context, id int64, responseStatus int, deliveredAt time.Time) *lgo.OperationResult {
+	r.Modify(id, func(existing *datamodels.WebhookDelivery) {
+		existing.Status = enum.DeliveryStatusDelivered
+		existing.ResponseStatus = responseStatus
+		existing.LastError = ""
+		existing.LastAttemptAt = &deliveredAt
+		existing.DeliveredAt = &deliveredAt
+		existing.NextAttemptAt = nil
+	})
+	return lgo.NewSuccess(nil)
+}
+
+func (r *WebhookDeliveryRepository) MarkFailed(c *models.Context, id int64, responseStatus int, lastError string, attemptedAt time.Time, nextAttemptAt *time.Time) *lgo.OperationResult {
+	r.Modify(id, func(existing *datamodels.WebhookDelivery) {
+		existing.ResponseStatus = responseStatus
+		existing.LastError = lastError
+		existing.LastAttemptAt = &attemptedAt
+		existing.NextAttemptAt = nextAttemptAt
+		if nextAttemptAt == nil {
+			existing.Status = enum.DeliveryStatusFailed
+		}
+	})
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Webhook Delivery Repository
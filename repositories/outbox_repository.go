@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"gorm.io/gorm"
+)
+
+// OutboxRepository, alan olaylarının giden kutusudur. Add, Context bir işlem taşıyorsa olayı
+// o işlemle birlikte yazar; diğer metotlar dağıtıcı tarafından kullanılır.
+type OutboxRepository interface {
+	Add(c *models.Context, event *datamodels.OutboxEvent) *lgo.OperationResult
+	// Claim, zamanı gelmiş en fazla limit olayı sırayla döndürür. Dönen olayların deneme
+	// sayısı artırılır ve lease süresince başka bir dağıtıcıya verilmez; bu sürede
+	// MarkDispatched veya MarkFailed çağrılmazsa olay yeniden dağıtılır.
+	Claim(c *models.Context, now time.Time, lease time.Duration, limit int) *lgo.OperationResult
+	MarkDispatched(c *models.Context, id int64, dispatchedAt time.Time) *lgo.OperationResult
+	// MarkFailed, hatayı kaydeder ve olayı nextAttemptAt'te yeniden denenmek üzere bırakır.
+	// nextAttemptAt nil ise olay bir daha denenmez.
+	MarkFailed(c *models.Context, id int64, lastError string, nextAttemptAt *time.Time) *lgo.OperationResult
+}
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// outboxClaimQuery, zamanı gelmiş olayları kilitleyerek seçer; başka bir dağıtıcının
+// kilitlediği satırlar atlanır
+const outboxClaimQuery = `
+UPDATE "OutboxEvents" SET "Attempts" = "Attempts" + 1, "NextAttemptAt" = ?
+WHERE "Id" IN (
+    SELECT "Id" FROM "OutboxEvents"
+    WHERE "DispatchedAt" IS NULL AND "NextAttemptAt" <= ?
+    ORDER BY "Id"
+    LIMIT ?
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// #region Add
+func (r *outboxRepository) Add(c *models.Context, event *datamodels.OutboxEvent) *lgo.OperationResult {
+	if err := conn(c, r.db).Create(event).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(event)
+}
+
+//#endregion Add
+
+// #region Claim
+func (r *outboxRepository) Claim(c *models.Context, now time.Time, lease time.Duration, limit int) *lgo.OperationResult {
+	var events []*datamodels.OutboxEvent
+	if err := conn(c, r.db).Raw(outboxClaimQuery, now.Add(lease), now, limit).Scan(&events).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+
+	// RETURNING satırları sırasız döndürür
+	slices.SortFunc(events, func(a, b *datamodels.OutboxEvent) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return lgo.NewSuccess(events)
+}
+
+//#endregion Claim
+
+// #region Mark Dispatched
+func (r *outboxRepository) MarkDispatched(c *models.Context, id int64, dispatchedAt time.Time) *lgo.OperationResult {
+	err := conn(c, r.db).Model(&datamodels.OutboxEvent{}).Where("\"Id\" = ?", id).
+		Updates(map[string]any{"DispatchedAt": dispatchedAt, "NextAttemptAt": nil, "LastError": ""}).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Mark Dispatched
+
+// #region Mark Failed
+func (r *outboxRepository) MarkFailed(c *models.Context, id int64, lastError string, nextAttemptAt *time.Time) *lgo.OperationResult {
+	err := conn(c, r.db).Model(&datamodels.OutboxEvent{}).Where("\"Id\" = ?", id).
+		Updates(map[string]any{"LastError": lastError, "NextAttemptAt": nextAttemptAt}).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Mark Failed
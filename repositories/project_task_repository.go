@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"strconv"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"gorm.io/gorm"
+)
+
+type ProjectTaskRepository interface {
+	CrudRepository[datamodels.ProjectTask, int]
+	GetByClientProjectId(c *models.Context, clientProjectId int) *lgo.OperationResult
+}
+
+// projectTaskQuerySchema, GetAll'da filtrelenebilen ve sıralanabilen alanlardır
+var projectTaskQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `"Id"`, Type: QueryFieldInt},
+	QueryField{Name: "cpid", Alias: "ClientProjectId", Column: `"ClientProjectId"`, Type: QueryFieldInt},
+	QueryField{Name: "n", Alias: "Name", Column: `"Name"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "st", Alias: "Status", Column: `"Status"`, Parse: parseTaskStatus},
+).WithDefaultSorting("n", false)
+
+// parseTaskStatus, durum filtresinde sayısal değeri veya durum adını ("Done") kabul eder
+func parseTaskStatus(value string) (any, error) {
+	if status, err := strconv.Atoi(value); err == nil && enum.TaskStatusEnum(status).IsValid() {
+		return status, nil
+	}
+	status, err := enum.ParseTaskStatus(value)
+	if err != nil {
+		return nil, err
+	}
+	return int(status), nil
+}
+
+type projectTaskRepository struct {
+	CrudRepository[datamodels.ProjectTask, int]
+	db *gorm.DB
+}
+
+func NewProjectTaskRepository(db *gorm.DB) ProjectTaskRepository {
+	return &projectTaskRepository{
+		CrudRepository: NewCrudRepository[datamodels.ProjectTask, int](db, CrudOptions[datamodels.ProjectTask]{
+			NotFound: i18n.TaskNotFound,
+			Schema:   projectTaskQuerySchema,
+			Apply: func(existing *datamodels.ProjectTask, task *datamodels.ProjectTask) {
+				existing.Name = task.Name
+				existing.Description = task.Description
+				existing.EstimateHours = task.EstimateHours
+				existing.Status = task.Status
+			},
+		}),
+		db: db,
+	}
+}
+
+// #region Get Tasks By ClientProjectId
+func (r *projectTaskRepository) GetByClientProjectId(c *models.Context, clientProjectId int) *lgo.OperationResult {
+	var tasks []*datamodels.ProjectTask
+	result := conn(c, r.db).Where("\"ClientProjectId\" = ?", clientProjectId).Order("\"Name\" ASC").Find(&tasks)
+	if result.Error != nil {
+		return mvc.NewDatabaseError(result.Error)
+	}
+	return lgo.NewSuccess(tasks)
+}
+
+// #endregion Get Tasks By ClientProjectId
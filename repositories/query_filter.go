@@ -0,0 +1,382 @@
+package repositories
+
+import (
+	"strings"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+)
+
+// Filtre ifadesi sınırları; kötü niyetli ya da hatalı istemcilerin aşırı büyük
+// sorgular üretmesini engeller
+const (
+	maxFilterLength     = 2000
+	maxFilterConditions = 20
+	maxFilterDepth      = 5
+	maxFilterInValues   = 100
+)
+
+// #region Filter Tokenizer
+
+type filterTokenKind int
+
+const (
+	filterTokenWord filterTokenKind = iota
+	filterTokenString
+	filterTokenOperator
+	filterTokenLeftParen
+	filterTokenRightParen
+	filterTokenComma
+	filterTokenEnd
+)
+
+type filterToken struct {
+	kind  filterTokenKind
+	text  string
+	index int
+}
+
+// isKeyword, tırnaksız bir kelimenin verilen anahtar kelime olup olmadığını kontrol eder
+func (t filterToken) isKeyword(keyword string) bool {
+	return t.kind == filterTokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func tokenizeFilter(input string) ([]filterToken, *lgo.OperationResult) {
+	var tokens []filterToken
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: filterTokenLeftParen, text: "(", index: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: filterTokenRightParen, text: ")", index: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, filterToken{kind: filterTokenComma, text: ",", index: i})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			start := i
+			i++
+			if i < len(runes) && (runes[i] == '=' || (r == '<' && runes[i] == '>')) {
+				i++
+			}
+			operator := string(runes[start:i])
+			if operator == "!" {
+				return nil, filterSyntaxError(start, i18n.New(i18n.FilterUseNotEquals))
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenOperator, text: operator, index: start})
+		case r == '\'' || r == '"':
+			// Tırnak karakteri iki kez yazılarak kaçırılır: 'O''Brien'
+			start := i
+			var value strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, filterSyntaxError(start, i18n.New(i18n.FilterUnclosedQuote))
+				}
+				if runes[i] == r {
+					if i+1 < len(runes) && runes[i+1] == r {
+						value.WriteRune(r)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				value.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenString, text: value.String(), index: start})
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n\r(),=!<>'\"", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenWord, text: string(runes[start:i]), index: start})
+		}
+	}
+
+	return append(tokens, filterToken{kind: filterTokenEnd, index: len(runes)}), lgo.NewSuccess(nil)
+}
+
+// #endregion Filter Tokenizer
+
+// #region Filter Parser
+
+// filterParser, aşağıdaki dilbilgisini parametreli bir SQL koşuluna çevirir:
+//
+//	expr      := and ( OR and )*
+//	and       := unary ( AND unary )*
+//	unary     := '(' expr ')' | condition
+//	condition := field ( = | != | <> | < | <= | > | >= ) value
+//	           | field [NOT] IN '(' value ( ',' value )* ')'
+//	           | field [NOT] BETWEEN value AND value
+//	           | field [NOT] LIKE value
+//	           | field IS [NOT] NULL
+//
+// Alan adları şemadan çözülür ve değerler her zaman parametre olarak bağlanır.
+type filterParser struct {
+	schema     *QuerySchema
+	tokens     []filterToken
+	position   int
+	depth      int
+	conditions int
+	args       []any
+}
+
+// parseFilter, filtre ifadesini "WHERE" koşuluna ve parametrelerine çevirir
+func parseFilter(input string, schema *QuerySchema) (string, []any, *lgo.OperationResult) {
+	if len([]rune(input)) > maxFilterLength {
+		return "", nil, mvc.NewLogicError(i18n.FilterTooLong, maxFilterLength)
+	}
+
+	tokens, result := tokenizeFilter(input)
+	if !result.IsSuccess() {
+		return "", nil, result
+	}
+
+	p := &filterParser{schema: schema, tokens: tokens}
+	clause, result := p.parseOr()
+	if !result.IsSuccess() {
+		return "", nil, result
+	}
+	if next := p.peek(); next.kind != filterTokenEnd {
+		return "", nil, filterSyntaxError(next.index, i18n.New(i18n.FilterUnexpectedToken, next.text))
+	}
+	return clause, p.args, lgo.NewSuccess(nil)
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.position]
+}
+
+func (p *filterParser) next() filterToken {
+	token := p.tokens[p.position]
+	if token.kind != filterTokenEnd {
+		p.position++
+	}
+	return token
+}
+
+func (p *filterParser) parseOr() (string, *lgo.OperationResult) {
+	clause, result := p.parseAnd()
+	if !result.IsSuccess() {
+		return "", result
+	}
+
+	parts := []string{clause}
+	for p.peek().isKeyword("OR") {
+		p.next()
+		clause, result = p.parseAnd()
+		if !result.IsSuccess() {
+			return "", result
+		}
+		parts = append(parts, clause)
+	}
+	return strings.Join(parts, " OR "), lgo.NewSuccess(nil)
+}
+
+func (p *filterParser) parseAnd() (string, *lgo.OperationResult) {
+	clause, result := p.parseUnary()
+	if !result.IsSuccess() {
+		return "", result
+	}
+
+	parts := []string{clause}
+	for p.peek().isKeyword("AND") {
+		p.next()
+		clause, result = p.parseUnary()
+		if !result.IsSuccess() {
+			return "", result
+		}
+		parts = append(parts, clause)
+	}
+	return strings.Join(parts, " AND "), lgo.NewSuccess(nil)
+}
+
+func (p *filterParser) parseUnary() (string, *lgo.OperationResult) {
+	if p.peek().kind != filterTokenLeftParen {
+		return p.parseCondition()
+	}
+
+	open := p.next()
+	p.depth++
+	if p.depth > maxFilterDepth {
+		return "", mvc.NewLogicError(i18n.FilterTooDeep, maxFilterDepth)
+	}
+
+	clause, result := p.parseOr()
+	if !result.IsSuccess() {
+		return "", result
+	}
+	if p.next().kind != filterTokenRightParen {
+		return "", filterSyntaxError(open.index, i18n.New(i18n.FilterUnclosedParen))
+	}
+	p.depth--
+	return "(" + clause + ")", lgo.NewSuccess(nil)
+}
+
+func (p *filterParser) parseCondition() (string, *lgo.OperationResult) {
+	p.conditions++
+	if p.conditions > maxFilterConditions {
+		return "", mvc.NewLogicError(i18n.FilterTooManyConditions, maxFilterConditions)
+	}
+
+	fieldToken := p.next()
+	if fieldToken.kind != filterTokenWord {
+		return "", filterSyntaxError(fieldToken.index, i18n.New(i18n.FilterFieldExpected))
+	}
+	field, result := p.schema.Field(fieldToken.text)
+	if !result.IsSuccess() {
+		return "", result
+	}
+
+	clause, result := p.parseFieldCondition(field)
+	if !result.IsSuccess() || field.Exists == "" {
+		return clause, result
+	}
+	return strings.Replace(field.Exists, "%s", clause, 1), result
+}
+
+func (p *filterParser) parseFieldCondition(field *QueryField) (string, *lgo.OperationResult) {
+	operator := p.next()
+	switch {
+	case operator.kind == filterTokenOperator:
+		return p.parseComparison(field, operator)
+	case operator.isKeyword("IS"):
+		negate := p.peek().isKeyword("NOT")
+		if negate {
+			p.next()
+		}
+		if null := p.next(); !null.isKeyword("NULL") {
+			return "", filterSyntaxError(null.index, i18n.New(i18n.FilterNullExpected))
+		}
+		if negate {
+			return field.Column + " IS NOT NULL", lgo.NewSuccess(nil)
+		}
+		return field.Column + " IS NULL", lgo.NewSuccess(nil)
+	}
+
+	negate := operator.isKeyword("NOT")
+	if negate {
+		operator = p.next()
+	}
+	prefix := field.Column + " "
+	if negate {
+		prefix += "NOT "
+	}
+
+	switch {
+	case operator.isKeyword("IN"):
+		return p.parseIn(field, prefix)
+	case operator.isKeyword("BETWEEN"):
+		return p.parseBetween(field, prefix)
+	case operator.isKeyword("LIKE"):
+		if field.Type != QueryFieldString || field.Parse != nil {
+			return "", mvc.NewLogicError(i18n.FilterLikeRequiresText, field.Name)
+		}
+		value, result := p.parseValue(field)
+		if !result.IsSuccess() {
+			return "", result
+		}
+		p.args = append(p.args, value)
+		return prefix + "LIKE ?", lgo.NewSuccess(nil)
+	}
+
+	return "", filterSyntaxError(operator.index, i18n.New(i18n.FilterOperatorExpected, field.Name))
+}
+
+func (p *filterParser) parseComparison(field *QueryField, operator filterToken) (string, *lgo.OperationResult) {
+	sqlOperator := operator.text
+	switch sqlOperator {
+	case "=", "<>":
+	case "!=":
+		sqlOperator = "<>"
+	case "<", "<=", ">", ">=":
+		if field.Type == QueryFieldBool || field.Type == QueryFieldUUID {
+			return "", mvc.NewLogicError(i18n.FilterOperatorNotAllowed, operator.text, field.Name)
+		}
+	default:
+		return "", filterSyntaxError(operator.index, i18n.New(i18n.FilterUnknownOperator, operator.text))
+	}
+
+	value, result := p.parseValue(field)
+	if !result.IsSuccess() {
+		return "", result
+	}
+	p.args = append(p.args, value)
+	return field.Column + " " + sqlOperator + " ?", lgo.NewSuccess(nil)
+}
+
+func (p *filterParser) parseIn(field *QueryField, prefix string) (string, *lgo.OperationResult) {
+	open := p.next()
+	if open.kind != filterTokenLeftParen {
+		return "", filterSyntaxError(open.index, i18n.New(i18n.FilterInParenExpected))
+	}
+
+	var values []any
+	for {
+		value, result := p.parseValue(field)
+		if !result.IsSuccess() {
+			return "", result
+		}
+		values = append(values, value)
+		if len(values) > maxFilterInValues {
+			return "", mvc.NewLogicError(i18n.FilterTooManyValues, maxFilterInValues)
+		}
+
+		separator := p.next()
+		if separator.kind == filterTokenRightParen {
+			break
+		}
+		if separator.kind != filterTokenComma {
+			return "", filterSyntaxError(separator.index, i18n.New(i18n.FilterInSeparatorExpected))
+		}
+	}
+
+	p.args = append(p.args, values)
+	return prefix + "IN ?", lgo.NewSuccess(nil)
+}
+
+func (p *filterParser) parseBetween(field *QueryField, prefix string) (string, *lgo.OperationResult) {
+	if field.Type == QueryFieldBool || field.Type == QueryFieldUUID {
+		return "", mvc.NewLogicError(i18n.FilterOperatorNotAllowed, "between", field.Name)
+	}
+
+	low, result := p.parseValue(field)
+	if !result.IsSuccess() {
+		return "", result
+	}
+	if and := p.next(); !and.isKeyword("AND") {
+		return "", filterSyntaxError(and.index, i18n.New(i18n.FilterBetweenAndExpected))
+	}
+	high, result := p.parseValue(field)
+	if !result.IsSuccess() {
+		return "", result
+	}
+
+	p.args = append(p.args, low, high)
+	return prefix + "BETWEEN ? AND ?", lgo.NewSuccess(nil)
+}
+
+func (p *filterParser) parseValue(field *QueryField) (any, *lgo.OperationResult) {
+	token := p.next()
+	if token.kind != filterTokenWord && token.kind != filterTokenString {
+		return nil, filterSyntaxError(token.index, i18n.New(i18n.FilterValueExpected, field.Name))
+	}
+	return field.Value(token.text)
+}
+
+// filterSyntaxError, hatanın ifadedeki konumunu (1'den başlayarak) ve ayrıntısını taşır
+func filterSyntaxError(index int, message *i18n.Message) *lgo.OperationResult {
+	return mvc.NewLogicError(i18n.FilterSyntax, index+1, message)
+}
+
+// #endregion Filter Parser
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+// QueryFieldType, filtre değerlerinin hangi Go türüne çevrileceğini belirler
+type QueryFieldType int
+
+const (
+	QueryFieldString QueryFieldType = iota
+	QueryFieldInt
+	QueryFieldBool
+	QueryFieldTime
+	QueryFieldUUID
+)
+
+// QueryField, API'de kullanılan bir alan adını tırnaklı veritabanı sütununa eşler.
+// Yalnızca şemada tanımlı alanlar filtrelenebilir, sıralanabilir ve aranabilir.
+type QueryField struct {
+	Name       string         // API adı (JSON etiketi), örn. "t"
+	Alias      string         // Sonuç satırındaki Go alan adı, örn. "Title"; ikinci ad olarak da kabul edilir
+	Names      []string       // Geriye dönük uyumluluk için kabul edilen diğer adlar, örn. "t"
+	Column     string         // SQL'e yazılacak tırnaklı sütun, örn. `"Title"` veya `t."Title"`
+	Type       QueryFieldType // Filtre değerinin türü
+	Searchable bool           // SearchTerm ile ILIKE araması yapılır
+	Nullable   bool           // Sütun NULL olabilir; bu alanla sıralanan sorgularda imleç üretilmez
+
+	// Exists, alan kaydın bağlı satırlarındaysa (örn. etiketler) filtre koşulunu saran alt
+	// sorgudur; "%s" koşulla değiştirilir. Koşul en az bir bağlı satırda aranır. Bu alanlar
+	// sıralanamaz ve aranamaz.
+	Exists string
+
+	// Parse, Type yerine kullanılacak özel dönüştürücüdür (örn. enum adları)
+	Parse func(value string) (any, error)
+}
+
+// QuerySchema, bir varlığın sorgulanabilir alanlarını ve varsayılan sıralamasını tutar
+type QuerySchema struct {
+	fields         map[string]*QueryField
+	names          []string
+	searchable     []*QueryField
+	defaultSorting []*mvc.DataSortingOptionItem
+}
+
+// NewQuerySchema, verilen alanlardan bir şema oluşturur. Alan adları büyük/küçük harf
+// duyarsız eşleştirilir.
+func NewQuerySchema(fields ...QueryField) *QuerySchema {
+	schema := &QuerySchema{fields: map[string]*QueryField{}}
+	for i := range fields {
+		field := &fields[i]
+		schema.fields[strings.ToLower(field.Name)] = field
+		if field.Alias != "" {
+			schema.fields[strings.ToLower(field.Alias)] = field
+		}
+		for _, name := range field.Names {
+			schema.fields[strings.ToLower(name)] = field
+		}
+		schema.names = append(schema.names, field.Name)
+		if field.Searchable {
+			schema.searchable = append(schema.searchable, field)
+		}
+	}
+	sort.Strings(schema.names)
+	return schema
+}
+
+// WithDefaultSorting, sıralama belirtilmediğinde kullanılacak alanı tanımlar
+func (s *QuerySchema) WithDefaultSorting(name string, descending bool) *QuerySchema {
+	item := &mvc.DataSortingOptionItem{ColumnName: name}
+	if descending {
+		item.Sorting = 1
+	}
+	s.defaultSorting = append(s.defaultSorting, item)
+	return s
+}
+
+// Field, API adına karşılık gelen alanı döndürür. Eski istemcilerin gönderdiği
+// tırnaklı adlar ("\"Title\"") da kabul edilir.
+func (s *QuerySchema) Field(name string) (*QueryField, *lgo.OperationResult) {
+	key := strings.ToLower(strings.Trim(strings.TrimSpace(name), `"`))
+	field, ok := s.fields[key]
+	if !ok {
+		return nil, mvc.NewLogicError(i18n.QueryUnknownField, name, strings.Join(s.names, ", "))
+	}
+	return field, lgo.NewSuccess(nil)
+}
+
+// Value, ham filtre değerini alanın türüne çevirir
+func (f *QueryField) Value(raw string) (any, *lgo.OperationResult) {
+	var (
+		value any
+		err   error
+	)
+
+	switch {
+	case f.Parse != nil:
+		value, err = f.Parse(raw)
+	case f.Type == QueryFieldInt:
+		value, err = strconv.ParseInt(raw, 10, 64)
+	case f.Type == QueryFieldBool:
+		value, err = strconv.ParseBool(raw)
+	case f.Type == QueryFieldTime:
+		value, err = parseQueryTime(raw)
+	case f.Type == QueryFieldUUID:
+		value, err = uuid.Parse(raw)
+	default:
+		value = raw
+	}
+
+	if err != nil {
+		return nil, mvc.NewLogicError(i18n.QueryInvalidValue, f.Name, raw)
+	}
+	return value, lgo.NewSuccess(nil)
+}
+
+func parseQueryTime(raw string) (time.Time, error) {
+	if value, err := time.Parse(time.RFC3339, raw); err == nil {
+		return value, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
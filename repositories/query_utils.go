@@ -1,58 +1,306 @@
 package repositories
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models/mvc"
 
 	"github.com/LGYtech/lgo"
 	"gorm.io/gorm"
 )
 
-// ApplyQueryModel: Pagination, Sorting, Filtering ve Searching işlemlerini uygular
-func ApplyQueryModel(db *gorm.DB, query *mvc.QueryModel, searchableColumns []string, defaultSorting *mvc.DataSortingOptionItem) (*gorm.DB, *lgo.OperationResult) {
-	// Pagination
-	if query.PageNumber > 0 && query.RecordsPerPage > 0 {
-		db = db.Offset(query.GetSkip()).Limit(query.RecordsPerPage)
+// likeEscaper, arama teriminde geçen LIKE joker karakterlerini düz karakter olarak aratır
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// QueryPage, ApplyQueryModel'in hesapladığı sayfalama bilgisidir; sorgu çalıştırıldıktan
+// sonra NewPagedResult ile satırlardan PagedResult üretir
+type QueryPage struct {
+	pageNumber     int
+	recordsPerPage int
+	totalCount     int64
+	keys           []sortKey
+	cursorEnabled  bool
+}
+
+// sortKey, sıralamaya katılan bir alan ve yönüdür
+type sortKey struct {
+	field      *QueryField
+	descending bool
+}
+
+// queryCursor, bir sayfanın son satırındaki sıralama değerlerini taşır. Signature,
+// imlecin üretildiği sıralamayla kullanıldığını doğrulamak içindir.
+type queryCursor struct {
+	Signature string   `json:"s"`
+	Values    []string `json:"v"`
+}
+
+// applyArchivedFilter, "IsActive" sütunu olan müşteri ve proje sorgularını query.Archived'a
+// göre daraltır. Koşul istemcinin filtre ifadesine eklenmez, ayrı bir Where olarak verilir;
+// toplam kayıt sayısına da yansıması için ApplyQueryModel'den önce çağrılmalıdır.
+func applyArchivedFilter(db *gorm.DB, query *mvc.QueryModel) *gorm.DB {
+	if isActive, restricted := query.Archived.IsActive(); restricted {
+		return db.Where("\"IsActive\" = ?", isActive)
 	}
+	return db
+}
 
-	// Sorting
-	if len(query.SortingOptions) > 0 {
-		for _, sortOption := range query.SortingOptions {
-			db = db.Order(sortOption.ToGormOrderString())
+// ApplyQueryModel: Filtering ve Searching uygular, filtreye uyan toplam kayıt sayısını
+// hesaplar, ardından Sorting ve Pagination uygular. Alan adları yalnızca schema üzerinden
+// sütunlara çevrilir; istemciden gelen hiçbir ad doğrudan SQL'e yazılmaz. db, sayım
+// yapılabilmesi için Model veya Table ile hazırlanmış olmalıdır.
+func ApplyQueryModel(db *gorm.DB, query *mvc.QueryModel, schema *QuerySchema) (*gorm.DB, *QueryPage, *lgo.OperationResult) {
+	// Filter Expression
+	if strings.TrimSpace(query.Filter) != "" {
+		clause, args, result := parseFilter(query.Filter, schema)
+		if !result.IsSuccess() {
+			return nil, nil, result
 		}
-	} else if defaultSorting != nil {
-		// Varsayılan sıralamayı uygula
-		db = db.Order(defaultSorting.ToGormOrderString())
+		db = db.Where("("+clause+")", args...)
 	}
 
-	// Dynamic Filter (Single Filter String)
-	if query.Filter != "" {
-		parts := strings.Split(query.Filter, "=")
-		if len(parts) == 2 {
-			field := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			db = db.Where(field+" = ?", value)
+	// Searching
+	db, result := applySearch(db, query, schema)
+	if !result.IsSuccess() {
+		return nil, nil, result
+	}
+
+	// Total Count
+	page := &QueryPage{pageNumber: query.PageNumber, recordsPerPage: query.RecordsPerPage}
+	if page.recordsPerPage <= 0 || page.recordsPerPage > mvc.MaxRecordsPerPage {
+		page.recordsPerPage = mvc.MaxRecordsPerPage
+	}
+	if err := db.Session(&gorm.Session{}).Count(&page.totalCount).Error; err != nil {
+		return nil, nil, lgo.NewFailureWithError(err)
+	}
+
+	// Sorting
+	page.keys, result = resolveSortKeys(query, schema)
+	if !result.IsSuccess() {
+		return nil, nil, result
+	}
+	page.cursorEnabled = true
+	for _, key := range page.keys {
+		if key.field.Nullable || key.field.Alias == "" {
+			page.cursorEnabled = false
+		}
+		if key.descending {
+			db = db.Order(key.field.Column + " DESC")
 		} else {
-			return nil, lgo.NewLogicError("Geçersiz filtre formatı. Doğru format: 'field=value'", nil)
+			db = db.Order(key.field.Column + " ASC")
 		}
 	}
 
-	// Searching
-	if query.SearchTerm != "" && len(searchableColumns) > 0 {
-		var searchQuery strings.Builder
-		var searchParams []interface{}
+	// Pagination
+	if query.Cursor != "" {
+		if !page.cursorEnabled {
+			return nil, nil, mvc.NewLogicError(i18n.QueryCursorNotSupported)
+		}
+		clause, args, result := page.cursorCondition(query.Cursor)
+		if !result.IsSuccess() {
+			return nil, nil, result
+		}
+		db = db.Where(clause, args...)
+		page.pageNumber = 0
+	} else {
+		if page.pageNumber < 1 {
+			page.pageNumber = 1
+		}
+		db = db.Offset((page.pageNumber - 1) * page.recordsPerPage)
+	}
+
+	// Sonraki sayfanın varlığını anlamak için bir kayıt fazla okunur
+	db = db.Limit(page.recordsPerPage + 1)
+
+	return db, page, lgo.NewSuccess(nil)
+}
+
+// NewPagedResult, ApplyQueryModel ile hazırlanmış sorgunun satırlarından sayfalı sonucu üretir
+func NewPagedResult[T any](page *QueryPage, rows []T) *lgo.OperationResult {
+	hasMore := len(rows) > page.recordsPerPage
+	if hasMore {
+		rows = rows[:page.recordsPerPage]
+	}
+	if rows == nil {
+		rows = []T{}
+	}
+
+	paged := &mvc.PagedResult[T]{
+		Items:          rows,
+		TotalCount:     page.totalCount,
+		PageNumber:     page.pageNumber,
+		RecordsPerPage: page.recordsPerPage,
+		TotalPages:     int(math.Ceil(float64(page.totalCount) / float64(page.recordsPerPage))),
+		HasMore:        hasMore,
+	}
+	if hasMore && page.cursorEnabled {
+		paged.NextCursor = page.encodeCursor(rows[len(rows)-1])
+	}
+	return lgo.NewSuccess(paged)
+}
+
+func applySearch(db *gorm.DB, query *mvc.QueryModel, schema *QuerySchema) (*gorm.DB, *lgo.OperationResult) {
+	if query.SearchTerm == "" {
+		return db, lgo.NewSuccess(nil)
+	}
+
+	searchFields := schema.searchable
+	if len(query.SearchFields) > 0 {
+		searchFields = nil
+		for _, name := range query.SearchFields {
+			field, result := schema.Field(name)
+			if !result.IsSuccess() {
+				return nil, result
+			}
+			if !field.Searchable {
+				return nil, mvc.NewLogicError(i18n.QueryFieldNotSearchable, name)
+			}
+			searchFields = append(searchFields, field)
+		}
+	}
+	if len(searchFields) == 0 {
+		return db, lgo.NewSuccess(nil)
+	}
 
-		for i, col := range searchableColumns {
-			if i > 0 {
-				searchQuery.WriteString(" OR ")
+	var searchQuery strings.Builder
+	var searchParams []interface{}
+	searchTerm := "%" + likeEscaper.Replace(query.SearchTerm) + "%"
+
+	for i, field := range searchFields {
+		if i > 0 {
+			searchQuery.WriteString(" OR ")
+		}
+		searchQuery.WriteString(field.Column + " ILIKE ?")
+		searchParams = append(searchParams, searchTerm)
+	}
+
+	return db.Where("("+searchQuery.String()+")", searchParams...), lgo.NewSuccess(nil)
+}
+
+// resolveSortKeys, istenen sıralamayı şema alanlarına çevirir ve sıralamanın kararlı
+// olması için sona "id" alanını ekler
+func resolveSortKeys(query *mvc.QueryModel, schema *QuerySchema) ([]sortKey, *lgo.OperationResult) {
+	sortingOptions := query.SortingOptions
+	if len(sortingOptions) == 0 {
+		// Varsayılan sıralamayı uygula
+		sortingOptions = schema.defaultSorting
+	}
+
+	var keys []sortKey
+	for _, sortOption := range sortingOptions {
+		field, result := schema.Field(sortOption.ColumnName)
+		if !result.IsSuccess() {
+			return nil, result
+		}
+		if field.Exists != "" {
+			return nil, mvc.NewLogicError(i18n.QueryFieldNotSortable, field.Name)
+		}
+		if sortOption.Sorting != 0 && sortOption.Sorting != 1 {
+			return nil, mvc.NewLogicError(i18n.QueryInvalidSortDirection, sortOption.Sorting)
+		}
+		keys = append(keys, sortKey{field: field, descending: sortOption.Sorting == 1})
+	}
+
+	if id, ok := schema.fields["id"]; ok {
+		for _, key := range keys {
+			if key.field == id {
+				return keys, lgo.NewSuccess(nil)
 			}
-			searchQuery.WriteString(col + " ILIKE ?")
-			searchParams = append(searchParams, "%"+query.SearchTerm+"%")
 		}
+		keys = append(keys, sortKey{field: id})
+	}
+	return keys, lgo.NewSuccess(nil)
+}
 
-		db = db.Where(searchQuery.String(), searchParams...)
+func (p *QueryPage) signature() string {
+	parts := make([]string, len(p.keys))
+	for i, key := range p.keys {
+		parts[i] = key.field.Name + ":" + strconv.FormatBool(key.descending)
 	}
+	return strings.Join(parts, ",")
+}
+
+// cursorCondition, imleçteki değerlerden sonraki satırları seçen koşulu üretir:
+// (a > ?) OR (a = ? AND b > ?) ... Azalan sıralamada karşılaştırma ters çevrilir.
+func (p *QueryPage) cursorCondition(encoded string) (string, []any, *lgo.OperationResult) {
+	invalid := mvc.NewLogicError(i18n.QueryInvalidCursor)
 
-	return db, lgo.NewSuccess(nil)
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, invalid
+	}
+	var cursor queryCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || len(cursor.Values) != len(p.keys) {
+		return "", nil, invalid
+	}
+	if cursor.Signature != p.signature() {
+		return "", nil, mvc.NewLogicError(i18n.QueryCursorSortMismatch)
+	}
+
+	values := make([]any, len(p.keys))
+	for i, key := range p.keys {
+		value, result := key.field.Value(cursor.Values[i])
+		if !result.IsSuccess() {
+			return "", nil, invalid
+		}
+		values[i] = value
+	}
+
+	var clauses []string
+	var args []any
+	for i, key := range p.keys {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, p.keys[j].field.Column+" = ?")
+			args = append(args, values[j])
+		}
+		operator := " > ?"
+		if key.descending {
+			operator = " < ?"
+		}
+		parts = append(parts, key.field.Column+operator)
+		args = append(args, values[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")", args, lgo.NewSuccess(nil)
+}
+
+// encodeCursor, satırdaki sıralama alanlarını okuyarak imleç üretir. Alanlar satırda
+// bulunamazsa imleç üretilmez.
+func (p *QueryPage) encodeCursor(row any) string {
+	value := reflect.Indirect(reflect.ValueOf(row))
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+
+	cursor := queryCursor{Signature: p.signature()}
+	for _, key := range p.keys {
+		field := value.FieldByName(key.field.Alias)
+		if !field.IsValid() {
+			return ""
+		}
+		switch v := field.Interface().(type) {
+		case time.Time:
+			cursor.Values = append(cursor.Values, v.Format(time.RFC3339Nano))
+		case fmt.Stringer:
+			cursor.Values = append(cursor.Values, v.String())
+		default:
+			cursor.Values = append(cursor.Values, fmt.Sprint(v))
+		}
+	}
+
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
 }
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"html"
+	"strings"
+
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"gorm.io/gorm"
+)
+
+type SearchRepository interface {
+	Search(c *models.Context, term string, types []string, limit int) *lgo.OperationResult
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// ts_headline işaretleri; metin HTML olarak kaçırıldıktan sonra <mark> etiketine çevrilir
+const (
+	searchHighlightStart = "⟦"
+	searchHighlightStop  = "⟧"
+)
+
+// searchQueries, her varlık türü için arama sorgusudur. Tüm parçalar aynı sütunları
+// döndürür ve "q" CTE'sindeki tsquery'yi kullanır.
+var searchQueries = map[string]string{
+	mvc.SearchTypeClient: `
+SELECT 'client' AS "Type", cl."Id", 0 AS "ParentId", '' AS "ParentTitle",
+       ts_headline('turkish', cl."Title", q.query, @title) AS "Title",
+       ts_headline('turkish', coalesce(cl."Notes", ''), q.query, @snippet) AS "Snippet",
+       ts_rank(cl."SearchVector", q.query) AS "Rank"
+FROM "Clients" AS cl, q
+WHERE cl."SearchVector" @@ q.query`,
+	mvc.SearchTypeClientProject: `
+SELECT 'client_project' AS "Type", cp."Id", cp."ClientId" AS "ParentId", cl."Title" AS "ParentTitle",
+       ts_headline('turkish', cp."Name", q.query, @title) AS "Title",
+       '' AS "Snippet",
+       ts_rank(cp."SearchVector", q.query) AS "Rank"
+FROM "ClientProjects" AS cp
+JOIN "Clients" AS cl ON cl."Id" = cp."ClientId", q
+WHERE cp."SearchVector" @@ q.query`,
+	mvc.SearchTypeTiming: `
+SELECT 'timing' AS "Type", t."Id", t."ClientProjectId" AS "ParentId", cp."Name" AS "ParentTitle",
+       ts_headline('turkish', t."Title", q.query, @title) AS "Title",
+       ts_headline('turkish', coalesce(t."Description", ''), q.query, @snippet) AS "Snippet",
+       ts_rank(t."SearchVector", q.query) AS "Rank"
+FROM "Timings" AS t
+JOIN "ClientProjects" AS cp ON cp."Id" = t."ClientProjectId", q
+WHERE t."SearchVector" @@ q.query`,
+}
+
+// #region Search
+func (r *searchRepository) Search(c *models.Context, term string, types []string, limit int) *lgo.OperationResult {
+	hits := []*mvc.SearchHit{}
+
+	var parts []string
+	for _, searchType := range types {
+		if query, ok := searchQueries[searchType]; ok {
+			parts = append(parts, query)
+		}
+	}
+	if len(parts) == 0 {
+		return lgo.NewSuccess(hits)
+	}
+
+	sql := `WITH q AS (SELECT websearch_to_tsquery('turkish', @term) AS query)` +
+		strings.Join(parts, "\nUNION ALL") +
+		`
+ORDER BY "Rank" DESC, "Type", "Id"
+LIMIT @limit`
+
+	options := "StartSel=" + searchHighlightStart + ", StopSel=" + searchHighlightStop
+	result := conn(c, r.db).Raw(sql, map[string]any{
+		"term":    term,
+		"title":   options + ", HighlightAll=true",
+		"snippet": options + ", MaxWords=25, MinWords=10, MaxFragments=2",
+		"limit":   limit,
+	}).Scan(&hits)
+	if result.Error != nil {
+		return lgo.NewFailureWithError(result.Error)
+	}
+
+	for _, hit := range hits {
+		hit.Title = highlightSearchText(hit.Title)
+		hit.Snippet = highlightSearchText(hit.Snippet)
+	}
+	return lgo.NewSuccess(hits)
+}
+
+// #endregion Search
+
+// highlightSearchText, kayıt içeriğini HTML olarak kaçırır ve ts_headline işaretlerini
+// <mark> etiketine çevirir
+func highlightSearchText(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, searchHighlightStart, "<mark>")
+	return strings.ReplaceAll(text, searchHighlightStop, "</mark>")
+}
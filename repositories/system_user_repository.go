@@ -3,6 +3,8 @@ package repositories
 import (
 	"errors"
 
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	"lms-web-services-main/models/mvc"
 
@@ -11,105 +13,60 @@ import (
 	"gorm.io/gorm"
 )
 
+// SystemUserRepository'de GetAll parola alanlarını okumaz
 type SystemUserRepository interface {
-	Create(systemUser *datamodels.SystemUser) *lgo.OperationResult
-	Update(systemUser *datamodels.SystemUser) *lgo.OperationResult
-	Delete(id uuid.UUID) *lgo.OperationResult
-	GetById(id uuid.UUID) *lgo.OperationResult
-	GetByEmail(email string) *lgo.OperationResult
-	GetAll(query *mvc.QueryModel) *lgo.OperationResult
-	CheckForeignReferences(systemUser *datamodels.SystemUser) *lgo.OperationResult
-	CheckExistingSystemUser(systemUser *datamodels.SystemUser) *lgo.OperationResult
+	CrudRepository[datamodels.SystemUser, uuid.UUID]
+	GetByEmail(c *models.Context, email string) *lgo.OperationResult
+	CheckForeignReferences(c *models.Context, systemUser *datamodels.SystemUser) *lgo.OperationResult
+	CheckExistingSystemUser(c *models.Context, systemUser *datamodels.SystemUser) *lgo.OperationResult
 }
 
+// systemUserQuerySchema, GetAll'da filtrelenebilen ve sıralanabilen alanlardır.
+// Parola alanları bilinçli olarak dışarıda bırakılmıştır.
+var systemUserQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `"Id"`, Type: QueryFieldUUID},
+	QueryField{Name: "n", Alias: "Name", Column: `"Name"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "sn", Alias: "Surname", Column: `"Surname"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "e", Alias: "Email", Column: `"Email"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "ia", Alias: "IsActive", Column: `"IsActive"`, Type: QueryFieldBool},
+).WithDefaultSorting("n", false)
+
 type systemUserRepository struct {
+	CrudRepository[datamodels.SystemUser, uuid.UUID]
 	db *gorm.DB
 }
 
 func NewSystemUserRepository(db *gorm.DB) SystemUserRepository {
-	return &systemUserRepository{db: db}
-}
-
-// #region Create SystemUser
-func (r *systemUserRepository) Create(systemUser *datamodels.SystemUser) *lgo.OperationResult {
-	result := r.db.Create(&systemUser)
-	if result.Error != nil {
-		return lgo.NewLogicError(result.Error.Error(), nil)
-	}
-	return lgo.NewSuccess(systemUser)
-}
-
-// #endregion Create SystemUser
-
-// #region Update SystemUser
-func (r *systemUserRepository) Update(systemUser *datamodels.SystemUser) *lgo.OperationResult {
-	existingUser := &datamodels.SystemUser{}
-	if err := r.db.First(&existingUser, systemUser.Id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Kullanıcı bulunamadı.", nil)
-		}
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	existingUser.Name = systemUser.Name
-	existingUser.Surname = systemUser.Surname
-	existingUser.Email = systemUser.Email
-	existingUser.Password = systemUser.Password
-	existingUser.PasswordSalt = systemUser.PasswordSalt
-	existingUser.IsActive = systemUser.IsActive
-
-	if err := r.db.Save(&existingUser).Error; err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
+	return &systemUserRepository{
+		CrudRepository: NewCrudRepository[datamodels.SystemUser, uuid.UUID](db, CrudOptions[datamodels.SystemUser]{
+			NotFound: i18n.SystemUserNotFound,
+			Schema:   systemUserQuerySchema,
+			Apply: func(existing *datamodels.SystemUser, systemUser *datamodels.SystemUser) {
+				existing.Name = systemUser.Name
+				existing.Surname = systemUser.Surname
+				existing.Email = systemUser.Email
+				// Parola yalnızca yenisi verildiğinde değişir
+				if systemUser.Password != "" {
+					existing.Password = systemUser.Password
+					existing.PasswordSalt = systemUser.PasswordSalt
+				}
+				existing.IsActive = systemUser.IsActive
+			},
+		}),
+		db: db,
 	}
-
-	return lgo.NewSuccess(existingUser)
 }
 
-// #endregion Update SystemUser
-
-// #region Delete SystemUser
-func (r *systemUserRepository) Delete(id uuid.UUID) *lgo.OperationResult {
-	existingUser := &datamodels.SystemUser{}
-	if err := r.db.First(&existingUser, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Kullanıcı bulunamadı.", nil)
-		}
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	if err := r.db.Delete(&existingUser).Error; err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	return lgo.NewSuccess(nil)
-}
-
-// #endregion Delete SystemUser
-
-// #region Get SystemUser By Id
-func (r *systemUserRepository) GetById(id uuid.UUID) *lgo.OperationResult {
-	systemUser := &datamodels.SystemUser{}
-	if err := r.db.First(&systemUser, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Kullanıcı bulunamadı.", nil)
-		}
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-	return lgo.NewSuccess(systemUser)
-}
-
-// #endregion Get SystemUser By Id
-
 // #region GetByEmail
-func (r *systemUserRepository) GetByEmail(email string) *lgo.OperationResult {
+func (r *systemUserRepository) GetByEmail(c *models.Context, email string) *lgo.OperationResult {
 	var systemUser *datamodels.SystemUser
 
-	result := r.db.Where("\"Email\" = ?", email).First(&systemUser)
+	result := conn(c, r.db).Where("\"Email\" = ?", email).First(&systemUser)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return lgo.NewSuccess(nil)
 		}
-		return lgo.NewLogicError(result.Error.Error(), nil)
+		return mvc.NewDatabaseError(result.Error)
 	}
 
 	return lgo.NewSuccess(systemUser)
@@ -118,48 +75,41 @@ func (r *systemUserRepository) GetByEmail(email string) *lgo.OperationResult {
 // #endregion GetByEmail
 
 // #region GetAll
-func (r *systemUserRepository) GetAll(query *mvc.QueryModel) *lgo.OperationResult {
+func (r *systemUserRepository) GetAll(c *models.Context, query *mvc.QueryModel) *lgo.OperationResult {
 	var systemUsers []*datamodels.SystemUser
 
-	defaultSorting := &mvc.DataSortingOptionItem{
-		ColumnName: "\"Name\"",
-		Sorting:    0,
-	}
-
-	searchableColums := []string{"\"Name\"", "\"Surname\"", "\"Email\""}
-
-	db, result := ApplyQueryModel(r.db, query, searchableColums, defaultSorting)
+	db, page, result := ApplyQueryModel(conn(c, r.db).Model(&datamodels.SystemUser{}), query, systemUserQuerySchema)
 	if !result.IsSuccess() {
-		return lgo.NewLogicError("Sorgu modeli uygulanırken bir hata oluştur", nil)
+		return result
 	}
 
-	queryResult := db.Select("Id, Name, Surname, Email, IsActive").Find(&systemUsers)
+	queryResult := db.Select(`"Id", "Name", "Surname", "Email", "IsActive"`).Find(&systemUsers)
 	if queryResult.Error != nil {
-		return lgo.NewLogicError(queryResult.Error.Error(), nil)
+		return mvc.NewDatabaseError(queryResult.Error)
 	}
-	return lgo.NewSuccess(systemUsers)
+	return NewPagedResult(page, systemUsers)
 }
 
 // #endregion GetAll
 
 // #region Check Foreign References
-func (r *systemUserRepository) CheckForeignReferences(systemUser *datamodels.SystemUser) *lgo.OperationResult {
+func (r *systemUserRepository) CheckForeignReferences(c *models.Context, systemUser *datamodels.SystemUser) *lgo.OperationResult {
 	var referenceCount int64
 
 	// Check references in SystemUserSetting
-	if err := r.db.Model(&datamodels.SystemUserSetting{}).Where("\"SystemUserId\"=?", systemUser.Id).Count(&referenceCount).Error; err != nil {
-		return lgo.NewLogicError("Error checking references in SystemUserSetting: "+err.Error(), nil)
+	if err := conn(c, r.db).Model(&datamodels.SystemUserSetting{}).Where("\"SystemUserId\"=?", systemUser.Id).Count(&referenceCount).Error; err != nil {
+		return mvc.NewDatabaseError(err)
 	}
 	if referenceCount > 0 {
-		return lgo.NewLogicError("SystemUser is referenced in SystemUserSetting.", nil)
+		return mvc.NewConflictError(i18n.SystemUserHasSettings)
 	}
 
 	// Check references in Timing
-	if err := r.db.Model(&datamodels.Timing{}).Where("\"SystemUserId\"=?", systemUser.Id).Count(&referenceCount).Error; err != nil {
-		return lgo.NewLogicError("Error checking references in Timing: "+err.Error(), nil)
+	if err := conn(c, r.db).Model(&datamodels.Timing{}).Where("\"SystemUserId\"=?", systemUser.Id).Count(&referenceCount).Error; err != nil {
+		return mvc.NewDatabaseError(err)
 	}
 	if referenceCount > 0 {
-		return lgo.NewLogicError("SystemUser is referenced in Timing.", nil)
+		return mvc.NewConflictError(i18n.SystemUserHasTimings)
 	}
 
 	return lgo.NewSuccess(nil)
@@ -168,13 +118,13 @@ func (r *systemUserRepository) CheckForeignReferences(systemUser *datamodels.Sys
 // #endregion Check Foreign References
 
 // #region Check Existing SystemUser
-func (r *systemUserRepository) CheckExistingSystemUser(systemUser *datamodels.SystemUser) *lgo.OperationResult {
+func (r *systemUserRepository) CheckExistingSystemUser(c *models.Context, systemUser *datamodels.SystemUser) *lgo.OperationResult {
 	existingUser := &datamodels.SystemUser{}
-	if err := r.db.Where("\"Email\" = ?", systemUser.Email).First(&existingUser).Error; err != nil {
+	if err := conn(c, r.db).Where("\"Email\" = ?", systemUser.Email).First(&existingUser).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return lgo.NewSuccess(nil)
 		}
-		return lgo.NewLogicError(err.Error(), nil)
+		return mvc.NewDatabaseError(err)
 	}
 	return lgo.NewSuccess(existingUser)
 }
@@ -3,64 +3,59 @@ package repositories
 import (
 	"errors"
 
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
 	"github.com/LGYtech/lgo"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
-
-	"lms-web-services-main/models"
-	datamodels "lms-web-services-main/models/data"
 )
 
 type SystemUserSettingRepository interface {
-	GetByUserId(systemUserId uuid.UUID) *lgo.OperationResult
-	GetById(id int) *lgo.OperationResult
-	Set(setting *datamodels.SystemUserSetting) *lgo.OperationResult
-	Delete(id int) *lgo.OperationResult
+	GetByUserId(c *models.Context, systemUserId uuid.UUID) *lgo.OperationResult
+	GetById(c *models.Context, id int) *lgo.OperationResult
+	Set(c *models.Context, setting *datamodels.SystemUserSetting) *lgo.OperationResult
+	Delete(c *models.Context, id int) *lgo.OperationResult
 	GetValue(c *models.Context, systemUserId uuid.UUID, key string) *lgo.OperationResult
 }
 
+// systemUserSettingRepository, GetById ve Delete için genel depoyu kullanır. Ayarlar
+// kullanıcı ve anahtar çiftine göre Set ile eklenir veya güncellenir.
 type systemUserSettingRepository struct {
+	CrudRepository[datamodels.SystemUserSetting, int]
 	db *gorm.DB
 }
 
 func NewSystemUserSettingRepository(db *gorm.DB) SystemUserSettingRepository {
-	return &systemUserSettingRepository{db: db}
+	return &systemUserSettingRepository{
+		CrudRepository: NewCrudRepository[datamodels.SystemUserSetting, int](db, CrudOptions[datamodels.SystemUserSetting]{
+			NotFound: i18n.SettingNotFound,
+		}),
+		db: db,
+	}
 }
 
 // #region GetByUserId
-func (r *systemUserSettingRepository) GetByUserId(systemUserId uuid.UUID) *lgo.OperationResult {
+func (r *systemUserSettingRepository) GetByUserId(c *models.Context, systemUserId uuid.UUID) *lgo.OperationResult {
 	var settings []*datamodels.SystemUserSetting
-	result := r.db.Where("\"SystemUserId\" = ?", systemUserId).Find(&settings)
+	result := conn(c, r.db).Where("\"SystemUserId\" = ?", systemUserId).Find(&settings)
 	if result.Error != nil {
-		return lgo.NewLogicError(result.Error.Error(), nil)
+		return mvc.NewDatabaseError(result.Error)
 	}
 	return lgo.NewSuccess(settings)
 }
 
 // #endregion GetByUserId
 
-// #region GetById
-func (r *systemUserSettingRepository) GetById(id int) *lgo.OperationResult {
-	var setting datamodels.SystemUserSetting
-	result := r.db.First(&setting, id)
-	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Kayıt bulunamadı.", nil)
-		}
-		return lgo.NewLogicError(result.Error.Error(), nil)
-	}
-	return lgo.NewSuccess(&setting)
-}
-
-// #endregion GetById
-
 // #region Set
-func (r *systemUserSettingRepository) Set(setting *datamodels.SystemUserSetting) *lgo.OperationResult {
+func (r *systemUserSettingRepository) Set(c *models.Context, setting *datamodels.SystemUserSetting) *lgo.OperationResult {
 	var existingSetting datamodels.SystemUserSetting
-	result := r.db.Where("\"SystemUserId\" = ? AND \"Key\" = ?", setting.SystemUserId, setting.Key).First(&existingSetting)
+	result := conn(c, r.db).Where("\"SystemUserId\" = ? AND \"Key\" = ?", setting.SystemUserId, setting.Key).First(&existingSetting)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			if err := r.db.Create(setting).Error; err != nil {
+			if err := conn(c, r.db).Create(setting).Error; err != nil {
 				return lgo.NewFailureWithError(err)
 			}
 			return lgo.NewSuccess(setting)
@@ -69,36 +64,22 @@ func (r *systemUserSettingRepository) Set(setting *datamodels.SystemUserSetting)
 	}
 
 	existingSetting.Value = setting.Value
-	saveResult := r.db.Save(&existingSetting)
+	saveResult := conn(c, r.db).Save(&existingSetting)
 	if saveResult.Error != nil {
 		return lgo.NewFailureWithError(saveResult.Error)
 	}
 	if saveResult.RowsAffected == 0 {
-		return lgo.NewLogicError("Kayıt güncellenemedi.", nil)
+		return mvc.NewLogicError(i18n.SettingUpdateFailed)
 	}
 	return lgo.NewSuccess(existingSetting)
 }
 
 // #endregion Set
 
-// #region Delete
-func (r *systemUserSettingRepository) Delete(id int) *lgo.OperationResult {
-	result := r.db.Delete(&datamodels.SystemUserSetting{}, id)
-	if result.Error != nil {
-		return lgo.NewFailureWithError(result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return lgo.NewLogicError("Kayıt bulunamadı", nil)
-	}
-	return lgo.NewSuccess(nil)
-}
-
-// #endregion Delete
-
 // #region GetValue
 func (r *systemUserSettingRepository) GetValue(c *models.Context, systemUserId uuid.UUID, key string) *lgo.OperationResult {
 	var systemUserSetting datamodels.SystemUserSetting
-	result := r.db.Where("\"SystemUserId\" = ? AND \"Key\" = ?", systemUserId, key).First(&systemUserSetting)
+	result := conn(c, r.db).Where("\"SystemUserId\" = ? AND \"Key\" = ?", systemUserId, key).First(&systemUserSetting)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return lgo.NewSuccess(nil)
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"errors"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"gorm.io/gorm"
+)
+
+type TagRepository interface {
+	CrudRepository[datamodels.Tag, int]
+	// GetByName, adı büyük/küçük harf duyarsız eşleşen etiketi döndürür
+	GetByName(c *models.Context, name string) *lgo.OperationResult
+	// GetByIds, verilen kimliklerden kayıtlı olanları ada göre sıralı döndürür
+	GetByIds(c *models.Context, ids []int) *lgo.OperationResult
+}
+
+// tagQuerySchema, GetAll'da filtrelenebilen ve sıralanabilen alanlardır
+var tagQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `"Id"`, Type: QueryFieldInt},
+	QueryField{Name: "n", Alias: "Name", Column: `"Name"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "c", Alias: "Color", Column: `"Color"`, Type: QueryFieldString},
+	QueryField{Name: "desc", Alias: "Description", Column: `"Description"`, Type: QueryFieldString, Searchable: true},
+).WithDefaultSorting("n", false)
+
+type tagRepository struct {
+	CrudRepository[datamodels.Tag, int]
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{
+		CrudRepository: NewCrudRepository[datamodels.Tag, int](db, CrudOptions[datamodels.Tag]{
+			NotFound: i18n.TagNotFound,
+			Schema:   tagQuerySchema,
+			Apply: func(existing *datamodels.Tag, tag *datamodels.Tag) {
+				existing.Name = tag.Name
+				existing.Color = tag.Color
+				existing.Description = tag.Description
+			},
+		}),
+		db: db,
+	}
+}
+
+// #region Get Tag By Name
+func (r *tagRepository) GetByName(c *models.Context, name string) *lgo.OperationResult {
+	var tag datamodels.Tag
+	err := conn(c, r.db).Where("LOWER(\"Name\") = LOWER(?)", name).First(&tag).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return mvc.NewNotFoundError(i18n.TagNotFound)
+	}
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(&tag)
+}
+
+// #endregion Get Tag By Name
+
+// #region Get Tags By Ids
+func (r *tagRepository) GetByIds(c *models.Context, ids []int) *lgo.OperationResult {
+	tags := []*datamodels.Tag{}
+	if len(ids) == 0 {
+		return lgo.NewSuccess(tags)
+	}
+	if err := conn(c, r.db).Where("\"Id\" IN ?", ids).Order("\"Name\" ASC").Find(&tags).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(tags)
+}
+
+// #endregion Get Tags By Ids
@@ -1,155 +1,184 @@
 package repositories
 
 import (
-	"errors"
+	"strconv"
 	"time"
 
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
 	"lms-web-services-main/models/mvc"
 
 	"github.com/LGYtech/lgo"
 	"gorm.io/gorm"
 )
 
+// TimingRepository'de GetAll, kayıtları müşteri ve proje adlarıyla birlikte
+// TimingViewModel olarak döndürür
 type TimingRepository interface {
-	Create(timing *datamodels.Timing) *lgo.OperationResult
-	Update(timing *datamodels.Timing) *lgo.OperationResult
-	Delete(id int) *lgo.OperationResult
-	GetById(id int) *lgo.OperationResult
-	GetAll(query *mvc.QueryModel) *lgo.OperationResult
-	GetByClientProjectId(clientProjectId int) *lgo.OperationResult
-	GetByDateRange(startDate time.Time, endDate time.Time) *lgo.OperationResult
+	CrudRepository[datamodels.Timing, int]
+	GetByClientProjectId(c *models.Context, clientProjectId int) *lgo.OperationResult
+	GetByDateRange(c *models.Context, startDate time.Time, endDate time.Time) *lgo.OperationResult
+	CountByStatus(c *models.Context, status enum.StatusEnum) *lgo.OperationResult
+	SumHours(c *models.Context, clientProjectId int, from *time.Time, to *time.Time) *lgo.OperationResult
+	SumHoursByTask(c *models.Context, clientProjectId int, from *time.Time, to *time.Time) *lgo.OperationResult
+	SumHoursByTag(c *models.Context, clientProjectId int, from *time.Time, to *time.Time) *lgo.OperationResult
+	// GetTags, kayda bağlı etiketleri ada göre sıralı []*datamodels.Tag olarak döndürür
+	GetTags(c *models.Context, timingId int) *lgo.OperationResult
+	// SetTags, kaydın etiketlerini verilenlerle tek işlemde değiştirir
+	SetTags(c *models.Context, timingId int, tagIds []int) *lgo.OperationResult
 }
 
-type timingRepository struct {
-	db *gorm.DB
-}
+// timingQuerySchema, GetAll'da filtrelenebilen ve sıralanabilen alanlardır. Sorgu
+// Clients ve ClientProjects ile birleştirildiği için sütunlar tablo takma adıyla yazılır.
+// Etiket alanları yalnızca filtrede kullanılır: "tag_id in (1, 2)" etiketlerden herhangi
+// birini, "tag = 'Toplantı' and tag = 'Destek'" ikisini birden taşıyan kayıtları seçer.
+var timingQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `t."Id"`, Type: QueryFieldInt},
+	QueryField{Name: "cpid", Alias: "ClientProjectId", Column: `t."ClientProjectId"`, Type: QueryFieldInt},
+	QueryField{Name: "cid", Alias: "ClientId", Column: `cp."ClientId"`, Type: QueryFieldInt},
+	QueryField{Name: "suid", Alias: "SystemUserId", Column: `t."SystemUserId"`, Type: QueryFieldUUID},
+	QueryField{Name: "title", Alias: "Title", Names: []string{"t"}, Column: `t."Title"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "description", Alias: "Description", Names: []string{"desc"}, Column: `t."Description"`, Type: QueryFieldString, Searchable: true, Nullable: true},
+	QueryField{Name: "start_date_time", Alias: "StartDateTime", Names: []string{"sdt"}, Column: `t."StartDateTime"`, Type: QueryFieldTime},
+	QueryField{Name: "end_date_time", Alias: "EndDateTime", Names: []string{"edt"}, Column: `t."EndDateTime"`, Type: QueryFieldTime},
+	QueryField{Name: "status", Alias: "Status", Names: []string{"st"}, Column: `t."Status"`, Parse: parseTimingStatus},
+	QueryField{Name: "client", Alias: "Client", Column: `c."Title"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "client_project", Alias: "ClientProject", Column: `cp."Name"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "task_id", Alias: "TaskId", Column: `t."TaskId"`, Type: QueryFieldInt, Nullable: true},
+	QueryField{Name: "task", Alias: "Task", Column: `pt."Name"`, Type: QueryFieldString, Searchable: true, Nullable: true},
+	QueryField{Name: "tag_id", Alias: "TagId", Column: `tt."TagId"`, Type: QueryFieldInt,
+		Exists: `EXISTS (SELECT 1 FROM "TimingTags" AS tt WHERE tt."TimingId" = t."Id" AND %s)`},
+	QueryField{Name: "tag", Alias: "Tag", Column: `tg."Name"`, Type: QueryFieldString,
+		Exists: `EXISTS (SELECT 1 FROM "TimingTags" AS tt JOIN "Tags" AS tg ON tg."Id" = tt."TagId" WHERE tt."TimingId" = t."Id" AND %s)`},
+).WithDefaultSorting("title", false)
 
-func NewTimingRepository(db *gorm.DB) TimingRepository {
-	return &timingRepository{db: db}
-}
-
-// #region Create Timing
-func (r *timingRepository) Create(timing *datamodels.Timing) *lgo.OperationResult {
-	if err := timing.Validate(); err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
+// parseTimingStatus, durum filtresinde sayısal değeri veya durum adını ("Started") kabul eder
+func parseTimingStatus(value string) (any, error) {
+	if status, err := strconv.Atoi(value); err == nil && enum.StatusEnum(status).IsValid() {
+		return status, nil
 	}
-
-	result := r.db.Create(&timing)
-	if result.Error != nil {
-		return lgo.NewLogicError(result.Error.Error(), nil)
+	status, err := enum.ParseStatus(value)
+	if err != nil {
+		return nil, err
 	}
-	return lgo.NewSuccess(timing)
+	return int(status), nil
 }
 
-// #endregion Create Timing
-
-// #region Update Timing
-func (r *timingRepository) Update(timing *datamodels.Timing) *lgo.OperationResult {
-	if err := timing.ValidateForUpdate(); err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	existingTiming := &datamodels.Timing{}
-	if err := r.db.First(&existingTiming, timing.Id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Timing not found.", nil)
-		}
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	existingTiming.Title = timing.Title
-	existingTiming.Description = timing.Description
-	existingTiming.StartDateTime = timing.StartDateTime
-	existingTiming.EndDateTime = timing.EndDateTime
-	existingTiming.Status = timing.Status
-
-	if err := r.db.Save(&existingTiming).Error; err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-	return lgo.NewSuccess(existingTiming)
-}
-
-// #endregion Update Timing
-
-// #region Delete Timing
-func (r *timingRepository) Delete(id int) *lgo.OperationResult {
-	timing := &datamodels.Timing{}
-	if err := r.db.First(&timing, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Timing not found.", nil)
-		}
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	if err := r.db.Delete(&timing).Error; err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-	return lgo.NewSuccess(nil)
+type timingRepository struct {
+	CrudRepository[datamodels.Timing, int]
+	db         *gorm.DB
+	transactor Transactor
 }
 
-// #endregion Delete Timing
-
-// #region Get Timing By Id
-func (r *timingRepository) GetById(id int) *lgo.OperationResult {
-	timing := &datamodels.Timing{}
-	if err := r.db.First(&timing, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return lgo.NewLogicError("Timing not found.", nil)
-		}
-		return lgo.NewLogicError(err.Error(), nil)
+func NewTimingRepository(db *gorm.DB) TimingRepository {
+	return &timingRepository{
+		CrudRepository: NewCrudRepository[datamodels.Timing, int](db, CrudOptions[datamodels.Timing]{
+			NotFound: i18n.TimingNotFound,
+			Schema:   timingQuerySchema,
+			Apply: func(existing *datamodels.Timing, timing *datamodels.Timing) {
+				existing.Title = timing.Title
+				existing.Description = timing.Description
+				existing.StartDateTime = timing.StartDateTime
+				existing.EndDateTime = timing.EndDateTime
+				existing.Status = timing.Status
+				existing.TaskId = timing.TaskId
+			},
+		}),
+		db:         db,
+		transactor: NewTransactor(db),
 	}
-	return lgo.NewSuccess(timing)
 }
 
-// #endregion Get Timing By Id
-
 // #region Get All Timings
-func (r *timingRepository) GetAll(query *mvc.QueryModel) *lgo.OperationResult {
+func (r *timingRepository) GetAll(c *models.Context, query *mvc.QueryModel) *lgo.OperationResult {
 	var timings []mvc.TimingViewModel
 
-	defaultSorting := &mvc.DataSortingOptionItem{
-		ColumnName: "\"Title\"",
-		Sorting:    0,
-	}
-
-	searchableColumns := []string{"\"Title\"", "\"Description\""}
+	// Filtreler birleştirilen tablolardaki sütunları da kullanabildiği için birleştirmeler
+	// sayım öncesinde eklenir
+	db := conn(c, r.db).Table("\"Timings\" AS t").
+		Joins("LEFT JOIN \"ClientProjects\" AS cp ON t.\"ClientProjectId\" = cp.\"Id\"").
+		Joins("LEFT JOIN \"Clients\" AS c ON cp.\"ClientId\" = c.\"Id\"").
+		Joins("LEFT JOIN \"ProjectTasks\" AS pt ON t.\"TaskId\" = pt.\"Id\"")
 
-	db, result := ApplyQueryModel(r.db, query, searchableColumns, defaultSorting)
+	db, page, result := ApplyQueryModel(db, query, timingQuerySchema)
 	if !result.IsSuccess() {
-		return lgo.NewLogicError(("Sorgu modeli uygulanırken hata oluştur: " + result.ErrorMessage), nil)
+		return result
 	}
 
-	db = db.Table("\"Timings\" AS t").Select(`
+	db = db.Select(`
     t."Id",
+    t."ClientProjectId",
+    cp."ClientId",
+    t."SystemUserId",
+    t."TaskId",
     t."Title",
     t."Description",
     t."StartDateTime",
     t."EndDateTime",
     t."Status",
-    c."Title" AS Client,
-    cp."Name" AS ClientProject
-`).
-		Joins("LEFT JOIN \"ClientProjects\" AS cp ON t.\"ClientProjectId\" = cp.\"Id\"").
-		Joins("LEFT JOIN \"Clients\" AS c ON cp.\"ClientId\" = c.\"Id\"")
+    c."Title" AS "Client",
+    cp."Name" AS "ClientProject",
+    COALESCE(pt."Name", '') AS "Task"
+`)
 
 	// Veriyi ViewModel'e dönüştür
 	queryResult := db.Scan(&timings)
 	if queryResult.Error != nil {
-		return lgo.NewLogicError("Veritabanı sorgusu başarızı: "+queryResult.Error.Error(), nil)
+		return mvc.NewDatabaseError(queryResult.Error)
+	}
+	if err := r.loadTags(c, timings); err != nil {
+		return mvc.NewDatabaseError(err)
 	}
 
-	return lgo.NewSuccess(timings)
+	return NewPagedResult(page, timings)
+}
+
+// loadTags, sayfadaki kayıtların etiketlerini tek sorguda yükler
+func (r *timingRepository) loadTags(c *models.Context, timings []mvc.TimingViewModel) error {
+	if len(timings) == 0 {
+		return nil
+	}
+	ids := make([]int, len(timings))
+	for i := range timings {
+		ids[i] = timings[i].Id
+		timings[i].Tags = []mvc.TagViewModel{}
+	}
+
+	var rows []struct {
+		TimingId int
+		mvc.TagViewModel
+	}
+	err := conn(c, r.db).Table("\"TimingTags\" AS tt").
+		Joins("JOIN \"Tags\" AS tg ON tg.\"Id\" = tt.\"TagId\"").
+		Where("tt.\"TimingId\" IN ?", ids).
+		Order("tg.\"Name\" ASC").
+		Select("tt.\"TimingId\", tg.\"Id\", tg.\"Name\", tg.\"Color\"").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	index := make(map[int]int, len(timings))
+	for i := range timings {
+		index[timings[i].Id] = i
+	}
+	for _, row := range rows {
+		i := index[row.TimingId]
+		timings[i].Tags = append(timings[i].Tags, row.TagViewModel)
+	}
+	return nil
 }
 
 // #endregion Get All Timings
 
 // #region Get Timings By ClientProjectId
-func (r *timingRepository) GetByClientProjectId(clientProjectId int) *lgo.OperationResult {
+func (r *timingRepository) GetByClientProjectId(c *models.Context, clientProjectId int) *lgo.OperationResult {
 	var timings []*datamodels.Timing
-	result := r.db.Where("client_project_id = ?", clientProjectId).Find(&timings)
+	result := conn(c, r.db).Where("\"ClientProjectId\" = ?", clientProjectId).Find(&timings)
 	if result.Error != nil {
-		return lgo.NewLogicError(result.Error.Error(), nil)
+		return mvc.NewDatabaseError(result.Error)
 	}
 	return lgo.NewSuccess(timings)
 }
@@ -157,13 +186,223 @@ func (r *timingRepository) GetByClientProjectId(clientProjectId int) *lgo.Operat
 // #endregion Get Timings By ClientProjectId
 
 // #region Get Timings By Date Range
-func (r *timingRepository) GetByDateRange(startDate time.Time, endDate time.Time) *lgo.OperationResult {
+func (r *timingRepository) GetByDateRange(c *models.Context, startDate time.Time, endDate time.Time) *lgo.OperationResult {
 	var timings []*datamodels.Timing
-	result := r.db.Where("start_date_time >= ? AND end_date_time <= ?", startDate, endDate).Find(&timings)
+	result := conn(c, r.db).Where("\"StartDateTime\" >= ? AND \"EndDateTime\" <= ?", startDate, endDate).Find(&timings)
 	if result.Error != nil {
-		return lgo.NewLogicError(result.Error.Error(), nil)
+		return mvc.NewDatabaseError(result.Error)
 	}
 	return lgo.NewSuccess(timings)
 }
 
 // #endregion Get Timings By Date Range
+
+// #region Count Timings By Status
+func (r *timingRepository) CountByStatus(c *models.Context, status enum.StatusEnum) *lgo.OperationResult {
+	var count int64
+	if err := conn(c, r.db).Model(&datamodels.Timing{}).Where("\"Status\" = ?", status).Count(&count).Error; err != nil {
+		return lgo.NewFailureWithError(err)
+	}
+	return lgo.NewSuccess(count)
+}
+
+// #endregion Count Timings By Status
+
+// #region Sum Timing Hours
+// SumHours, projenin [from, to) aralığında başlayan zaman kayıtlarının toplam süresini ve
+// tutarını *mvc.TimingHours olarak döndürür. Tutar, kaydı giren üyenin saatlik ücretiyle,
+// üyenin ücreti yoksa projenin ücretiyle hesaplanır. nil sınırlar uygulanmaz.
+func (r *timingRepository) SumHours(c *models.Context, clientProjectId int, from *time.Time, to *time.Time) *lgo.OperationResult {
+	db := conn(c, r.db).Table("\"Timings\" AS t").
+		Joins("JOIN \"ClientProjects\" AS cp ON t.\"ClientProjectId\" = cp.\"Id\"").
+		Joins("LEFT JOIN \"ClientProjectMembers\" AS m ON m.\"ClientProjectId\" = t.\"ClientProjectId\" AND m.\"SystemUserId\" = t.\"SystemUserId\"").
+		Where("t.\"ClientProjectId\" = ?", clientProjectId)
+	if from != nil {
+		db = db.Where("t.\"StartDateTime\" >= ?", *from)
+	}
+	if to != nil {
+		db = db.Where("t.\"StartDateTime\" < ?", *to)
+	}
+
+	var hours mvc.TimingHours
+	err := db.Select(`
+    COALESCE(SUM(EXTRACT(EPOCH FROM (t."EndDateTime" - t."StartDateTime"))), 0)::float8 / 3600 AS "Hours",
+    COALESCE(SUM(EXTRACT(EPOCH FROM (t."EndDateTime" - t."StartDateTime")) * COALESCE(m."HourlyRate", cp."HourlyRate")), 0)::float8 / 3600 AS "Amount",
+    MIN(t."StartDateTime") AS "FirstStartDateTime"
+`).Scan(&hours).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(&hours)
+}
+
+// #endregion Sum Timing Hours
+
+// #region Sum Timing Hours By Task
+// SumHoursByTask, projenin her görevi için [from, to) aralığında başlayan kayıtların toplam
+// süresini görev adına göre sıralı []*mvc.TaskHours olarak döndürür. Kaydı olmayan görevler
+// sıfır saatle yer alır; göreve bağlanmamış kayıtlar varsa TaskId'si nil olan son satırdadır.
+func (r *timingRepository) SumHoursByTask(c *models.Context, clientProjectId int, from *time.Time, to *time.Time) *lgo.OperationResult {
+	// Aralık koşulları, kaydı olmayan görevler de dönsün diye JOIN'e eklenir
+	join := "LEFT JOIN \"Timings\" AS t ON t.\"TaskId\" = pt.\"Id\""
+	var args []any
+	if from != nil {
+		join += " AND t.\"StartDateTime\" >= ?"
+		args = append(args, *from)
+	}
+	if to != nil {
+		join += " AND t.\"StartDateTime\" < ?"
+		args = append(args, *to)
+	}
+
+	var tasks []*mvc.TaskHours
+	err := conn(c, r.db).Table("\"ProjectTasks\" AS pt").
+		Joins(join, args...).
+		Where("pt.\"ClientProjectId\" = ?", clientProjectId).
+		Group("pt.\"Id\"").
+		Order("pt.\"Name\" ASC").
+		Select(`
+    pt."Id" AS "TaskId",
+    pt."Name" AS "Task",
+    pt."Status",
+    pt."EstimateHours"::float8 AS "EstimateHours",
+    COALESCE(SUM(EXTRACT(EPOCH FROM (t."EndDateTime" - t."StartDateTime"))), 0)::float8 / 3600 AS "Hours"
+`).Scan(&tasks).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+
+	db := conn(c, r.db).Table("\"Timings\" AS t").
+		Where("t.\"ClientProjectId\" = ? AND t.\"TaskId\" IS NULL", clientProjectId)
+	if from != nil {
+		db = db.Where("t.\"StartDateTime\" >= ?", *from)
+	}
+	if to != nil {
+		db = db.Where("t.\"StartDateTime\" < ?", *to)
+	}
+	var unassigned float64
+	err = db.Select(`COALESCE(SUM(EXTRACT(EPOCH FROM (t."EndDateTime" - t."StartDateTime"))), 0)::float8 / 3600`).Scan(&unassigned).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	if unassigned > 0 {
+		tasks = append(tasks, &mvc.TaskHours{Hours: unassigned})
+	}
+	return lgo.NewSuccess(tasks)
+}
+
+// #endregion Sum Timing Hours By Task
+
+// #region Sum Timing Hours By Tag
+// SumHoursByTag, [from, to) aralığında başlayan kayıtların her etiketteki sayısını ve toplam
+// süresini etiket adına göre sıralı []*mvc.TagHours olarak döndürür. Kaydı olmayan etiketler
+// de listelenir; etiketsiz kayıtlar varsa TagId'si nil olan bir satırla sona eklenir.
+// clientProjectId sıfırsa tüm projeler sayılır.
+func (r *timingRepository) SumHoursByTag(c *models.Context, clientProjectId int, from *time.Time, to *time.Time) *lgo.OperationResult {
+	// Kayıt koşulları, kaydı olmayan etiketler de dönsün diye JOIN'e eklenir
+	conditions := ""
+	var args []any
+	if clientProjectId > 0 {
+		conditions += " AND t.\"ClientProjectId\" = ?"
+		args = append(args, clientProjectId)
+	}
+	if from != nil {
+		conditions += " AND t.\"StartDateTime\" >= ?"
+		args = append(args, *from)
+	}
+	if to != nil {
+		conditions += " AND t.\"StartDateTime\" < ?"
+		args = append(args, *to)
+	}
+
+	var tags []*mvc.TagHours
+	err := conn(c, r.db).Table("\"Tags\" AS tg").
+		Joins("LEFT JOIN (\"TimingTags\" AS tt JOIN \"Timings\" AS t ON t.\"Id\" = tt.\"TimingId\""+conditions+") ON tt.\"TagId\" = tg.\"Id\"", args...).
+		Group("tg.\"Id\"").
+		Order("tg.\"Name\" ASC").
+		Select(`
+    tg."Id" AS "TagId",
+    tg."Name" AS "Tag",
+    tg."Color",
+    COUNT(t."Id") AS "Timings",
+    COALESCE(SUM(EXTRACT(EPOCH FROM (t."EndDateTime" - t."StartDateTime"))), 0)::float8 / 3600 AS "Hours"
+`).Scan(&tags).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+
+	db := conn(c, r.db).Table("\"Timings\" AS t").
+		Where("NOT EXISTS (SELECT 1 FROM \"TimingTags\" AS tt WHERE tt.\"TimingId\" = t.\"Id\")")
+	if clientProjectId > 0 {
+		db = db.Where("t.\"ClientProjectId\" = ?", clientProjectId)
+	}
+	if from != nil {
+		db = db.Where("t.\"StartDateTime\" >= ?", *from)
+	}
+	if to != nil {
+		db = db.Where("t.\"StartDateTime\" < ?", *to)
+	}
+	var untagged mvc.TagHours
+	err = db.Select(`
+    COUNT(t."Id") AS "Timings",
+    COALESCE(SUM(EXTRACT(EPOCH FROM (t."EndDateTime" - t."StartDateTime"))), 0)::float8 / 3600 AS "Hours"
+`).Scan(&untagged).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	if untagged.Timings > 0 {
+		tags = append(tags, &untagged)
+	}
+	return lgo.NewSuccess(tags)
+}
+
+// #endregion Sum Timing Hours By Tag
+
+// #region Delete Timing
+// Delete, etiket bağlantılarını kayıtla aynı işlemde siler. TimingTags'in foreign key'i
+// ON DELETE RESTRICT olduğu için bağlantılar kayıttan önce silinmelidir.
+func (r *timingRepository) Delete(c *models.Context, id int) *lgo.OperationResult {
+	return r.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		if err := conn(tx, r.db).Where("\"TimingId\" = ?", id).Delete(&datamodels.TimingTag{}).Error; err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+		return r.CrudRepository.Delete(tx, id)
+	})
+}
+
+// #endregion Delete Timing
+
+// #region Timing Tags
+func (r *timingRepository) GetTags(c *models.Context, timingId int) *lgo.OperationResult {
+	tags := []*datamodels.Tag{}
+	err := conn(c, r.db).
+		Joins("JOIN \"TimingTags\" AS tt ON tt.\"TagId\" = \"Tags\".\"Id\"").
+		Where("tt.\"TimingId\" = ?", timingId).
+		Order("\"Tags\".\"Name\" ASC").
+		Find(&tags).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(tags)
+}
+
+func (r *timingRepository) SetTags(c *models.Context, timingId int, tagIds []int) *lgo.OperationResult {
+	return r.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		if err := conn(tx, r.db).Where("\"TimingId\" = ?", timingId).Delete(&datamodels.TimingTag{}).Error; err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+		if len(tagIds) == 0 {
+			return lgo.NewSuccess(nil)
+		}
+		links := make([]datamodels.TimingTag, len(tagIds))
+		for i, tagId := range tagIds {
+			links[i] = datamodels.TimingTag{TimingId: timingId, TagId: tagId}
+		}
+		if err := conn(tx, r.db).Create(&links).Error; err != nil {
+			return mvc.NewDatabaseError(err)
+		}
+		return lgo.NewSuccess(nil)
+	})
+}
+
+// #endregion Timing Tags
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"gorm.io/gorm"
+)
+
+// Transactor, birden fazla depo işlemini tek veritabanı işleminde çalıştırır. fn'e verilen
+// Context işlemi taşır; bu Context'le çağrılan tüm depolar aynı işlemi kullanır.
+type Transactor interface {
+	// Transaction, fn başarısız bir sonuç döndürürse işlemi geri alır ve bu sonucu döndürür
+	Transaction(c *models.Context, fn func(tx *models.Context) *lgo.OperationResult) *lgo.OperationResult
+}
+
+type transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactor{db: db}
+}
+
+// txKey, Context'te taşınan *gorm.DB işleminin anahtarıdır
+type txKey struct{}
+
+// errRollback, işlemin başarısız bir OperationResult ile geri alınmasını sağlar
+var errRollback = errors.New("rollback")
+
+// #region Transaction
+func (t *transactor) Transaction(c *models.Context, fn func(tx *models.Context) *lgo.OperationResult) *lgo.OperationResult {
+	var result *lgo.OperationResult
+	err := conn(c, t.db).Transaction(func(tx *gorm.DB) error {
+		result = fn(c.WithContext(context.WithValue(c, txKey{}, tx)))
+		if !result.IsSuccess() {
+			return errRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRollback) {
+		return mvc.NewDatabaseError(err)
+	}
+	return result
+}
+
+//#endregion Transaction
+
+// conn, c bir işlem taşıyorsa o işlemi, taşımıyorsa db'yi c'ye bağlayarak döndürür. Depolar
+// veritabanına her zaman bu fonksiyonla erişir.
+func conn(c *models.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := c.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(c)
+	}
+	return db.WithContext(c)
+}
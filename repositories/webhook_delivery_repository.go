@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookDeliveryRepository, webhook gönderimlerinin kuyruğu ve günlüğüdür. Claim,
+// MarkDelivered ve MarkFailed gönderim işçisi tarafından kullanılır.
+type WebhookDeliveryRepository interface {
+	// Add, gönderimi ekler. Olay aboneliğe daha önce gönderilmek üzere eklenmişse (olay
+	// yeniden dağıtıldığında) yeni kayıt açılmaz. Yeniden gönderimler (RedeliveryOf dolu)
+	// her zaman eklenir.
+	Add(c *models.Context, delivery *datamodels.WebhookDelivery) *lgo.OperationResult
+	GetById(c *models.Context, id int64) *lgo.OperationResult
+	// GetBySubscriptionId, aboneliğin gönderimlerini varsayılan olarak yeniden eskiye sayfalar
+	GetBySubscriptionId(c *models.Context, subscriptionId int, query *mvc.QueryModel) *lgo.OperationResult
+	// Claim, zamanı gelmiş en fazla limit bekleyen gönderimi sırayla döndürür. Dönen
+	// gönderimlerin deneme sayısı artırılır ve lease süresince başka bir işçiye verilmez.
+	Claim(c *models.Context, now time.Time, lease time.Duration, limit int) *lgo.OperationResult
+	MarkDelivered(c *models.Context, id int64, responseStatus int, deliveredAt time.Time) *lgo.OperationResult
+	// MarkFailed, denemenin sonucunu kaydeder ve gönderimi nextAttemptAt'te yeniden
+	// denenmek üzere bırakır. nextAttemptAt nil ise gönderim Failed durumuna geçer.
+	MarkFailed(c *models.Context, id int64, responseStatus int, lastError string, attemptedAt time.Time, nextAttemptAt *time.Time) *lgo.OperationResult
+}
+
+// webhookDeliveryQuerySchema, gönderim günlüğünde filtrelenebilen ve sıralanabilen alanlardır
+var webhookDeliveryQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `"Id"`, Type: QueryFieldInt},
+	QueryField{Name: "eid", Alias: "EventId", Column: `"EventId"`, Type: QueryFieldInt},
+	QueryField{Name: "et", Alias: "EventType", Column: `"EventType"`, Type: QueryFieldString},
+	QueryField{Name: "st", Alias: "Status", Column: `"Status"`, Parse: parseDeliveryStatus},
+	QueryField{Name: "ca", Alias: "CreatedAt", Column: `"CreatedAt"`, Type: QueryFieldTime},
+).WithDefaultSorting("id", true)
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{db: db}
+}
+
+// webhookDeliveryClaimQuery, zamanı gelmiş gönderimleri kilitleyerek seçer; başka bir işçinin
+// kilitlediği satırlar atlanır
+const webhookDeliveryClaimQuery = `
+UPDATE "WebhookDeliveries" SET "Attempts" = "Attempts" + 1, "NextAttemptAt" = ?
+WHERE "Id" IN (
+    SELECT "Id" FROM "WebhookDeliveries"
+    WHERE "Status" = 0 AND "NextAttemptAt" <= ?
+    ORDER BY "Id"
+    LIMIT ?
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+// #region Add
+func (r *webhookDeliveryRepository) Add(c *models.Context, delivery *datamodels.WebhookDelivery) *lgo.OperationResult {
+	if err := conn(c, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(delivery).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(delivery)
+}
+
+//#endregion Add
+
+// #region Get Delivery By Id
+func (r *webhookDeliveryRepository) GetById(c *models.Context, id int64) *lgo.OperationResult {
+	var delivery datamodels.WebhookDelivery
+	err := conn(c, r.db).First(&delivery, "\"Id\" = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return mvc.NewNotFoundError(i18n.DeliveryNotFound)
+	}
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(&delivery)
+}
+
+//#endregion Get Delivery By Id
+
+// #region Get Deliveries By SubscriptionId
+func (r *webhookDeliveryRepository) GetBySubscriptionId(c *models.Context, subscriptionId int, query *mvc.QueryModel) *lgo.OperationResult {
+	var deliveries []*datamodels.WebhookDelivery
+
+	db := conn(c, r.db).Model(&datamodels.WebhookDelivery{}).Where("\"SubscriptionId\" = ?", subscriptionId)
+	db, page, result := ApplyQueryModel(db, query, webhookDeliveryQuerySchema)
+	if !result.IsSuccess() {
+		return result
+	}
+
+	if err := db.Find(&deliveries).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return NewPagedResult(page, deliveries)
+}
+
+//#endregion Get Deliveries By SubscriptionId
+
+// #region Claim
+func (r *webhookDeliveryRepository) Claim(c *models.Context, now time.Time, lease time.Duration, limit int) *lgo.OperationResult {
+	var deliveries []*datamodels.WebhookDelivery
+	if err := conn(c, r.db).Raw(webhookDeliveryClaimQuery, now.Add(lease), now, limit).Scan(&deliveries).Error; err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+
+	// RETURNING satırları sırasız döndürür
+	slices.SortFunc(deliveries, func(a, b *datamodels.WebhookDelivery) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return lgo.NewSuccess(deliveries)
+}
+
+//#endregion Claim
+
+// #region Mark Delivered
+func (r *webhookDeliveryRepository) MarkDelivered(c *models.Context, id int64, responseStatus int, deliveredAt time.Time) *lgo.OperationResult {
+	return r.update(c, id, map[string]any{
+		"Status":         enum.DeliveryStatusDelivered,
+		"ResponseStatus": responseStatus,
+		"LastError":      "",
+		"LastAttemptAt":  deliveredAt,
+		"DeliveredAt":    deliveredAt,
+		"NextAttemptAt":  nil,
+	})
+}
+
+//#endregion Mark Delivered
+
+// #region Mark Failed
+func (r *webhookDeliveryRepository) MarkFailed(c *models.Context, id int64, responseStatus int, lastError string, attemptedAt time.Time, nextAttemptAt *time.Time) *lgo.OperationResult {
+	values := map[string]any{
+		"ResponseStatus": responseStatus,
+		"LastError":      lastError,
+		"LastAttemptAt":  attemptedAt,
+		"NextAttemptAt":  nextAttemptAt,
+	}
+	if nextAttemptAt == nil {
+		values["Status"] = enum.DeliveryStatusFailed
+	}
+	return r.update(c, id, values)
+}
+
+//#endregion Mark Failed
+
+func (r *webhookDeliveryRepository) update(c *models.Context, id int64, values map[string]any) *lgo.OperationResult {
+	err := conn(c, r.db).Model(&datamodels.WebhookDelivery{}).Where("\"Id\" = ?", id).Updates(values).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// parseDeliveryStatus, "st" filtresinde sayısal değerleri ve durum adlarını (Pending,
+// Delivered, Failed) kabul eder
+func parseDeliveryStatus(value string) (any, error) {
+	if status, err := strconv.Atoi(value); err == nil && enum.DeliveryStatusEnum(status).IsValid() {
+		return status, nil
+	}
+	status, err := enum.ParseDeliveryStatus(value)
+	if err != nil {
+		return nil, err
+	}
+	return int(status), nil
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"encoding/json"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	CrudRepository[datamodels.WebhookSubscription, int]
+	// GetActiveByEventType, eventType olayını dinleyen etkin abonelikleri döndürür
+	GetActiveByEventType(c *models.Context, eventType string) *lgo.OperationResult
+}
+
+// webhookQuerySchema, GetAll'da filtrelenebilen ve sıralanabilen alanlardır
+var webhookQuerySchema = NewQuerySchema(
+	QueryField{Name: "id", Alias: "Id", Column: `"Id"`, Type: QueryFieldInt},
+	QueryField{Name: "url", Alias: "Url", Column: `"Url"`, Type: QueryFieldString, Searchable: true},
+	QueryField{Name: "ia", Alias: "IsActive", Column: `"IsActive"`, Type: QueryFieldBool},
+	QueryField{Name: "desc", Alias: "Description", Column: `"Description"`, Type: QueryFieldString, Searchable: true},
+).WithDefaultSorting("id", false)
+
+type webhookRepository struct {
+	CrudRepository[datamodels.WebhookSubscription, int]
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{
+		CrudRepository: NewCrudRepository[datamodels.WebhookSubscription, int](db, CrudOptions[datamodels.WebhookSubscription]{
+			NotFound: i18n.WebhookNotFound,
+			Schema:   webhookQuerySchema,
+			Apply:    ApplyWebhookSubscription,
+		}),
+		db: db,
+	}
+}
+
+// ApplyWebhookSubscription, güncellemede değiştirilebilen alanları kopyalar. Secret boşsa
+// kayıtlı anahtar korunur.
+func ApplyWebhookSubscription(existing *datamodels.WebhookSubscription, subscription *datamodels.WebhookSubscription) {
+	existing.Url = subscription.Url
+	existing.EventTypes = subscription.EventTypes
+	existing.IsActive = subscription.IsActive
+	existing.Description = subscription.Description
+	if subscription.Secret != "" {
+		existing.Secret = subscription.Secret
+	}
+}
+
+// #region Get Active Webhooks By Event Type
+func (r *webhookRepository) GetActiveByEventType(c *models.Context, eventType string) *lgo.OperationResult {
+	contains, err := json.Marshal([]string{eventType})
+	if err != nil {
+		return lgo.NewFailureWithError(err)
+	}
+
+	subscriptions := []*datamodels.WebhookSubscription{}
+	err = conn(c, r.db).Where("\"IsActive\" AND \"EventTypes\" @> ?::jsonb", string(contains)).
+		Order("\"Id\" ASC").Find(&subscriptions).Error
+	if err != nil {
+		return mvc.NewDatabaseError(err)
+	}
+	return lgo.NewSuccess(subscriptions)
+}
+
+// #endregion Get Active Webhooks By Event Type
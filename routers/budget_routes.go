@@ -0,0 +1,25 @@
+package routers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BudgetRoutesV1, proje bütçe durumu rotasını kaydeder. Bütçe alanları projeyle birlikte
+// /client-projects üzerinden yönetilir.
+func BudgetRoutesV1(router *gin.RouterGroup, service services.BudgetService) {
+	controller := controllers.NewBudgetController(service)
+	router.GET("/client-projects/:id/budget-status", controller.GetStatus)
+}
+
+func budgetRoutesV1Doc(doc *openapi.Document) {
+	doc.Route("GET", "/api/v1/client-projects/:id/budget-status").Tag("ClientProjects").Summary("Projenin bütçe durumunu getirir").
+		Description("Bütçe aralığında başlayan zaman kayıtlarından harcanan ve kalan saat/tutarı, bütçenin mevcut hızla tükeneceği tahmini tarihi döndürür.").
+		PathParam("id", "integer", "Proje ID").Responds(http.StatusOK, mvc.BudgetStatus{}).Problems(http.StatusNotFound)
+}
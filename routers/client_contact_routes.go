@@ -0,0 +1,47 @@
+package routers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientContactRoutesV1, müşteri yetkililerinin rotalarını kaydeder. Yetkililerin eski
+// (OperationResult dönen) rotaları yoktur.
+func ClientContactRoutesV1(router *gin.RouterGroup, service services.ClientContactService) {
+	controller := controllers.NewClientContactController(service)
+	routes := router.Group("/clients/:id/contacts")
+	{
+		routes.POST("", controller.Create)
+		routes.GET("", controller.GetByClientId)
+		routes.GET("/:contactId", controller.GetById)
+		routes.PUT("/:contactId", controller.Update)
+		routes.DELETE("/:contactId", controller.Delete)
+	}
+}
+
+func clientContactRoutesV1Doc(doc *openapi.Document) {
+	doc.AddTag("ClientContacts", "Müşteri yetkilileri (görüntüleme clients.view, değişiklikler clients.update yetkisi)")
+	doc.Route("POST", "/api/v1/clients/:id/contacts").Tag("ClientContacts").Summary("Müşteriye yetkili ekler").
+		Description("Birincil (ip) işaretlenen yetkili, müşterinin önceki birincil yetkilisinin yerini alır.").
+		PathParam("id", "integer", "Müşteri ID").Body(data.ClientContact{}).Responds(http.StatusCreated, data.ClientContact{}).
+		Problems(http.StatusNotFound, http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/clients/:id/contacts").Tag("ClientContacts").Summary("Müşterinin yetkililerini listeler").
+		Description("Birincil yetkili ilk sırada döner.").
+		PathParam("id", "integer", "Müşteri ID").Responds(http.StatusOK, []*data.ClientContact{}).Problems(http.StatusNotFound)
+	doc.Route("GET", "/api/v1/clients/:id/contacts/:contactId").Tag("ClientContacts").Summary("Yetkiliyi getirir").
+		PathParam("id", "integer", "Müşteri ID").PathParam("contactId", "integer", "Yetkili ID").
+		Responds(http.StatusOK, data.ClientContact{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/clients/:id/contacts/:contactId").Tag("ClientContacts").Summary("Yetkiliyi günceller").
+		PathParam("id", "integer", "Müşteri ID").PathParam("contactId", "integer", "Yetkili ID").
+		Body(data.ClientContact{}).Responds(http.StatusOK, data.ClientContact{}).
+		Problems(http.StatusNotFound, http.StatusUnprocessableEntity)
+	doc.Route("DELETE", "/api/v1/clients/:id/contacts/:contactId").Tag("ClientContacts").Summary("Yetkiliyi siler").
+		PathParam("id", "integer", "Müşteri ID").PathParam("contactId", "integer", "Yetkili ID").
+		Responds(http.StatusNoContent, nil).Problems(http.StatusNotFound)
+}
@@ -0,0 +1,47 @@
+package routers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientProjectMemberRoutesV1, proje üyeliklerinin rotalarını kaydeder. Üyeliklerin eski
+// (OperationResult dönen) rotaları yoktur.
+func ClientProjectMemberRoutesV1(router *gin.RouterGroup, service services.ClientProjectMemberService) {
+	controller := controllers.NewClientProjectMemberController(service)
+	routes := router.Group("/client-projects/:id/members")
+	{
+		routes.POST("", controller.Create)
+		routes.GET("", controller.GetByClientProjectId)
+		routes.GET("/:memberId", controller.GetById)
+		routes.PUT("/:memberId", controller.Update)
+		routes.DELETE("/:memberId", controller.Delete)
+	}
+}
+
+func clientProjectMemberRoutesV1Doc(doc *openapi.Document) {
+	doc.AddTag("ClientProjectMembers", "Proje üyeleri (görüntüleme clientprojects.view, değişiklikler clientprojects.update yetkisi)")
+	doc.Route("POST", "/api/v1/client-projects/:id/members").Tag("ClientProjectMembers").Summary("Kullanıcıyı projeye üye yapar").
+		Description("Yalnızca üyeler projeye zaman kaydı girebilir. Saatlik ücret (hr) verilmezse projenin ücreti geçerlidir.").
+		PathParam("id", "integer", "Proje ID").Body(data.ClientProjectMember{}).Responds(http.StatusCreated, data.ClientProjectMember{}).
+		Problems(http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/client-projects/:id/members").Tag("ClientProjectMembers").Summary("Projenin üyelerini listeler").
+		PathParam("id", "integer", "Proje ID").Responds(http.StatusOK, []*data.ClientProjectMember{}).Problems(http.StatusNotFound)
+	doc.Route("GET", "/api/v1/client-projects/:id/members/:memberId").Tag("ClientProjectMembers").Summary("Üyeliği getirir").
+		PathParam("id", "integer", "Proje ID").PathParam("memberId", "integer", "Üyelik ID").
+		Responds(http.StatusOK, data.ClientProjectMember{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/client-projects/:id/members/:memberId").Tag("ClientProjectMembers").Summary("Üyeliğin rolünü ve ücretini günceller").
+		Description("Üyeliğin kullanıcısı (suid) değiştirilemez.").
+		PathParam("id", "integer", "Proje ID").PathParam("memberId", "integer", "Üyelik ID").
+		Body(data.ClientProjectMember{}).Responds(http.StatusOK, data.ClientProjectMember{}).
+		Problems(http.StatusNotFound, http.StatusUnprocessableEntity)
+	doc.Route("DELETE", "/api/v1/client-projects/:id/members/:memberId").Tag("ClientProjectMembers").Summary("Üyeliği siler").
+		PathParam("id", "integer", "Proje ID").PathParam("memberId", "integer", "Üyelik ID").
+		Responds(http.StatusNoContent, nil).Problems(http.StatusNotFound)
+}
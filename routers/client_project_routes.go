@@ -1,21 +1,90 @@
 package routers
 
 import (
+	"net/http"
+
 	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
 	"lms-web-services-main/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ClientProjectRoutes, eski rotaları kaydeder; yerlerine ClientProjectRoutesV1 kullanılmalıdır
 func ClientProjectRoutes(router *gin.RouterGroup, service services.ClientProjectService) {
 	controller := controllers.NewClientProjectController(service)
 	routes := router.Group("/client-projects")
 	{
-		routes.POST("/create", controller.Create)
-		routes.PUT("/update", controller.Update)
-		routes.DELETE(":id", controller.Delete)
-		routes.GET(":id", controller.GetById)
-		routes.GET("/all", controller.GetAll)
-		routes.GET("/client/:clientId", controller.GetByClientId)
+		routes.POST("/create", deprecated("/api/v1/client-projects"), controller.Create)
+		routes.PUT("/update", deprecated("/api/v1/client-projects/{id}"), controller.Update)
+		routes.DELETE(":id", deprecated("/api/v1/client-projects/{id}"), controller.Delete)
+		routes.GET(":id", deprecated("/api/v1/client-projects/{id}"), controller.GetById)
+		routes.GET("/all", deprecated("/api/v1/client-projects"), controller.GetAll)
+		routes.GET("/client/:clientId", deprecated("/api/v1/clients/{id}/projects"), controller.GetByClientId)
 	}
 }
+
+func ClientProjectRoutesV1(router *gin.RouterGroup, service services.ClientProjectService) {
+	controller := controllers.NewClientProjectController(service)
+	routes := router.Group("/client-projects")
+	{
+		routes.POST("", controller.Create)
+		routes.GET("", controller.GetAll)
+		routes.GET("/:id", controller.GetById)
+		routes.PUT("/:id", controller.Update)
+		routes.DELETE("/:id", controller.Delete)
+		routes.POST("/:id/archive", controller.Archive)
+		routes.POST("/:id/unarchive", controller.Unarchive)
+	}
+	router.GET("/clients/:id/projects", withParamAlias("id", "clientId", controller.GetByClientId))
+}
+
+func clientProjectRoutesDoc(doc *openapi.Document) {
+	doc.AddTag("ClientProjects", "Müşteri projeleri (clientprojects.* yetkileri)")
+	doc.Route("POST", "/client-projects/create").Tag("ClientProjects").Summary("Proje oluşturur").Deprecated().
+		Body(data.ClientProject{}).Returns(data.ClientProject{})
+	doc.Route("PUT", "/client-projects/update").Tag("ClientProjects").Summary("Projeyi günceller").Deprecated().
+		Body(data.ClientProject{}).Returns(data.ClientProject{})
+	doc.Route("DELETE", "/client-projects/:id").Tag("ClientProjects").Summary("Projeyi siler").Deprecated().
+		PathParam("id", "integer", "Proje ID").Returns(nil)
+	doc.Route("GET", "/client-projects/:id").Tag("ClientProjects").Summary("Projeyi getirir").Deprecated().
+		PathParam("id", "integer", "Proje ID").Returns(data.ClientProject{})
+	doc.Route("GET", "/client-projects/all").Tag("ClientProjects").Summary("Projeleri sayfalı listeler").Deprecated().
+		Description("Alanlar: id, cid, n, ia").
+		Query(mvc.QueryModel{}).Returns(mvc.PagedResult[*data.ClientProject]{})
+	doc.Route("GET", "/client-projects/client/:clientId").Tag("ClientProjects").Summary("Müşterinin projelerini listeler").Deprecated().
+		PathParam("clientId", "integer", "Müşteri ID").Returns([]*data.ClientProject{})
+}
+
+func clientProjectRoutesV1Doc(doc *openapi.Document) {
+	doc.Route("POST", "/api/v1/client-projects").Tag("ClientProjects").Summary("Proje oluşturur").
+		Body(data.ClientProject{}).Responds(http.StatusCreated, data.ClientProject{}).Problems(http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/client-projects").Tag("ClientProjects").Summary("Projeleri sayfalı listeler").
+		Description("Alanlar: id, cid, n, ia, bed, eb, aa").
+		Query(mvc.QueryModel{}).QueryParam("mine", "boolean", false, "Yalnızca oturum açmış kullanıcının üyesi olduğu projeler").
+		QueryParam("archived", "string", false, "Arşivlenmiş kayıtlar: false (varsayılan, gizlenir), true (yalnızca arşivdekiler) veya all").
+		Responds(http.StatusOK, mvc.PagedResult[*data.ClientProject]{})
+	doc.Route("GET", "/api/v1/client-projects/:id").Tag("ClientProjects").Summary("Projeyi getirir").
+		PathParam("id", "integer", "Proje ID").Responds(http.StatusOK, data.ClientProject{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/client-projects/:id").Tag("ClientProjects").Summary("Projeyi günceller").
+		PathParam("id", "integer", "Proje ID").Body(data.ClientProject{}).Responds(http.StatusOK, data.ClientProject{}).
+		Problems(http.StatusNotFound, http.StatusUnprocessableEntity)
+	doc.Route("DELETE", "/api/v1/client-projects/:id").Tag("ClientProjects").Summary("Projeyi siler").
+		Description("Üyesi, görevi veya zaman kaydı olan proje silinmez; 409 yanıtının errors alanı "+
+			"birlikte silinecek kayıtları listeler. force=true bu kayıtları da siler, clientprojects.force_delete "+
+			"yetkisi gerektirir ve silinen kayıtların sayılarını döndürür.").
+		PathParam("id", "integer", "Proje ID").QueryParam("force", "boolean", false, "Bağlı kayıtlarla birlikte sil").
+		Responds(http.StatusNoContent, nil).Responds(http.StatusOK, mvc.ForeignReferences{}).
+		Problems(http.StatusNotFound, http.StatusConflict)
+	doc.Route("GET", "/api/v1/clients/:id/projects").Tag("ClientProjects").Summary("Müşterinin projelerini listeler").
+		PathParam("id", "integer", "Müşteri ID").QueryParam("archived", "string", false, "Arşivlenmiş kayıtlar: false (varsayılan, gizlenir), true (yalnızca arşivdekiler) veya all").
+		Responds(http.StatusOK, []*data.ClientProject{})
+	doc.Route("POST", "/api/v1/client-projects/:id/archive").Tag("ClientProjects").Summary("Projeyi arşivler").
+		PathParam("id", "integer", "Proje ID").Responds(http.StatusOK, data.ClientProject{}).Problems(http.StatusNotFound)
+	doc.Route("POST", "/api/v1/client-projects/:id/unarchive").Tag("ClientProjects").Summary("Projeyi arşivden çıkarır").
+		Description("Müşterisi arşivdeyse 409 döner").
+		PathParam("id", "integer", "Proje ID").Responds(http.StatusOK, data.ClientProject{}).
+		Problems(http.StatusNotFound, http.StatusConflict)
+}
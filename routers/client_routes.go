@@ -1,20 +1,81 @@
 package routers
 
 import (
+	"net/http"
+
 	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
 	"lms-web-services-main/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ClientRoutes, eski rotaları kaydeder; yerlerine ClientRoutesV1 kullanılmalıdır
 func ClientRoutes(router *gin.RouterGroup, service services.ClientService) {
 	controller := controllers.NewClientController(service)
 	routes := router.Group("/clients")
 	{
-		routes.POST("/create", controller.Create)
-		routes.PUT("/update", controller.Update)
-		routes.DELETE(":id", controller.Delete)
-		routes.GET(":id", controller.GetById)
-		routes.GET("/all", controller.GetAll)
+		routes.POST("/create", deprecated("/api/v1/clients"), controller.Create)
+		routes.PUT("/update", deprecated("/api/v1/clients/{id}"), controller.Update)
+		routes.DELETE(":id", deprecated("/api/v1/clients/{id}"), controller.Delete)
+		routes.GET(":id", deprecated("/api/v1/clients/{id}"), controller.GetById)
+		routes.GET("/all", deprecated("/api/v1/clients"), controller.GetAll)
 	}
 }
+
+func ClientRoutesV1(router *gin.RouterGroup, service services.ClientService) {
+	controller := controllers.NewClientController(service)
+	routes := router.Group("/clients")
+	{
+		routes.POST("", controller.Create)
+		routes.GET("", controller.GetAll)
+		routes.GET("/:id", controller.GetById)
+		routes.PUT("/:id", controller.Update)
+		routes.DELETE("/:id", controller.Delete)
+		routes.POST("/:id/archive", controller.Archive)
+		routes.POST("/:id/unarchive", controller.Unarchive)
+	}
+}
+
+func clientRoutesDoc(doc *openapi.Document) {
+	doc.AddTag("Clients", "Müşteriler (clients.* yetkileri)")
+	doc.Route("POST", "/clients/create").Tag("Clients").Summary("Müşteri oluşturur").Deprecated().
+		Body(data.Client{}).Returns(data.Client{})
+	doc.Route("PUT", "/clients/update").Tag("Clients").Summary("Müşteriyi günceller").Deprecated().
+		Body(data.Client{}).Returns(data.Client{})
+	doc.Route("DELETE", "/clients/:id").Tag("Clients").Summary("Müşteriyi siler").Deprecated().
+		PathParam("id", "integer", "Müşteri ID").Returns(nil)
+	doc.Route("GET", "/clients/:id").Tag("Clients").Summary("Müşteriyi getirir").Deprecated().
+		PathParam("id", "integer", "Müşteri ID").Returns(data.Client{})
+	doc.Route("GET", "/clients/all").Tag("Clients").Summary("Müşterileri sayfalı listeler").Deprecated().
+		Description("Alanlar: id, st, t, nt, ia, to, tn, cur, ptd").
+		Query(mvc.QueryModel{}).Returns(mvc.PagedResult[*data.Client]{})
+}
+
+func clientRoutesV1Doc(doc *openapi.Document) {
+	doc.Route("POST", "/api/v1/clients").Tag("Clients").Summary("Müşteri oluşturur").
+		Body(data.Client{}).Responds(http.StatusCreated, data.Client{}).Problems(http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/clients").Tag("Clients").Summary("Müşterileri sayfalı listeler").
+		Description("Alanlar: id, st, t, nt, ia, to, tn, cur, ptd, aa").
+		Query(mvc.QueryModel{}).QueryParam("archived", "string", false, "Arşivlenmiş kayıtlar: false (varsayılan, gizlenir), true (yalnızca arşivdekiler) veya all").
+		Responds(http.StatusOK, mvc.PagedResult[*data.Client]{})
+	doc.Route("GET", "/api/v1/clients/:id").Tag("Clients").Summary("Müşteriyi getirir").
+		PathParam("id", "integer", "Müşteri ID").Responds(http.StatusOK, data.Client{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/clients/:id").Tag("Clients").Summary("Müşteriyi günceller").
+		PathParam("id", "integer", "Müşteri ID").Body(data.Client{}).Responds(http.StatusOK, data.Client{}).
+		Problems(http.StatusNotFound, http.StatusUnprocessableEntity)
+	doc.Route("DELETE", "/api/v1/clients/:id").Tag("Clients").Summary("Müşteriyi siler").
+		Description("Projesi, yetkilisi veya zaman kaydı olan müşteri silinmez; 409 yanıtının errors alanı "+
+			"birlikte silinecek kayıtları listeler. force=true bu kayıtları da siler, clients.force_delete "+
+			"yetkisi gerektirir ve silinen kayıtların sayılarını döndürür.").
+		PathParam("id", "integer", "Müşteri ID").QueryParam("force", "boolean", false, "Bağlı kayıtlarla birlikte sil").
+		Responds(http.StatusNoContent, nil).Responds(http.StatusOK, mvc.ForeignReferences{}).
+		Problems(http.StatusNotFound, http.StatusConflict)
+	doc.Route("POST", "/api/v1/clients/:id/archive").Tag("Clients").Summary("Müşteriyi ve aktif projelerini arşivler").
+		PathParam("id", "integer", "Müşteri ID").Responds(http.StatusOK, data.Client{}).Problems(http.StatusNotFound)
+	doc.Route("POST", "/api/v1/clients/:id/unarchive").Tag("Clients").Summary("Müşteriyi arşivden çıkarır").
+		Description("Müşteriyle birlikte arşivlenen projeler de arşivden çıkarılır").
+		PathParam("id", "integer", "Müşteri ID").Responds(http.StatusOK, data.Client{}).Problems(http.StatusNotFound)
+}
@@ -0,0 +1,89 @@
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// docsPage, /openapi.json belgesini Swagger UI ile gösterir
+const docsPage = `<!DOCTYPE html>
+<html lang="tr">
+<head>
+	<meta charset="utf-8">
+	<title>LMS API</title>
+	<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+	<script>
+		window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui", persistAuthorization: true });
+	</script>
+</body>
+</html>`
+
+// OpenAPIDocument, routers altındaki tüm rotaları açıklayan OpenAPI belgesini oluşturur.
+// Yeni bir rota eklendiğinde ilgili *RoutesDoc fonksiyonuna da eklenmelidir; eksik rotalar
+// application paketindeki testte yakalanır.
+func OpenAPIDocument(version string) *openapi.Document {
+	doc := openapi.New("LMS API",
+		"Müşteri, proje ve zaman kaydı yönetimi. /api/v1 rotaları HTTP durum kodlarını kullanır ve hataları "+
+			"RFC 9457 Problem Details olarak döndürür. Kullanımdan kaldırılan eski rotalar OperationResult "+
+			"zarfıyla döner; işlemin sonucu \"r\" alanıyla belirtilir.",
+		version)
+
+	healthRoutesDoc(doc)
+	metricsRoutesDoc(doc)
+	docsRoutesDoc(doc)
+	nonProtectedRoutesDoc(doc)
+	systemUserRoutesDoc(doc)
+	systemUserSettingRoutesDoc(doc)
+	clientRoutesDoc(doc)
+	clientProjectRoutesDoc(doc)
+	timingRoutesDoc(doc)
+	searchRoutesDoc(doc)
+
+	doc.UseProblemDetails(mvc.ProblemDetails{}, mvc.ProblemDetailsContentType)
+	nonProtectedRoutesV1Doc(doc)
+	systemUserRoutesV1Doc(doc)
+	systemUserSettingRoutesV1Doc(doc)
+	clientRoutesV1Doc(doc)
+	clientContactRoutesV1Doc(doc)
+	clientProjectRoutesV1Doc(doc)
+	clientProjectMemberRoutesV1Doc(doc)
+	projectTaskRoutesV1Doc(doc)
+	budgetRoutesV1Doc(doc)
+	timingRoutesV1Doc(doc)
+	tagRoutesV1Doc(doc)
+	searchRoutesV1Doc(doc)
+	reportRoutesV1Doc(doc)
+	webhookRoutesV1Doc(doc)
+	return doc
+}
+
+func DocsRoutes(router *gin.RouterGroup, document *openapi.Document) {
+	// Belge değişmediği için bir kez serileştirilir
+	spec, err := json.Marshal(document)
+	if err != nil {
+		panic("openapi: marshal document: " + err.Error())
+	}
+
+	router.GET("/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+	})
+	router.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+	})
+}
+
+func docsRoutesDoc(doc *openapi.Document) {
+	doc.Route("GET", "/openapi.json").Tag("Operations").Summary("OpenAPI belgesi").Public().
+		RawResponse("200", "OpenAPI 3 belgesi", "application/json", &openapi.Schema{Type: "object"})
+	doc.Route("GET", "/docs").Tag("Operations").Summary("Etkileşimli API dokümantasyonu").Public().
+		RawResponse("200", "Swagger UI sayfası", "text/html", &openapi.Schema{Type: "string"})
+}
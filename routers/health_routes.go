@@ -0,0 +1,25 @@
+package routers
+
+import (
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func HealthRoutes(router *gin.RouterGroup, service services.HealthService) {
+	controller := controllers.NewHealthController(service)
+	router.GET("/healthz", controller.Liveness)
+	router.GET("/readyz", controller.Readiness)
+}
+
+func healthRoutesDoc(doc *openapi.Document) {
+	doc.AddTag("Operations", "Sağlık, metrik ve dokümantasyon uç noktaları")
+	doc.Route("GET", "/healthz").Tag("Operations").Summary("Süreç ayakta mı (liveness)").
+		Public().Returns(mvc.HealthReport{})
+	doc.Route("GET", "/readyz").Tag("Operations").Summary("Bağımlılıklar hazır mı (readiness)").
+		Public().Returns(mvc.HealthReport{}).
+		Response("503", "Bağımlılıklardan en az biri erişilemez", mvc.HealthReport{})
+}
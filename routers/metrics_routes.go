@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MetricsRoutes(router *gin.RouterGroup, handler http.Handler) {
+	router.GET("/metrics", gin.WrapH(handler))
+}
+
+func metricsRoutesDoc(doc *openapi.Document) {
+	doc.Route("GET", "/metrics").Tag("Operations").Summary("Prometheus metrikleri").Public().
+		RawResponse("200", "Prometheus metin biçimi", "text/plain", &openapi.Schema{Type: "string"})
+}
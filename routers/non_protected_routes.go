@@ -1,7 +1,11 @@
 package routers
 
 import (
+	"net/http"
+
 	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
 	"lms-web-services-main/services"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +16,23 @@ func NonProtectedRoutes(router *gin.RouterGroup, systemUserService services.Syst
 
 	routes := router.Group("/system-user")
 	{
-		routes.POST("/login", controller.Login)
+		routes.POST("/login", deprecated("/api/v1/sessions"), controller.Login)
 	}
 }
+
+func NonProtectedRoutesV1(router *gin.RouterGroup, systemUserService services.SystemUserService) {
+	controller := controllers.NewSystemUserController(systemUserService)
+	router.POST("/sessions", controller.Login)
+}
+
+func nonProtectedRoutesDoc(doc *openapi.Document) {
+	doc.Route("POST", "/system-user/login").Tag("SystemUsers").Summary("Giriş yapar").Deprecated().
+		Description("Başarılı yanıttaki \"t\" değeri sonraki isteklerde X-Token başlığıyla gönderilir.").
+		Public().Body(mvc.SystemUserLoginRequest{}).Returns(map[string]string{})
+}
+
+func nonProtectedRoutesV1Doc(doc *openapi.Document) {
+	doc.Route("POST", "/api/v1/sessions").Tag("SystemUsers").Summary("Giriş yapar").
+		Description("Yanıttaki \"t\" değeri sonraki isteklerde X-Token başlığıyla gönderilir.").
+		Public().Body(mvc.SystemUserLoginRequest{}).Responds(http.StatusCreated, map[string]string{})
+}
@@ -0,0 +1,47 @@
+package routers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectTaskRoutesV1, proje görevlerinin rotalarını kaydeder. Görevlerin eski
+// (OperationResult dönen) rotaları yoktur.
+func ProjectTaskRoutesV1(router *gin.RouterGroup, service services.ProjectTaskService) {
+	controller := controllers.NewProjectTaskController(service)
+	routes := router.Group("/client-projects/:id/tasks")
+	{
+		routes.POST("", controller.Create)
+		routes.GET("", controller.GetByClientProjectId)
+		routes.GET("/:taskId", controller.GetById)
+		routes.PUT("/:taskId", controller.Update)
+		routes.DELETE("/:taskId", controller.Delete)
+	}
+}
+
+func projectTaskRoutesV1Doc(doc *openapi.Document) {
+	doc.AddTag("ProjectTasks", "Proje görevleri (görüntüleme clientprojects.view, değişiklikler clientprojects.update yetkisi)")
+	doc.Route("POST", "/api/v1/client-projects/:id/tasks").Tag("ProjectTasks").Summary("Projeye görev ekler").
+		Description("Görev adı proje içinde benzersizdir. Tahmin (eh) saat cinsindendir; sıfır tahmin yok demektir.").
+		PathParam("id", "integer", "Proje ID").Body(data.ProjectTask{}).Responds(http.StatusCreated, data.ProjectTask{}).
+		Problems(http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/client-projects/:id/tasks").Tag("ProjectTasks").Summary("Projenin görevlerini ada göre listeler").
+		PathParam("id", "integer", "Proje ID").Responds(http.StatusOK, []*data.ProjectTask{}).Problems(http.StatusNotFound)
+	doc.Route("GET", "/api/v1/client-projects/:id/tasks/:taskId").Tag("ProjectTasks").Summary("Görevi getirir").
+		PathParam("id", "integer", "Proje ID").PathParam("taskId", "integer", "Görev ID").
+		Responds(http.StatusOK, data.ProjectTask{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/client-projects/:id/tasks/:taskId").Tag("ProjectTasks").Summary("Görevin adını, tahminini ve durumunu günceller").
+		PathParam("id", "integer", "Proje ID").PathParam("taskId", "integer", "Görev ID").
+		Body(data.ProjectTask{}).Responds(http.StatusOK, data.ProjectTask{}).
+		Problems(http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity)
+	doc.Route("DELETE", "/api/v1/client-projects/:id/tasks/:taskId").Tag("ProjectTasks").Summary("Görevi siler").
+		Description("Göreve bağlı zaman kayıtları silinmez; görev bağlantıları kaldırılır.").
+		PathParam("id", "integer", "Proje ID").PathParam("taskId", "integer", "Görev ID").
+		Responds(http.StatusNoContent, nil).Problems(http.StatusNotFound)
+}
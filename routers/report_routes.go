@@ -0,0 +1,32 @@
+package routers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportRoutesV1, rapor rotalarını kaydeder. Raporların eski (OperationResult dönen) rotaları yoktur.
+func ReportRoutesV1(router *gin.RouterGroup, service services.ReportService) {
+	controller := controllers.NewReportController(service)
+	routes := router.Group("/reports")
+	{
+		routes.GET("/tasks", controller.GetTaskReport)
+		routes.GET("/tags", controller.GetTagReport)
+	}
+}
+
+func reportRoutesV1Doc(doc *openapi.Document) {
+	doc.AddTag("Reports", "Zaman kayıtlarından üretilen raporlar (timings.view yetkisi)")
+	doc.Route("GET", "/api/v1/reports/tasks").Tag("Reports").Summary("Projenin görevlerinde tahmini ve gerçekleşen süreyi karşılaştırır").
+		Description("Görevler ada göre sıralanır; kaydı olmayan görevler de listelenir. Göreve bağlanmamış kayıtlar uh'de toplanır.").
+		Query(mvc.ReportQuery{}).Responds(http.StatusOK, mvc.TaskReport{}).Problems(http.StatusNotFound)
+	doc.Route("GET", "/api/v1/reports/tags").Tag("Reports").Summary("Sürelerin etiketlere dağılımını döndürür").
+		Description("cpid verilmezse tüm projeler sayılır. Etiketler ada göre sıralanır; kaydı olmayan etiketler de listelenir. Birden fazla etiketi olan bir kayıt her etiketinde sayılır; etiketsiz kayıtlar uth'de toplanır.").
+		Query(mvc.ReportQuery{}).Responds(http.StatusOK, mvc.TagReport{}).Problems(http.StatusNotFound)
+}
@@ -0,0 +1,32 @@
+package routers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// legacyRoutesDeprecatedAt, eski rotaların kullanımdan kaldırıldığı tarihtir (RFC 9745)
+var legacyRoutesDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// deprecated, eski rotanın yanıtına Deprecation ve yerine geçen /api/v1 rotasını gösteren
+// Link başlıklarını ekler
+func deprecated(successor string) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", legacyRoutesDeprecatedAt.Unix())
+	link := fmt.Sprintf(`<%s>; rel="successor-version"`, successor)
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		c.Header("Link", link)
+		c.Next()
+	}
+}
+
+// withParamAlias, /api/v1 rotalarındaki yol parametresini eski controller'ın beklediği adla
+// da erişilebilir yapar (örn. /clients/:id/projects -> clientId)
+func withParamAlias(param string, alias string, handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Params = append(c.Params, gin.Param{Key: alias, Value: c.Param(param)})
+		handler(c)
+	}
+}
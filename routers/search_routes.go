@@ -0,0 +1,35 @@
+package routers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SearchRoutes(router *gin.RouterGroup, service services.SearchService) {
+	controller := controllers.NewSearchController(service)
+	router.GET("/search", deprecated("/api/v1/search"), controller.Search)
+}
+
+func SearchRoutesV1(router *gin.RouterGroup, service services.SearchService) {
+	controller := controllers.NewSearchController(service)
+	router.GET("/search", controller.Search)
+}
+
+func searchRoutesDoc(doc *openapi.Document) {
+	doc.AddTag("Search", "Tam metin arama")
+	doc.Route("GET", "/search").Tag("Search").Summary("Müşteri, proje ve zaman kayıtlarında arar").
+		Description("Yalnızca okuma yetkisi olunan türler aranır. Title ve snippet HTML olarak kaçırılmıştır; eşleşmeler <mark> ile işaretlenir.").
+		Deprecated().Query(mvc.SearchQuery{}).Returns([]*mvc.SearchHit{})
+}
+
+func searchRoutesV1Doc(doc *openapi.Document) {
+	doc.Route("GET", "/api/v1/search").Tag("Search").Summary("Müşteri, proje ve zaman kayıtlarında arar").
+		Description("Yalnızca okuma yetkisi olunan türler aranır. Title ve snippet HTML olarak kaçırılmıştır; eşleşmeler <mark> ile işaretlenir.").
+		Query(mvc.SearchQuery{}).Responds(http.StatusOK, []*mvc.SearchHit{})
+}
@@ -1,22 +1,82 @@
 package routers
 
 import (
+	"net/http"
+
 	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
 	"lms-web-services-main/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+// SystemUserRoutes, eski rotaları kaydeder; yerlerine SystemUserRoutesV1 kullanılmalıdır
 func SystemUserRoutes(router *gin.RouterGroup, service services.SystemUserService) {
 	controller := controllers.NewSystemUserController(service)
 	routes := router.Group("/system-user")
 	{
-		routes.POST("/create", controller.Create)
-		routes.PUT("/update", controller.Update)
-		routes.DELETE("/:id", controller.Delete)
+		routes.POST("/create", deprecated("/api/v1/system-users"), controller.Create)
+		routes.PUT("/update", deprecated("/api/v1/system-users/{id}"), controller.Update)
+		routes.DELETE("/:id", deprecated("/api/v1/system-users/{id}"), controller.Delete)
+		routes.GET("/:id", deprecated("/api/v1/system-users/{id}"), controller.GetById)
+		routes.GET("/email", deprecated("/api/v1/system-users/by-email"), controller.GetByEmail)
+		routes.GET("/all", deprecated("/api/v1/system-users"), controller.GetAll)
+		routes.POST("/logout", deprecated("/api/v1/sessions/current"), controller.Logout)
+	}
+}
+
+func SystemUserRoutesV1(router *gin.RouterGroup, service services.SystemUserService) {
+	controller := controllers.NewSystemUserController(service)
+	routes := router.Group("/system-users")
+	{
+		routes.POST("", controller.Create)
+		routes.GET("", controller.GetAll)
+		routes.GET("/by-email", controller.GetByEmail)
 		routes.GET("/:id", controller.GetById)
-		routes.GET("/email", controller.GetByEmail)
-		routes.GET("/all", controller.GetAll)
-		routes.POST("/logout", controller.Logout)
+		routes.PUT("/:id", controller.Update)
+		routes.DELETE("/:id", controller.Delete)
 	}
+	router.DELETE("/sessions/current", controller.Logout)
+}
+
+func systemUserRoutesDoc(doc *openapi.Document) {
+	doc.AddTag("SystemUsers", "Sistem kullanıcıları ve oturum (system.users.* yetkileri)")
+	doc.Route("POST", "/system-user/create").Tag("SystemUsers").Summary("Kullanıcı oluşturur").Deprecated().
+		Body(data.SystemUser{}).Returns(data.SystemUser{})
+	doc.Route("PUT", "/system-user/update").Tag("SystemUsers").Summary("Kullanıcıyı günceller").Deprecated().
+		Body(data.SystemUser{}).Returns(data.SystemUser{})
+	doc.Route("DELETE", "/system-user/:id").Tag("SystemUsers").Summary("Kullanıcıyı siler").Deprecated().
+		PathParam("id", "uuid", "Kullanıcı ID").Returns(nil)
+	doc.Route("GET", "/system-user/:id").Tag("SystemUsers").Summary("Kullanıcıyı getirir").Deprecated().
+		PathParam("id", "uuid", "Kullanıcı ID").Returns(data.SystemUser{})
+	doc.Route("GET", "/system-user/email").Tag("SystemUsers").Summary("Kullanıcıyı e-posta adresiyle getirir").Deprecated().
+		QueryParam("email", "string", true, "E-posta adresi").Returns(data.SystemUser{})
+	doc.Route("GET", "/system-user/all").Tag("SystemUsers").Summary("Kullanıcıları sayfalı listeler").Deprecated().
+		Description("Alanlar: id, n, sn, e, ia").
+		Query(mvc.QueryModel{}).Returns(mvc.PagedResult[*data.SystemUser]{})
+	doc.Route("POST", "/system-user/logout").Tag("SystemUsers").Summary("Oturumu kapatır").Deprecated().Returns(nil)
+}
+
+func systemUserRoutesV1Doc(doc *openapi.Document) {
+	doc.Route("POST", "/api/v1/system-users").Tag("SystemUsers").Summary("Kullanıcı oluşturur").
+		Body(data.SystemUser{}).Responds(http.StatusCreated, data.SystemUser{}).
+		Problems(http.StatusConflict, http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/system-users").Tag("SystemUsers").Summary("Kullanıcıları sayfalı listeler").
+		Description("Alanlar: id, n, sn, e, ia").
+		Query(mvc.QueryModel{}).Responds(http.StatusOK, mvc.PagedResult[*data.SystemUser]{})
+	doc.Route("GET", "/api/v1/system-users/by-email").Tag("SystemUsers").Summary("Kullanıcıyı e-posta adresiyle getirir").
+		QueryParam("email", "string", true, "E-posta adresi").Responds(http.StatusOK, data.SystemUser{}).Problems(http.StatusNotFound)
+	doc.Route("GET", "/api/v1/system-users/:id").Tag("SystemUsers").Summary("Kullanıcıyı getirir").
+		PathParam("id", "uuid", "Kullanıcı ID").Responds(http.StatusOK, data.SystemUser{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/system-users/:id").Tag("SystemUsers").Summary("Kullanıcıyı günceller").
+		PathParam("id", "uuid", "Kullanıcı ID").Body(data.SystemUser{}).Responds(http.StatusOK, data.SystemUser{}).
+		Problems(http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity)
+	doc.Route("DELETE", "/api/v1/system-users/:id").Tag("SystemUsers").Summary("Kullanıcıyı siler").
+		Description("Kullanıcının ayarları veya zaman kayıtları varsa 409 döner.").
+		PathParam("id", "uuid", "Kullanıcı ID").Responds(http.StatusNoContent, nil).
+		Problems(http.StatusNotFound, http.StatusConflict)
+	doc.Route("DELETE", "/api/v1/sessions/current").Tag("SystemUsers").Summary("Oturumu kapatır").
+		Responds(http.StatusNoContent, nil)
 }
@@ -1,20 +1,67 @@
 package routers
 
 import (
+	"net/http"
+
 	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/openapi"
 	"lms-web-services-main/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+// SystemUserSettingRoutes, eski rotaları kaydeder; yerlerine SystemUserSettingRoutesV1 kullanılmalıdır
 func SystemUserSettingRoutes(router *gin.RouterGroup, service services.SystemUserSettingService) {
 	controller := controllers.NewSystemUserSettingController(service)
 	routes := router.Group("/system-user-settings")
 	{
-		routes.GET("/user/:userId", controller.GetByUserId)
+		routes.GET("/user/:userId", deprecated("/api/v1/system-users/{id}/settings"), controller.GetByUserId)
+		routes.GET("/:id", deprecated("/api/v1/system-user-settings/{id}"), controller.GetById)
+		routes.POST("/", deprecated("/api/v1/system-user-settings"), controller.Set)
+		routes.DELETE("/:id", deprecated("/api/v1/system-user-settings/{id}"), controller.Delete)
+		routes.GET("/value/:userId", deprecated("/api/v1/system-users/{id}/settings/value"), controller.GetValue)
+	}
+}
+
+func SystemUserSettingRoutesV1(router *gin.RouterGroup, service services.SystemUserSettingService) {
+	controller := controllers.NewSystemUserSettingController(service)
+	routes := router.Group("/system-user-settings")
+	{
+		routes.PUT("", controller.Set)
 		routes.GET("/:id", controller.GetById)
-		routes.POST("/", controller.Set)
 		routes.DELETE("/:id", controller.Delete)
-		routes.GET("/value/:userId", controller.GetValue)
 	}
+	router.GET("/system-users/:id/settings", withParamAlias("id", "userId", controller.GetByUserId))
+	router.GET("/system-users/:id/settings/value", withParamAlias("id", "userId", controller.GetValue))
+}
+
+func systemUserSettingRoutesDoc(doc *openapi.Document) {
+	doc.AddTag("SystemUserSettings", "Kullanıcı ayarları ve yetkileri (system.settings.* yetkileri)")
+	doc.Route("GET", "/system-user-settings/user/:userId").Tag("SystemUserSettings").Summary("Kullanıcının ayarlarını listeler").Deprecated().
+		PathParam("userId", "uuid", "Kullanıcı ID").Returns([]*data.SystemUserSetting{})
+	doc.Route("GET", "/system-user-settings/:id").Tag("SystemUserSettings").Summary("Ayarı getirir").Deprecated().
+		PathParam("id", "integer", "Ayar ID").Returns(data.SystemUserSetting{})
+	doc.Route("POST", "/system-user-settings/").Tag("SystemUserSettings").Summary("Ayarı oluşturur veya günceller").Deprecated().
+		Body(data.SystemUserSetting{}).Returns(data.SystemUserSetting{})
+	doc.Route("DELETE", "/system-user-settings/:id").Tag("SystemUserSettings").Summary("Ayarı siler").Deprecated().
+		PathParam("id", "integer", "Ayar ID").Returns(nil)
+	doc.Route("GET", "/system-user-settings/value/:userId").Tag("SystemUserSettings").Summary("Ayarın değerini getirir").Deprecated().
+		PathParam("userId", "uuid", "Kullanıcı ID").
+		QueryParam("key", "string", true, "Ayar anahtarı, örn. clients.view").Returns("")
+}
+
+func systemUserSettingRoutesV1Doc(doc *openapi.Document) {
+	doc.Route("PUT", "/api/v1/system-user-settings").Tag("SystemUserSettings").Summary("Ayarı oluşturur veya günceller").
+		Description("Kayıt kullanıcı ve anahtar ile eşleştirilir.").
+		Body(data.SystemUserSetting{}).Responds(http.StatusOK, data.SystemUserSetting{}).Problems(http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/system-user-settings/:id").Tag("SystemUserSettings").Summary("Ayarı getirir").
+		PathParam("id", "integer", "Ayar ID").Responds(http.StatusOK, data.SystemUserSetting{}).Problems(http.StatusNotFound)
+	doc.Route("DELETE", "/api/v1/system-user-settings/:id").Tag("SystemUserSettings").Summary("Ayarı siler").
+		PathParam("id", "integer", "Ayar ID").Responds(http.StatusNoContent, nil).Problems(http.StatusNotFound)
+	doc.Route("GET", "/api/v1/system-users/:id/settings").Tag("SystemUserSettings").Summary("Kullanıcının ayarlarını listeler").
+		PathParam("id", "uuid", "Kullanıcı ID").Responds(http.StatusOK, []*data.SystemUserSetting{})
+	doc.Route("GET", "/api/v1/system-users/:id/settings/value").Tag("SystemUserSettings").Summary("Ayarın değerini getirir").
+		PathParam("id", "uuid", "Kullanıcı ID").
+		QueryParam("key", "string", true, "Ayar anahtarı, örn. clients.view").Responds(http.StatusOK, "")
 }
@@ -0,0 +1,44 @@
+package routers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagRoutesV1, etiket rotalarını kaydeder. Etiketlerin eski (OperationResult dönen) rotaları yoktur.
+func TagRoutesV1(router *gin.RouterGroup, service services.TagService) {
+	controller := controllers.NewTagController(service)
+	routes := router.Group("/tags")
+	{
+		routes.POST("", controller.Create)
+		routes.GET("", controller.GetAll)
+		routes.GET("/:id", controller.GetById)
+		routes.PUT("/:id", controller.Update)
+		routes.DELETE("/:id", controller.Delete)
+	}
+}
+
+func tagRoutesV1Doc(doc *openapi.Document) {
+	doc.AddTag("Tags", "Zaman kayıtlarının etiketleri (listeleme timings.view, değişiklik system_settings.update yetkisi)")
+	doc.Route("POST", "/api/v1/tags").Tag("Tags").Summary("Etiket oluşturur").
+		Description("Etiket adı büyük/küçük harf duyarsız olarak tekildir (409). Renk #RRGGBB biçimindedir.").
+		Body(data.Tag{}).Responds(http.StatusCreated, data.Tag{}).Problems(http.StatusConflict, http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/tags").Tag("Tags").Summary("Etiketleri sayfalı listeler").
+		Description("Alanlar: id, n, c, desc").
+		Query(mvc.QueryModel{}).Responds(http.StatusOK, mvc.PagedResult[*data.Tag]{})
+	doc.Route("GET", "/api/v1/tags/:id").Tag("Tags").Summary("Etiketi getirir").
+		PathParam("id", "integer", "Etiket ID").Responds(http.StatusOK, data.Tag{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/tags/:id").Tag("Tags").Summary("Etiketi günceller").
+		PathParam("id", "integer", "Etiket ID").Body(data.Tag{}).Responds(http.StatusOK, data.Tag{}).
+		Problems(http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity)
+	doc.Route("DELETE", "/api/v1/tags/:id").Tag("Tags").Summary("Etiketi siler").
+		Description("Etiket, bağlı olduğu zaman kayıtlarından da kaldırılır.").
+		PathParam("id", "integer", "Etiket ID").Responds(http.StatusNoContent, nil).Problems(http.StatusNotFound)
+}
@@ -1,22 +1,97 @@
 package routers
 
 import (
+	"net/http"
+
 	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
 	"lms-web-services-main/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+// TimingRoutes, eski rotaları kaydeder; yerlerine TimingRoutesV1 kullanılmalıdır
 func TimingRoutes(router *gin.RouterGroup, service services.TimingService) {
 	controller := controllers.NewTimingController(service)
 	routes := router.Group("/timings")
 	{
-		routes.POST("/create", controller.Create)
-		routes.PUT("/update", controller.Update)
-		routes.DELETE(":id", controller.Delete)
-		routes.GET(":id", controller.GetById)
-		routes.GET("/all", controller.GetAll)
-		routes.GET("/client-project/:clientProjectId", controller.GetByClientProjectId)
+		routes.POST("/create", deprecated("/api/v1/timings"), controller.Create)
+		routes.PUT("/update", deprecated("/api/v1/timings/{id}"), controller.Update)
+		routes.DELETE(":id", deprecated("/api/v1/timings/{id}"), controller.Delete)
+		routes.GET(":id", deprecated("/api/v1/timings/{id}"), controller.GetById)
+		routes.GET("/all", deprecated("/api/v1/timings"), controller.GetAll)
+		routes.GET("/client-project/:clientProjectId", deprecated("/api/v1/client-projects/{id}/timings"), controller.GetByClientProjectId)
+		routes.GET("/date-range", deprecated("/api/v1/timings/date-range"), controller.GetByDateRange)
+	}
+}
+
+func TimingRoutesV1(router *gin.RouterGroup, service services.TimingService) {
+	controller := controllers.NewTimingController(service)
+	routes := router.Group("/timings")
+	{
+		routes.POST("", controller.Create)
+		routes.GET("", controller.GetAll)
 		routes.GET("/date-range", controller.GetByDateRange)
+		routes.GET("/:id", controller.GetById)
+		routes.PUT("/:id", controller.Update)
+		routes.DELETE("/:id", controller.Delete)
+		routes.GET("/:id/tags", controller.GetTags)
+		routes.PUT("/:id/tags", controller.SetTags)
 	}
+	router.GET("/client-projects/:id/timings", withParamAlias("id", "clientProjectId", controller.GetByClientProjectId))
+}
+
+func timingRoutesDoc(doc *openapi.Document) {
+	doc.AddTag("Timings", "Zaman kayıtları (timings.* yetkileri)")
+	doc.Route("POST", "/timings/create").Tag("Timings").Summary("Zaman kaydı oluşturur").Deprecated().
+		Body(data.Timing{}).Returns(data.Timing{})
+	doc.Route("PUT", "/timings/update").Tag("Timings").Summary("Zaman kaydını günceller").Deprecated().
+		Body(data.Timing{}).Returns(data.Timing{})
+	doc.Route("DELETE", "/timings/:id").Tag("Timings").Summary("Zaman kaydını siler").Deprecated().
+		PathParam("id", "integer", "Zaman kaydı ID").Returns(nil)
+	doc.Route("GET", "/timings/:id").Tag("Timings").Summary("Zaman kaydını getirir").Deprecated().
+		PathParam("id", "integer", "Zaman kaydı ID").Returns(data.Timing{})
+	doc.Route("GET", "/timings/all").Tag("Timings").Summary("Zaman kayıtlarını sayfalı listeler").Deprecated().
+		Description(timingFieldsDescription).
+		Query(mvc.QueryModel{}).Returns(mvc.PagedResult[*mvc.TimingViewModel]{})
+	doc.Route("GET", "/timings/client-project/:clientProjectId").Tag("Timings").Summary("Projenin zaman kayıtlarını listeler").Deprecated().
+		PathParam("clientProjectId", "integer", "Proje ID").Returns([]*data.Timing{})
+	doc.Route("GET", "/timings/date-range").Tag("Timings").Summary("Tarih aralığındaki zaman kayıtlarını listeler").Deprecated().
+		QueryParam("startDate", "date-time", true, "Başlangıç (RFC3339)").
+		QueryParam("endDate", "date-time", true, "Bitiş (RFC3339)").
+		Returns([]*data.Timing{})
 }
+
+func timingRoutesV1Doc(doc *openapi.Document) {
+	doc.Route("POST", "/api/v1/timings").Tag("Timings").Summary("Zaman kaydı oluşturur").
+		Description("Kaydın sahibi (suid) projenin üyesi olmalıdır. Pasif projelere, pasif müşterilerin projelerine ve bütçesi aşılan zorunlu bütçeli projelere kayıt girilemez (409). Görev (tid) verilirse kaydın projesine ait olmalıdır.").
+		Body(data.Timing{}).Responds(http.StatusCreated, data.Timing{}).Problems(http.StatusConflict, http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/timings").Tag("Timings").Summary("Zaman kayıtlarını sayfalı listeler").
+		Description(timingFieldsDescription).
+		Query(mvc.QueryModel{}).Responds(http.StatusOK, mvc.PagedResult[*mvc.TimingViewModel]{})
+	doc.Route("GET", "/api/v1/timings/date-range").Tag("Timings").Summary("Tarih aralığındaki zaman kayıtlarını listeler").
+		QueryParam("startDate", "date-time", true, "Başlangıç (RFC3339)").
+		QueryParam("endDate", "date-time", true, "Bitiş (RFC3339)").
+		Responds(http.StatusOK, []*data.Timing{})
+	doc.Route("GET", "/api/v1/timings/:id").Tag("Timings").Summary("Zaman kaydını getirir").
+		PathParam("id", "integer", "Zaman kaydı ID").Responds(http.StatusOK, data.Timing{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/timings/:id").Tag("Timings").Summary("Zaman kaydını günceller").
+		Description("Kaydı değiştiren kullanıcı kaydın projesinin üyesi olmalıdır; başka bir kullanıcının kaydı timings.add_for_others yetkisi gerektirir. Bütçesi zorunlu tutulan projelerde kaydın yeni süresi kalan bütçeyi aşamaz (409). Görev (tid) verilirse kaydın projesine ait olmalıdır.").
+		PathParam("id", "integer", "Zaman kaydı ID").Body(data.Timing{}).Responds(http.StatusOK, data.Timing{}).
+		Problems(http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity)
+	doc.Route("DELETE", "/api/v1/timings/:id").Tag("Timings").Summary("Zaman kaydını siler").
+		Description("Kaydı silen kullanıcı kaydın projesinin üyesi olmalıdır; başka bir kullanıcının kaydı timings.add_for_others yetkisi gerektirir.").
+		PathParam("id", "integer", "Zaman kaydı ID").Responds(http.StatusNoContent, nil).Problems(http.StatusNotFound, http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/timings/:id/tags").Tag("Timings").Summary("Zaman kaydının etiketlerini listeler").
+		PathParam("id", "integer", "Zaman kaydı ID").Responds(http.StatusOK, []*data.Tag{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/timings/:id/tags").Tag("Timings").Summary("Zaman kaydının etiketlerini değiştirir").
+		Description("Kaydın etiketleri verilenlerle değiştirilir; boş liste tüm etiketleri kaldırır. En fazla 20 etiket verilebilir. timings.update yetkisi gerektirir; kaydı değiştirme kuralları güncellemeyle aynıdır.").
+		PathParam("id", "integer", "Zaman kaydı ID").Body(mvc.TimingTags{}).Responds(http.StatusOK, []*data.Tag{}).
+		Problems(http.StatusNotFound, http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/client-projects/:id/timings").Tag("Timings").Summary("Projenin zaman kayıtlarını listeler").
+		PathParam("id", "integer", "Proje ID").Responds(http.StatusOK, []*data.Timing{})
+}
+
+const timingFieldsDescription = "Alanlar: id, cpid, cid, suid, title (t), description (desc), start_date_time (sdt), end_date_time (edt), status (st), client, client_project, task_id, task, tag_id, tag. tag_id ve tag, kaydın etiketlerinden biriyle eşleşir: \"tag_id in (1,2)\" etiketlerden birini, \"tag = 'a' and tag = 'b'\" ikisini de taşıyan kayıtları getirir."
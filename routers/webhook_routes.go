@@ -0,0 +1,63 @@
+package routers
+
+import (
+	"net/http"
+
+	"lms-web-services-main/controllers"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/openapi"
+	"lms-web-services-main/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookRoutesV1, webhook aboneliklerinin ve gönderim günlüğünün rotalarını kaydeder.
+// Webhook'ların eski (OperationResult dönen) rotaları yoktur.
+func WebhookRoutesV1(router *gin.RouterGroup, service services.WebhookService) {
+	controller := controllers.NewWebhookController(service)
+	routes := router.Group("/webhooks")
+	{
+		routes.POST("", controller.Create)
+		routes.GET("", controller.GetAll)
+		routes.GET("/:id", controller.GetById)
+		routes.PUT("/:id", controller.Update)
+		routes.DELETE("/:id", controller.Delete)
+		routes.GET("/:id/deliveries", controller.GetDeliveries)
+		routes.POST("/:id/deliveries/:deliveryId/redeliver", controller.Redeliver)
+	}
+}
+
+func webhookRoutesV1Doc(doc *openapi.Document) {
+	doc.AddTag("Webhooks", "Giden webhook abonelikleri (webhooks.view, webhooks.add, webhooks.update, webhooks.delete yetkileri)")
+	doc.Route("POST", "/api/v1/webhooks").Tag("Webhooks").Summary("Webhook aboneliği oluşturur").
+		Description("Aboneliğin olay türlerindeki (evts) her olay, url'e JSON olarak POST edilir: "+
+			"{\"id\", \"type\", \"occurred_at\", \"data\"}. İstek X-LMS-Event, X-LMS-Delivery, X-LMS-Timestamp ve "+
+			"X-LMS-Signature başlıklarını taşır; imza \"sha256=\" + hex(HMAC-SHA256(sec, X-LMS-Timestamp + \".\" + gövde)) "+
+			"biçimindedir. 2xx dışındaki yanıtlar üstel artan beklemeyle yeniden denenir. Olay türleri: "+
+			"client.*, client_project.* (client_project.budget_threshold_crossed dahil), client_contact.*, "+
+			"client_project_member.*, project_task.*, timing.*, tag.*, system_user.*, system_user_setting.*.").
+		Body(data.WebhookSubscription{}).Responds(http.StatusCreated, data.WebhookSubscription{}).Problems(http.StatusUnprocessableEntity)
+	doc.Route("GET", "/api/v1/webhooks").Tag("Webhooks").Summary("Webhook aboneliklerini sayfalı listeler").
+		Description("Alanlar: id, url, ia, desc").
+		Query(mvc.QueryModel{}).Responds(http.StatusOK, mvc.PagedResult[*data.WebhookSubscription]{})
+	doc.Route("GET", "/api/v1/webhooks/:id").Tag("Webhooks").Summary("Webhook aboneliğini getirir").
+		Description("İmza anahtarı (sec) yanıtlarda dönmez.").
+		PathParam("id", "integer", "Abonelik ID").Responds(http.StatusOK, data.WebhookSubscription{}).Problems(http.StatusNotFound)
+	doc.Route("PUT", "/api/v1/webhooks/:id").Tag("Webhooks").Summary("Webhook aboneliğini günceller").
+		Description("sec boş bırakılırsa kayıtlı imza anahtarı korunur.").
+		PathParam("id", "integer", "Abonelik ID").Body(data.WebhookSubscription{}).Responds(http.StatusOK, data.WebhookSubscription{}).
+		Problems(http.StatusNotFound, http.StatusUnprocessableEntity)
+	doc.Route("DELETE", "/api/v1/webhooks/:id").Tag("Webhooks").Summary("Webhook aboneliğini siler").
+		Description("Aboneliğin gönderim günlüğü de silinir; bekleyen gönderimler yapılmaz.").
+		PathParam("id", "integer", "Abonelik ID").Responds(http.StatusNoContent, nil).Problems(http.StatusNotFound)
+	doc.Route("GET", "/api/v1/webhooks/:id/deliveries").Tag("Webhooks").Summary("Aboneliğin gönderim günlüğünü sayfalı listeler").
+		Description("Varsayılan sıralama yeniden eskiyedir. Alanlar: id, eid, et, st (Pending, Delivered, Failed), ca").
+		PathParam("id", "integer", "Abonelik ID").Query(mvc.QueryModel{}).
+		Responds(http.StatusOK, mvc.PagedResult[*data.WebhookDelivery]{}).Problems(http.StatusNotFound)
+	doc.Route("POST", "/api/v1/webhooks/:id/deliveries/:deliveryId/redeliver").Tag("Webhooks").Summary("Gönderimi yeniden gönderir").
+		Description("Olay, asıl gönderime (rdo) bağlı yeni bir gönderimle kuyruğa eklenir; gövde ve olay kimliği değişmez. "+
+			"webhooks.update yetkisi gerektirir.").
+		PathParam("id", "integer", "Abonelik ID").PathParam("deliveryId", "integer", "Gönderim ID").
+		Responds(http.StatusAccepted, data.WebhookDelivery{}).Problems(http.StatusNotFound)
+}
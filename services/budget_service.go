@@ -0,0 +1,320 @@
+package services
+
+import (
+	"log/slog"
+	"math"
+	"time"
+
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/metrics"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	repositories "lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// #region Budget Service Interface
+
+// BudgetService, proje bütçelerinin harcanma durumunu zaman kayıtlarından hesaplar
+type BudgetService interface {
+	// GetStatus, projenin bütçe durumunu *mvc.BudgetStatus olarak döndürür
+	GetStatus(clientProjectId int, c *models.Context) *lgo.OperationResult
+	// Allow, bütçesi zorunlu tutulan (EnforceBudget) projelerde kaydın bütçeyi aşmadığını kontrol
+	// eder. previous, güncellenen kaydın veritabanındaki halidir; süresi harcanan bütçeden
+	// düşülür. Oluşturmada nil'dir.
+	Allow(timing *datamodels.Timing, previous *datamodels.Timing, c *models.Context) *lgo.OperationResult
+	// Track, kaydedilen ya da güncellenen zaman kaydıyla aşılan her eşik için
+	// ClientProjectBudgetThresholdCrossed olayı yayımlar ve eşiği BudgetAlerter'a bildirir.
+	// previous, güncellenen kaydın önceki halidir; oluşturmada nil'dir. Yalnızca olay yazılamazsa
+	// başarısız olur; bütçe hesaplanamazsa uyarı üretilmez.
+	Track(timing *datamodels.Timing, previous *datamodels.Timing, c *models.Context) *lgo.OperationResult
+}
+
+// BudgetAlerter, proje bütçesi bir eşiği ilk kez aştığında bilgilendirilir
+type BudgetAlerter interface {
+	BudgetThresholdCrossed(c *models.Context, alert *mvc.BudgetAlert)
+}
+
+//#endregion Budget Service Interface
+
+// #region Budget Service Implementation
+type budgetService struct {
+	projectRepo  repositories.ClientProjectRepository
+	timingRepo   repositories.TimingRepository
+	memberRepo   repositories.ClientProjectMemberRepository
+	cacheService CacheService
+	eventService EventService
+	alerter      BudgetAlerter
+	thresholds   []int
+	now          func() time.Time
+}
+
+// NewBudgetService, eşikleri yüzde olarak alır (ör. 80, 100). Eşik verilmezse uyarı üretilmez.
+func NewBudgetService(projectRepo repositories.ClientProjectRepository, timingRepo repositories.TimingRepository, memberRepo repositories.ClientProjectMemberRepository, cacheService CacheService, eventService EventService, alerter BudgetAlerter, thresholds []int) BudgetService {
+	return &budgetService{
+		projectRepo:  projectRepo,
+		timingRepo:   timingRepo,
+		memberRepo:   memberRepo,
+		cacheService: cacheService,
+		eventService: eventService,
+		alerter:      alerter,
+		thresholds:   thresholds,
+		now:          time.Now,
+	}
+}
+
+// #region Get Budget Status
+func (s *budgetService) GetStatus(clientProjectId int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "BudgetService.GetStatus")()
+
+	if clientProjectId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientProjectId)
+	}
+	if result := checkPermission(s.cacheService, c, datamodels.ClientProjectPermissions.View); !result.IsSuccess() {
+		return result
+	}
+
+	project, result := s.project(c, clientProjectId)
+	if !result.IsSuccess() {
+		return result
+	}
+	hours, result := s.consumed(c, project)
+	if !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(budgetStatus(project, hours, s.now()))
+}
+
+//#endregion Get Budget Status
+
+// #region Allow Timing
+func (s *budgetService) Allow(timing *datamodels.Timing, previous *datamodels.Timing, c *models.Context) *lgo.OperationResult {
+	project, result := s.project(c, timing.ClientProjectId)
+	if !result.IsSuccess() {
+		return result
+	}
+	if !project.EnforceBudget || !project.HasBudget() || !inBudgetWindow(project, timing.StartDateTime) {
+		return lgo.NewSuccess(nil)
+	}
+
+	// Süresi uzamayan güncellemeler bütçe aşılmış olsa da kabul edilir
+	duration := timing.EndDateTime.Sub(timing.StartDateTime).Hours()
+	previousHours, previousAmount := s.spent(c, project, previous)
+	if previous != nil && duration <= previousHours {
+		return lgo.NewSuccess(nil)
+	}
+
+	hours, result := s.consumed(c, project)
+	if !result.IsSuccess() {
+		return result
+	}
+	remaining := remainingHours(project, hours.Hours-previousHours, hours.Amount-previousAmount, s.hourlyRate(c, project, timing))
+	if duration > remaining {
+		return mvc.NewConflictError(i18n.ProjectBudgetExceeded, math.Max(remaining, 0))
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Allow Timing
+
+// #region Track Timing
+func (s *budgetService) Track(timing *datamodels.Timing, previous *datamodels.Timing, c *models.Context) *lgo.OperationResult {
+	if len(s.thresholds) == 0 {
+		return lgo.NewSuccess(nil)
+	}
+
+	project, result := s.project(c, timing.ClientProjectId)
+	if !result.IsSuccess() || !project.HasBudget() {
+		return lgo.NewSuccess(nil)
+	}
+	spentHours, spentAmount := s.spent(c, project, timing)
+	previousHours, previousAmount := s.spent(c, project, previous)
+	if spentHours <= previousHours && spentAmount <= previousAmount {
+		return lgo.NewSuccess(nil)
+	}
+	hours, result := s.consumed(c, project)
+	if !result.IsSuccess() {
+		return lgo.NewSuccess(nil)
+	}
+
+	// Kayıttan önceki harcama, kaydın payı çıkarılıp önceki hali eklenerek bulunur
+	after := consumedPercent(project, hours.Hours, hours.Amount)
+	before := consumedPercent(project, hours.Hours-spentHours+previousHours, hours.Amount-spentAmount+previousAmount)
+	for _, threshold := range s.thresholds {
+		if before < float64(threshold) && after >= float64(threshold) {
+			alert := &mvc.BudgetAlert{
+				ClientProjectId: project.Id,
+				Threshold:       threshold,
+				ConsumedPercent: round2(after),
+			}
+			crossed := &events.ClientProjectBudgetThresholdCrossed{
+				ClientProjectId: alert.ClientProjectId,
+				Threshold:       alert.Threshold,
+				ConsumedPercent: alert.ConsumedPercent,
+			}
+			if result := s.eventService.Publish(c, crossed); !result.IsSuccess() {
+				return result
+			}
+			s.alerter.BudgetThresholdCrossed(c, alert)
+		}
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Track Timing
+
+func (s *budgetService) project(c *models.Context, clientProjectId int) (*datamodels.ClientProject, *lgo.OperationResult) {
+	result := s.projectRepo.GetById(c, clientProjectId)
+	if !result.IsSuccess() {
+		return nil, result
+	}
+	return result.ReturnObject.(*datamodels.ClientProject), result
+}
+
+// hourlyRate, kaydı giren üyenin saatlik ücretini, üyenin ücreti yoksa projenin ücretini döndürür
+func (s *budgetService) hourlyRate(c *models.Context, project *datamodels.ClientProject, timing *datamodels.Timing) float64 {
+	result := s.memberRepo.GetByMember(c, project.Id, timing.SystemUserId)
+	if result.IsSuccess() {
+		if rate := result.ReturnObject.(*datamodels.ClientProjectMember).HourlyRate; rate != nil {
+			return *rate
+		}
+	}
+	return project.HourlyRate
+}
+
+// spent, kaydın projenin bütçesinden harcadığı saat ve tutardır. Kayıt nil ise, başka bir
+// projeye aitse ya da bütçe tarihleri dışında başlıyorsa sıfırdır.
+func (s *budgetService) spent(c *models.Context, project *datamodels.ClientProject, timing *datamodels.Timing) (float64, float64) {
+	if timing == nil || timing.ClientProjectId != project.Id || !inBudgetWindow(project, timing.StartDateTime) {
+		return 0, 0
+	}
+	hours := timing.EndDateTime.Sub(timing.StartDateTime).Hours()
+	return hours, hours * s.hourlyRate(c, project, timing)
+}
+
+// consumed, projenin bütçe aralığında başlayan kayıtlarının toplam süresini ve tutarını döndürür.
+// Bitiş tarihi gün olarak dahildir.
+func (s *budgetService) consumed(c *models.Context, project *datamodels.ClientProject) (*mvc.TimingHours, *lgo.OperationResult) {
+	var to *time.Time
+	if project.BudgetEndDate != nil {
+		end := project.BudgetEndDate.AddDate(0, 0, 1)
+		to = &end
+	}
+	result := s.timingRepo.SumHours(c, project.Id, project.BudgetStartDate, to)
+	if !result.IsSuccess() {
+		return nil, result
+	}
+	return result.ReturnObject.(*mvc.TimingHours), result
+}
+
+//#endregion Budget Service Implementation
+
+// #region Budget Calculation
+
+// budgetStatus, harcanan saatlerden bütçe durumunu hesaplar. Tahmini bitiş, bütçe
+// başlangıcından (yoksa ilk kayıttan) bu yana geçen günlerdeki ortalama harcama hızıyla
+// kalan bütçenin ne zaman tükeneceğidir.
+func budgetStatus(project *datamodels.ClientProject, hours *mvc.TimingHours, now time.Time) *mvc.BudgetStatus {
+	status := &mvc.BudgetStatus{
+		ClientProjectId: project.Id,
+		StartDate:       project.BudgetStartDate,
+		EndDate:         project.BudgetEndDate,
+		BudgetHours:     project.BudgetHours,
+		ConsumedHours:   round2(hours.Hours),
+		BudgetAmount:    project.BudgetAmount,
+		ConsumedAmount:  round2(hours.Amount),
+		ConsumedPercent: round2(consumedPercent(project, hours.Hours, hours.Amount)),
+	}
+	if project.BudgetHours > 0 {
+		status.RemainingHours = round2(math.Max(project.BudgetHours-hours.Hours, 0))
+	}
+	if project.BudgetAmount > 0 {
+		status.RemainingAmount = round2(math.Max(project.BudgetAmount-hours.Amount, 0))
+	}
+
+	// Kalan tutar, şimdiye kadarki ortalama ücretle saate çevrilir
+	rate := project.HourlyRate
+	if hours.Hours > 0 && hours.Amount > 0 {
+		rate = hours.Amount / hours.Hours
+	}
+	remaining := remainingHours(project, hours.Hours, hours.Amount, rate)
+	status.Exhausted = project.HasBudget() && remaining <= 0
+	if status.Exhausted || math.IsInf(remaining, 1) || hours.Hours == 0 {
+		return status
+	}
+
+	start := hours.FirstStartDateTime
+	if project.BudgetStartDate != nil {
+		start = project.BudgetStartDate
+	}
+	elapsedDays := math.Max(now.Sub(*start).Hours()/24, 1)
+	daysLeft := remaining / (hours.Hours / elapsedDays)
+	projected := now.Add(time.Duration(daysLeft * 24 * float64(time.Hour)))
+	status.ProjectedCompletion = &projected
+	status.ProjectedOverrun = project.BudgetEndDate != nil && projected.Before(project.BudgetEndDate.AddDate(0, 0, 1))
+	return status
+}
+
+// remainingHours, saat ve tutar bütçelerinden hangisi önce tükenecekse onun kalan saatini
+// döndürür; kalan tutar rate ücretiyle saate çevrilir. Bütçe yoksa +Inf döner.
+func remainingHours(project *datamodels.ClientProject, consumedHours float64, consumedAmount float64, rate float64) float64 {
+	remaining := math.Inf(1)
+	if project.BudgetHours > 0 {
+		remaining = project.BudgetHours - consumedHours
+	}
+	if project.BudgetAmount > 0 && rate > 0 {
+		remaining = math.Min(remaining, (project.BudgetAmount-consumedAmount)/rate)
+	}
+	return remaining
+}
+
+// consumedPercent, saat ve tutar bütçelerinin harcanma oranlarından büyük olanıdır
+func consumedPercent(project *datamodels.ClientProject, consumedHours float64, consumedAmount float64) float64 {
+	percent := 0.0
+	if project.BudgetHours > 0 {
+		percent = consumedHours / project.BudgetHours * 100
+	}
+	if project.BudgetAmount > 0 {
+		percent = math.Max(percent, consumedAmount/project.BudgetAmount*100)
+	}
+	return percent
+}
+
+// inBudgetWindow, zamanın projenin bütçe tarihleri içinde olup olmadığını döndürür
+func inBudgetWindow(project *datamodels.ClientProject, start time.Time) bool {
+	if project.BudgetStartDate != nil && start.Before(*project.BudgetStartDate) {
+		return false
+	}
+	return project.BudgetEndDate == nil || start.Before(project.BudgetEndDate.AddDate(0, 0, 1))
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// #endregion Budget Calculation
+
+// #region Log Budget Alerter
+
+// logBudgetAlerter, eşik aşımlarını uyarı olarak loglar ve metrik olarak sayar
+type logBudgetAlerter struct {
+	logger  *slog.Logger
+	metrics metrics.Recorder
+}
+
+func NewLogBudgetAlerter(logger *slog.Logger, metrics metrics.Recorder) BudgetAlerter {
+	return &logBudgetAlerter{logger: logger, metrics: metrics}
+}
+
+func (a *logBudgetAlerter) BudgetThresholdCrossed(c *models.Context, alert *mvc.BudgetAlert) {
+	a.metrics.BudgetAlert(alert.Threshold)
+	a.logger.WarnContext(c, "project budget threshold crossed",
+		slog.Int("client_project_id", alert.ClientProjectId),
+		slog.Int("threshold", alert.Threshold),
+		slog.Float64("consumed_percent", alert.ConsumedPercent))
+}
+
+// #endregion Log Budget Alerter
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+)
+
+var (
+	budgetStart = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	budgetEnd   = time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// addHours, projeye budgetStart'tan days gün sonra başlayan hours saatlik bir kayıt ekler
+func (f *fixture) addHours(t *testing.T, projectId int, days int, hours float64) {
+	t.Helper()
+	start := budgetStart.AddDate(0, 0, days).Add(9 * time.Hour)
+	f.addTiming(t, &datamodels.Timing{
+		ClientProjectId: projectId,
+		SystemUserId:    f.user.Id,
+		Title:           "Geliştirme",
+		StartDateTime:   start,
+		EndDateTime:     start.Add(time.Duration(hours * float64(time.Hour))),
+	})
+}
+
+func TestBudgetServiceStatus(t *testing.T) {
+	projected := func(day int) *time.Time {
+		value := budgetStart.AddDate(0, 0, day)
+		return &value
+	}
+
+	tests := []struct {
+		name    string
+		project datamodels.ClientProject
+		want    mvc.BudgetStatus
+	}{
+		{
+			name:    "without a budget",
+			project: datamodels.ClientProject{},
+			want:    mvc.BudgetStatus{ConsumedHours: 4},
+		},
+		{
+			name:    "hour budget projects completion from the burn rate",
+			project: datamodels.ClientProject{BudgetHours: 10, BudgetStartDate: &budgetStart, BudgetEndDate: &budgetEnd},
+			want: mvc.BudgetStatus{
+				StartDate: &budgetStart, EndDate: &budgetEnd,
+				BudgetHours: 10, ConsumedHours: 4, RemainingHours: 6, ConsumedPercent: 40,
+				ProjectedCompletion: projected(10), ProjectedOverrun: true,
+			},
+		},
+		{
+			name:    "amount budget uses the hourly rate",
+			project: datamodels.ClientProject{BudgetAmount: 1000, HourlyRate: 100, BudgetStartDate: &budgetStart},
+			want: mvc.BudgetStatus{
+				StartDate:     &budgetStart,
+				ConsumedHours: 4,
+				BudgetAmount:  1000, ConsumedAmount: 400, RemainingAmount: 600, ConsumedPercent: 40,
+				ProjectedCompletion: projected(10),
+			},
+		},
+		{
+			name:    "the tighter budget wins",
+			project: datamodels.ClientProject{BudgetHours: 5, BudgetAmount: 1000, HourlyRate: 100, BudgetStartDate: &budgetStart},
+			want: mvc.BudgetStatus{
+				StartDate:   &budgetStart,
+				BudgetHours: 5, ConsumedHours: 4, RemainingHours: 1,
+				BudgetAmount: 1000, ConsumedAmount: 400, RemainingAmount: 600, ConsumedPercent: 80,
+				ProjectedCompletion: projected(5),
+			},
+		},
+		{
+			name:    "exhausted budget has no projection",
+			project: datamodels.ClientProject{BudgetHours: 4},
+			want:    mvc.BudgetStatus{BudgetHours: 4, ConsumedHours: 4, ConsumedPercent: 100, Exhausted: true},
+		},
+		{
+			name:    "timings outside the budget dates are not counted",
+			project: datamodels.ClientProject{BudgetHours: 10, BudgetStartDate: projected(2), BudgetEndDate: projected(2)},
+			want: mvc.BudgetStatus{
+				StartDate: projected(2), EndDate: projected(2),
+				BudgetHours: 10, ConsumedHours: 1, RemainingHours: 9, ConsumedPercent: 10,
+				ProjectedCompletion: projected(22),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, datamodels.CLIENTPROJECTS_VIEW)
+			project := test.project
+			project.ClientId = f.addClient(t, "ACME").Id
+			project.Name = "Web sitesi"
+			mustSucceed(t, f.repos.ClientProjects.Create(f.c, &project))
+			for day := 0; day < 4; day++ {
+				f.addHours(t, project.Id, day, 1)
+			}
+
+			service := f.budgetService(nil).(*budgetService)
+			service.now = func() time.Time { return budgetStart.AddDate(0, 0, 4) }
+			result := service.GetStatus(project.Id, f.c)
+			mustSucceed(t, result)
+
+			got := result.ReturnObject.(*mvc.BudgetStatus)
+			test.want.ClientProjectId = project.Id
+			if !equalBudgetStatus(got, &test.want) {
+				t.Fatalf("want %+v\ngot  %+v", test.want, *got)
+			}
+		})
+	}
+}
+
+func TestBudgetServiceStatusRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		id          func(existing *datamodels.ClientProject) int
+		want        expected
+	}{
+		{name: "invalid id", permissions: []string{datamodels.CLIENTPROJECTS_VIEW}, id: func(*datamodels.ClientProject) int { return 0 }, want: invalid(i18n.InvalidClientProjectId)},
+		{name: "missing project", permissions: []string{datamodels.CLIENTPROJECTS_VIEW}, id: func(*datamodels.ClientProject) int { return 99 }, want: notFound(i18n.ClientProjectNotFound)},
+		{name: "needs the project view permission", permissions: []string{datamodels.TIMINGS_VIEW}, id: func(existing *datamodels.ClientProject) int { return existing.Id }, want: invalid(i18n.PermissionNotFound)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			existing := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+			test.want.check(t, f.budgetService(nil).GetStatus(test.id(existing), f.c))
+		})
+	}
+}
+
+// TestBudgetServiceAlerts, her eşiğin yalnızca aşıldığı kayıtta bir kez bildirildiğini doğrular
+func TestBudgetServiceAlerts(t *testing.T) {
+	f := newFixture(t, allTimingPermissions...)
+	project := &datamodels.ClientProject{ClientId: f.addClient(t, "ACME").Id, Name: "Web sitesi", IsActive: true, BudgetHours: 10}
+	mustSucceed(t, f.repos.ClientProjects.Create(f.c, project))
+	f.addMember(t, project.Id, f.user)
+
+	alerts := &budgetAlerts{}
+	service := f.timingService(f.budgetService(alerts, 80, 100))
+
+	steps := []struct {
+		hours float64
+		want  []int
+	}{
+		{hours: 7, want: nil},
+		{hours: 1, want: []int{80}},
+		{hours: 1, want: nil},
+		{hours: 2, want: []int{100}},
+		{hours: 1, want: nil},
+	}
+	for day, step := range steps {
+		*alerts = nil
+		start := budgetStart.AddDate(0, 0, day)
+		mustSucceed(t, service.Create(&datamodels.Timing{
+			ClientProjectId: project.Id,
+			SystemUserId:    f.user.Id,
+			Title:           "Geliştirme",
+			StartDateTime:   start,
+			EndDateTime:     start.Add(time.Duration(step.hours * float64(time.Hour))),
+		}, f.c))
+
+		var got []int
+		for _, alert := range *alerts {
+			if alert.ClientProjectId != project.Id {
+				t.Errorf("want alerts for project %d, got %+v", project.Id, alert)
+			}
+			got = append(got, alert.Threshold)
+		}
+		if len(got) != len(step.want) || (len(got) > 0 && got[0] != step.want[0]) {
+			t.Errorf("timing %d: want alerts %v, got %v", day+1, step.want, got)
+		}
+	}
+}
+
+func TestTimingServiceEnforcesBudget(t *testing.T) {
+	tests := []struct {
+		name    string
+		project datamodels.ClientProject
+		rate    *float64
+		day     int
+		hours   float64
+		want    expected
+	}{
+		{name: "within the budget", project: datamodels.ClientProject{BudgetHours: 2, EnforceBudget: true}, hours: 0.5, want: ok()},
+		{name: "over the hour budget", project: datamodels.ClientProject{BudgetHours: 2, EnforceBudget: true}, hours: 1, want: conflict(i18n.ProjectBudgetExceeded)},
+		{name: "over the amount budget", project: datamodels.ClientProject{BudgetAmount: 300, HourlyRate: 100, EnforceBudget: true}, hours: 2, want: conflict(i18n.ProjectBudgetExceeded)},
+		{name: "over the amount budget at the member's rate", project: datamodels.ClientProject{BudgetAmount: 300, HourlyRate: 100, EnforceBudget: true}, rate: ptr(200.0), hours: 0.5, want: conflict(i18n.ProjectBudgetExceeded)},
+		{name: "within the amount budget at the project's rate", project: datamodels.ClientProject{BudgetAmount: 300, HourlyRate: 100, EnforceBudget: true}, hours: 0.5, want: ok()},
+		{name: "budget not enforced", project: datamodels.ClientProject{BudgetHours: 2}, hours: 1, want: ok()},
+		{name: "after the budget end date", project: datamodels.ClientProject{BudgetHours: 2, BudgetEndDate: &budgetStart, EnforceBudget: true}, day: 1, hours: 1, want: ok()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, allTimingPermissions...)
+			project := test.project
+			project.ClientId = f.addClient(t, "ACME").Id
+			project.Name = "Web sitesi"
+			project.IsActive = true
+			mustSucceed(t, f.repos.ClientProjects.Create(f.c, &project))
+			member := f.addMember(t, project.Id, f.user)
+			member.HourlyRate = test.rate
+			mustSucceed(t, f.repos.ClientProjectMembers.Update(f.c, member))
+			f.addHours(t, project.Id, 0, 1.5)
+
+			start := budgetStart.AddDate(0, 0, test.day).Add(13 * time.Hour)
+			result := f.timingService(f.budgetService(nil)).Create(&datamodels.Timing{
+				ClientProjectId: project.Id,
+				SystemUserId:    f.user.Id,
+				Title:           "Geliştirme",
+				StartDateTime:   start,
+				EndDateTime:     start.Add(time.Duration(test.hours * float64(time.Hour))),
+			}, f.c)
+			test.want.check(t, result)
+		})
+	}
+}
+
+// TestTimingServiceBudgetOnUpdate, güncellemede kaydın önceki süresinin harcanan bütçeden
+// düşüldüğünü ve eşiklerin güncellemeyle aşıldığında bildirildiğini doğrular
+func TestTimingServiceBudgetOnUpdate(t *testing.T) {
+	f := newFixture(t, allTimingPermissions...)
+	project := &datamodels.ClientProject{ClientId: f.addClient(t, "ACME").Id, Name: "Web sitesi", IsActive: true, BudgetHours: 10, EnforceBudget: true}
+	mustSucceed(t, f.repos.ClientProjects.Create(f.c, project))
+	f.addMember(t, project.Id, f.user)
+	f.addHours(t, project.Id, 0, 6)
+
+	alerts := &budgetAlerts{}
+	service := f.timingService(f.budgetService(alerts, 80, 100))
+	start := budgetStart.AddDate(0, 0, 1).Add(9 * time.Hour)
+	timing := f.addTiming(t, &datamodels.Timing{
+		ClientProjectId: project.Id,
+		SystemUserId:    f.user.Id,
+		Title:           "Geliştirme",
+		StartDateTime:   start,
+		EndDateTime:     start.Add(time.Hour),
+	})
+
+	steps := []struct {
+		name   string
+		hours  float64
+		want   expected
+		alerts []int
+	}{
+		{name: "extending past a threshold", hours: 2.5, want: ok(), alerts: []int{80}},
+		{name: "extending below the next threshold", hours: 3, want: ok()},
+		{name: "extending over the budget", hours: 4.5, want: conflict(i18n.ProjectBudgetExceeded)},
+		{name: "extending up to the budget", hours: 4, want: ok(), alerts: []int{100}},
+		{name: "shortening", hours: 1, want: ok()},
+	}
+	for _, step := range steps {
+		*alerts = nil
+		update := *timing
+		update.EndDateTime = start.Add(time.Duration(step.hours * float64(time.Hour)))
+		step.want.check(t, service.Update(&update, f.c))
+
+		var got []int
+		for _, alert := range *alerts {
+			got = append(got, alert.Threshold)
+		}
+		if len(got) != len(step.alerts) || (len(got) > 0 && got[0] != step.alerts[0]) {
+			t.Errorf("%s: want alerts %v, got %v", step.name, step.alerts, got)
+		}
+	}
+}
+
+func equalBudgetStatus(a *mvc.BudgetStatus, b *mvc.BudgetStatus) bool {
+	equalTime := func(x *time.Time, y *time.Time) bool {
+		return (x == nil && y == nil) || (x != nil && y != nil && x.Equal(*y))
+	}
+	ac, bc := *a, *b
+	ac.StartDate, ac.EndDate, ac.ProjectedCompletion = nil, nil, nil
+	bc.StartDate, bc.EndDate, bc.ProjectedCompletion = nil, nil, nil
+	return ac == bc && equalTime(a.StartDate, b.StartDate) && equalTime(a.EndDate, b.EndDate) &&
+		equalTime(a.ProjectedCompletion, b.ProjectedCompletion)
+}
@@ -9,47 +9,43 @@ import (
 	"github.com/google/uuid"
 )
 
-var (
-	CacheService cacheServiceInterface = &cacheService{}
-)
-
-type cacheServiceInterface interface {
-	AuthenticateSystemUser(token string) *lgo.OperationResult
-	GetSystemUserCredential(token string) *lgo.OperationResult
-	RegisterSystemUserCredential(token string, systemUser *datamodels.SystemUser) *lgo.OperationResult
-	DeleteSystemUserCredential(token string) *lgo.OperationResult
-	DeleteSystemUserCredentialById(id uuid.UUID) *lgo.OperationResult
+type CacheService interface {
+	GetSystemUserCredential(c *models.Context, token string) *lgo.OperationResult
+	RegisterSystemUserCredential(c *models.Context, token string, systemUser *datamodels.SystemUser) *lgo.OperationResult
+	DeleteSystemUserCredential(c *models.Context, token string) *lgo.OperationResult
+	DeleteSystemUserCredentialById(c *models.Context, id uuid.UUID) *lgo.OperationResult
 	GetSystemUserSetting(c *models.Context, setting string) *lgo.OperationResult
-	RemoveSystemUserSetting(systemUserId uuid.UUID, setting string) *lgo.OperationResult
+	RemoveSystemUserSetting(c *models.Context, systemUserId uuid.UUID, setting string) *lgo.OperationResult
 }
 
 type cacheService struct {
+	repo repositories.CacheRepository
 }
 
-func (*cacheService) AuthenticateSystemUser(token string) *lgo.OperationResult {
-	return repositories.CacheRepository.AuthenticateSystemUser(token)
+func NewCacheService(repo repositories.CacheRepository) CacheService {
+	return &cacheService{repo: repo}
 }
 
-func (*cacheService) GetSystemUserCredential(token string) *lgo.OperationResult {
-	return repositories.CacheRepository.GetSystemUserCredential(token)
+func (s *cacheService) GetSystemUserCredential(c *models.Context, token string) *lgo.OperationResult {
+	return s.repo.GetSystemUserCredential(c, token)
 }
 
-func (*cacheService) RegisterSystemUserCredential(token string, systemUser *datamodels.SystemUser) *lgo.OperationResult {
-	return repositories.CacheRepository.RegisterSystemUserCredential(token, systemUser)
+func (s *cacheService) RegisterSystemUserCredential(c *models.Context, token string, systemUser *datamodels.SystemUser) *lgo.OperationResult {
+	return s.repo.RegisterSystemUserCredential(c, token, systemUser)
 }
 
-func (*cacheService) DeleteSystemUserCredential(token string) *lgo.OperationResult {
-	return repositories.CacheRepository.DeleteSystemUserCredential(token)
+func (s *cacheService) DeleteSystemUserCredential(c *models.Context, token string) *lgo.OperationResult {
+	return s.repo.DeleteSystemUserCredential(c, token)
 }
 
-func (*cacheService) GetSystemUserSetting(c *models.Context, setting string) *lgo.OperationResult {
-	return repositories.CacheRepository.GetSystemUserSetting(c, setting)
+func (s *cacheService) GetSystemUserSetting(c *models.Context, setting string) *lgo.OperationResult {
+	return s.repo.GetSystemUserSetting(c, setting)
 }
 
-func (*cacheService) RemoveSystemUserSetting(systemUserId uuid.UUID, setting string) *lgo.OperationResult {
-	return repositories.CacheRepository.RemoveSystemUserSetting(systemUserId, setting)
+func (s *cacheService) RemoveSystemUserSetting(c *models.Context, systemUserId uuid.UUID, setting string) *lgo.OperationResult {
+	return s.repo.RemoveSystemUserSetting(c, systemUserId, setting)
 }
 
-func (*cacheService) DeleteSystemUserCredentialById(id uuid.UUID) *lgo.OperationResult {
-	return repositories.CacheRepository.DeleteSystemUserCredentialById(id)
+func (s *cacheService) DeleteSystemUserCredentialById(c *models.Context, id uuid.UUID) *lgo.OperationResult {
+	return s.repo.DeleteSystemUserCredentialById(c, id)
 }
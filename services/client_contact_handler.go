@@ -0,0 +1,30 @@
+package services
+
+import (
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// ClientContact'a özgü kurallar. Doğrulama ve yetki kuralları crudService tarafından kurulur.
+
+// #region Client Exists
+// ClientContactRuleHandlerClientExists, yeni yetkilinin eklendiği müşterinin kayıtlı
+// olduğunu kontrol eder; müşteri yoksa ClientNotFound döner
+type ClientContactRuleHandlerClientExists struct {
+	ClientRepository repositories.ClientRepository
+}
+
+func (h *ClientContactRuleHandlerClientExists) Handle(model *datamodels.ClientContact, c *models.Context) *lgo.OperationResult {
+	if !model.IsNew() {
+		return lgo.NewSuccess(nil)
+	}
+	if result := h.ClientRepository.GetById(c, model.ClientId); !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Client Exists
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	repositories "lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// #region Client Contact Service Interface
+
+// ClientContactService, /clients/:id/contacts alt kaynağının işlemleridir. Yetkililere her
+// zaman müşterileri üzerinden erişilir; başka bir müşterinin yetkilisi bulunamadı sayılır.
+type ClientContactService interface {
+	Create(contact *datamodels.ClientContact, c *models.Context) *lgo.OperationResult
+	Update(contact *datamodels.ClientContact, c *models.Context) *lgo.OperationResult
+	Delete(clientId int, id int, c *models.Context) *lgo.OperationResult
+	GetById(clientId int, id int, c *models.Context) *lgo.OperationResult
+	GetByClientId(clientId int, c *models.Context) *lgo.OperationResult
+}
+
+//#endregion Client Contact Service Interface
+
+// #region Client Contact Service Implementation
+type clientContactService struct {
+	crud       *crudService[datamodels.ClientContact, int, *datamodels.ClientContact]
+	repo       repositories.ClientContactRepository
+	clientRepo repositories.ClientRepository
+}
+
+func NewClientContactService(repo repositories.ClientContactRepository, clientRepo repositories.ClientRepository, cacheService CacheService, eventService EventService) ClientContactService {
+	service := &clientContactService{
+		crud: newCrudService[datamodels.ClientContact, int](repo, cacheService, CrudServiceOptions{
+			Name:        "ClientContactService",
+			Permissions: datamodels.ClientContactPermissions,
+			InvalidId:   i18n.InvalidId,
+		}),
+		repo:       repo,
+		clientRepo: clientRepo,
+	}
+
+	service.crud.events = crudEvents[datamodels.ClientContact, int]{
+		service: eventService,
+		created: func(contact *datamodels.ClientContact) events.Event {
+			return &events.ClientContactCreated{Contact: *contact}
+		},
+		updated: func(_ *datamodels.ClientContact, contact *datamodels.ClientContact) events.Event {
+			return &events.ClientContactUpdated{Contact: *contact}
+		},
+		deleted: func(id int) events.Event { return &events.ClientContactDeleted{ContactId: id} },
+	}
+
+	service.crud.saveRules = service.crud.saveRules.Then(
+		&ClientContactRuleHandlerClientExists{ClientRepository: clientRepo},
+	)
+
+	return service
+}
+
+// #region Create
+func (s *clientContactService) Create(contact *datamodels.ClientContact, c *models.Context) *lgo.OperationResult {
+	return s.crud.Create(contact, c)
+}
+
+//#endregion Create
+
+// #region Update
+func (s *clientContactService) Update(contact *datamodels.ClientContact, c *models.Context) *lgo.OperationResult {
+	if result := s.GetById(contact.ClientId, contact.Id, c); !result.IsSuccess() {
+		return result
+	}
+	return s.crud.Update(contact, c)
+}
+
+//#endregion Update
+
+// #region Delete
+func (s *clientContactService) Delete(clientId int, id int, c *models.Context) *lgo.OperationResult {
+	if result := s.GetById(clientId, id, c); !result.IsSuccess() {
+		return result
+	}
+	return s.crud.Delete(id, c)
+}
+
+//#endregion Delete
+
+// #region Get By Id
+func (s *clientContactService) GetById(clientId int, id int, c *models.Context) *lgo.OperationResult {
+	if clientId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientId)
+	}
+
+	result := s.crud.GetById(id, c)
+	if !result.IsSuccess() {
+		return result
+	}
+	if result.ReturnObject.(*datamodels.ClientContact).ClientId != clientId {
+		return mvc.NewNotFoundError(i18n.ClientContactNotFound)
+	}
+	return result
+}
+
+//#endregion Get By Id
+
+// #region Get ClientContacts By ClientId
+func (s *clientContactService) GetByClientId(clientId int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientContactService.GetByClientId")()
+
+	if clientId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientId)
+	}
+
+	contact := &datamodels.ClientContact{ClientId: clientId}
+	if result := handleRules(c, "ClientContactService.readRules", s.crud.readRules, contact); !result.IsSuccess() {
+		return result
+	}
+	if result := s.clientRepo.GetById(c, clientId); !result.IsSuccess() {
+		return result
+	}
+	return s.repo.GetByClientId(c, clientId)
+}
+
+//#endregion Get ClientContacts By ClientId
+
+//#endregion Client Contact Service Implementation
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+
+	"github.com/LGYtech/lgo"
+)
+
+var allClientContactPermissions = []string{datamodels.CLIENTS_VIEW, datamodels.CLIENTS_UPDATE}
+
+func TestClientContactServiceRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s ClientContactService, f *fixture, existing *datamodels.ClientContact) *lgo.OperationResult
+		want        expected
+	}{
+		{
+			name:        "create",
+			permissions: allClientContactPermissions,
+			run: func(s ClientContactService, f *fixture, existing *datamodels.ClientContact) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientContact{ClientId: existing.ClientId, Name: "Can Demir", Email: "can@example.com"}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create validates the email",
+			permissions: allClientContactPermissions,
+			run: func(s ClientContactService, f *fixture, existing *datamodels.ClientContact) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientContact{ClientId: existing.ClientId, Name: "Can Demir", Email: "can"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create for a missing client",
+			permissions: allClientContactPermissions,
+			run: func(s ClientContactService, f *fixture, _ *datamodels.ClientContact) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientContact{ClientId: 99, Name: "Can Demir"}, f.c)
+			},
+			want: notFound(i18n.ClientNotFound),
+		},
+		{
+			name:        "create needs the client update permission",
+			permissions: []string{datamodels.CLIENTS_VIEW},
+			run: func(s ClientContactService, f *fixture, existing *datamodels.ClientContact) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientContact{ClientId: existing.ClientId, Name: "Can Demir"}, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "update",
+			permissions: allClientContactPermissions,
+			run: func(s ClientContactService, f *fixture, existing *datamodels.ClientContact) *lgo.OperationResult {
+				existing.Role = "Satın alma"
+				return s.Update(existing, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "update a contact of another client",
+			permissions: allClientContactPermissions,
+			run: func(s ClientContactService, f *fixture, existing *datamodels.ClientContact) *lgo.OperationResult {
+				existing.ClientId = f.addClient(t, "GLOBEX").Id
+				return s.Update(existing, f.c)
+			},
+			want: notFound(i18n.ClientContactNotFound),
+		},
+		{
+			name:        "delete",
+			permissions: allClientContactPermissions,
+			run: func(s ClientContactService, f *fixture, existing *datamodels.ClientContact) *lgo.OperationResult {
+				return s.Delete(existing.ClientId, existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "delete missing contact",
+			permissions: allClientContactPermissions,
+			run: func(s ClientContactService, f *fixture, existing *datamodels.ClientContact) *lgo.OperationResult {
+				return s.Delete(existing.ClientId, 99, f.c)
+			},
+			want: notFound(i18n.ClientContactNotFound),
+		},
+		{
+			name:        "get by id",
+			permissions: []string{datamodels.CLIENTS_VIEW},
+			run: func(s ClientContactService, f *fixture, existing *datamodels.ClientContact) *lgo.OperationResult {
+				return s.GetById(existing.ClientId, existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "list for a missing client",
+			permissions: []string{datamodels.CLIENTS_VIEW},
+			run: func(s ClientContactService, f *fixture, _ *datamodels.ClientContact) *lgo.OperationResult {
+				return s.GetByClientId(99, f.c)
+			},
+			want: notFound(i18n.ClientNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			existing := &datamodels.ClientContact{ClientId: f.addClient(t, "ACME").Id, Name: "Ada Yılmaz", IsPrimary: true}
+			mustSucceed(t, f.repos.ClientContacts.Create(f.c, existing))
+			test.want.check(t, test.run(NewClientContactService(f.repos.ClientContacts, f.repos.Clients, f.cache, f.events), f, existing))
+		})
+	}
+}
+
+// TestClientContactServiceKeepsOnePrimary, yeni birincil yetkilinin öncekinin yerini aldığını doğrular
+func TestClientContactServiceKeepsOnePrimary(t *testing.T) {
+	f := newFixture(t, allClientContactPermissions...)
+	client := f.addClient(t, "ACME")
+	s := NewClientContactService(f.repos.ClientContacts, f.repos.Clients, f.cache, f.events)
+
+	mustSucceed(t, s.Create(&datamodels.ClientContact{ClientId: client.Id, Name: "Ada Yılmaz", IsPrimary: true}, f.c))
+	mustSucceed(t, s.Create(&datamodels.ClientContact{ClientId: client.Id, Name: "Can Demir", IsPrimary: true}, f.c))
+
+	result := s.GetByClientId(client.Id, f.c)
+	mustSucceed(t, result)
+	for _, contact := range result.ReturnObject.([]*datamodels.ClientContact) {
+		if contact.IsPrimary != (contact.Name == "Can Demir") {
+			t.Errorf("want only the latest contact to be primary, got %+v", contact)
+		}
+	}
+}
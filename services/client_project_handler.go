@@ -1,143 +1,70 @@
 package services
 
 import (
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
-	"lms-web-services-main/models/data"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
 
 	"github.com/LGYtech/lgo"
 )
 
-type ClientProjectRuleHandler interface {
-	Handle(model *data.ClientProject, c *models.Context) *lgo.OperationResult
-	SetNext(handler ClientProjectRuleHandler) ClientProjectRuleHandler
-}
-
-type BaseClientProjectRuleHandler struct {
-	next ClientProjectRuleHandler
-}
+// ClientProject'e özgü kurallar. Doğrulama ve yetki kuralları crudService tarafından kurulur.
 
-func (h *BaseClientProjectRuleHandler) SetNext(handler ClientProjectRuleHandler) ClientProjectRuleHandler {
-	h.next = handler
-	return handler
-}
+// #region Budget
+// ClientProjectRuleHandlerBudget, tutar bütçesi için saatlik ücreti zorunlu tutar ve bütçe
+// bitiş tarihinin başlangıçtan önce olmasını engeller. Tarihlerden yalnızca biri verilebilir.
+type ClientProjectRuleHandlerBudget struct{}
 
-func (h *BaseClientProjectRuleHandler) Handle(model *data.ClientProject, c *models.Context) *lgo.OperationResult {
-	if h.next != nil {
-		return h.next.Handle(model, c)
+func (h *ClientProjectRuleHandlerBudget) Handle(model *datamodels.ClientProject, c *models.Context) *lgo.OperationResult {
+	message := i18n.New(i18n.InvalidFields)
+	if model.BudgetAmount > 0 && model.HourlyRate == 0 {
+		message.WithField("hr", i18n.New(i18n.Required, i18n.New(i18n.FieldHourlyRate)))
 	}
-	return lgo.NewSuccess(nil)
-}
-
-// #region Validation Handler
-type ClientProjectRuleHandlerValidation struct {
-	BaseClientProjectRuleHandler
-}
-
-func (h *ClientProjectRuleHandlerValidation) Handle(model *data.ClientProject, c *models.Context) *lgo.OperationResult {
-	err := model.Validate()
-	if err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
+	if model.BudgetStartDate != nil && model.BudgetEndDate != nil && model.BudgetEndDate.Before(*model.BudgetStartDate) {
+		message.WithField("bed", i18n.New(i18n.NotBefore, i18n.New(i18n.FieldBudgetEndDate), i18n.New(i18n.FieldBudgetStartDate)))
 	}
-
-	if h.next != nil {
-		return h.next.Handle(model, c)
+	if len(message.Fields) > 0 {
+		return mvc.NewValidationErrorFrom(message)
 	}
 	return lgo.NewSuccess(nil)
 }
 
-//#endregion Validation Handler
+//#endregion Budget
 
-// #region Update Validation Handler
-type ClientProjectRuleHandlerUpdateValidation struct {
-	BaseClientProjectRuleHandler
+// #region Client
+// ClientProjectRuleHandlerClient, projenin müşterisinin var olmasını ve arşivdeki müşteriye
+// aktif proje açılmamasını sağlar. Yalnızca oluşturmada çalışır; müşteri güncellemede
+// değiştirilemez.
+type ClientProjectRuleHandlerClient struct {
+	ClientRepository repositories.ClientRepository
 }
 
-func (h *ClientProjectRuleHandlerUpdateValidation) Handle(model *data.ClientProject, c *models.Context) *lgo.OperationResult {
-	err := model.ValidateForUpdate()
-	if err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
-	}
-
-	if h.next != nil {
-		return h.next.Handle(model, c)
-	}
-	return lgo.NewSuccess(nil)
-}
-
-//#endregion Update Validation Handler
-
-// #region Alter Authorization Handler
-type ClientProjectRuleHandlerCheckAlterAuthorization struct {
-	BaseClientProjectRuleHandler
-}
-
-func (h *ClientProjectRuleHandlerCheckAlterAuthorization) Handle(model *data.ClientProject, c *models.Context) *lgo.OperationResult {
-	var permissionKey string
-	if model.Id == 0 {
-		permissionKey = data.CLIENTPROJECTS_ADD
-	} else {
-		permissionKey = data.CLIENTPROJECTS_UPDATE
-	}
-
-	result := CacheService.GetSystemUserSetting(c, permissionKey)
+func (h *ClientProjectRuleHandlerClient) Handle(model *datamodels.ClientProject, c *models.Context) *lgo.OperationResult {
+	result := h.ClientRepository.GetById(c, model.ClientId)
 	if !result.IsSuccess() {
 		return result
 	}
-	if result.ReturnObject.(string) != "1" {
-		result = lgo.NewLogicError("Bu işlem için yetkiniz yok.", nil)
-		return result
-	}
-
-	if h.next != nil {
-		return h.next.Handle(model, c)
-	}
-	return lgo.NewSuccess(nil)
-}
-
-//#endregion Alter Authorization Handler
-
-//#region Read Authorization Handler
-
-type ClientProjectRuleHandlerCheckReadAuthorization struct {
-	BaseClientProjectRuleHandler
-}
-
-func (h *ClientProjectRuleHandlerCheckReadAuthorization) Handle(model *data.ClientProject, c *models.Context) *lgo.OperationResult {
-	result := CacheService.GetSystemUserSetting(c, data.CLIENTPROJECTS_VIEW)
-	if !result.IsSuccess() {
-		return result
-	}
-	if result.ReturnObject.(string) != "1" {
-		return lgo.NewLogicError("Bu kaydı görüntülemek için yetkiniz yok.", nil)
-	}
-
-	if h.next != nil {
-		return h.next.Handle(model, c)
+	if model.IsActive && !result.ReturnObject.(*datamodels.Client).IsActive {
+		return mvc.NewConflictError(i18n.ClientArchived)
 	}
 	return lgo.NewSuccess(nil)
 }
 
-//#endregion Read Authorization Handler
+//#endregion Client
 
-//#region Delete Authorization Handler
-
-type ClientProjectRuleHandlerCheckDeleteAuthorization struct {
-	BaseClientProjectRuleHandler
+// #region Check Foreign References
+// ClientProjectRuleHandlerCheckForeignReferences, projeye bağlı üyelikler, görevler ve zaman
+// kayıtları varsa silmeyi sayılarıyla reddeder. Sayım ile silme arasında eklenen bir kaydı
+// veritabanındaki ON DELETE RESTRICT yakalar. Bu kayıtlarla birlikte silmek için
+// ClientProjectService.ForceDelete kullanılır.
+type ClientProjectRuleHandlerCheckForeignReferences struct {
+	ClientProjectService ClientProjectService
 }
 
-func (h *ClientProjectRuleHandlerCheckDeleteAuthorization) Handle(model *data.ClientProject, c *models.Context) *lgo.OperationResult {
-	result := CacheService.GetSystemUserSetting(c, data.CLIENTPROJECTS_DELETE)
-	if !result.IsSuccess() {
-		return result
-	}
-	if result.ReturnObject.(string) != "1" {
-		return lgo.NewLogicError("Bu kaydı silmek için yetkiniz yok.", nil)
-	}
-
-	if h.next != nil {
-		return h.next.Handle(model, c)
-	}
-	return lgo.NewSuccess(nil)
+func (h *ClientProjectRuleHandlerCheckForeignReferences) Handle(model *datamodels.ClientProject, c *models.Context) *lgo.OperationResult {
+	return h.ClientProjectService.CheckForeignReferences(model, c)
 }
 
-//#endregion Delete Authorization Handler
+//#endregion Check Foreign References
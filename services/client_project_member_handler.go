@@ -0,0 +1,58 @@
+package services
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// ClientProjectMember'a özgü kurallar. Doğrulama ve yetki kuralları crudService tarafından kurulur.
+
+// #region References Exist
+// ClientProjectMemberRuleHandlerReferencesExist, yeni üyeliğin projesinin ve kullanıcısının
+// kayıtlı olduğunu kontrol eder
+type ClientProjectMemberRuleHandlerReferencesExist struct {
+	ClientProjectRepository repositories.ClientProjectRepository
+	SystemUserRepository    repositories.SystemUserRepository
+}
+
+func (h *ClientProjectMemberRuleHandlerReferencesExist) Handle(model *datamodels.ClientProjectMember, c *models.Context) *lgo.OperationResult {
+	if !model.IsNew() {
+		return lgo.NewSuccess(nil)
+	}
+	if result := h.ClientProjectRepository.GetById(c, model.ClientProjectId); !result.IsSuccess() {
+		return result
+	}
+	if result := h.SystemUserRepository.GetById(c, model.SystemUserId); !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion References Exist
+
+// #region Unique Member
+// ClientProjectMemberRuleHandlerUnique, kullanıcının aynı projeye ikinci kez eklenmesini engeller
+type ClientProjectMemberRuleHandlerUnique struct {
+	ClientProjectMemberRepository repositories.ClientProjectMemberRepository
+}
+
+func (h *ClientProjectMemberRuleHandlerUnique) Handle(model *datamodels.ClientProjectMember, c *models.Context) *lgo.OperationResult {
+	if !model.IsNew() {
+		return lgo.NewSuccess(nil)
+	}
+	result := h.ClientProjectMemberRepository.GetByMember(c, model.ClientProjectId, model.SystemUserId)
+	if result.IsSuccess() {
+		return mvc.NewConflictError(i18n.MemberExists)
+	}
+	if result.ErrorCode != mvc.ErrorCodeNotFound {
+		return result
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Unique Member
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	repositories "lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// #region Client Project Member Service Interface
+
+// ClientProjectMemberService, /client-projects/:id/members alt kaynağının işlemleridir.
+// Üyeliklere her zaman projeleri üzerinden erişilir; başka bir projenin üyeliği bulunamadı sayılır.
+type ClientProjectMemberService interface {
+	Create(member *datamodels.ClientProjectMember, c *models.Context) *lgo.OperationResult
+	Update(member *datamodels.ClientProjectMember, c *models.Context) *lgo.OperationResult
+	Delete(clientProjectId int, id int, c *models.Context) *lgo.OperationResult
+	GetById(clientProjectId int, id int, c *models.Context) *lgo.OperationResult
+	GetByClientProjectId(clientProjectId int, c *models.Context) *lgo.OperationResult
+}
+
+//#endregion Client Project Member Service Interface
+
+// #region Client Project Member Service Implementation
+type clientProjectMemberService struct {
+	crud        *crudService[datamodels.ClientProjectMember, int, *datamodels.ClientProjectMember]
+	repo        repositories.ClientProjectMemberRepository
+	projectRepo repositories.ClientProjectRepository
+}
+
+func NewClientProjectMemberService(repo repositories.ClientProjectMemberRepository, projectRepo repositories.ClientProjectRepository, systemUserRepo repositories.SystemUserRepository, cacheService CacheService, eventService EventService) ClientProjectMemberService {
+	service := &clientProjectMemberService{
+		crud: newCrudService[datamodels.ClientProjectMember, int](repo, cacheService, CrudServiceOptions{
+			Name:        "ClientProjectMemberService",
+			Permissions: datamodels.ClientProjectMemberPermissions,
+			InvalidId:   i18n.InvalidId,
+		}),
+		repo:        repo,
+		projectRepo: projectRepo,
+	}
+
+	service.crud.events = crudEvents[datamodels.ClientProjectMember, int]{
+		service: eventService,
+		created: func(member *datamodels.ClientProjectMember) events.Event {
+			return &events.ClientProjectMemberAdded{Member: *member}
+		},
+		deleted: func(id int) events.Event { return &events.ClientProjectMemberRemoved{MemberId: id} },
+	}
+
+	service.crud.saveRules = service.crud.saveRules.Then(
+		&ClientProjectMemberRuleHandlerReferencesExist{ClientProjectRepository: projectRepo, SystemUserRepository: systemUserRepo},
+		&ClientProjectMemberRuleHandlerUnique{ClientProjectMemberRepository: repo},
+	)
+
+	return service
+}
+
+// #region Create
+func (s *clientProjectMemberService) Create(member *datamodels.ClientProjectMember, c *models.Context) *lgo.OperationResult {
+	return s.crud.Create(member, c)
+}
+
+//#endregion Create
+
+// #region Update
+func (s *clientProjectMemberService) Update(member *datamodels.ClientProjectMember, c *models.Context) *lgo.OperationResult {
+	if result := s.GetById(member.ClientProjectId, member.Id, c); !result.IsSuccess() {
+		return result
+	}
+	return s.crud.Update(member, c)
+}
+
+//#endregion Update
+
+// #region Delete
+func (s *clientProjectMemberService) Delete(clientProjectId int, id int, c *models.Context) *lgo.OperationResult {
+	if result := s.GetById(clientProjectId, id, c); !result.IsSuccess() {
+		return result
+	}
+	return s.crud.Delete(id, c)
+}
+
+//#endregion Delete
+
+// #region Get By Id
+func (s *clientProjectMemberService) GetById(clientProjectId int, id int, c *models.Context) *lgo.OperationResult {
+	if clientProjectId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientProjectId)
+	}
+
+	result := s.crud.GetById(id, c)
+	if !result.IsSuccess() {
+		return result
+	}
+	if result.ReturnObject.(*datamodels.ClientProjectMember).ClientProjectId != clientProjectId {
+		return mvc.NewNotFoundError(i18n.MemberNotFound)
+	}
+	return result
+}
+
+//#endregion Get By Id
+
+// #region Get Members By ClientProjectId
+func (s *clientProjectMemberService) GetByClientProjectId(clientProjectId int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientProjectMemberService.GetByClientProjectId")()
+
+	if clientProjectId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientProjectId)
+	}
+
+	member := &datamodels.ClientProjectMember{ClientProjectId: clientProjectId}
+	if result := handleRules(c, "ClientProjectMemberService.readRules", s.crud.readRules, member); !result.IsSuccess() {
+		return result
+	}
+	if result := s.projectRepo.GetById(c, clientProjectId); !result.IsSuccess() {
+		return result
+	}
+	return s.repo.GetByClientProjectId(c, clientProjectId)
+}
+
+//#endregion Get Members By ClientProjectId
+
+//#endregion Client Project Member Service Implementation
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+var allClientProjectMemberPermissions = []string{datamodels.CLIENTPROJECTS_VIEW, datamodels.CLIENTPROJECTS_UPDATE}
+
+func TestClientProjectMemberServiceRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult
+		want        expected
+	}{
+		{
+			name:        "create",
+			permissions: allClientProjectMemberPermissions,
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				user := f.addUser(t, "grace@example.com")
+				return s.Create(&datamodels.ClientProjectMember{ClientProjectId: existing.ClientProjectId, SystemUserId: user.Id, Role: "Geliştirici", HourlyRate: ptr(150.0)}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create validates the rate",
+			permissions: allClientProjectMemberPermissions,
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				user := f.addUser(t, "grace@example.com")
+				return s.Create(&datamodels.ClientProjectMember{ClientProjectId: existing.ClientProjectId, SystemUserId: user.Id, HourlyRate: ptr(-1.0)}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create for a missing project",
+			permissions: allClientProjectMemberPermissions,
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProjectMember{ClientProjectId: 99, SystemUserId: existing.SystemUserId}, f.c)
+			},
+			want: notFound(i18n.ClientProjectNotFound),
+		},
+		{
+			name:        "create for a missing user",
+			permissions: allClientProjectMemberPermissions,
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProjectMember{ClientProjectId: existing.ClientProjectId, SystemUserId: uuid.New()}, f.c)
+			},
+			want: notFound(i18n.SystemUserNotFound),
+		},
+		{
+			name:        "create an existing member",
+			permissions: allClientProjectMemberPermissions,
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProjectMember{ClientProjectId: existing.ClientProjectId, SystemUserId: existing.SystemUserId}, f.c)
+			},
+			want: conflict(i18n.MemberExists),
+		},
+		{
+			name:        "create needs the project update permission",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				user := f.addUser(t, "grace@example.com")
+				return s.Create(&datamodels.ClientProjectMember{ClientProjectId: existing.ClientProjectId, SystemUserId: user.Id}, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "update",
+			permissions: allClientProjectMemberPermissions,
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				existing.Role = "Proje yöneticisi"
+				return s.Update(existing, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "update a member of another project",
+			permissions: allClientProjectMemberPermissions,
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				existing.ClientProjectId = f.addClientProject(t, f.addClient(t, "GLOBEX").Id, "Mobil uygulama").Id
+				return s.Update(existing, f.c)
+			},
+			want: notFound(i18n.MemberNotFound),
+		},
+		{
+			name:        "delete",
+			permissions: allClientProjectMemberPermissions,
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				return s.Delete(existing.ClientProjectId, existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "delete missing member",
+			permissions: allClientProjectMemberPermissions,
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				return s.Delete(existing.ClientProjectId, 99, f.c)
+			},
+			want: notFound(i18n.MemberNotFound),
+		},
+		{
+			name:        "get by id",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				return s.GetById(existing.ClientProjectId, existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "list for a missing project",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ClientProjectMemberService, f *fixture, _ *datamodels.ClientProjectMember) *lgo.OperationResult {
+				return s.GetByClientProjectId(99, f.c)
+			},
+			want: notFound(i18n.ClientProjectNotFound),
+		},
+		{
+			name:        "list needs the project view permission",
+			permissions: []string{datamodels.CLIENTPROJECTS_UPDATE},
+			run: func(s ClientProjectMemberService, f *fixture, existing *datamodels.ClientProjectMember) *lgo.OperationResult {
+				return s.GetByClientProjectId(existing.ClientProjectId, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			project := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+			existing := f.addMember(t, project.Id, f.user)
+			service := NewClientProjectMemberService(f.repos.ClientProjectMembers, f.repos.ClientProjects, f.repos.SystemUsers, f.cache, f.events)
+			test.want.check(t, test.run(service, f, existing))
+		})
+	}
+}
@@ -1,120 +1,292 @@
 package services
 
 import (
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	"lms-web-services-main/models/mvc"
 	repositories "lms-web-services-main/repositories"
 
 	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
 )
 
 type ClientProjectService interface {
-	Create(clientProject *datamodels.ClientProject, c *models.Context) *lgo.OperationResult
-	Update(clientProject *datamodels.ClientProject, c *models.Context) *lgo.OperationResult
-	Delete(id int, c *models.Context) *lgo.OperationResult
-	GetById(id int, c *models.Context) *lgo.OperationResult
-	GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult
-	GetByClientId(clientId int, c *models.Context) *lgo.OperationResult
+	CrudService[datamodels.ClientProject, int]
+	// GetByClientId, müşterinin projelerini döndürür; archived arşivlenmiş projelerin listeye
+	// girip girmeyeceğidir
+	GetByClientId(clientId int, archived mvc.ArchivedFilter, c *models.Context) *lgo.OperationResult
+	// GetMine, GetAll'ı oturum açmış kullanıcının üyesi olduğu projelerle sınırlar
+	GetMine(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult
+	// Archive, projeyi arşivler ve projeyi döndürür
+	Archive(id int, c *models.Context) *lgo.OperationResult
+	// Unarchive, projeyi arşivden çıkarır. Müşterisi arşivdeyse reddedilir.
+	Unarchive(id int, c *models.Context) *lgo.OperationResult
+	// CheckForeignReferences, proje silinirse onunla birlikte silinecek kayıtlar varsa silmeyi
+	// bu kayıtların sayılarıyla reddeder
+	CheckForeignReferences(clientProject *datamodels.ClientProject, c *models.Context) *lgo.OperationResult
+	// ForceDelete, projeyi bağlı kayıtlarıyla birlikte siler ve silinen kayıtların sayılarını
+	// döndürür (*mvc.ForeignReferences). Silme yetkisinin yanında clientprojects.force_delete
+	// yetkisi gerektirir.
+	ForceDelete(id int, c *models.Context) *lgo.OperationResult
 }
 
 type clientProjectService struct {
-	repo        repositories.ClientProjectRepository
-	saveRules   ClientProjectRuleHandler
-	updateRules ClientProjectRuleHandler
-	deleteRules ClientProjectRuleHandler
-	readRules   ClientProjectRuleHandler
+	*crudService[datamodels.ClientProject, int, *datamodels.ClientProject]
+	repo             repositories.ClientProjectRepository
+	clientRepo       repositories.ClientRepository
+	transactor       repositories.Transactor
+	archiveRules     RuleHandler[*datamodels.ClientProject] // Arşivleme projeyi güncelleme yetkisi gerektirir
+	forceDeleteRules RuleHandler[*datamodels.ClientProject] // Bağlı kayıtlarla silme, silme yetkisine ek olarak clientprojects.force_delete gerektirir
 }
 
-func NewClientProjectService(repo repositories.ClientProjectRepository) ClientProjectService {
-	return &clientProjectService{
-		repo: repo,
-		saveRules: (&ClientProjectRuleHandlerValidation{}).
-			SetNext(&ClientProjectRuleHandlerCheckAlterAuthorization{}),
-		updateRules: (&ClientProjectRuleHandlerUpdateValidation{}).
-			SetNext(&ClientProjectRuleHandlerCheckAlterAuthorization{}),
-		deleteRules: (&ClientProjectRuleHandlerCheckDeleteAuthorization{}),
-		readRules:   &ClientProjectRuleHandlerCheckReadAuthorization{},
+func NewClientProjectService(repo repositories.ClientProjectRepository, clientRepo repositories.ClientRepository, transactor repositories.Transactor, cacheService CacheService, eventService EventService) ClientProjectService {
+	service := &clientProjectService{
+		crudService: newCrudService[datamodels.ClientProject, int](repo, cacheService, CrudServiceOptions{
+			Name:        "ClientProjectService",
+			Permissions: datamodels.ClientProjectPermissions,
+			InvalidId:   i18n.InvalidId,
+		}),
+		repo:         repo,
+		clientRepo:   clientRepo,
+		transactor:   transactor,
+		archiveRules: PermissionRule[*datamodels.ClientProject]{CacheService: cacheService, Key: datamodels.ClientProjectPermissions.Update},
+		forceDeleteRules: Chain[*datamodels.ClientProject](
+			PermissionRule[*datamodels.ClientProject]{CacheService: cacheService, Key: datamodels.ClientProjectPermissions.Delete},
+			PermissionRule[*datamodels.ClientProject]{CacheService: cacheService, Key: datamodels.CLIENTPROJECTS_FORCE_DELETE},
+		),
 	}
+
+	service.events = crudEvents[datamodels.ClientProject, int]{
+		service: eventService,
+		created: func(clientProject *datamodels.ClientProject) events.Event {
+			return &events.ClientProjectCreated{ClientProject: *clientProject}
+		},
+		updated: func(_ *datamodels.ClientProject, clientProject *datamodels.ClientProject) events.Event {
+			return &events.ClientProjectUpdated{ClientProject: *clientProject}
+		},
+		deleted: func(id int) events.Event { return &events.ClientProjectDeleted{ClientProjectId: id} },
+	}
+
+	budget := &ClientProjectRuleHandlerBudget{}
+	service.saveRules = service.saveRules.Then(budget, &ClientProjectRuleHandlerClient{ClientRepository: clientRepo})
+	service.updateRules = service.updateRules.Then(budget)
+	service.deleteRules = service.deleteRules.Then(
+		&ClientProjectRuleHandlerCheckForeignReferences{ClientProjectService: service},
+	)
+
+	return service
 }
 
+// #region Get ClientProjects By ClientId
+func (s *clientProjectService) GetByClientId(clientId int, archived mvc.ArchivedFilter, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientProjectService.GetByClientId")()
+
+	if clientId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientId)
+	}
+
+	clientProject := &datamodels.ClientProject{ClientId: clientId}
+	if result := handleRules(c, "ClientProjectService.readRules", s.readRules, clientProject); !result.IsSuccess() {
+		return result
+	}
+	result := s.repo.GetByClientId(c, clientId)
+	if !result.IsSuccess() {
+		return result
+	}
+
+	clientProjects := []*datamodels.ClientProject{}
+	for _, clientProject := range result.ReturnObject.([]*datamodels.ClientProject) {
+		if archived.Matches(clientProject.IsActive) {
+			clientProjects = append(clientProjects, clientProject)
+		}
+	}
+	return lgo.NewSuccess(clientProjects)
+}
+
+//#endregion Get ClientProjects By ClientId
+
+// #region Get My ClientProjects
+func (s *clientProjectService) GetMine(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientProjectService.GetMine")()
+
+	if result := handleRules(c, "ClientProjectService.readRules", s.readRules, &datamodels.ClientProject{}); !result.IsSuccess() {
+		return result
+	}
+	if result := query.Validate(); !result.IsSuccess() {
+		return result
+	}
+
+	if c.Principal == nil {
+		return lgo.NewAuthError()
+	}
+	systemUserId, err := uuid.Parse(c.Principal.Id)
+	if err != nil {
+		return lgo.NewFailureWithError(err)
+	}
+	return s.repo.GetAllForMember(c, query, systemUserId)
+}
+
+//#endregion Get My ClientProjects
+
 // #region Create ClientProject
+// Create, pasif olarak açılan projeye arşivlenme zamanını yazar
 func (s *clientProjectService) Create(clientProject *datamodels.ClientProject, c *models.Context) *lgo.OperationResult {
-	if result := s.saveRules.Handle(clientProject, c); !result.IsSuccess() {
-		return result
+	clientProject.ArchivedAt = nil
+	if !clientProject.IsActive {
+		archivedAt := archiveTime()
+		clientProject.ArchivedAt = &archivedAt
 	}
-	return s.repo.Create(clientProject)
+	return s.crudService.Create(clientProject, c)
 }
 
 //#endregion Create ClientProject
 
 // #region Update ClientProject
+// Update, "ia" değiştiyse projeyi Archive ve Unarchive gibi arşivler ya da arşivden çıkarır.
+// Güncelleme ve arşivleme tek işlemde yapılır; arşivleme başarısız olursa güncelleme de geri
+// alınır.
 func (s *clientProjectService) Update(clientProject *datamodels.ClientProject, c *models.Context) *lgo.OperationResult {
-	if result := s.updateRules.Handle(clientProject, c); !result.IsSuccess() {
-		return result
-	}
-	return s.repo.Update(clientProject)
+	return s.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		existing := s.repo.GetById(tx, clientProject.Id)
+		changed := existing.IsSuccess() && existing.ReturnObject.(*datamodels.ClientProject).IsActive != clientProject.IsActive
+		if changed && clientProject.IsActive {
+			if result := s.checkClientActive(existing.ReturnObject.(*datamodels.ClientProject), tx); !result.IsSuccess() {
+				return result
+			}
+		}
+
+		result := s.crudService.Update(clientProject, tx)
+		if !result.IsSuccess() || !changed {
+			return result
+		}
+
+		if result := s.setArchived(clientProject.Id, !clientProject.IsActive, tx); !result.IsSuccess() {
+			return result
+		}
+		return s.repo.GetById(tx, clientProject.Id)
+	})
 }
 
 //#endregion Update ClientProject
 
-// #region Delete ClientProject
-func (s *clientProjectService) Delete(id int, c *models.Context) *lgo.OperationResult {
-	if id <= 0 {
-		return lgo.NewLogicError("Geçersiz ID.", nil)
-	}
+// #region Archive ClientProject
+func (s *clientProjectService) Archive(id int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientProjectService.Archive")()
 
-	clientProject := &datamodels.ClientProject{Id: id}
-	if result := s.deleteRules.Handle(clientProject, c); !result.IsSuccess() {
+	clientProject, result := s.archivable(id, c)
+	if !result.IsSuccess() {
 		return result
 	}
-	return s.repo.Delete(id)
+	if clientProject.IsActive {
+		if result := s.setArchived(id, true, c); !result.IsSuccess() {
+			return result
+		}
+	}
+	return s.repo.GetById(c, id)
 }
 
-//#endregion Delete ClientProject
+//#endregion Archive ClientProject
 
-// #region Get ClientProject By Id
-func (s *clientProjectService) GetById(id int, c *models.Context) *lgo.OperationResult {
-	if id <= 0 {
-		return lgo.NewLogicError("Geçersiz ID.", nil)
-	}
+// #region Unarchive ClientProject
+func (s *clientProjectService) Unarchive(id int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientProjectService.Unarchive")()
 
-	clientProject := &datamodels.ClientProject{Id: id}
-	if result := s.readRules.Handle(clientProject, c); !result.IsSuccess() {
+	clientProject, result := s.archivable(id, c)
+	if !result.IsSuccess() {
 		return result
 	}
-	return s.repo.GetById(id)
+	if !clientProject.IsActive {
+		if result := s.checkClientActive(clientProject, c); !result.IsSuccess() {
+			return result
+		}
+		if result := s.setArchived(id, false, c); !result.IsSuccess() {
+			return result
+		}
+	}
+	return s.repo.GetById(c, id)
 }
 
-//#endregion Get ClientProject By Id
+//#endregion Unarchive ClientProject
 
-// #region Get All ClientProjects
-func (s *clientProjectService) GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult {
-	clientProject := &datamodels.ClientProject{}
-	if result := s.readRules.Handle(clientProject, c); !result.IsSuccess() {
+// #region Check Foreign References
+func (s *clientProjectService) CheckForeignReferences(clientProject *datamodels.ClientProject, c *models.Context) *lgo.OperationResult {
+	result := s.repo.CountForeignReferences(c, clientProject.Id)
+	if !result.IsSuccess() {
 		return result
 	}
-
-	if result := query.Validate(); !result.IsSuccess() {
-		return lgo.NewLogicError("Geçersiz sorgu parametreleri: "+result.ErrorMessage, nil)
+	if references := result.ReturnObject.(*mvc.ForeignReferences); references.Any() {
+		return mvc.NewCodedError(mvc.ErrorCodeConflict, references.Message(i18n.ClientProjectHasReferences))
 	}
+	return lgo.NewSuccess(nil)
+}
 
-	return s.repo.GetAll(query)
+//#endregion Check Foreign References
+
+// #region Force Delete ClientProject
+func (s *clientProjectService) ForceDelete(id int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientProjectService.ForceDelete")()
+
+	if id <= 0 {
+		return mvc.NewLogicError(i18n.InvalidId)
+	}
+	if result := handleRules(c, "ClientProjectService.forceDeleteRules", s.forceDeleteRules, &datamodels.ClientProject{Id: id}); !result.IsSuccess() {
+		return result
+	}
+	result := s.events.service.RecordAll(c, func(tx *models.Context) *lgo.OperationResult {
+		return s.repo.ForceDelete(tx, id)
+	}, func(result *lgo.OperationResult) []events.Event {
+		deleted := result.ReturnObject.(*repositories.DeletedRecords)
+		return append([]events.Event{&events.ClientProjectDeleted{ClientProjectId: id}}, deletedEvents(deleted)...)
+	})
+	if !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(result.ReturnObject.(*repositories.DeletedRecords).References)
 }
 
-//#endregion Get All ClientProjects
+//#endregion Force Delete ClientProject
 
-// #region Get ClientProjects By ClientId
-func (s *clientProjectService) GetByClientId(clientId int, c *models.Context) *lgo.OperationResult {
-	if clientId <= 0 {
-		return lgo.NewLogicError("Geçersiz müşteri ID.", nil)
+// setArchived, projeyi arşivler ya da arşivden çıkarır ve bunu aynı işlemde olay olarak
+// yayımlar
+func (s *clientProjectService) setArchived(id int, archived bool, c *models.Context) *lgo.OperationResult {
+	return s.events.service.Record(c, func(tx *models.Context) *lgo.OperationResult {
+		if archived {
+			return s.repo.Archive(tx, id, archiveTime())
+		}
+		return s.repo.Unarchive(tx, id)
+	}, func(*lgo.OperationResult) events.Event {
+		if archived {
+			return &events.ClientProjectArchived{ClientProjectId: id}
+		}
+		return &events.ClientProjectUnarchived{ClientProjectId: id}
+	})
+}
+
+// archivable, kimliği ve yetkiyi kontrol edip projeyi getirir
+func (s *clientProjectService) archivable(id int, c *models.Context) (*datamodels.ClientProject, *lgo.OperationResult) {
+	if id <= 0 {
+		return nil, mvc.NewLogicError(i18n.InvalidId)
+	}
+	if result := handleRules(c, "ClientProjectService.archiveRules", s.archiveRules, &datamodels.ClientProject{Id: id}); !result.IsSuccess() {
+		return nil, result
 	}
+	result := s.repo.GetById(c, id)
+	if !result.IsSuccess() {
+		return nil, result
+	}
+	return result.ReturnObject.(*datamodels.ClientProject), result
+}
 
-	clientProject := &datamodels.ClientProject{ClientId: clientId}
-	if result := s.readRules.Handle(clientProject, c); !result.IsSuccess() {
+// checkClientActive, arşivdeki müşterinin projesinin arşivden çıkarılmasını engeller
+func (s *clientProjectService) checkClientActive(clientProject *datamodels.ClientProject, c *models.Context) *lgo.OperationResult {
+	result := s.clientRepo.GetById(c, clientProject.ClientId)
+	if !result.IsSuccess() {
 		return result
 	}
-	return s.repo.GetByClientId(clientId)
+	if !result.ReturnObject.(*datamodels.Client).IsActive {
+		return mvc.NewConflictError(i18n.ClientArchived)
+	}
+	return lgo.NewSuccess(nil)
 }
-
-//#endregion Get ClientProjects By ClientId
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+)
+
+var allClientProjectPermissions = []string{
+	datamodels.CLIENTPROJECTS_VIEW, datamodels.CLIENTPROJECTS_ADD, datamodels.CLIENTPROJECTS_UPDATE, datamodels.CLIENTPROJECTS_DELETE,
+}
+
+func TestClientProjectServiceRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult
+		want        expected
+	}{
+		{
+			name:        "create",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProject{ClientId: existing.ClientId, Name: "Mobil uygulama"}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create requires the client",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, _ *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProject{Name: "Mobil uygulama"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create forbidden",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProject{ClientId: existing.ClientId, Name: "Mobil uygulama"}, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "update does not require the client",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Update(&datamodels.ClientProject{Id: existing.Id, Name: "Yeni ad"}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "update validates the name",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Update(&datamodels.ClientProject{Id: existing.Id}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "delete missing project",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, _ *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Delete(99, f.c)
+			},
+			want: notFound(i18n.ClientProjectNotFound),
+		},
+		{
+			name:        "delete forbidden",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Delete(existing.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "get by client id",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.GetByClientId(existing.ClientId, mvc.ArchivedExclude, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "get by client id rejects an invalid id",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ClientProjectService, f *fixture, _ *datamodels.ClientProject) *lgo.OperationResult {
+				return s.GetByClientId(0, mvc.ArchivedExclude, f.c)
+			},
+			want: invalid(i18n.InvalidClientId),
+		},
+		{
+			name:        "create with an amount budget and hourly rate",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProject{ClientId: existing.ClientId, Name: "Bakım", BudgetAmount: 50000, HourlyRate: 1250}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create with an amount budget requires the hourly rate",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProject{ClientId: existing.ClientId, Name: "Bakım", BudgetAmount: 50000}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create rejects a negative hour budget",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProject{ClientId: existing.ClientId, Name: "Bakım", BudgetHours: -1}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "update rejects a budget ending before it starts",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+				end := start.AddDate(0, 0, -1)
+				return s.Update(&datamodels.ClientProject{Id: existing.Id, Name: "Yeni ad", BudgetStartDate: &start, BudgetEndDate: &end}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "get by client id forbidden",
+			permissions: []string{datamodels.CLIENTPROJECTS_ADD},
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.GetByClientId(existing.ClientId, mvc.ArchivedExclude, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "create active under an archived client",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				mustSucceed(t, f.repos.Clients.Archive(f.c, existing.ClientId, time.Now()))
+				return s.Create(&datamodels.ClientProject{ClientId: existing.ClientId, Name: "Mobil uygulama", IsActive: true}, f.c)
+			},
+			want: conflict(i18n.ClientArchived),
+		},
+		{
+			name:        "create for a missing client",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, _ *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Create(&datamodels.ClientProject{ClientId: 99, Name: "Mobil uygulama"}, f.c)
+			},
+			want: notFound(i18n.ClientNotFound),
+		},
+		{
+			name:        "unarchive under an archived client",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				mustSucceed(t, f.repos.Clients.Archive(f.c, existing.ClientId, time.Now()))
+				return s.Unarchive(existing.Id, f.c)
+			},
+			want: conflict(i18n.ClientArchived),
+		},
+		{
+			name:        "reactivate under an archived client",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				mustSucceed(t, f.repos.Clients.Archive(f.c, existing.ClientId, time.Now()))
+				return s.Update(&datamodels.ClientProject{Id: existing.Id, Name: "Web sitesi", IsActive: true}, f.c)
+			},
+			want: conflict(i18n.ClientArchived),
+		},
+		{
+			name:        "archive missing project",
+			permissions: allClientProjectPermissions,
+			run: func(s ClientProjectService, f *fixture, _ *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Archive(99, f.c)
+			},
+			want: notFound(i18n.ClientProjectNotFound),
+		},
+		{
+			name:        "archive forbidden",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ClientProjectService, f *fixture, existing *datamodels.ClientProject) *lgo.OperationResult {
+				return s.Archive(existing.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			existing := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+			test.want.check(t, test.run(NewClientProjectService(f.repos.ClientProjects, f.repos.Clients, f.repos.Transactor, f.cache, f.events), f, existing))
+		})
+	}
+}
+
+func TestClientProjectServiceUpdateKeepsClient(t *testing.T) {
+	f := newFixture(t, allClientProjectPermissions...)
+	existing := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+	service := NewClientProjectService(f.repos.ClientProjects, f.repos.Clients, f.repos.Transactor, f.cache, f.events)
+
+	result := service.Update(&datamodels.ClientProject{Id: existing.Id, ClientId: 42, Name: "Yeni ad"}, f.c)
+	ok().check(t, result)
+
+	updated := result.ReturnObject.(*datamodels.ClientProject)
+	if updated.ClientId != existing.ClientId || updated.Name != "Yeni ad" {
+		t.Fatalf("want client %d and name %q, got %+v", existing.ClientId, "Yeni ad", updated)
+	}
+}
+
+func TestClientProjectServiceGetMine(t *testing.T) {
+	f := newFixture(t, allClientProjectPermissions...)
+	client := f.addClient(t, "ACME")
+	mine := f.addClientProject(t, client.Id, "Web sitesi")
+	f.addClientProject(t, client.Id, "Mobil uygulama")
+	f.addMember(t, mine.Id, f.user)
+	other := f.addClientProject(t, client.Id, "Bakım")
+	f.addMember(t, other.Id, f.addUser(t, "grace@example.com"))
+
+	result := NewClientProjectService(f.repos.ClientProjects, f.repos.Clients, f.repos.Transactor, f.cache, f.events).GetMine(&mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10}, f.c)
+	ok().check(t, result)
+
+	page := result.ReturnObject.(*mvc.PagedResult[*datamodels.ClientProject])
+	if page.TotalCount != 1 || page.Items[0].Id != mine.Id {
+		t.Fatalf("want only project %d, got %+v", mine.Id, page.Items)
+	}
+}
+
+func TestClientProjectServiceGetMineWithoutPrincipal(t *testing.T) {
+	f := newFixture(t, allClientProjectPermissions...)
+	f.c.Principal = nil
+
+	result := NewClientProjectService(f.repos.ClientProjects, f.repos.Clients, f.repos.Transactor, f.cache, f.events).GetMine(&mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10}, f.c)
+	anonymous().check(t, result)
+}
+
+func TestClientProjectServiceArchive(t *testing.T) {
+	f := newFixture(t, allClientProjectPermissions...)
+	client := f.addClient(t, "ACME")
+	project := f.addClientProject(t, client.Id, "Web sitesi")
+	f.addClientProject(t, client.Id, "Mobil uygulama")
+	service := NewClientProjectService(f.repos.ClientProjects, f.repos.Clients, f.repos.Transactor, f.cache, f.events)
+
+	result := service.Archive(project.Id, f.c)
+	ok().check(t, result)
+	if archived := result.ReturnObject.(*datamodels.ClientProject); archived.IsActive || archived.ArchivedAt == nil {
+		t.Fatalf("want the project archived, got %+v", archived)
+	}
+
+	count := func(archived mvc.ArchivedFilter) int {
+		t.Helper()
+		result := service.GetByClientId(client.Id, archived, f.c)
+		mustSucceed(t, result)
+		return len(result.ReturnObject.([]*datamodels.ClientProject))
+	}
+	if count(mvc.ArchivedExclude) != 1 || count(mvc.ArchivedOnly) != 1 || count(mvc.ArchivedInclude) != 2 {
+		t.Fatal("want the archived filter applied to the client's projects")
+	}
+
+	result = service.Unarchive(project.Id, f.c)
+	ok().check(t, result)
+	if restored := result.ReturnObject.(*datamodels.ClientProject); !restored.IsActive || restored.ArchivedAt != nil {
+		t.Fatalf("want the project unarchived, got %+v", restored)
+	}
+}
+
+func TestClientProjectServiceDeleteChecksForeignReferences(t *testing.T) {
+	f := newFixture(t, datamodels.CLIENTPROJECTS_DELETE)
+	project := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+	f.addMember(t, project.Id, f.user)
+	f.addTask(t, project.Id, "Tasarım")
+	service := NewClientProjectService(f.repos.ClientProjects, f.repos.Clients, f.repos.Transactor, f.cache, f.events)
+
+	result := service.Delete(project.Id, f.c)
+	conflict(i18n.ClientProjectHasReferences).check(t, result)
+	fields := result.ReturnObject.(*i18n.Message).FieldTexts(i18n.Turkish)
+	if len(fields) != 2 || fields["cpms"] != "1 proje üyeliği" || fields["pts"] != "1 görev" {
+		t.Fatalf("want the membership and the task listed, got %v", fields)
+	}
+
+	invalid(i18n.PermissionNotFound).check(t, service.ForceDelete(project.Id, f.c))
+
+	f.repos.Settings.Grant(f.user.Id, datamodels.CLIENTPROJECTS_FORCE_DELETE)
+	result = service.ForceDelete(project.Id, f.c)
+	ok().check(t, result)
+	if references := result.ReturnObject.(*mvc.ForeignReferences); *references != (mvc.ForeignReferences{ClientProjectMembers: 1, ProjectTasks: 1}) {
+		t.Fatalf("want the removed rows counted, got %+v", references)
+	}
+	if f.repos.ProjectTasks.Count(nil) != 0 || f.repos.ClientProjectMembers.Count(nil) != 0 {
+		t.Fatal("want the dependent rows removed with the project")
+	}
+}
@@ -1,6 +1,10 @@
 package services
 
 import (
+	"time"
+
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	"lms-web-services-main/models/mvc"
@@ -11,102 +15,233 @@ import (
 
 // #region Client Service Interface
 type ClientService interface {
-	Create(client *datamodels.Client, c *models.Context) *lgo.OperationResult
-	Update(client *datamodels.Client, c *models.Context) *lgo.OperationResult
-	Delete(id int, c *models.Context) *lgo.OperationResult
-	GetById(id int, c *models.Context) *lgo.OperationResult
-	GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult
+	CrudService[datamodels.Client, int]
+	// Archive, müşteriyi aktif projeleriyle birlikte arşivler ve müşteriyi döndürür
+	Archive(id int, c *models.Context) *lgo.OperationResult
+	// Unarchive, müşteriyi ve onunla birlikte arşivlenmiş projelerini geri açar
+	Unarchive(id int, c *models.Context) *lgo.OperationResult
+	// CheckForeignReferences, müşteri silinirse onunla birlikte silinecek kayıtlar varsa silmeyi
+	// bu kayıtların sayılarıyla reddeder
+	CheckForeignReferences(client *datamodels.Client, c *models.Context) *lgo.OperationResult
+	// ForceDelete, müşteriyi bağlı kayıtlarıyla birlikte siler ve silinen kayıtların sayılarını
+	// döndürür (*mvc.ForeignReferences). Silme yetkisinin yanında clients.force_delete
+	// yetkisi gerektirir.
+	ForceDelete(id int, c *models.Context) *lgo.OperationResult
 }
 
 //#endregion Client Service Interface
 
 // #region Client Service Implementation
 type clientService struct {
-	repo        repositories.ClientRepository
-	saveRules   ClientRuleHandler
-	updateRules ClientRuleHandler
-	deleteRules ClientRuleHandler
-	readRules   ClientRuleHandler
+	*crudService[datamodels.Client, int, *datamodels.Client]
+	repo             repositories.ClientRepository
+	transactor       repositories.Transactor
+	archiveRules     RuleHandler[*datamodels.Client] // Arşivleme müşteriyi güncelleme yetkisi gerektirir
+	forceDeleteRules RuleHandler[*datamodels.Client] // Bağlı kayıtlarla silme, silme yetkisine ek olarak clients.force_delete gerektirir
 }
 
-func NewClientService(repo repositories.ClientRepository) ClientService {
-	return &clientService{
-		repo: repo,
-		saveRules: (&ClientRuleHandlerValidation{}).
-			SetNext(&ClientRuleHandlerCheckAlterAuthorization{}),
-		updateRules: (&ClientRuleHandlerUpdateValidation{}).
-			SetNext(&ClientRuleHandlerCheckAlterAuthorization{}),
-		deleteRules: (&ClientRuleHandlerCheckDeleteAuthorization{}),
-		readRules:   &ClientRuleHandlerCheckReadAuthorization{},
+func NewClientService(repo repositories.ClientRepository, transactor repositories.Transactor, cacheService CacheService, eventService EventService) ClientService {
+	service := &clientService{
+		crudService: newCrudService[datamodels.Client, int](repo, cacheService, CrudServiceOptions{
+			Name:        "ClientService",
+			Permissions: datamodels.ClientPermissions,
+			InvalidId:   i18n.InvalidId,
+		}),
+		repo:         repo,
+		transactor:   transactor,
+		archiveRules: PermissionRule[*datamodels.Client]{CacheService: cacheService, Key: datamodels.ClientPermissions.Update},
+		forceDeleteRules: Chain[*datamodels.Client](
+			PermissionRule[*datamodels.Client]{CacheService: cacheService, Key: datamodels.ClientPermissions.Delete},
+			PermissionRule[*datamodels.Client]{CacheService: cacheService, Key: datamodels.CLIENTS_FORCE_DELETE},
+		),
 	}
-}
 
-//#endregion Client Service Implementation
+	service.events = crudEvents[datamodels.Client, int]{
+		service: eventService,
+		created: func(client *datamodels.Client) events.Event { return &events.ClientCreated{Client: *client} },
+		updated: func(_ *datamodels.Client, client *datamodels.Client) events.Event {
+			return &events.ClientUpdated{Client: *client}
+		},
+		deleted: func(id int) events.Event { return &events.ClientDeleted{ClientId: id} },
+	}
+
+	billingDetails := &ClientRuleHandlerBillingDetails{}
+	service.saveRules = service.saveRules.Then(billingDetails)
+	service.updateRules = service.updateRules.Then(billingDetails)
+	service.deleteRules = service.deleteRules.Then(
+		&ClientRuleHandlerCheckForeignReferences{ClientService: service},
+	)
+
+	return service
+}
 
 // #region Create Client
+// Create, pasif olarak açılan müşteriye arşivlenme zamanını yazar
 func (s *clientService) Create(client *datamodels.Client, c *models.Context) *lgo.OperationResult {
-	if result := s.saveRules.Handle(client, c); !result.IsSuccess() {
-		return result
+	client.ArchivedAt = nil
+	if !client.IsActive {
+		archivedAt := archiveTime()
+		client.ArchivedAt = &archivedAt
 	}
-	return s.repo.Create(client)
+	return s.crudService.Create(client, c)
 }
 
 //#endregion Create Client
 
 // #region Update Client
+// Update, "ia" değiştiyse müşteriyi Archive ve Unarchive gibi projeleriyle birlikte arşivler
+// ya da arşivden çıkarır. Güncelleme ve arşivleme tek işlemde yapılır; arşivleme başarısız
+// olursa güncelleme de geri alınır.
 func (s *clientService) Update(client *datamodels.Client, c *models.Context) *lgo.OperationResult {
-	if result := s.updateRules.Handle(client, c); !result.IsSuccess() {
-		return result
-	}
-	return s.repo.Update(client)
+	return s.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		existing := s.repo.GetById(tx, client.Id)
+
+		result := s.crudService.Update(client, tx)
+		if !result.IsSuccess() || !existing.IsSuccess() || existing.ReturnObject.(*datamodels.Client).IsActive == client.IsActive {
+			return result
+		}
+
+		if result := s.setArchived(client.Id, !client.IsActive, tx); !result.IsSuccess() {
+			return result
+		}
+		return s.repo.GetById(tx, client.Id)
+	})
 }
 
 //#endregion Update Client
 
-// #region Delete Client
-func (s *clientService) Delete(id int, c *models.Context) *lgo.OperationResult {
-	if id <= 0 {
-		return lgo.NewLogicError("Geçersiz ID.", nil)
+// #region Archive Client
+func (s *clientService) Archive(id int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientService.Archive")()
+
+	client, result := s.archivable(id, c)
+	if !result.IsSuccess() {
+		return result
 	}
+	if client.IsActive {
+		if result := s.setArchived(id, true, c); !result.IsSuccess() {
+			return result
+		}
+	}
+	return s.repo.GetById(c, id)
+}
 
-	client := &datamodels.Client{Id: id}
-	if result := s.deleteRules.Handle(client, c); !result.IsSuccess() {
+//#endregion Archive Client
+
+// #region Unarchive Client
+func (s *clientService) Unarchive(id int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientService.Unarchive")()
+
+	client, result := s.archivable(id, c)
+	if !result.IsSuccess() {
 		return result
 	}
+	if !client.IsActive {
+		if result := s.setArchived(id, false, c); !result.IsSuccess() {
+			return result
+		}
+	}
+	return s.repo.GetById(c, id)
+}
 
-	return s.repo.Delete(id)
+//#endregion Unarchive Client
+
+// #region Check Foreign References
+func (s *clientService) CheckForeignReferences(client *datamodels.Client, c *models.Context) *lgo.OperationResult {
+	result := s.repo.CountForeignReferences(c, client.Id)
+	if !result.IsSuccess() {
+		return result
+	}
+	if references := result.ReturnObject.(*mvc.ForeignReferences); references.Any() {
+		return mvc.NewCodedError(mvc.ErrorCodeConflict, references.Message(i18n.ClientHasReferences))
+	}
+	return lgo.NewSuccess(nil)
 }
 
-//#endregion Delete Client
+//#endregion Check Foreign References
+
+// #region Force Delete Client
+func (s *clientService) ForceDelete(id int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ClientService.ForceDelete")()
 
-// #region Get Client By Id
-func (s *clientService) GetById(id int, c *models.Context) *lgo.OperationResult {
 	if id <= 0 {
-		return lgo.NewLogicError("Geçersiz ID.", nil)
+		return mvc.NewLogicError(i18n.InvalidId)
 	}
-
-	client := &datamodels.Client{Id: id}
-	if result := s.readRules.Handle(client, c); !result.IsSuccess() {
+	if result := handleRules(c, "ClientService.forceDeleteRules", s.forceDeleteRules, &datamodels.Client{Id: id}); !result.IsSuccess() {
 		return result
 	}
-
-	return s.repo.GetById(id)
+	result := s.events.service.RecordAll(c, func(tx *models.Context) *lgo.OperationResult {
+		return s.repo.ForceDelete(tx, id)
+	}, func(result *lgo.OperationResult) []events.Event {
+		deleted := result.ReturnObject.(*repositories.DeletedRecords)
+		return append([]events.Event{&events.ClientDeleted{ClientId: id}}, deletedEvents(deleted)...)
+	})
+	if !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(result.ReturnObject.(*repositories.DeletedRecords).References)
 }
 
-//#endregion Get Client By Id
+//#endregion Force Delete Client
+
+// setArchived, müşteriyi projeleriyle birlikte arşivler ya da arşivden çıkarır ve bunu
+// müşteri ve etkilenen her proje için aynı işlemde olay olarak yayımlar
+func (s *clientService) setArchived(id int, archived bool, c *models.Context) *lgo.OperationResult {
+	return s.events.service.RecordAll(c, func(tx *models.Context) *lgo.OperationResult {
+		if archived {
+			return s.repo.Archive(tx, id, archiveTime())
+		}
+		return s.repo.Unarchive(tx, id)
+	}, func(result *lgo.OperationResult) []events.Event {
+		projectIds := result.ReturnObject.([]int)
+		recorded := make([]events.Event, 0, len(projectIds)+1)
+		if archived {
+			recorded = append(recorded, &events.ClientArchived{ClientId: id})
+			for _, projectId := range projectIds {
+				recorded = append(recorded, &events.ClientProjectArchived{ClientProjectId: projectId})
+			}
+			return recorded
+		}
+		recorded = append(recorded, &events.ClientUnarchived{ClientId: id})
+		for _, projectId := range projectIds {
+			recorded = append(recorded, &events.ClientProjectUnarchived{ClientProjectId: projectId})
+		}
+		return recorded
+	})
+}
 
-// #region Get All Clients
-func (s *clientService) GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult {
-	client := &datamodels.Client{}
-	if result := s.readRules.Handle(client, c); !result.IsSuccess() {
-		return result
+// archivable, kimliği ve yetkiyi kontrol edip müşteriyi getirir
+func (s *clientService) archivable(id int, c *models.Context) (*datamodels.Client, *lgo.OperationResult) {
+	if id <= 0 {
+		return nil, mvc.NewLogicError(i18n.InvalidId)
+	}
+	if result := handleRules(c, "ClientService.archiveRules", s.archiveRules, &datamodels.Client{Id: id}); !result.IsSuccess() {
+		return nil, result
+	}
+	result := s.repo.GetById(c, id)
+	if !result.IsSuccess() {
+		return nil, result
 	}
+	return result.ReturnObject.(*datamodels.Client), result
+}
 
-	if result := query.Validate(); !result.IsSuccess() {
-		return lgo.NewLogicError("Geçersiz sorgu parametreleri: "+result.ErrorMessage, nil)
+// deletedEvents, bağlı kayıtlarıyla birlikte silinen projeler ve zamanlamalar için
+// yayımlanan olaylardır
+func deletedEvents(deleted *repositories.DeletedRecords) []events.Event {
+	recorded := make([]events.Event, 0, len(deleted.ClientProjectIds)+len(deleted.TimingIds))
+	for _, projectId := range deleted.ClientProjectIds {
+		recorded = append(recorded, &events.ClientProjectDeleted{ClientProjectId: projectId})
+	}
+	for _, timingId := range deleted.TimingIds {
+		recorded = append(recorded, &events.TimingDeleted{TimingId: timingId})
 	}
+	return recorded
+}
 
-	return s.repo.GetAll(query)
+// archiveTime, arşivlenme zamanını PostgreSQL'in sakladığı mikrosaniye hassasiyetiyle
+// döndürür. Müşteriyle birlikte arşivlenen projeler bu değerle eşleştirilir.
+func archiveTime() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
 }
 
-//#endregion Get All Clients
+//#endregion Client Service Implementation
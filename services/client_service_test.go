@@ -0,0 +1,380 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+)
+
+var allClientPermissions = []string{
+	datamodels.CLIENTS_VIEW, datamodels.CLIENTS_ADD, datamodels.CLIENTS_UPDATE, datamodels.CLIENTS_DELETE,
+}
+
+func TestClientServiceRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult
+		want        expected
+	}{
+		// Create
+		{
+			name:        "create",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Create(&datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş."}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create validates before authorizing",
+			permissions: nil,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Create(&datamodels.Client{ShortTitle: strings.Repeat("ş", 51)}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create without add permission",
+			permissions: []string{datamodels.CLIENTS_UPDATE},
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Create(&datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş."}, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "create with an invalid tax number",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Create(&datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş.", TaxOffice: "Kadıköy", TaxNumber: "1234567801"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create with a VKN needs a tax office",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Create(&datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş.", TaxNumber: "1234567808"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create with a TCKN and currency",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Create(&datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş.", TaxNumber: "10000000146", Currency: "EUR", PaymentTermDays: 30}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create with an unknown currency",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Create(&datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş.", Currency: "XYZ"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		// Update
+		{
+			name:        "update",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+				existing.Title = "Yeni ünvan"
+				return s.Update(existing, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "update validates",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+				existing.Title = ""
+				return s.Update(existing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "update needs update permission, not add",
+			permissions: []string{datamodels.CLIENTS_ADD},
+			run: func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+				return s.Update(existing, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "update missing client",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+				existing.Id = 99
+				return s.Update(existing, f.c)
+			},
+			want: notFound(i18n.ClientNotFound),
+		},
+		// Delete
+		{
+			name:        "delete",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+				return s.Delete(existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "delete invalid id",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Delete(0, f.c)
+			},
+			want: invalid(i18n.InvalidId),
+		},
+		{
+			name:        "delete missing client",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Delete(99, f.c)
+			},
+			want: notFound(i18n.ClientNotFound),
+		},
+		// Read
+		{
+			name:        "get by id",
+			permissions: []string{datamodels.CLIENTS_VIEW},
+			run: func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+				return s.GetById(existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "get all rejects an invalid page",
+			permissions: []string{datamodels.CLIENTS_VIEW},
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.GetAll(&mvc.QueryModel{PageNumber: 0, RecordsPerPage: 10}, f.c)
+			},
+			want: invalid(i18n.QueryInvalidPage),
+		},
+		{
+			name:        "get all",
+			permissions: []string{datamodels.CLIENTS_VIEW},
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.GetAll(&mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10}, f.c)
+			},
+			want: ok(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			existing := f.addClient(t, "ACME")
+			test.want.check(t, test.run(NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events), f, existing))
+		})
+	}
+}
+
+// TestClientServiceForbidden, her işlemin yetki anahtarı "0" olduğunda eksik yetkiyi
+// bildirdiğini doğrular
+func TestClientServiceForbidden(t *testing.T) {
+	tests := []struct {
+		permission string
+		run        func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult
+	}{
+		{datamodels.CLIENTS_ADD, func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+			return s.Create(&datamodels.Client{ShortTitle: "ACME", Title: "ACME A.Ş."}, f.c)
+		}},
+		{datamodels.CLIENTS_UPDATE, func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+			return s.Update(existing, f.c)
+		}},
+		{datamodels.CLIENTS_DELETE, func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+			return s.Delete(existing.Id, f.c)
+		}},
+		{datamodels.CLIENTS_VIEW, func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+			return s.GetById(existing.Id, f.c)
+		}},
+		{datamodels.CLIENTS_VIEW, func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+			return s.GetAll(&mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10}, f.c)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.permission, func(t *testing.T) {
+			f := newFixture(t)
+			f.repos.Settings.Set(f.c, &datamodels.SystemUserSetting{SystemUserId: f.user.Id, Key: test.permission, Value: "0"})
+			existing := f.addClient(t, "ACME")
+			forbidden(test.permission).check(t, test.run(NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events), f, existing))
+		})
+	}
+}
+
+func TestClientServiceArchive(t *testing.T) {
+	f := newFixture(t, allClientPermissions...)
+	client := f.addClient(t, "ACME")
+	web := f.addClientProject(t, client.Id, "Web sitesi")
+	mobile := f.addClientProject(t, client.Id, "Mobil uygulama")
+	mustSucceed(t, f.repos.ClientProjects.Archive(f.c, mobile.Id, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	service := NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events)
+
+	isActive := func(id int) bool {
+		t.Helper()
+		result := f.repos.ClientProjects.GetById(f.c, id)
+		mustSucceed(t, result)
+		return result.ReturnObject.(*datamodels.ClientProject).IsActive
+	}
+
+	result := service.Archive(client.Id, f.c)
+	ok().check(t, result)
+	if archived := result.ReturnObject.(*datamodels.Client); archived.IsActive || archived.ArchivedAt == nil {
+		t.Fatalf("want the client archived, got %+v", archived)
+	}
+	if isActive(web.Id) {
+		t.Fatal("want the active project archived with the client")
+	}
+	ok().check(t, service.Archive(client.Id, f.c))
+
+	result = service.Unarchive(client.Id, f.c)
+	ok().check(t, result)
+	if restored := result.ReturnObject.(*datamodels.Client); !restored.IsActive || restored.ArchivedAt != nil {
+		t.Fatalf("want the client unarchived, got %+v", restored)
+	}
+	if !isActive(web.Id) || isActive(mobile.Id) {
+		t.Fatal("want only the project archived with the client restored")
+	}
+}
+
+func TestClientServiceUpdateArchives(t *testing.T) {
+	f := newFixture(t, allClientPermissions...)
+	client := f.addClient(t, "ACME")
+	project := f.addClientProject(t, client.Id, "Web sitesi")
+	service := NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events)
+
+	client.IsActive = false
+	result := service.Update(client, f.c)
+	ok().check(t, result)
+	if result.ReturnObject.(*datamodels.Client).ArchivedAt == nil {
+		t.Fatal("want the archive time set")
+	}
+	if f.repos.ClientProjects.GetById(f.c, project.Id).ReturnObject.(*datamodels.ClientProject).IsActive {
+		t.Fatal("want deactivating the client to archive its projects")
+	}
+}
+
+func TestClientServiceArchiveRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult
+		want        expected
+	}{
+		{
+			name:        "archive invalid id",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Archive(0, f.c)
+			},
+			want: invalid(i18n.InvalidId),
+		},
+		{
+			name:        "archive missing client",
+			permissions: allClientPermissions,
+			run: func(s ClientService, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return s.Archive(99, f.c)
+			},
+			want: notFound(i18n.ClientNotFound),
+		},
+		{
+			name:        "archive needs update permission",
+			permissions: []string{datamodels.CLIENTS_VIEW},
+			run: func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+				return s.Archive(existing.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "unarchive needs update permission",
+			permissions: []string{datamodels.CLIENTS_VIEW},
+			run: func(s ClientService, f *fixture, existing *datamodels.Client) *lgo.OperationResult {
+				return s.Unarchive(existing.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			existing := f.addClient(t, "ACME")
+			test.want.check(t, test.run(NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events), f, existing))
+		})
+	}
+}
+
+func TestClientServiceDeleteChecksForeignReferences(t *testing.T) {
+	f := newFixture(t, datamodels.CLIENTS_DELETE)
+	client := f.addClient(t, "ACME")
+	project := f.addClientProject(t, client.Id, "Web sitesi")
+	f.addMember(t, project.Id, f.user)
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	timing := f.addTiming(t, &datamodels.Timing{ClientProjectId: project.Id, SystemUserId: f.user.Id, Title: "Analiz", StartDateTime: start, EndDateTime: start.Add(time.Hour)})
+	mustSucceed(t, f.repos.Timings.SetTags(f.c, timing.Id, []int{f.addTag(t, "Toplantı").Id}))
+	mustSucceed(t, f.repos.ClientContacts.Create(f.c, &datamodels.ClientContact{ClientId: client.Id, Name: "Can Demir"}))
+	service := NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events)
+
+	result := service.Delete(client.Id, f.c)
+	conflict(i18n.ClientHasReferences).check(t, result)
+	fields := result.ReturnObject.(*i18n.Message).FieldTexts(i18n.English)
+	want := map[string]string{
+		"cps":  "1 projects",
+		"ccs":  "1 contacts",
+		"cpms": "1 project memberships",
+		"tms":  "1 timings",
+		"tts":  "1 tag links",
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("want the dependent rows %v, got %v", want, fields)
+	}
+	for field, text := range want {
+		if fields[field] != text {
+			t.Errorf("want %s to be %q, got %q", field, text, fields[field])
+		}
+	}
+
+	invalid(i18n.PermissionNotFound).check(t, service.ForceDelete(client.Id, f.c))
+
+	f.repos.Settings.Grant(f.user.Id, datamodels.CLIENTS_FORCE_DELETE)
+	result = service.ForceDelete(client.Id, f.c)
+	ok().check(t, result)
+	references := result.ReturnObject.(*mvc.ForeignReferences)
+	if *references != (mvc.ForeignReferences{ClientProjects: 1, ClientContacts: 1, ClientProjectMembers: 1, Timings: 1, TimingTags: 1}) {
+		t.Fatalf("want the removed rows counted, got %+v", references)
+	}
+	if f.repos.ClientProjects.Count(nil) != 0 || f.repos.Timings.Count(nil) != 0 || f.repos.ClientProjectMembers.Count(nil) != 0 {
+		t.Fatal("want the dependent rows removed with the client")
+	}
+	notFound(i18n.ClientNotFound).check(t, service.ForceDelete(client.Id, f.c))
+}
+
+func TestClientServiceForceDeleteRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		id          int
+		want        expected
+	}{
+		{name: "invalid id", permissions: []string{datamodels.CLIENTS_DELETE, datamodels.CLIENTS_FORCE_DELETE}, id: 0, want: invalid(i18n.InvalidId)},
+		{name: "needs the delete permission", permissions: []string{datamodels.CLIENTS_FORCE_DELETE}, id: 1, want: invalid(i18n.PermissionNotFound)},
+		{name: "without references", permissions: []string{datamodels.CLIENTS_DELETE, datamodels.CLIENTS_FORCE_DELETE}, id: 1, want: ok()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			f.addClient(t, "ACME")
+			test.want.check(t, NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events).ForceDelete(test.id, f.c))
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"lms-web-services-main/events"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+// CrudService, E varlığının K türündeki birincil anahtarla yapılan ve kural zincirleriyle
+// korunan ortak servis işlemleridir. Varlığa özgü servisler bu arayüzü gömer.
+type CrudService[E any, K comparable] interface {
+	Create(model *E, c *models.Context) *lgo.OperationResult
+	Update(model *E, c *models.Context) *lgo.OperationResult
+	Delete(id K, c *models.Context) *lgo.OperationResult
+	GetById(id K, c *models.Context) *lgo.OperationResult
+	GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult
+}
+
+// CrudEntity, genel servisin çalışabildiği varlıkların işaretçi türüdür
+type CrudEntity[E any, K comparable] interface {
+	*E
+	Entity
+	Validatable
+	UpdateValidatable
+	GetId() K
+	SetId(id K)
+}
+
+// CrudServiceOptions, genel servisin varlığa özgü ayarlarıdır
+type CrudServiceOptions struct {
+	Name        string             // Span adlarının öneki, örn. "ClientService"
+	Permissions data.PermissionSet // İşlemlerin yetki anahtarları
+	InvalidId   string             // Geçersiz id için i18n mesaj kodu
+}
+
+// crudEvents, genel servisin Create, Update ve Delete'te kaydın değişikliğiyle aynı işlemde
+// yayımladığı olaylardır. Verilmeyen işlemler olay yayımlamaz. updated, kaydın güncellemeden
+// önceki ve sonraki halini alır; nil döndürürse olay yayımlanmaz.
+type crudEvents[E any, K comparable] struct {
+	service EventService
+	created func(model *E) events.Event
+	updated func(previous *E, saved *E) events.Event
+	deleted func(id K) events.Event
+}
+
+// crudService varsayılan olarak şu zincirleri kurar:
+//
+//	saveRules:   Validate, Add/Update yetkisi
+//	updateRules: ValidateForUpdate, Update yetkisi
+//	deleteRules: Delete yetkisi
+//	readRules:   View yetkisi
+//
+// Servisler ek kuralları Then ile zincirlerin sonuna ekler, yayımlayacakları olayları events'e
+// atar.
+type crudService[E any, K comparable, P CrudEntity[E, K]] struct {
+	repo        repositories.CrudRepository[E, K]
+	options     CrudServiceOptions
+	saveRules   RuleChain[P]
+	updateRules RuleChain[P]
+	deleteRules RuleChain[P]
+	readRules   RuleChain[P]
+	events      crudEvents[E, K]
+}
+
+func newCrudService[E any, K comparable, P CrudEntity[E, K]](repo repositories.CrudRepository[E, K], cacheService CacheService, options CrudServiceOptions) *crudService[E, K, P] {
+	alterPermission := AlterPermissionRule[P]{CacheService: cacheService, Permissions: options.Permissions}
+	return &crudService[E, K, P]{
+		repo:        repo,
+		options:     options,
+		saveRules:   Chain[P](ValidationRule[P]{}, alterPermission),
+		updateRules: Chain[P](UpdateValidationRule[P]{}, alterPermission),
+		deleteRules: Chain[P](PermissionRule[P]{CacheService: cacheService, Key: options.Permissions.Delete}),
+		readRules:   Chain[P](PermissionRule[P]{CacheService: cacheService, Key: options.Permissions.View}),
+	}
+}
+
+// #region Create
+func (s *crudService[E, K, P]) Create(model *E, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, s.options.Name+".Create")()
+
+	if result := handleRules(c, s.options.Name+".saveRules", s.saveRules, P(model)); !result.IsSuccess() {
+		return result
+	}
+	if s.events.created == nil {
+		return s.repo.Create(c, model)
+	}
+
+	return s.events.service.Record(c, func(tx *models.Context) *lgo.OperationResult {
+		return s.repo.Create(tx, model)
+	}, func(*lgo.OperationResult) events.Event {
+		return s.events.created(model)
+	})
+}
+
+//#endregion Create
+
+// #region Update
+func (s *crudService[E, K, P]) Update(model *E, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, s.options.Name+".Update")()
+
+	if result := handleRules(c, s.options.Name+".updateRules", s.updateRules, P(model)); !result.IsSuccess() {
+		return result
+	}
+	if s.events.updated == nil {
+		return s.repo.Update(c, model)
+	}
+
+	var previous *E
+	return s.events.service.Record(c, func(tx *models.Context) *lgo.OperationResult {
+		existing := s.repo.GetById(tx, P(model).GetId())
+		if !existing.IsSuccess() {
+			return existing
+		}
+		previous = existing.ReturnObject.(*E)
+		return s.repo.Update(tx, model)
+	}, func(result *lgo.OperationResult) events.Event {
+		return s.events.updated(previous, result.ReturnObject.(*E))
+	})
+}
+
+//#endregion Update
+
+// #region Delete
+func (s *crudService[E, K, P]) Delete(id K, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, s.options.Name+".Delete")()
+
+	if !validId(id) {
+		return mvc.NewLogicError(s.options.InvalidId)
+	}
+
+	model := P(new(E))
+	model.SetId(id)
+	if result := handleRules(c, s.options.Name+".deleteRules", s.deleteRules, model); !result.IsSuccess() {
+		return result
+	}
+	if s.events.deleted == nil {
+		return s.repo.Delete(c, id)
+	}
+
+	return s.events.service.Record(c, func(tx *models.Context) *lgo.OperationResult {
+		return s.repo.Delete(tx, id)
+	}, func(*lgo.OperationResult) events.Event {
+		return s.events.deleted(id)
+	})
+}
+
+//#endregion Delete
+
+// #region Get By Id
+func (s *crudService[E, K, P]) GetById(id K, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, s.options.Name+".GetById")()
+
+	if !validId(id) {
+		return mvc.NewLogicError(s.options.InvalidId)
+	}
+
+	model := P(new(E))
+	model.SetId(id)
+	if result := handleRules(c, s.options.Name+".readRules", s.readRules, model); !result.IsSuccess() {
+		return result
+	}
+	return s.repo.GetById(c, id)
+}
+
+//#endregion Get By Id
+
+// #region Get All
+func (s *crudService[E, K, P]) GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, s.options.Name+".GetAll")()
+
+	if result := handleRules(c, s.options.Name+".readRules", s.readRules, P(new(E))); !result.IsSuccess() {
+		return result
+	}
+
+	if result := query.Validate(); !result.IsSuccess() {
+		return result
+	}
+	return s.repo.GetAll(c, query)
+}
+
+//#endregion Get All
+
+// validId, sayısal anahtarlarda pozitif, UUID ve diğer anahtarlarda boş olmayan değerleri
+// geçerli sayar
+func validId[K comparable](id K) bool {
+	switch value := any(id).(type) {
+	case int:
+		return value > 0
+	case uuid.UUID:
+		return value != uuid.Nil
+	}
+	var zero K
+	return id != zero
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// #region Event Handler
+
+// EventHandler, bir alan olayına abone olan işlemdir. Olaylar en az bir kez iletilir;
+// başarısız olan veya zaman aşımına uğrayan bir olay tüm abonelerine yeniden iletileceği
+// için aboneler aynı olayı tekrar işlemeye dayanıklı olmalıdır.
+type EventHandler interface {
+	Handle(envelope *events.Envelope, c *models.Context) *lgo.OperationResult
+}
+
+// EventFunc, tek bir fonksiyondan oluşan abonedir
+type EventFunc func(envelope *events.Envelope, c *models.Context) *lgo.OperationResult
+
+func (f EventFunc) Handle(envelope *events.Envelope, c *models.Context) *lgo.OperationResult {
+	return f(envelope, c)
+}
+
+//#endregion Event Handler
+
+// EventDispatcherConfig, giden kutusunun dağıtım ayarlarıdır
+type EventDispatcherConfig struct {
+	PollInterval  time.Duration // Bekleyen olayların aranma aralığı
+	BatchSize     int           // Bir aramada alınacak en fazla olay
+	MaxAttempts   int           // Bir olayın en fazla deneme sayısı; sonrasında olay bırakılır
+	RetryDelay    time.Duration // İlk yeniden denemeden önceki bekleme; her denemede iki katına çıkar
+	MaxRetryDelay time.Duration // Yeniden denemeler arasındaki en uzun bekleme
+	Lease         time.Duration // Alınan bir olayın başka dağıtıcılara verilmeyeceği süre
+}
+
+// EventDispatcher, giden kutusundaki olayları abonelerine iletir. Bir olay tüm aboneleri
+// başarılı olursa iletilmiş sayılır; herhangi biri başarısız olursa olay üstel artan
+// beklemeyle MaxAttempts'e kadar yeniden denenir. Birden fazla uygulama örneği aynı giden
+// kutusunu güvenle dağıtabilir.
+type EventDispatcher struct {
+	repo     repositories.OutboxRepository
+	config   EventDispatcherConfig
+	logger   *slog.Logger
+	handlers map[string][]EventHandler
+	all      []EventHandler
+}
+
+func NewEventDispatcher(repo repositories.OutboxRepository, config EventDispatcherConfig, logger *slog.Logger) *EventDispatcher {
+	return &EventDispatcher{
+		repo:     repo,
+		config:   config,
+		logger:   logger,
+		handlers: map[string][]EventHandler{},
+	}
+}
+
+// Subscribe, handler'ı verilen olay türlerine abone eder. Tür verilmezse handler tüm olayları
+// alır. Dağıtım başlamadan önce çağrılmalıdır.
+func (d *EventDispatcher) Subscribe(handler EventHandler, types ...string) {
+	if len(types) == 0 {
+		d.all = append(d.all, handler)
+		return
+	}
+	for _, eventType := range types {
+		d.handlers[eventType] = append(d.handlers[eventType], handler)
+	}
+}
+
+// #region Run
+// Run, ctx iptal edilene kadar giden kutusunu PollInterval aralıklarla dağıtır. Bir
+// dağıtım turu tam bir parti döndürdüyse beklemeden devam eder.
+func (d *EventDispatcher) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		wait := d.config.PollInterval
+		result := d.DispatchDue(models.NewSystemContext(ctx))
+		if !result.IsSuccess() {
+			d.logger.ErrorContext(ctx, "dispatching outbox failed", slog.String("error", result.ErrorMessage))
+		} else if claimed, _ := result.ReturnObject.(int); claimed >= d.config.BatchSize {
+			wait = 0
+		}
+		timer.Reset(wait)
+	}
+}
+
+//#endregion Run
+
+// #region Dispatch Due
+// DispatchDue, zamanı gelmiş en fazla BatchSize olayı iletir ve alınan olay sayısını
+// döndürür
+func (d *EventDispatcher) DispatchDue(c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "EventDispatcher.DispatchDue")()
+
+	claimResult := d.repo.Claim(c, time.Now(), d.config.Lease, d.config.BatchSize)
+	if !claimResult.IsSuccess() {
+		return claimResult
+	}
+
+	outboxEvents := claimResult.ReturnObject.([]*datamodels.OutboxEvent)
+	for _, outboxEvent := range outboxEvents {
+		if result := d.dispatch(c, outboxEvent); !result.IsSuccess() {
+			return result
+		}
+	}
+	return lgo.NewSuccess(len(outboxEvents))
+}
+
+// dispatch, olayı abonelerine iletir ve sonucunu giden kutusuna yazar. Dönen sonuç
+// yalnızca bu yazmanın sonucudur.
+func (d *EventDispatcher) dispatch(c *models.Context, outboxEvent *datamodels.OutboxEvent) *lgo.OperationResult {
+	err := d.deliver(c, outboxEvent)
+	if err == nil {
+		return d.repo.MarkDispatched(c, outboxEvent.Id, time.Now())
+	}
+
+	logger := d.logger.With(
+		slog.Int64("event_id", outboxEvent.Id),
+		slog.String("event_type", outboxEvent.Type),
+		slog.Int("attempts", outboxEvent.Attempts),
+		slog.String("error", err.Error()),
+	)
+	if outboxEvent.Attempts >= d.config.MaxAttempts {
+		logger.ErrorContext(c, "event dropped after max attempts")
+		return d.repo.MarkFailed(c, outboxEvent.Id, err.Error(), nil)
+	}
+
+	nextAttemptAt := time.Now().Add(d.retryDelay(outboxEvent.Attempts))
+	logger.WarnContext(c, "event delivery failed, retrying", slog.Time("next_attempt_at", nextAttemptAt))
+	return d.repo.MarkFailed(c, outboxEvent.Id, err.Error(), &nextAttemptAt)
+}
+
+// deliver, olayı çözer ve tüm abonelerini çalıştırır. Bir abone başarısız olsa da diğerleri
+// çalıştırılır.
+func (d *EventDispatcher) deliver(c *models.Context, outboxEvent *datamodels.OutboxEvent) error {
+	event, err := events.Decode(outboxEvent.Type, []byte(outboxEvent.Payload))
+	if err != nil {
+		return err
+	}
+	envelope := &events.Envelope{Id: outboxEvent.Id, Type: outboxEvent.Type, OccurredAt: outboxEvent.OccurredAt, Data: event}
+
+	var failed error
+	for _, handler := range slices.Concat(d.handlers[outboxEvent.Type], d.all) {
+		if err := handleEvent(handler, envelope, c); err != nil && failed == nil {
+			failed = err
+		}
+	}
+	return failed
+}
+
+// handleEvent, aboneyi çalıştırır; başarısız sonucu ve panikleri hataya çevirir
+func handleEvent(handler EventHandler, envelope *events.Envelope, c *models.Context) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("event handler panicked: %v", recovered)
+		}
+	}()
+
+	result := handler.Handle(envelope, c)
+	if !result.IsSuccess() {
+		message := result.ErrorMessage
+		if code, ok := result.ReturnObject.(*i18n.Message); ok && message == "" {
+			message = code.Code
+		}
+		return fmt.Errorf("event handler failed: %s", message)
+	}
+	return nil
+}
+
+// retryDelay, attempts denemeden sonraki beklemedir
+func (d *EventDispatcher) retryDelay(attempts int) time.Duration {
+	return backoff(d.config.RetryDelay, d.config.MaxRetryDelay, attempts)
+}
+
+// backoff, attempts denemeden sonraki üstel artan beklemedir: base, 2×base, 4×base...
+// Bekleme maxDelay'i aşmaz.
+func backoff(base, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+//#endregion Dispatch Due
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+// recorder, aldığı olay türlerini sırayla saklar; fails kadar çağrıda başarısız olur
+type recorder struct {
+	received []string
+	fails    int
+}
+
+func (r *recorder) Handle(envelope *events.Envelope, c *models.Context) *lgo.OperationResult {
+	r.received = append(r.received, envelope.Type)
+	if r.fails > 0 {
+		r.fails--
+		return mvc.NewLogicError(i18n.InvalidId)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+func TestEventDispatcherDeliversToSubscribers(t *testing.T) {
+	f := newFixture(t)
+	mustSucceed(t, f.events.Publish(f.c, &events.TimingDeleted{TimingId: 1}, &events.ClientArchived{ClientId: 2}))
+
+	timings, all := &recorder{}, &recorder{}
+	dispatcher := f.dispatcher(3)
+	dispatcher.Subscribe(timings, events.TypeTimingCreated, events.TypeTimingDeleted)
+	dispatcher.Subscribe(all)
+
+	result := dispatcher.DispatchDue(f.c)
+	ok().check(t, result)
+	if claimed := result.ReturnObject.(int); claimed != 2 {
+		t.Fatalf("want 2 events dispatched, got %d", claimed)
+	}
+	if len(timings.received) != 1 || timings.received[0] != events.TypeTimingDeleted {
+		t.Fatalf("want only the timing event for the timing subscriber, got %v", timings.received)
+	}
+	if len(all.received) != 2 {
+		t.Fatalf("want both events for the catch-all subscriber, got %v", all.received)
+	}
+	for _, stored := range f.repos.Outbox.Find(nil) {
+		if stored.DispatchedAt == nil || stored.NextAttemptAt != nil {
+			t.Fatalf("want every event marked dispatched, got %+v", stored)
+		}
+	}
+
+	// İletilmiş olaylar yeniden dağıtılmaz
+	ok().check(t, dispatcher.DispatchDue(f.c))
+	if len(all.received) != 2 {
+		t.Fatalf("want dispatched events delivered once, got %v", all.received)
+	}
+}
+
+func TestEventDispatcherRetriesFailedEvents(t *testing.T) {
+	tests := []struct {
+		name          string
+		fails         int
+		panics        bool
+		wantDelivered int  // Abonenin olayı kaç kez aldığı
+		wantDropped   bool // Olayın deneme hakkını bitirip bırakılması
+	}{
+		{name: "succeeds on retry", fails: 1, wantDelivered: 2},
+		{name: "dropped after max attempts", fails: 5, wantDelivered: 3, wantDropped: true},
+		{name: "panicking handler", panics: true, wantDelivered: 3, wantDropped: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t)
+			mustSucceed(t, f.events.Publish(f.c, &events.SystemUserDeactivated{SystemUserId: uuid.New()}))
+
+			handler := &recorder{fails: test.fails}
+			delivered := 0
+			dispatcher := f.dispatcher(3)
+			dispatcher.Subscribe(EventFunc(func(envelope *events.Envelope, c *models.Context) *lgo.OperationResult {
+				delivered++
+				if test.panics {
+					panic("boom")
+				}
+				return handler.Handle(envelope, c)
+			}))
+
+			// Yeniden deneme beklemesi sıfır olduğu için her tur olayı yeniden alır
+			for range 5 {
+				ok().check(t, dispatcher.DispatchDue(f.c))
+			}
+
+			if delivered != test.wantDelivered {
+				t.Fatalf("want %d deliveries, got %d", test.wantDelivered, delivered)
+			}
+			stored := f.repos.Outbox.Find(nil)[0]
+			if dropped := stored.DispatchedAt == nil && stored.NextAttemptAt == nil; dropped != test.wantDropped {
+				t.Fatalf("want dropped %v, got %+v", test.wantDropped, stored)
+			}
+			if test.wantDropped && stored.LastError == "" {
+				t.Fatal("want the last error kept on a dropped event")
+			}
+		})
+	}
+}
+
+func TestEventDispatcherRetryDelay(t *testing.T) {
+	dispatcher := &EventDispatcher{config: EventDispatcherConfig{RetryDelay: time.Second, MaxRetryDelay: 10 * time.Second}}
+
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 8 * time.Second, 5: 10 * time.Second, 30: 10 * time.Second} {
+		if got := dispatcher.retryDelay(attempts); got != want {
+			t.Errorf("want a %v delay after %d attempts, got %v", want, attempts, got)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"lms-web-services-main/events"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// EventService, alan olaylarını giden kutusuna (Outbox) yazar. Olaylar abonelere
+// EventDispatcher tarafından iletilir.
+type EventService interface {
+	// Publish, olayları giden kutusuna yazar. c bir işlem taşıyorsa olaylar işlemle birlikte
+	// kaydedilir veya geri alınır.
+	Publish(c *models.Context, events ...events.Event) *lgo.OperationResult
+	// Record, write'ı ve write'ın başarılı sonucundan event ile üretilen olayı tek veritabanı
+	// işleminde yazar; olay yazılamazsa write da geri alınır. event nil döndürürse olay
+	// yazılmaz. write'ın sonucunu döndürür.
+	Record(c *models.Context, write func(tx *models.Context) *lgo.OperationResult, event func(result *lgo.OperationResult) events.Event) *lgo.OperationResult
+	// RecordAll, Record gibidir; değişiklik bağlı kayıtları da etkiliyorsa her kayıt için
+	// ayrı olay yayımlamak içindir. Olaylar verilen sırayla yazılır.
+	RecordAll(c *models.Context, write func(tx *models.Context) *lgo.OperationResult, recorded func(result *lgo.OperationResult) []events.Event) *lgo.OperationResult
+}
+
+type eventService struct {
+	transactor repositories.Transactor
+	repo       repositories.OutboxRepository
+}
+
+func NewEventService(transactor repositories.Transactor, repo repositories.OutboxRepository) EventService {
+	return &eventService{transactor: transactor, repo: repo}
+}
+
+// #region Publish
+func (s *eventService) Publish(c *models.Context, events ...events.Event) *lgo.OperationResult {
+	defer startSpan(c, "EventService.Publish")()
+
+	occurredAt := time.Now().UTC().Truncate(time.Microsecond)
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return lgo.NewFailureWithError(err)
+		}
+
+		result := s.repo.Add(c, &datamodels.OutboxEvent{
+			Type:          event.EventType(),
+			Payload:       string(payload),
+			OccurredAt:    occurredAt,
+			NextAttemptAt: &occurredAt,
+		})
+		if !result.IsSuccess() {
+			return result
+		}
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Publish
+
+// #region Record
+func (s *eventService) Record(c *models.Context, write func(tx *models.Context) *lgo.OperationResult, event func(result *lgo.OperationResult) events.Event) *lgo.OperationResult {
+	return s.RecordAll(c, write, func(result *lgo.OperationResult) []events.Event {
+		if recorded := event(result); recorded != nil {
+			return []events.Event{recorded}
+		}
+		return nil
+	})
+}
+
+func (s *eventService) RecordAll(c *models.Context, write func(tx *models.Context) *lgo.OperationResult, recorded func(result *lgo.OperationResult) []events.Event) *lgo.OperationResult {
+	return s.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		result := write(tx)
+		if !result.IsSuccess() {
+			return result
+		}
+
+		if publishResult := s.Publish(tx, recorded(result)...); !publishResult.IsSuccess() {
+			return publishResult
+		}
+		return result
+	})
+}
+
+//#endregion Record
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"lms-web-services-main/events"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+func TestServicesPublishEvents(t *testing.T) {
+	permissions := slices.Concat(allClientPermissions, allClientProjectPermissions, allTimingPermissions, allSystemUserPermissions, allTagPermissions,
+		[]string{datamodels.CLIENTS_FORCE_DELETE, datamodels.CLIENTPROJECTS_FORCE_DELETE, datamodels.SYSTEM_SETTINGS_ADD, datamodels.SYSTEM_SETTINGS_DELETE})
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult
+		want []string
+	}{
+		{
+			name: "client create",
+			run: func(t *testing.T, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events).Create(&datamodels.Client{ShortTitle: "YENI", Title: "Yeni", IsActive: true}, f.c)
+			},
+			want: []string{events.TypeClientCreated},
+		},
+		{
+			name: "failed client create",
+			run: func(t *testing.T, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events).Create(&datamodels.Client{Title: "Kısa adı yok"}, f.c)
+				return lgo.NewSuccess(nil)
+			},
+			want: []string{},
+		},
+		{
+			name: "client update that archives",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				update := *client
+				update.IsActive = false
+				return NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events).Update(&update, f.c)
+			},
+			want: []string{events.TypeClientUpdated, events.TypeClientArchived},
+		},
+		{
+			name: "client archive",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				return NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events).Archive(client.Id, f.c)
+			},
+			want: []string{events.TypeClientArchived},
+		},
+		{
+			name: "client archive cascades to its active projects",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				f.addClientProject(t, client.Id, "Portal")
+				mustSucceed(t, f.repos.ClientProjects.Create(f.c, &datamodels.ClientProject{ClientId: client.Id, Name: "Arşivdeki"}))
+				return NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events).Archive(client.Id, f.c)
+			},
+			want: []string{events.TypeClientArchived, events.TypeClientProjectArchived},
+		},
+		{
+			name: "client unarchive cascades to the projects archived with it",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				f.addClientProject(t, client.Id, "Portal")
+				f.addClientProject(t, client.Id, "Mobil")
+				mustSucceed(t, f.repos.Clients.Archive(f.c, client.Id, time.Now()))
+				return NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events).Unarchive(client.Id, f.c)
+			},
+			want: []string{events.TypeClientUnarchived, events.TypeClientProjectUnarchived, events.TypeClientProjectUnarchived},
+		},
+		{
+			name: "client force delete cascades to its projects and timings",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				project := f.addClientProject(t, client.Id, "Portal")
+				f.addTiming(t, stoppedTiming(project.Id, f.user.Id))
+				return NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events).ForceDelete(client.Id, f.c)
+			},
+			want: []string{events.TypeClientDeleted, events.TypeClientProjectDeleted, events.TypeTimingDeleted},
+		},
+		{
+			name: "project force delete cascades to its timings",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				project := f.addClientProject(t, client.Id, "Portal")
+				f.addTiming(t, stoppedTiming(project.Id, f.user.Id))
+				f.addTiming(t, stoppedTiming(project.Id, f.user.Id))
+				return NewClientProjectService(f.repos.ClientProjects, f.repos.Clients, f.repos.Transactor, f.cache, f.events).ForceDelete(project.Id, f.c)
+			},
+			want: []string{events.TypeClientProjectDeleted, events.TypeTimingDeleted, events.TypeTimingDeleted},
+		},
+		{
+			name: "client contact create",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				return NewClientContactService(f.repos.ClientContacts, f.repos.Clients, f.cache, f.events).Create(&datamodels.ClientContact{ClientId: client.Id, Name: "Can Demir"}, f.c)
+			},
+			want: []string{events.TypeClientContactCreated},
+		},
+		{
+			name: "client contact delete",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				contact := &datamodels.ClientContact{ClientId: client.Id, Name: "Can Demir"}
+				mustSucceed(t, f.repos.ClientContacts.Create(f.c, contact))
+				return NewClientContactService(f.repos.ClientContacts, f.repos.Clients, f.cache, f.events).Delete(client.Id, contact.Id, f.c)
+			},
+			want: []string{events.TypeClientContactDeleted},
+		},
+		{
+			name: "tag update",
+			run: func(t *testing.T, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				tag := f.addTag(t, "Toplantı")
+				update := *tag
+				update.Name = "Görüşme"
+				return NewTagService(f.repos.Tags, f.cache, f.events).Update(&update, f.c)
+			},
+			want: []string{events.TypeTagUpdated},
+		},
+		{
+			name: "setting set",
+			run: func(t *testing.T, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				return NewSystemUserSettingService(f.repos.Settings, f.cache, f.events).Set(&datamodels.SystemUserSetting{SystemUserId: f.user.Id, Key: datamodels.SYSTEM_LANGUAGE, Value: "en"}, f.c)
+			},
+			want: []string{events.TypeSystemUserSettingSet},
+		},
+		{
+			name: "setting delete",
+			run: func(t *testing.T, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				setting := &datamodels.SystemUserSetting{SystemUserId: f.user.Id, Key: datamodels.SYSTEM_LANGUAGE, Value: "en"}
+				mustSucceed(t, f.repos.Settings.Create(f.c, setting))
+				return NewSystemUserSettingService(f.repos.Settings, f.cache, f.events).Delete(setting.Id, f.c)
+			},
+			want: []string{events.TypeSystemUserSettingDeleted},
+		},
+		{
+			name: "client delete",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				return NewClientService(f.repos.Clients, f.repos.Transactor, f.cache, f.events).Delete(client.Id, f.c)
+			},
+			want: []string{events.TypeClientDeleted},
+		},
+		{
+			name: "project unarchive",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				project := &datamodels.ClientProject{ClientId: client.Id, Name: "Arşivdeki"}
+				mustSucceed(t, f.repos.ClientProjects.Create(f.c, project))
+				return NewClientProjectService(f.repos.ClientProjects, f.repos.Clients, f.repos.Transactor, f.cache, f.events).Unarchive(project.Id, f.c)
+			},
+			want: []string{events.TypeClientProjectUnarchived},
+		},
+		{
+			name: "timing create",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				project := f.addClientProject(t, client.Id, "Portal")
+				f.addMember(t, project.Id, f.user)
+				start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+				return f.timingService(f.budgetService(nil)).Create(&datamodels.Timing{
+					ClientProjectId: project.Id, SystemUserId: f.user.Id, Title: "Analiz",
+					StartDateTime: start, EndDateTime: start.Add(time.Hour), Status: enum.StatusStopped,
+				}, f.c)
+			},
+			want: []string{events.TypeTimingCreated},
+		},
+		{
+			name: "timing create crossing budget thresholds",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				project := &datamodels.ClientProject{ClientId: client.Id, Name: "Bakım", IsActive: true, BudgetHours: 1}
+				mustSucceed(t, f.repos.ClientProjects.Create(f.c, project))
+				f.addMember(t, project.Id, f.user)
+				return f.timingService(f.budgetService(nil, 80, 100)).Create(stoppedTiming(project.Id, f.user.Id), f.c)
+			},
+			want: []string{events.TypeTimingCreated, events.TypeClientProjectBudgetThresholdCrossed, events.TypeClientProjectBudgetThresholdCrossed},
+		},
+		{
+			name: "timing tags",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				project := f.addClientProject(t, client.Id, "Portal")
+				f.addMember(t, project.Id, f.user)
+				timing := f.addTiming(t, stoppedTiming(project.Id, f.user.Id))
+				tag := f.addTag(t, "Toplantı")
+				return f.timingService(f.budgetService(nil)).SetTags(timing.Id, &mvc.TimingTags{TagIds: []int{tag.Id}}, f.c)
+			},
+			want: []string{events.TypeTimingTagsSet},
+		},
+		{
+			name: "timing completion",
+			run: func(t *testing.T, f *fixture, client *datamodels.Client) *lgo.OperationResult {
+				project := f.addClientProject(t, client.Id, "Portal")
+				f.addMember(t, project.Id, f.user)
+				start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+				timing := f.addTiming(t, &datamodels.Timing{
+					ClientProjectId: project.Id, SystemUserId: f.user.Id, Title: "Analiz",
+					StartDateTime: start, EndDateTime: start.Add(time.Hour), Status: enum.StatusStopped,
+				})
+				update := *timing
+				update.Status = enum.StatusCompleted
+				return f.timingService(f.budgetService(nil)).Update(&update, f.c)
+			},
+			want: []string{events.TypeTimingCompleted},
+		},
+		{
+			name: "system user update without an activation change",
+			run: func(t *testing.T, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				update := *f.user
+				update.Password = ""
+				update.Name = "Ayşe"
+				return f.systemUserService().Update(&update, f.c)
+			},
+			want: []string{},
+		},
+		{
+			name: "system user deactivation",
+			run: func(t *testing.T, f *fixture, _ *datamodels.Client) *lgo.OperationResult {
+				other := f.addUser(t, "other@example.com")
+				update := *other
+				update.Password = ""
+				update.IsActive = false
+				return f.systemUserService().Update(&update, f.c)
+			},
+			want: []string{events.TypeSystemUserDeactivated},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, permissions...)
+			client := f.addClient(t, "ACME")
+
+			ok().check(t, test.run(t, f, client))
+			if got := f.repos.Outbox.Types(); !slices.Equal(got, test.want) {
+				t.Fatalf("want events %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+// stoppedTiming, projede bir saatlik durdurulmuş zaman kaydıdır
+func stoppedTiming(clientProjectId int, systemUserId uuid.UUID) *datamodels.Timing {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	return &datamodels.Timing{
+		ClientProjectId: clientProjectId, SystemUserId: systemUserId, Title: "Analiz",
+		StartDateTime: start, EndDateTime: start.Add(time.Hour), Status: enum.StatusStopped,
+	}
+}
+
+func TestEventServicePublishWritesDecodableEvents(t *testing.T) {
+	f := newFixture(t)
+	published := &events.TimingCreated{Timing: datamodels.Timing{Id: 7, ClientProjectId: 3, SystemUserId: f.user.Id, Title: "Analiz"}}
+
+	ok().check(t, f.events.Publish(f.c, published))
+
+	stored := f.repos.Outbox.Find(nil)
+	if len(stored) != 1 || stored[0].NextAttemptAt == nil || stored[0].DispatchedAt != nil {
+		t.Fatalf("want one pending event, got %+v", stored)
+	}
+	decoded, err := events.Decode(stored[0].Type, []byte(stored[0].Payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := decoded.(*events.TimingCreated); got.Timing.Id != 7 || got.Timing.SystemUserId != f.user.Id || got.Timing.Title != "Analiz" {
+		t.Fatalf("want the published timing back, got %+v", got.Timing)
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories/memory"
+
+	"github.com/LGYtech/lgo"
+)
+
+// fixture, bellekteki depolarla kurulmuş servislerin ortak test ortamıdır. user, verilen
+// yetkilerle oturum açmış kullanıcıdır.
+type fixture struct {
+	repos  *memory.Repositories
+	cache  CacheService
+	events EventService
+	user   *datamodels.SystemUser
+	c      *models.Context
+}
+
+func newFixture(t *testing.T, permissions ...string) *fixture {
+	t.Helper()
+
+	repos := memory.NewRepositories()
+	f := &fixture{
+		repos:  repos,
+		cache:  NewCacheService(repos.Cache),
+		events: NewEventService(repos.Transactor, repos.Outbox),
+		c:      &models.Context{Context: context.Background(), Token: "session-token"},
+	}
+	f.user = f.addUser(t, "ada@example.com")
+	repos.Settings.Grant(f.user.Id, permissions...)
+	repos.Cache.RegisterSystemUserCredential(f.c, f.c.Token, f.user)
+	f.c.Principal = f.cache.GetSystemUserCredential(f.c, f.c.Token).ReturnObject.(*mvc.SystemUserCredential)
+	return f
+}
+
+// addUser, servis kurallarını atlayarak depoya etkin bir kullanıcı ekler
+func (f *fixture) addUser(t *testing.T, email string) *datamodels.SystemUser {
+	t.Helper()
+	user := &datamodels.SystemUser{Name: "Ada", Surname: "Yılmaz", Email: email, Password: "hash", PasswordSalt: "salt", IsActive: true}
+	mustSucceed(t, f.repos.SystemUsers.Create(f.c, user))
+	return user
+}
+
+func (f *fixture) addClient(t *testing.T, title string) *datamodels.Client {
+	t.Helper()
+	client := &datamodels.Client{ShortTitle: title, Title: title, IsActive: true}
+	mustSucceed(t, f.repos.Clients.Create(f.c, client))
+	return client
+}
+
+func (f *fixture) addClientProject(t *testing.T, clientId int, name string) *datamodels.ClientProject {
+	t.Helper()
+	clientProject := &datamodels.ClientProject{ClientId: clientId, Name: name, IsActive: true}
+	mustSucceed(t, f.repos.ClientProjects.Create(f.c, clientProject))
+	return clientProject
+}
+
+// addMember, servis kurallarını atlayarak kullanıcıyı projeye üye yapar
+func (f *fixture) addMember(t *testing.T, clientProjectId int, systemUser *datamodels.SystemUser) *datamodels.ClientProjectMember {
+	t.Helper()
+	member := &datamodels.ClientProjectMember{ClientProjectId: clientProjectId, SystemUserId: systemUser.Id}
+	mustSucceed(t, f.repos.ClientProjectMembers.Create(f.c, member))
+	return member
+}
+
+// addTask, servis kurallarını atlayarak projeye görev ekler
+func (f *fixture) addTask(t *testing.T, clientProjectId int, name string) *datamodels.ProjectTask {
+	t.Helper()
+	task := &datamodels.ProjectTask{ClientProjectId: clientProjectId, Name: name}
+	mustSucceed(t, f.repos.ProjectTasks.Create(f.c, task))
+	return task
+}
+
+// addTag, servis kurallarını atlayarak etiket ekler
+func (f *fixture) addTag(t *testing.T, name string) *datamodels.Tag {
+	t.Helper()
+	tag := &datamodels.Tag{Name: name, Color: "#1E88E5"}
+	mustSucceed(t, f.repos.Tags.Create(f.c, tag))
+	return tag
+}
+
+// timingService, fixture'ın depolarıyla zaman kaydı servisini kurar
+func (f *fixture) timingService(budgetService BudgetService) TimingService {
+	return NewTimingService(f.repos.Timings, f.repos.ClientProjects, f.repos.Clients, f.repos.ClientProjectMembers, f.repos.ProjectTasks, f.repos.Tags, budgetService, f.repos.Transactor, f.cache, f.events)
+}
+
+func (f *fixture) addTiming(t *testing.T, timing *datamodels.Timing) *datamodels.Timing {
+	t.Helper()
+	mustSucceed(t, f.repos.Timings.Create(f.c, timing))
+	return timing
+}
+
+// dispatcher, giden kutusunu yeniden denemeleri beklemeden dağıtan bir dağıtıcı kurar
+func (f *fixture) dispatcher(maxAttempts int) *EventDispatcher {
+	config := EventDispatcherConfig{BatchSize: 100, MaxAttempts: maxAttempts, Lease: time.Minute}
+	return NewEventDispatcher(f.repos.Outbox, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// budgetService, verilen eşiklerle aşılan bütçeleri alerts'a kaydeden servisi kurar
+func (f *fixture) budgetService(alerts *budgetAlerts, thresholds ...int) BudgetService {
+	if alerts == nil {
+		alerts = &budgetAlerts{}
+	}
+	return NewBudgetService(f.repos.ClientProjects, f.repos.Timings, f.repos.ClientProjectMembers, f.cache, f.events, alerts, thresholds)
+}
+
+// budgetAlerts, bildirilen bütçe uyarılarını sırayla saklar
+type budgetAlerts []*mvc.BudgetAlert
+
+func (a *budgetAlerts) BudgetThresholdCrossed(c *models.Context, alert *mvc.BudgetAlert) {
+	*a = append(*a, alert)
+}
+
+func mustSucceed(t *testing.T, result *lgo.OperationResult) {
+	t.Helper()
+	if !result.IsSuccess() {
+		t.Fatalf("setup failed: %s", result.ErrorMessage)
+	}
+}
+
+// expected, bir işlemin sonucundan beklenendir. Sıfır değeri başarılı sonuç demektir.
+type expected struct {
+	forbidden string // Eksik yetkinin anahtarı (lgo.NewAutoError)
+	anonymous bool   // Oturum açmış kullanıcı yok (lgo.NewAuthError)
+	failure   bool   // Sistem hatası (lgo.NewFailure), örn. oturum bulunamadı
+	errorCode uint8  // İş kuralı hatasının türü (mvc.ErrorCode*)
+	code      string // İş kuralı hatasının i18n mesaj kodu
+}
+
+func ok() expected {
+	return expected{}
+}
+
+func forbidden(key string) expected {
+	return expected{forbidden: key}
+}
+
+func anonymous() expected {
+	return expected{anonymous: true}
+}
+
+func failure() expected {
+	return expected{failure: true}
+}
+
+func invalid(code string) expected {
+	return expected{errorCode: mvc.ErrorCodeNone, code: code}
+}
+
+func invalidFields() expected {
+	return expected{errorCode: mvc.ErrorCodeValidation, code: i18n.InvalidFields}
+}
+
+func notFound(code string) expected {
+	return expected{errorCode: mvc.ErrorCodeNotFound, code: code}
+}
+
+func conflict(code string) expected {
+	return expected{errorCode: mvc.ErrorCodeConflict, code: code}
+}
+
+func (want expected) check(t *testing.T, result *lgo.OperationResult) {
+	t.Helper()
+
+	switch {
+	case want.forbidden != "":
+		if result.Result != lgo.NewAutoError().Result || result.ErrorMessage != want.forbidden {
+			t.Fatalf("want forbidden %q, got %s", want.forbidden, describe(result))
+		}
+	case want.anonymous:
+		if result.Result != lgo.NewAuthError().Result {
+			t.Fatalf("want auth error, got %s", describe(result))
+		}
+	case want.failure:
+		if result.Result != lgo.NewFailure().Result {
+			t.Fatalf("want failure, got %s", describe(result))
+		}
+	case want.code != "":
+		message, _ := result.ReturnObject.(*i18n.Message)
+		if result.Result != lgo.NewLogicError("", nil).Result || result.ErrorCode != want.errorCode || message == nil || message.Code != want.code {
+			t.Fatalf("want logic error %d/%s, got %s", want.errorCode, want.code, describe(result))
+		}
+	default:
+		if !result.IsSuccess() {
+			t.Fatalf("want success, got %s", describe(result))
+		}
+	}
+}
+
+func describe(result *lgo.OperationResult) string {
+	if message, ok := result.ReturnObject.(*i18n.Message); ok {
+		return fmt.Sprintf("result %v, ec %d, code %s", result.Result, result.ErrorCode, message.Code)
+	}
+	return fmt.Sprintf("result %v, em %q", result.Result, result.ErrorMessage)
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
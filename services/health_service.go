@@ -0,0 +1,101 @@
+package services
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+type HealthService interface {
+	Liveness() *lgo.OperationResult
+	Readiness(c *models.Context) *lgo.OperationResult
+	MarkShuttingDown()
+}
+
+type healthService struct {
+	repo         repositories.HealthRepository
+	version      string
+	startedAt    time.Time
+	shuttingDown atomic.Bool
+}
+
+func NewHealthService(repo repositories.HealthRepository, version string) HealthService {
+	return &healthService{
+		repo:      repo,
+		version:   version,
+		startedAt: time.Now(),
+	}
+}
+
+// #region Liveness
+func (s *healthService) Liveness() *lgo.OperationResult {
+	return lgo.NewSuccess(s.newReport())
+}
+
+//#endregion Liveness
+
+// #region Readiness
+func (s *healthService) Readiness(c *models.Context) *lgo.OperationResult {
+	report := s.newReport()
+	report.Checks = []*mvc.HealthCheck{
+		runHealthCheck("postgres", func() *lgo.OperationResult { return s.repo.PingDatabase(c) }),
+		runHealthCheck("redis", func() *lgo.OperationResult { return s.repo.PingCache(c) }),
+	}
+
+	ready := !s.shuttingDown.Load()
+	for _, check := range report.Checks {
+		if check.Status != mvc.HealthStatusOk {
+			ready = false
+		}
+	}
+
+	if !ready {
+		report.Status = mvc.HealthStatusUnavailable
+		return lgo.NewFailureWithReturnObject(report)
+	}
+	return lgo.NewSuccess(report)
+}
+
+//#endregion Readiness
+
+// #region Mark Shutting Down
+// MarkShuttingDown, readiness kontrolünü kalıcı olarak başarısız yapar; böylece
+// orkestratör kapanış sırasında yeni trafik yönlendirmez
+func (s *healthService) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+//#endregion Mark Shutting Down
+
+func (s *healthService) newReport() *mvc.HealthReport {
+	return &mvc.HealthReport{
+		Status:    mvc.HealthStatusOk,
+		Version:   s.version,
+		GoVersion: runtime.Version(),
+		Uptime:    time.Since(s.startedAt).Round(time.Second).String(),
+	}
+}
+
+func runHealthCheck(name string, ping func() *lgo.OperationResult) *mvc.HealthCheck {
+	start := time.Now()
+	result := ping()
+	check := &mvc.HealthCheck{
+		Name:      name,
+		Status:    mvc.HealthStatusOk,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+
+	if !result.IsSuccess() {
+		check.Status = mvc.HealthStatusUnavailable
+		check.Error = result.ErrorMessage
+		return check
+	}
+	check.Version, _ = result.ReturnObject.(string)
+	return check
+}
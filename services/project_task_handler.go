@@ -0,0 +1,55 @@
+package services
+
+import (
+	"strings"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// ProjectTask'a özgü kurallar. Doğrulama ve yetki kuralları crudService tarafından kurulur.
+
+// #region Client Project Exists
+// ProjectTaskRuleHandlerClientProjectExists, yeni görevin projesinin kayıtlı olduğunu kontrol eder
+type ProjectTaskRuleHandlerClientProjectExists struct {
+	ClientProjectRepository repositories.ClientProjectRepository
+}
+
+func (h *ProjectTaskRuleHandlerClientProjectExists) Handle(model *datamodels.ProjectTask, c *models.Context) *lgo.OperationResult {
+	if !model.IsNew() {
+		return lgo.NewSuccess(nil)
+	}
+	if result := h.ClientProjectRepository.GetById(c, model.ClientProjectId); !result.IsSuccess() {
+		return result
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Client Project Exists
+
+// #region Unique Task Name
+// ProjectTaskRuleHandlerUnique, aynı projede aynı adla (büyük/küçük harf duyarsız) ikinci bir
+// görev açılmasını engeller. Oluşturmada ve güncellemede çalışır.
+type ProjectTaskRuleHandlerUnique struct {
+	ProjectTaskRepository repositories.ProjectTaskRepository
+}
+
+func (h *ProjectTaskRuleHandlerUnique) Handle(model *datamodels.ProjectTask, c *models.Context) *lgo.OperationResult {
+	result := h.ProjectTaskRepository.GetByClientProjectId(c, model.ClientProjectId)
+	if !result.IsSuccess() {
+		return result
+	}
+	for _, task := range result.ReturnObject.([]*datamodels.ProjectTask) {
+		if task.Id != model.Id && strings.EqualFold(task.Name, model.Name) {
+			return mvc.NewConflictError(i18n.TaskExists)
+		}
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Unique Task Name
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	repositories "lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// #region Project Task Service Interface
+
+// ProjectTaskService, /client-projects/:id/tasks alt kaynağının işlemleridir.
+// Görevlere her zaman projeleri üzerinden erişilir; başka bir projenin görevi bulunamadı sayılır.
+type ProjectTaskService interface {
+	Create(task *datamodels.ProjectTask, c *models.Context) *lgo.OperationResult
+	Update(task *datamodels.ProjectTask, c *models.Context) *lgo.OperationResult
+	Delete(clientProjectId int, id int, c *models.Context) *lgo.OperationResult
+	GetById(clientProjectId int, id int, c *models.Context) *lgo.OperationResult
+	GetByClientProjectId(clientProjectId int, c *models.Context) *lgo.OperationResult
+}
+
+//#endregion Project Task Service Interface
+
+// #region Project Task Service Implementation
+type projectTaskService struct {
+	crud        *crudService[datamodels.ProjectTask, int, *datamodels.ProjectTask]
+	repo        repositories.ProjectTaskRepository
+	projectRepo repositories.ClientProjectRepository
+}
+
+func NewProjectTaskService(repo repositories.ProjectTaskRepository, projectRepo repositories.ClientProjectRepository, cacheService CacheService, eventService EventService) ProjectTaskService {
+	service := &projectTaskService{
+		crud: newCrudService[datamodels.ProjectTask, int](repo, cacheService, CrudServiceOptions{
+			Name:        "ProjectTaskService",
+			Permissions: datamodels.ProjectTaskPermissions,
+			InvalidId:   i18n.InvalidId,
+		}),
+		repo:        repo,
+		projectRepo: projectRepo,
+	}
+
+	service.crud.events = crudEvents[datamodels.ProjectTask, int]{
+		service: eventService,
+		created: func(task *datamodels.ProjectTask) events.Event { return &events.ProjectTaskCreated{Task: *task} },
+		updated: func(_ *datamodels.ProjectTask, task *datamodels.ProjectTask) events.Event {
+			return &events.ProjectTaskUpdated{Task: *task}
+		},
+		deleted: func(id int) events.Event { return &events.ProjectTaskDeleted{TaskId: id} },
+	}
+
+	unique := &ProjectTaskRuleHandlerUnique{ProjectTaskRepository: repo}
+	service.crud.saveRules = service.crud.saveRules.Then(
+		&ProjectTaskRuleHandlerClientProjectExists{ClientProjectRepository: projectRepo},
+		unique,
+	)
+	service.crud.updateRules = service.crud.updateRules.Then(unique)
+
+	return service
+}
+
+// #region Create
+func (s *projectTaskService) Create(task *datamodels.ProjectTask, c *models.Context) *lgo.OperationResult {
+	return s.crud.Create(task, c)
+}
+
+//#endregion Create
+
+// #region Update
+func (s *projectTaskService) Update(task *datamodels.ProjectTask, c *models.Context) *lgo.OperationResult {
+	if result := s.GetById(task.ClientProjectId, task.Id, c); !result.IsSuccess() {
+		return result
+	}
+	return s.crud.Update(task, c)
+}
+
+//#endregion Update
+
+// #region Delete
+func (s *projectTaskService) Delete(clientProjectId int, id int, c *models.Context) *lgo.OperationResult {
+	if result := s.GetById(clientProjectId, id, c); !result.IsSuccess() {
+		return result
+	}
+	return s.crud.Delete(id, c)
+}
+
+//#endregion Delete
+
+// #region Get By Id
+func (s *projectTaskService) GetById(clientProjectId int, id int, c *models.Context) *lgo.OperationResult {
+	if clientProjectId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientProjectId)
+	}
+
+	result := s.crud.GetById(id, c)
+	if !result.IsSuccess() {
+		return result
+	}
+	if result.ReturnObject.(*datamodels.ProjectTask).ClientProjectId != clientProjectId {
+		return mvc.NewNotFoundError(i18n.TaskNotFound)
+	}
+	return result
+}
+
+//#endregion Get By Id
+
+// #region Get Tasks By ClientProjectId
+func (s *projectTaskService) GetByClientProjectId(clientProjectId int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ProjectTaskService.GetByClientProjectId")()
+
+	if clientProjectId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientProjectId)
+	}
+
+	task := &datamodels.ProjectTask{ClientProjectId: clientProjectId}
+	if result := handleRules(c, "ProjectTaskService.readRules", s.crud.readRules, task); !result.IsSuccess() {
+		return result
+	}
+	if result := s.projectRepo.GetById(c, clientProjectId); !result.IsSuccess() {
+		return result
+	}
+	return s.repo.GetByClientProjectId(c, clientProjectId)
+}
+
+//#endregion Get Tasks By ClientProjectId
+
+//#endregion Project Task Service Implementation
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+
+	"github.com/LGYtech/lgo"
+)
+
+var allProjectTaskPermissions = []string{datamodels.CLIENTPROJECTS_VIEW, datamodels.CLIENTPROJECTS_UPDATE}
+
+func TestProjectTaskServiceRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult
+		want        expected
+	}{
+		{
+			name:        "create",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.Create(&datamodels.ProjectTask{ClientProjectId: existing.ClientProjectId, Name: "Test", EstimateHours: 12}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create validates the estimate",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.Create(&datamodels.ProjectTask{ClientProjectId: existing.ClientProjectId, Name: "Test", EstimateHours: -1}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create validates the status",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.Create(&datamodels.ProjectTask{ClientProjectId: existing.ClientProjectId, Name: "Test", Status: enum.TaskStatusEnum(9)}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create for a missing project",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, _ *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.Create(&datamodels.ProjectTask{ClientProjectId: 99, Name: "Test"}, f.c)
+			},
+			want: notFound(i18n.ClientProjectNotFound),
+		},
+		{
+			name:        "create with a name taken in the project",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.Create(&datamodels.ProjectTask{ClientProjectId: existing.ClientProjectId, Name: "tasarım"}, f.c)
+			},
+			want: conflict(i18n.TaskExists),
+		},
+		{
+			name:        "create with a name taken in another project",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				other := f.addClientProject(t, f.addClient(t, "GLOBEX").Id, "Mobil uygulama")
+				return s.Create(&datamodels.ProjectTask{ClientProjectId: other.Id, Name: existing.Name}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create needs the project update permission",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.Create(&datamodels.ProjectTask{ClientProjectId: existing.ClientProjectId, Name: "Test"}, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "update",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				existing.Status = enum.TaskStatusInProgress
+				return s.Update(existing, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "update to a name taken in the project",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				f.addTask(t, existing.ClientProjectId, "Backend")
+				existing.Name = "Backend"
+				return s.Update(existing, f.c)
+			},
+			want: conflict(i18n.TaskExists),
+		},
+		{
+			name:        "update a task of another project",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				existing.ClientProjectId = f.addClientProject(t, f.addClient(t, "GLOBEX").Id, "Mobil uygulama").Id
+				return s.Update(existing, f.c)
+			},
+			want: notFound(i18n.TaskNotFound),
+		},
+		{
+			name:        "delete",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.Delete(existing.ClientProjectId, existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "delete missing task",
+			permissions: allProjectTaskPermissions,
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.Delete(existing.ClientProjectId, 99, f.c)
+			},
+			want: notFound(i18n.TaskNotFound),
+		},
+		{
+			name:        "get by id",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.GetById(existing.ClientProjectId, existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "list for a missing project",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			run: func(s ProjectTaskService, f *fixture, _ *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.GetByClientProjectId(99, f.c)
+			},
+			want: notFound(i18n.ClientProjectNotFound),
+		},
+		{
+			name:        "list needs the project view permission",
+			permissions: []string{datamodels.CLIENTPROJECTS_UPDATE},
+			run: func(s ProjectTaskService, f *fixture, existing *datamodels.ProjectTask) *lgo.OperationResult {
+				return s.GetByClientProjectId(existing.ClientProjectId, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			project := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+			existing := f.addTask(t, project.Id, "Tasarım")
+			service := NewProjectTaskService(f.repos.ProjectTasks, f.repos.ClientProjects, f.cache, f.events)
+			test.want.check(t, test.run(service, f, existing))
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+	repositories "lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// #region Report Service Interface
+
+// ReportService, zaman kayıtlarından özet raporlar üretir
+type ReportService interface {
+	// GetTaskReport, projenin görevlerinin tahmini ve gerçekleşen sürelerini *mvc.TaskReport olarak döndürür
+	GetTaskReport(query *mvc.ReportQuery, c *models.Context) *lgo.OperationResult
+	// GetTagReport, sürelerin etiketlere dağılımını *mvc.TagReport olarak döndürür. Proje
+	// verilmemişse tüm projeler sayılır.
+	GetTagReport(query *mvc.ReportQuery, c *models.Context) *lgo.OperationResult
+}
+
+//#endregion Report Service Interface
+
+// #region Report Service Implementation
+type reportService struct {
+	timingRepo   repositories.TimingRepository
+	projectRepo  repositories.ClientProjectRepository
+	cacheService CacheService
+}
+
+func NewReportService(timingRepo repositories.TimingRepository, projectRepo repositories.ClientProjectRepository, cacheService CacheService) ReportService {
+	return &reportService{
+		timingRepo:   timingRepo,
+		projectRepo:  projectRepo,
+		cacheService: cacheService,
+	}
+}
+
+// #region Get Task Report
+func (s *reportService) GetTaskReport(query *mvc.ReportQuery, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ReportService.GetTaskReport")()
+
+	if query.ClientProjectId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientProjectId)
+	}
+	if result := query.Validate(); !result.IsSuccess() {
+		return result
+	}
+	if result := checkPermission(s.cacheService, c, datamodels.TimingPermissions.View); !result.IsSuccess() {
+		return result
+	}
+	if result := s.projectRepo.GetById(c, query.ClientProjectId); !result.IsSuccess() {
+		return result
+	}
+
+	from, to := query.Range()
+	result := s.timingRepo.SumHoursByTask(c, query.ClientProjectId, from, to)
+	if !result.IsSuccess() {
+		return result
+	}
+
+	report := taskReport(result.ReturnObject.([]*mvc.TaskHours))
+	report.ClientProjectId = query.ClientProjectId
+	report.StartDate, report.EndDate = from, to
+	return lgo.NewSuccess(report)
+}
+
+//#endregion Get Task Report
+
+// #region Get Tag Report
+func (s *reportService) GetTagReport(query *mvc.ReportQuery, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "ReportService.GetTagReport")()
+
+	if query.ClientProjectId < 0 {
+		return mvc.NewLogicError(i18n.InvalidClientProjectId)
+	}
+	if result := query.Validate(); !result.IsSuccess() {
+		return result
+	}
+	if result := checkPermission(s.cacheService, c, datamodels.TimingPermissions.View); !result.IsSuccess() {
+		return result
+	}
+	if query.ClientProjectId > 0 {
+		if result := s.projectRepo.GetById(c, query.ClientProjectId); !result.IsSuccess() {
+			return result
+		}
+	}
+
+	from, to := query.Range()
+	result := s.timingRepo.SumHoursByTag(c, query.ClientProjectId, from, to)
+	if !result.IsSuccess() {
+		return result
+	}
+
+	report := tagReport(result.ReturnObject.([]*mvc.TagHours))
+	report.ClientProjectId = query.ClientProjectId
+	report.StartDate, report.EndDate = from, to
+	return lgo.NewSuccess(report)
+}
+
+//#endregion Get Tag Report
+
+// tagReport, etiket toplamlarını yuvarlar. Etiketsiz satır UntaggedHours'a yazılır.
+func tagReport(hours []*mvc.TagHours) *mvc.TagReport {
+	report := &mvc.TagReport{Tags: []*mvc.TagReportRow{}}
+	for _, row := range hours {
+		if row.TagId == nil {
+			report.UntaggedHours = round2(row.Hours)
+			continue
+		}
+		report.Tags = append(report.Tags, &mvc.TagReportRow{
+			TagId:   *row.TagId,
+			Tag:     row.Tag,
+			Color:   row.Color,
+			Timings: row.Timings,
+			Hours:   round2(row.Hours),
+		})
+	}
+	return report
+}
+
+// taskReport, görev toplamlarını tahminle karşılaştırır. Görevsiz satır UnassignedHours'a yazılır.
+func taskReport(hours []*mvc.TaskHours) *mvc.TaskReport {
+	report := &mvc.TaskReport{Tasks: []*mvc.TaskReportRow{}}
+	for _, row := range hours {
+		report.ActualHours += row.Hours
+		if row.TaskId == nil {
+			report.UnassignedHours += row.Hours
+			continue
+		}
+
+		report.EstimateHours += row.EstimateHours
+		taskRow := &mvc.TaskReportRow{
+			TaskId:        *row.TaskId,
+			Task:          row.Task,
+			Status:        enum.TaskStatusEnum(row.Status).String(),
+			EstimateHours: round2(row.EstimateHours),
+			ActualHours:   round2(row.Hours),
+			VarianceHours: round2(row.Hours - row.EstimateHours),
+			OverEstimate:  row.EstimateHours > 0 && row.Hours > row.EstimateHours,
+		}
+		if row.EstimateHours > 0 {
+			taskRow.ConsumedPercent = round2(row.Hours / row.EstimateHours * 100)
+		}
+		report.Tasks = append(report.Tasks, taskRow)
+	}
+
+	report.UnassignedHours = round2(report.UnassignedHours)
+	report.EstimateHours = round2(report.EstimateHours)
+	report.ActualHours = round2(report.ActualHours)
+	return report
+}
+
+//#endregion Report Service Implementation
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+)
+
+func TestReportServiceTaskReport(t *testing.T) {
+	f := newFixture(t, datamodels.TIMINGS_VIEW)
+	project := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+
+	design := f.addTask(t, project.Id, "Tasarım")
+	design.EstimateHours = 2
+	design.Status = enum.TaskStatusDone
+	mustSucceed(t, f.repos.ProjectTasks.Update(f.c, design))
+	backend := f.addTask(t, project.Id, "Backend")
+	backend.EstimateHours = 8
+	mustSucceed(t, f.repos.ProjectTasks.Update(f.c, backend))
+	f.addTask(t, project.Id, "Test")
+
+	log := func(day int, hours float64, taskId *int) {
+		start := budgetStart.AddDate(0, 0, day).Add(9 * time.Hour)
+		f.addTiming(t, &datamodels.Timing{
+			ClientProjectId: project.Id,
+			SystemUserId:    f.user.Id,
+			TaskId:          taskId,
+			Title:           "Geliştirme",
+			StartDateTime:   start,
+			EndDateTime:     start.Add(time.Duration(hours * float64(time.Hour))),
+		})
+	}
+	log(0, 3, &design.Id)
+	log(1, 2, &backend.Id)
+	log(2, 1.5, nil)
+	log(10, 4, &backend.Id)
+
+	service := NewReportService(f.repos.Timings, f.repos.ClientProjects, f.cache)
+
+	t.Run("all time", func(t *testing.T) {
+		result := service.GetTaskReport(&mvc.ReportQuery{ClientProjectId: project.Id}, f.c)
+		mustSucceed(t, result)
+		report := result.ReturnObject.(*mvc.TaskReport)
+
+		want := []mvc.TaskReportRow{
+			{TaskId: backend.Id, Task: "Backend", Status: "Todo", EstimateHours: 8, ActualHours: 6, VarianceHours: -2, ConsumedPercent: 75},
+			{TaskId: design.Id, Task: "Tasarım", Status: "Done", EstimateHours: 2, ActualHours: 3, VarianceHours: 1, ConsumedPercent: 150, OverEstimate: true},
+			{Task: "Test", Status: "Todo"},
+		}
+		if len(report.Tasks) != len(want) {
+			t.Fatalf("want %d tasks, got %+v", len(want), report.Tasks)
+		}
+		for i, row := range report.Tasks {
+			if i == 2 {
+				want[i].TaskId = row.TaskId
+			}
+			if *row != want[i] {
+				t.Errorf("task %d: want %+v, got %+v", i, want[i], *row)
+			}
+		}
+		if report.UnassignedHours != 1.5 || report.EstimateHours != 10 || report.ActualHours != 10.5 {
+			t.Errorf("want totals 1.5/10/10.5, got %v/%v/%v", report.UnassignedHours, report.EstimateHours, report.ActualHours)
+		}
+	})
+
+	t.Run("date range", func(t *testing.T) {
+		result := service.GetTaskReport(&mvc.ReportQuery{ClientProjectId: project.Id, StartDate: budgetStart.AddDate(0, 0, 1), EndDate: budgetStart.AddDate(0, 0, 5)}, f.c)
+		mustSucceed(t, result)
+		report := result.ReturnObject.(*mvc.TaskReport)
+		if report.Tasks[0].ActualHours != 2 || report.Tasks[1].ActualHours != 0 || report.UnassignedHours != 1.5 {
+			t.Errorf("want hours 2/0/1.5 in the range, got %+v, unassigned %v", report.Tasks, report.UnassignedHours)
+		}
+		if report.StartDate == nil || report.EndDate == nil {
+			t.Errorf("want the range in the report, got %v - %v", report.StartDate, report.EndDate)
+		}
+	})
+}
+
+func TestReportServiceTaskReportRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		query       func(existing *datamodels.ClientProject) *mvc.ReportQuery
+		want        expected
+	}{
+		{
+			name:        "invalid project id",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			query:       func(*datamodels.ClientProject) *mvc.ReportQuery { return &mvc.ReportQuery{} },
+			want:        invalid(i18n.InvalidClientProjectId),
+		},
+		{
+			name:        "end before start",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			query: func(existing *datamodels.ClientProject) *mvc.ReportQuery {
+				return &mvc.ReportQuery{ClientProjectId: existing.Id, StartDate: budgetEnd, EndDate: budgetStart}
+			},
+			want: invalid(i18n.EndBeforeStart),
+		},
+		{
+			name:        "missing project",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			query:       func(*datamodels.ClientProject) *mvc.ReportQuery { return &mvc.ReportQuery{ClientProjectId: 99} },
+			want:        notFound(i18n.ClientProjectNotFound),
+		},
+		{
+			name:        "needs the timing view permission",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			query: func(existing *datamodels.ClientProject) *mvc.ReportQuery {
+				return &mvc.ReportQuery{ClientProjectId: existing.Id}
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			existing := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+			service := NewReportService(f.repos.Timings, f.repos.ClientProjects, f.cache)
+			test.want.check(t, service.GetTaskReport(test.query(existing), f.c))
+		})
+	}
+}
+
+func TestReportServiceTagReport(t *testing.T) {
+	f := newFixture(t, datamodels.TIMINGS_VIEW)
+	client := f.addClient(t, "ACME")
+	project := f.addClientProject(t, client.Id, "Web sitesi")
+	other := f.addClientProject(t, client.Id, "Mobil uygulama")
+
+	meeting := f.addTag(t, "Toplantı")
+	support := f.addTag(t, "Destek")
+	f.addTag(t, "Hata")
+
+	log := func(clientProjectId int, day int, hours float64, tagIds ...int) {
+		start := budgetStart.AddDate(0, 0, day).Add(9 * time.Hour)
+		timing := f.addTiming(t, &datamodels.Timing{
+			ClientProjectId: clientProjectId,
+			SystemUserId:    f.user.Id,
+			Title:           "Geliştirme",
+			StartDateTime:   start,
+			EndDateTime:     start.Add(time.Duration(hours * float64(time.Hour))),
+		})
+		mustSucceed(t, f.repos.Timings.SetTags(f.c, timing.Id, tagIds))
+	}
+	log(project.Id, 0, 2, meeting.Id)
+	log(project.Id, 1, 1.5, meeting.Id, support.Id)
+	log(project.Id, 2, 3)
+	log(other.Id, 3, 4, support.Id)
+
+	service := NewReportService(f.repos.Timings, f.repos.ClientProjects, f.cache)
+
+	t.Run("one project", func(t *testing.T) {
+		result := service.GetTagReport(&mvc.ReportQuery{ClientProjectId: project.Id}, f.c)
+		mustSucceed(t, result)
+		report := result.ReturnObject.(*mvc.TagReport)
+
+		want := []mvc.TagReportRow{
+			{TagId: support.Id, Tag: "Destek", Color: "#1E88E5", Timings: 1, Hours: 1.5},
+			{Tag: "Hata", Color: "#1E88E5"},
+			{TagId: meeting.Id, Tag: "Toplantı", Color: "#1E88E5", Timings: 2, Hours: 3.5},
+		}
+		if len(report.Tags) != len(want) {
+			t.Fatalf("want %d tags, got %+v", len(want), report.Tags)
+		}
+		for i, row := range report.Tags {
+			if i == 1 {
+				want[i].TagId = row.TagId
+			}
+			if *row != want[i] {
+				t.Errorf("tag %d: want %+v, got %+v", i, want[i], *row)
+			}
+		}
+		if report.UntaggedHours != 3 || report.ClientProjectId != project.Id {
+			t.Errorf("want 3 untagged hours for project %d, got %v for %d", project.Id, report.UntaggedHours, report.ClientProjectId)
+		}
+	})
+
+	t.Run("all projects", func(t *testing.T) {
+		result := service.GetTagReport(&mvc.ReportQuery{}, f.c)
+		mustSucceed(t, result)
+		report := result.ReturnObject.(*mvc.TagReport)
+		if report.Tags[0].Hours != 5.5 || report.Tags[0].Timings != 2 {
+			t.Errorf("want 2 timings and 5.5 hours on Destek across projects, got %+v", report.Tags[0])
+		}
+	})
+
+	t.Run("date range", func(t *testing.T) {
+		result := service.GetTagReport(&mvc.ReportQuery{ClientProjectId: project.Id, StartDate: budgetStart.AddDate(0, 0, 1), EndDate: budgetStart.AddDate(0, 0, 2)}, f.c)
+		mustSucceed(t, result)
+		report := result.ReturnObject.(*mvc.TagReport)
+		if report.Tags[2].Hours != 1.5 || report.UntaggedHours != 0 {
+			t.Errorf("want 1.5 hours on Toplantı and none untagged in the range, got %+v, untagged %v", report.Tags[2], report.UntaggedHours)
+		}
+	})
+}
+
+func TestReportServiceTagReportRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		query       *mvc.ReportQuery
+		want        expected
+	}{
+		{
+			name:        "all projects",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			query:       &mvc.ReportQuery{},
+			want:        ok(),
+		},
+		{
+			name:        "invalid project id",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			query:       &mvc.ReportQuery{ClientProjectId: -1},
+			want:        invalid(i18n.InvalidClientProjectId),
+		},
+		{
+			name:        "end before start",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			query:       &mvc.ReportQuery{StartDate: budgetEnd, EndDate: budgetStart},
+			want:        invalid(i18n.EndBeforeStart),
+		},
+		{
+			name:        "missing project",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			query:       &mvc.ReportQuery{ClientProjectId: 99},
+			want:        notFound(i18n.ClientProjectNotFound),
+		},
+		{
+			name:        "needs the timing view permission",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			query:       &mvc.ReportQuery{},
+			want:        invalid(i18n.PermissionNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			service := NewReportService(f.repos.Timings, f.repos.ClientProjects, f.cache)
+			test.want.check(t, service.GetTagReport(test.query, f.c))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+)
+
+// #region Rule Handler
+
+// RuleHandler, T modeli üzerinde çalışan bir iş kuralıdır. Kurallar birbirini çağırmaz;
+// sıralama Chain ile kurulan zincirin sorumluluğundadır.
+type RuleHandler[T any] interface {
+	Handle(model T, c *models.Context) *lgo.OperationResult
+}
+
+// RuleFunc, tek bir fonksiyondan oluşan kuraldır
+type RuleFunc[T any] func(model T, c *models.Context) *lgo.OperationResult
+
+func (f RuleFunc[T]) Handle(model T, c *models.Context) *lgo.OperationResult {
+	return f(model, c)
+}
+
+// RuleChain, kuralları verildikleri sırayla çalıştırır ve ilk başarısız sonuçta durur.
+// Boş zincir her zaman başarılıdır.
+type RuleChain[T any] []RuleHandler[T]
+
+// Chain, verilen kurallardan bir zincir oluşturur
+func Chain[T any](handlers ...RuleHandler[T]) RuleChain[T] {
+	return RuleChain[T](handlers)
+}
+
+// Then, zincirin sonuna kurallar eklenmiş yeni bir zincir döndürür; mevcut zincir değişmez
+func (chain RuleChain[T]) Then(handlers ...RuleHandler[T]) RuleChain[T] {
+	next := make(RuleChain[T], 0, len(chain)+len(handlers))
+	next = append(next, chain...)
+	return append(next, handlers...)
+}
+
+func (chain RuleChain[T]) Handle(model T, c *models.Context) *lgo.OperationResult {
+	for _, handler := range chain {
+		if result := handler.Handle(model, c); !result.IsSuccess() {
+			return result
+		}
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// #endregion Rule Handler
+
+// #region Validation Rules
+
+// Validatable, Validate ile doğrulanabilen modellerdir
+type Validatable interface {
+	Validate() error
+}
+
+// UpdateValidatable, güncellemede ValidateForUpdate ile doğrulanan modellerdir
+type UpdateValidatable interface {
+	ValidateForUpdate() error
+}
+
+// ValidationRule, modeli Validate ile doğrular
+type ValidationRule[T Validatable] struct{}
+
+func (ValidationRule[T]) Handle(model T, c *models.Context) *lgo.OperationResult {
+	if err := model.Validate(); err != nil {
+		return mvc.NewValidationErrorFrom(err)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// UpdateValidationRule, modeli ValidateForUpdate ile doğrular
+type UpdateValidationRule[T UpdateValidatable] struct{}
+
+func (UpdateValidationRule[T]) Handle(model T, c *models.Context) *lgo.OperationResult {
+	if err := model.ValidateForUpdate(); err != nil {
+		return mvc.NewValidationErrorFrom(err)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// #endregion Validation Rules
+
+// #region Authorization Rules
+
+// PermissionRule, oturum açmış kullanıcının Key yetkisine sahip olduğunu kontrol eder.
+// Key boşsa kontrol yapılmaz.
+type PermissionRule[T any] struct {
+	CacheService CacheService
+	Key          string
+}
+
+func (h PermissionRule[T]) Handle(model T, c *models.Context) *lgo.OperationResult {
+	return checkPermission(h.CacheService, c, h.Key)
+}
+
+// Entity, yeni (henüz kaydedilmemiş) olup olmadığı bilinen modellerdir
+type Entity interface {
+	IsNew() bool
+}
+
+// AlterPermissionRule, yeni kayıtlarda Permissions.Add, mevcut kayıtlarda Permissions.Update
+// yetkisini kontrol eder
+type AlterPermissionRule[T Entity] struct {
+	CacheService CacheService
+	Permissions  data.PermissionSet
+}
+
+func (h AlterPermissionRule[T]) Handle(model T, c *models.Context) *lgo.OperationResult {
+	if model.IsNew() {
+		return checkPermission(h.CacheService, c, h.Permissions.Add)
+	}
+	return checkPermission(h.CacheService, c, h.Permissions.Update)
+}
+
+func checkPermission(cacheService CacheService, c *models.Context, permissionKey string) *lgo.OperationResult {
+	if permissionKey == "" {
+		return lgo.NewSuccess(nil)
+	}
+
+	result := cacheService.GetSystemUserSetting(c, permissionKey)
+	if !result.IsSuccess() {
+		return result
+	}
+	if result.ReturnObject.(string) != "1" {
+		return mvc.NewForbiddenError(permissionKey)
+	}
+	return lgo.NewSuccess(nil)
+}
+
+// #endregion Authorization Rules
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+
+	"github.com/LGYtech/lgo"
+)
+
+func TestRuleChain(t *testing.T) {
+	// record, çağrıldığında adını calls'a ekleyen ve verilen sonucu döndüren kuraldır
+	var calls []string
+	record := func(name string, result *lgo.OperationResult) RuleHandler[string] {
+		return RuleFunc[string](func(model string, c *models.Context) *lgo.OperationResult {
+			calls = append(calls, name)
+			return result
+		})
+	}
+
+	tests := []struct {
+		name        string
+		chain       RuleChain[string]
+		wantSuccess bool
+		wantCalls   []string
+	}{
+		{
+			name:        "empty chain succeeds",
+			chain:       Chain[string](),
+			wantSuccess: true,
+		},
+		{
+			name:        "all rules run in order",
+			chain:       Chain(record("first", lgo.NewSuccess(nil)), record("second", lgo.NewSuccess(nil))),
+			wantSuccess: true,
+			wantCalls:   []string{"first", "second"},
+		},
+		{
+			name:      "stops at the first failure",
+			chain:     Chain(record("first", lgo.NewAutoError()), record("second", lgo.NewSuccess(nil))),
+			wantCalls: []string{"first"},
+		},
+		{
+			name:      "Then appends after existing rules",
+			chain:     Chain(record("first", lgo.NewSuccess(nil))).Then(record("second", lgo.NewFailure())),
+			wantCalls: []string{"first", "second"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls = nil
+			result := test.chain.Handle("model", &models.Context{Context: context.Background()})
+			if result.IsSuccess() != test.wantSuccess {
+				t.Fatalf("want success %v, got %s", test.wantSuccess, describe(result))
+			}
+			if !slices.Equal(calls, test.wantCalls) {
+				t.Fatalf("want calls %v, got %v", test.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRuleChainThenDoesNotModifyChain(t *testing.T) {
+	base := make(RuleChain[string], 1, 4)
+	base[0] = RuleFunc[string](func(string, *models.Context) *lgo.OperationResult { return lgo.NewSuccess(nil) })
+
+	first := base.Then(RuleFunc[string](func(string, *models.Context) *lgo.OperationResult { return lgo.NewFailure() }))
+	second := base.Then(RuleFunc[string](func(string, *models.Context) *lgo.OperationResult { return lgo.NewSuccess(nil) }))
+
+	if len(base) != 1 {
+		t.Fatalf("Then modified the base chain: %d rules", len(base))
+	}
+	if first.Handle("", nil).IsSuccess() {
+		t.Fatal("a later Then overwrote the rules of an earlier chain")
+	}
+	if !second.Handle("", nil).IsSuccess() {
+		t.Fatal("second chain should succeed")
+	}
+}
+
+func TestPermissionRule(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string // Kullanıcının ayarındaki değer; boşsa ayar yoktur
+		token string // Boşsa fixture'ın oturumu kullanılır
+		want  expected
+	}{
+		{name: "granted", key: datamodels.CLIENTS_VIEW, value: "1", want: ok()},
+		{name: "denied", key: datamodels.CLIENTS_VIEW, value: "0", want: forbidden(datamodels.CLIENTS_VIEW)},
+		{name: "setting missing", key: datamodels.CLIENTS_VIEW, want: invalid(i18n.PermissionNotFound)},
+		{name: "empty key skips the check", key: "", token: "unknown", want: ok()},
+		{name: "no session", key: datamodels.CLIENTS_VIEW, value: "1", token: "unknown", want: failure()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t)
+			if test.value != "" {
+				f.repos.Settings.Set(f.c, &datamodels.SystemUserSetting{SystemUserId: f.user.Id, Key: test.key, Value: test.value})
+			}
+			c := f.c
+			if test.token != "" {
+				c = &models.Context{Context: context.Background(), Token: test.token}
+			}
+
+			rule := PermissionRule[*datamodels.Client]{CacheService: f.cache, Key: test.key}
+			test.want.check(t, rule.Handle(&datamodels.Client{}, c))
+		})
+	}
+}
+
+func TestAlterPermissionRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		model       *datamodels.Client
+		want        expected
+	}{
+		{name: "new record needs add", permissions: []string{datamodels.CLIENTS_ADD}, model: &datamodels.Client{}, want: ok()},
+		{name: "new record without add", permissions: []string{datamodels.CLIENTS_UPDATE}, model: &datamodels.Client{}, want: invalid(i18n.PermissionNotFound)},
+		{name: "existing record needs update", permissions: []string{datamodels.CLIENTS_UPDATE}, model: &datamodels.Client{Id: 1}, want: ok()},
+		{name: "existing record without update", permissions: []string{datamodels.CLIENTS_ADD}, model: &datamodels.Client{Id: 1}, want: invalid(i18n.PermissionNotFound)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			rule := AlterPermissionRule[*datamodels.Client]{CacheService: f.cache, Permissions: datamodels.ClientPermissions}
+			test.want.check(t, rule.Handle(test.model, f.c))
+		})
+	}
+}
+
+func TestValidationRules(t *testing.T) {
+	valid := &datamodels.ClientProject{ClientId: 1, Name: "Web sitesi"}
+	withoutClient := &datamodels.ClientProject{Name: "Web sitesi"}
+	withoutName := &datamodels.ClientProject{ClientId: 1}
+
+	tests := []struct {
+		name  string
+		rule  RuleHandler[*datamodels.ClientProject]
+		model *datamodels.ClientProject
+		want  expected
+	}{
+		{name: "create accepts a valid model", rule: ValidationRule[*datamodels.ClientProject]{}, model: valid, want: ok()},
+		{name: "create requires the client", rule: ValidationRule[*datamodels.ClientProject]{}, model: withoutClient, want: invalidFields()},
+		{name: "update ignores the client", rule: UpdateValidationRule[*datamodels.ClientProject]{}, model: withoutClient, want: ok()},
+		{name: "update requires the name", rule: UpdateValidationRule[*datamodels.ClientProject]{}, model: withoutName, want: invalidFields()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.want.check(t, test.rule.Handle(test.model, nil))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"lms-web-services-main/models"
+	"lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// #region Search Service Interface
+type SearchService interface {
+	Search(query *mvc.SearchQuery, c *models.Context) *lgo.OperationResult
+}
+
+//#endregion Search Service Interface
+
+// #region Search Service Implementation
+type searchService struct {
+	repo                   repositories.SearchRepository
+	clientReadRules        RuleHandler[*data.Client]
+	clientProjectReadRules RuleHandler[*data.ClientProject]
+	timingReadRules        RuleHandler[*data.Timing]
+}
+
+func NewSearchService(repo repositories.SearchRepository, cacheService CacheService) SearchService {
+	return &searchService{
+		repo:                   repo,
+		clientReadRules:        PermissionRule[*data.Client]{CacheService: cacheService, Key: data.ClientPermissions.View},
+		clientProjectReadRules: PermissionRule[*data.ClientProject]{CacheService: cacheService, Key: data.ClientProjectPermissions.View},
+		timingReadRules:        PermissionRule[*data.Timing]{CacheService: cacheService, Key: data.TimingPermissions.View},
+	}
+}
+
+//#endregion Search Service Implementation
+
+// #region Search
+// Search, kullanıcının okuma yetkisi olan türlerde arama yapar. Yetkisi olmayan türler
+// sonuçlardan sessizce çıkarılır; hiçbir türde yetki yoksa yetkilendirme hatası döner.
+func (s *searchService) Search(query *mvc.SearchQuery, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "SearchService.Search")()
+
+	if result := query.Validate(); !result.IsSuccess() {
+		return result
+	}
+
+	checks := []struct {
+		searchType string
+		authorize  func() *lgo.OperationResult
+	}{
+		{mvc.SearchTypeClient, func() *lgo.OperationResult {
+			return handleRules(c, "SearchService.clientReadRules", s.clientReadRules, &data.Client{})
+		}},
+		{mvc.SearchTypeClientProject, func() *lgo.OperationResult {
+			return handleRules(c, "SearchService.clientProjectReadRules", s.clientProjectReadRules, &data.ClientProject{})
+		}},
+		{mvc.SearchTypeTiming, func() *lgo.OperationResult {
+			return handleRules(c, "SearchService.timingReadRules", s.timingReadRules, &data.Timing{})
+		}},
+	}
+
+	var types []string
+	for _, check := range checks {
+		if !query.HasType(check.searchType) {
+			continue
+		}
+		result := check.authorize()
+		if result.IsSuccess() {
+			types = append(types, check.searchType)
+			continue
+		}
+		// Yetki eksikliği türü atlatır; Redis veya veritabanı hataları ise isteği düşürür
+		if mvc.IsFailure(result) {
+			return result
+		}
+	}
+
+	if len(types) == 0 {
+		return lgo.NewAutoError()
+	}
+
+	return s.repo.Search(c, query.Term, types, query.Limit)
+}
+
+//#endregion Search
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+)
+
+func TestSearchServiceAuthorizesEachType(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		types       []string
+		want        expected
+		wantTypes   []string
+	}{
+		{
+			name:        "all readable types",
+			permissions: []string{datamodels.CLIENTS_VIEW, datamodels.CLIENTPROJECTS_VIEW, datamodels.TIMINGS_VIEW},
+			want:        ok(),
+			wantTypes:   []string{mvc.SearchTypeClient, mvc.SearchTypeClientProject},
+		},
+		{
+			name:        "types without permission are skipped",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			want:        ok(),
+			wantTypes:   []string{mvc.SearchTypeClientProject},
+		},
+		{
+			name:        "requested type without permission",
+			permissions: []string{datamodels.CLIENTPROJECTS_VIEW},
+			types:       []string{mvc.SearchTypeClient},
+			want:        forbidden(lgo.NewAutoError().ErrorMessage),
+		},
+		{
+			name: "no permission at all",
+			want: forbidden(lgo.NewAutoError().ErrorMessage),
+		},
+		{
+			name:        "unknown type",
+			permissions: []string{datamodels.CLIENTS_VIEW},
+			types:       []string{"invoice"},
+			want:        expected{errorCode: mvc.ErrorCodeValidation, code: i18n.SearchInvalidType},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			client := f.addClient(t, "Akdeniz Lojistik")
+			f.addClientProject(t, client.Id, "Akdeniz Portal")
+
+			service := NewSearchService(f.repos.Search, f.cache)
+			result := service.Search(&mvc.SearchQuery{Term: "akdeniz", Types: test.types}, f.c)
+			test.want.check(t, result)
+			if !result.IsSuccess() {
+				return
+			}
+
+			var types []string
+			for _, hit := range result.ReturnObject.([]*mvc.SearchHit) {
+				types = append(types, hit.Type)
+			}
+			if len(types) != len(test.wantTypes) {
+				t.Fatalf("want hits of types %v, got %v", test.wantTypes, types)
+			}
+			for i := range types {
+				if types[i] != test.wantTypes[i] {
+					t.Fatalf("want hits of types %v, got %v", test.wantTypes, types)
+				}
+			}
+		})
+	}
+}
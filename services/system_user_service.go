@@ -1,7 +1,9 @@
 package services
 
 import (
-	"lms-web-services-main/database/datasources"
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/metrics"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
 	mvc "lms-web-services-main/models/mvc"
@@ -13,49 +15,64 @@ import (
 )
 
 type SystemUserService interface {
-	Create(systemUser *datamodels.SystemUser, c *models.Context) *lgo.OperationResult
-	Update(systemUser *datamodels.SystemUser, c *models.Context) *lgo.OperationResult
-	Delete(id uuid.UUID, c *models.Context) *lgo.OperationResult
-	GetById(id uuid.UUID, c *models.Context) *lgo.OperationResult
-	GetByEmail(email string) *lgo.OperationResult
-	GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult
-	CheckForeignReferences(systemUser *datamodels.SystemUser) *lgo.OperationResult
-	CheckExistingSystemUser(systemUser *datamodels.SystemUser) *lgo.OperationResult
+	CrudService[datamodels.SystemUser, uuid.UUID]
+	GetByEmail(email string, c *models.Context) *lgo.OperationResult
+	CheckForeignReferences(systemUser *datamodels.SystemUser, c *models.Context) *lgo.OperationResult
+	CheckExistingSystemUser(systemUser *datamodels.SystemUser, c *models.Context) *lgo.OperationResult
 	Login(c *models.Context, request *mvc.SystemUserLoginRequest) *lgo.OperationResult
-	Logout(token string) *lgo.OperationResult
+	Logout(c *models.Context) *lgo.OperationResult
 }
 
 type systemUserService struct {
-	repo            repositories.SystemUserRepository
-	saveRules       SystemUserRuleHandler
-	deleteRules     SystemUserRuleHandler
-	updateRules     SystemUserRuleHandler
-	readRules       SystemUserRuleHandler
-	postUpdateRules SystemUserRuleHandler
+	*crudService[datamodels.SystemUser, uuid.UUID, *datamodels.SystemUser]
+	repo           repositories.SystemUserRepository
+	settingService SystemUserSettingService
+	cacheService   CacheService
+	metrics        metrics.Recorder
 }
 
-func NewSystemUserService(repo repositories.SystemUserRepository) SystemUserService {
+func NewSystemUserService(repo repositories.SystemUserRepository, settingService SystemUserSettingService, cacheService CacheService, eventService EventService, metrics metrics.Recorder) SystemUserService {
 	service := &systemUserService{
-		repo: repo,
+		crudService: newCrudService[datamodels.SystemUser, uuid.UUID](repo, cacheService, CrudServiceOptions{
+			Name:        "SystemUserService",
+			Permissions: datamodels.SystemUserPermissions,
+			InvalidId:   i18n.InvalidUserId,
+		}),
+		repo:           repo,
+		settingService: settingService,
+		cacheService:   cacheService,
+		metrics:        metrics,
 	}
-	service.saveRules = (&SystemUserRuleHandlerValidation{}).
-		SetNext(&SystemUserRuleHandlerCheckAlterAuthorization{}).
-		SetNext(&SystemUserRuleHandlerDataIntegrity{
-			SystemUserService: service,
-		})
-
-	service.deleteRules = (&SystemUserRuleHandlerCheckDeleteAuthorization{}).
-		SetNext(&SystemUserRuleHandlerCheckForeignReferences{SystemUserService: service})
-	service.updateRules = (&SystemUserRuleHandlerValidation{}).
-		SetNext(&SystemUserRuleHandlerCheckAlterAuthorization{}).
-		SetNext(&SystemUserRuleHandlerDataIntegrity{
-			SystemUserService: service,
-		})
-
-	service.postUpdateRules = &SystemUserRuleHandlerIsActiveChange{
-		SystemUserService: service,
+
+	// Etkinleştirilen, devre dışı bırakılan veya silinen kullanıcının oturumları bu olaylarla
+	// SystemUserEventHandlerSessions tarafından sonlandırılır
+	service.events = crudEvents[datamodels.SystemUser, uuid.UUID]{
+		service: eventService,
+		created: func(systemUser *datamodels.SystemUser) events.Event {
+			return &events.SystemUserCreated{SystemUserId: systemUser.Id, Email: systemUser.Email}
+		},
+		updated: func(previous *datamodels.SystemUser, systemUser *datamodels.SystemUser) events.Event {
+			switch {
+			case previous.IsActive == systemUser.IsActive:
+				return nil
+			case systemUser.IsActive:
+				return &events.SystemUserActivated{SystemUserId: systemUser.Id}
+			default:
+				return &events.SystemUserDeactivated{SystemUserId: systemUser.Id}
+			}
+		},
+		deleted: func(id uuid.UUID) events.Event { return &events.SystemUserDeleted{SystemUserId: id} },
 	}
-	service.readRules = &SystemUserRuleHandlerCheckReadAuthorization{}
+
+	service.saveRules = service.saveRules.Then(
+		&SystemUserRuleHandlerDataIntegrity{SystemUserService: service},
+	)
+	service.updateRules = service.updateRules.Then(
+		&SystemUserRuleHandlerDataIntegrity{SystemUserService: service},
+	)
+	service.deleteRules = service.deleteRules.Then(
+		&SystemUserRuleHandlerCheckForeignReferences{SystemUserService: service},
+	)
 
 	return service
 }
@@ -63,7 +80,7 @@ func NewSystemUserService(repo repositories.SystemUserRepository) SystemUserServ
 // #region Create
 func (s *systemUserService) Create(systemUser *datamodels.SystemUser, c *models.Context) *lgo.OperationResult {
 	if systemUser.Email == "" {
-		return lgo.NewLogicError("E-posta adresi zorunludur.", nil)
+		return mvc.NewLogicError(i18n.Required, i18n.New(i18n.FieldEmail))
 	}
 
 	// Şifre tuzu oluştur ve şifreyi hash'le
@@ -72,13 +89,8 @@ func (s *systemUserService) Create(systemUser *datamodels.SystemUser, c *models.
 	hashedPassword := utils.ComputeSHA256(systemUser.Password, salt)
 	systemUser.Password = hashedPassword
 
-	// Kuralları çalıştır
-	if result := s.saveRules.Handle(systemUser, c); !result.IsSuccess() {
-		return result
-	}
-
-	// Kullanıcıyı veritabanına ekle
-	createResult := s.repo.Create(systemUser)
+	// Kuralları çalıştır ve kullanıcıyı veritabanına ekle
+	createResult := s.crudService.Create(systemUser, c)
 	if !createResult.IsSuccess() {
 		return createResult
 	}
@@ -93,6 +105,8 @@ func (s *systemUserService) Create(systemUser *datamodels.SystemUser, c *models.
 }
 
 func (s *systemUserService) assignDefaultPermissions(systemUserId uuid.UUID, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "SystemUserService.assignDefaultPermissions")()
+
 	// Varsayılan izinler
 	defaultPermissions := []datamodels.SystemUserSetting{
 		{SystemUserId: systemUserId, Key: datamodels.SYSTEM_USERS_VIEW, Value: "1", Description: "Sistem kullanıcılarını görüntüleme yetkisi"},
@@ -109,22 +123,29 @@ func (s *systemUserService) assignDefaultPermissions(systemUserId uuid.UUID, c *
 		{SystemUserId: systemUserId, Key: datamodels.CLIENTS_ADD, Value: "1", Description: "Müşterileri ekleme yetkisi"},
 		{SystemUserId: systemUserId, Key: datamodels.CLIENTS_UPDATE, Value: "1", Description: "Müşterileri güncelleme yetkisi"},
 		{SystemUserId: systemUserId, Key: datamodels.CLIENTS_DELETE, Value: "1", Description: "Müşterileri silme yetkisi"},
+		{SystemUserId: systemUserId, Key: datamodels.CLIENTS_FORCE_DELETE, Value: "0", Description: "Müşterileri bağlı kayıtlarıyla birlikte silme yetkisi"},
 
 		{SystemUserId: systemUserId, Key: datamodels.CLIENTPROJECTS_VIEW, Value: "1", Description: "Müşteri projelerini görüntüleme yetkisi"},
 		{SystemUserId: systemUserId, Key: datamodels.CLIENTPROJECTS_ADD, Value: "1", Description: "Müşteri projelerini ekleme yetkisi"},
 		{SystemUserId: systemUserId, Key: datamodels.CLIENTPROJECTS_UPDATE, Value: "1", Description: "Müşteri projelerini güncelleme yetkisi"},
 		{SystemUserId: systemUserId, Key: datamodels.CLIENTPROJECTS_DELETE, Value: "1", Description: "Müşteri projelerini silme yetkisi"},
+		{SystemUserId: systemUserId, Key: datamodels.CLIENTPROJECTS_FORCE_DELETE, Value: "0", Description: "Müşteri projelerini bağlı kayıtlarıyla birlikte silme yetkisi"},
 
 		{SystemUserId: systemUserId, Key: datamodels.TIMINGS_VIEW, Value: "1", Description: "Zamanlamaları görüntüleme yetkisi"},
 		{SystemUserId: systemUserId, Key: datamodels.TIMINGS_ADD, Value: "1", Description: "Zamanlamaları ekleme yetkisi"},
 		{SystemUserId: systemUserId, Key: datamodels.TIMINGS_UPDATE, Value: "1", Description: "Zamanlamaları güncelleme yetkisi"},
 		{SystemUserId: systemUserId, Key: datamodels.TIMINGS_DELETE, Value: "1", Description: "Zamanlamaları silme yetkisi"},
+		{SystemUserId: systemUserId, Key: datamodels.TIMINGS_ADD_FOR_OTHERS, Value: "0", Description: "Başka kullanıcılar adına zamanlama ekleme yetkisi"},
+
+		{SystemUserId: systemUserId, Key: datamodels.WEBHOOKS_VIEW, Value: "0", Description: "Webhook aboneliklerini ve gönderim günlüğünü görüntüleme yetkisi"},
+		{SystemUserId: systemUserId, Key: datamodels.WEBHOOKS_ADD, Value: "0", Description: "Webhook aboneliği ekleme yetkisi"},
+		{SystemUserId: systemUserId, Key: datamodels.WEBHOOKS_UPDATE, Value: "0", Description: "Webhook aboneliklerini güncelleme ve gönderimleri yeniden gönderme yetkisi"},
+		{SystemUserId: systemUserId, Key: datamodels.WEBHOOKS_DELETE, Value: "0", Description: "Webhook aboneliklerini silme yetkisi"},
 	}
 
 	// Her bir izin için `Set` metodunu çağır
 	for _, permission := range defaultPermissions {
-		var systemUserSettingService = NewSystemUserSettingService(repositories.NewSystemUserSettingRepository(datasources.Database))
-		result := systemUserSettingService.Set(&permission, c)
+		result := s.settingService.Set(&permission, c)
 		if !result.IsSuccess() {
 			return result // Hata varsa işlemi durdur
 		}
@@ -138,7 +159,7 @@ func (s *systemUserService) assignDefaultPermissions(systemUserId uuid.UUID, c *
 // #region Update
 func (s *systemUserService) Update(systemUser *datamodels.SystemUser, c *models.Context) *lgo.OperationResult {
 	if systemUser.Id == uuid.Nil {
-		return lgo.NewLogicError("Geçersiz kullanıcı ID.", nil)
+		return mvc.NewLogicError(i18n.InvalidUserId)
 	}
 
 	if systemUser.Password != "" {
@@ -148,111 +169,61 @@ func (s *systemUserService) Update(systemUser *datamodels.SystemUser, c *models.
 		systemUser.Password = hashedPassword
 	}
 
-	if result := s.updateRules.Handle(systemUser, c); !result.IsSuccess() {
-		return result
-	}
-
-	updateResult := s.repo.Update(systemUser)
-	if !updateResult.IsSuccess() {
-		return updateResult
-	}
-
-	return s.postUpdateRules.Handle(systemUser, c)
+	return s.crudService.Update(systemUser, c)
 }
 
 //#endregion Update
 
-// #region Delete
-func (s *systemUserService) Delete(id uuid.UUID, c *models.Context) *lgo.OperationResult {
-	if id == uuid.Nil {
-		return lgo.NewLogicError("Geçersiz kullanıcı ID.", nil)
-	}
-
-	systemUser := &datamodels.SystemUser{Id: id}
-	result := s.deleteRules.Handle(systemUser, c)
-	if !result.IsSuccess() {
-		return result
-	}
-
-	deleteResult := s.repo.Delete(id)
-	if !deleteResult.IsSuccess() {
-		return deleteResult
-	}
-
-	return CacheService.DeleteSystemUserCredentialById(id)
-}
-
-//#endregion Delete
-
-// #region GetById
-func (s *systemUserService) GetById(id uuid.UUID, c *models.Context) *lgo.OperationResult {
-	if id == uuid.Nil {
-		return lgo.NewLogicError("Geçersiz kullanıcı ID.", nil)
-	}
-
-	if result := s.readRules.Handle(&datamodels.SystemUser{Id: id}, c); !result.IsSuccess() {
-		return result
-	}
-
-	return s.repo.GetById(id)
-}
-
-//#endregion GetById
-
 // #region GetByEmail
-func (s *systemUserService) GetByEmail(email string) *lgo.OperationResult {
+func (s *systemUserService) GetByEmail(email string, c *models.Context) *lgo.OperationResult {
 	if email == "" {
-		return lgo.NewLogicError("E-posta adresi zorunludur.", nil)
+		return mvc.NewLogicError(i18n.Required, i18n.New(i18n.FieldEmail))
 	}
 
-	return s.repo.GetByEmail(email)
+	return s.repo.GetByEmail(c, email)
 }
 
 //#endregion GetByEmail
 
-// #region GetAll
-func (s *systemUserService) GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult {
-	if result := query.Validate(); !result.IsSuccess() {
-		return lgo.NewLogicError("Geçersiz sorgu parametreleri: "+result.ErrorMessage, nil)
-	}
-	return s.repo.GetAll(query)
-}
-
-//#endregion GetAll
-
 // #region Check Foreign References
-func (s *systemUserService) CheckForeignReferences(systemUser *datamodels.SystemUser) *lgo.OperationResult {
-	return s.repo.CheckForeignReferences(systemUser)
+func (s *systemUserService) CheckForeignReferences(systemUser *datamodels.SystemUser, c *models.Context) *lgo.OperationResult {
+	return s.repo.CheckForeignReferences(c, systemUser)
 }
 
 //#endregion Check Foreign References
 
 // #region Check Existing SystemUser
-func (s *systemUserService) CheckExistingSystemUser(systemUser *datamodels.SystemUser) *lgo.OperationResult {
-	return s.repo.CheckExistingSystemUser(systemUser)
+func (s *systemUserService) CheckExistingSystemUser(systemUser *datamodels.SystemUser, c *models.Context) *lgo.OperationResult {
+	return s.repo.CheckExistingSystemUser(c, systemUser)
 }
 
 //#endregion Check Existing SystemUser
 
 // #region Login
 func (s *systemUserService) Login(c *models.Context, request *mvc.SystemUserLoginRequest) *lgo.OperationResult {
+	defer startSpan(c, "SystemUserService.Login")()
+
 	if result := request.Validate(); !result.IsSuccess() {
 		return result
 	}
 
-	systemUserResult := s.GetByEmail(request.Email)
+	systemUserResult := s.GetByEmail(request.Email, c)
 	if !systemUserResult.IsSuccess() {
 		return systemUserResult
 	}
 
+	// Bilinmeyen e-posta, yanlış şifreyle aynı hatayı döndürür; böylece kayıtlı adresler
+	// giriş denemeleriyle öğrenilemez
 	systemUser, ok := systemUserResult.ReturnObject.(*datamodels.SystemUser)
 	if !ok {
-		return lgo.NewFailure()
+		s.metrics.Login(false)
+		return mvc.NewLogicError(i18n.InvalidCredentials)
 	}
 
 	hashedRequestPassword := utils.ComputeSHA256(request.Password, systemUser.PasswordSalt)
 	if hashedRequestPassword != systemUser.Password {
-		return lgo.NewLogicError("Email veya Şifre hatalı.", nil)
+		s.metrics.Login(false)
+		return mvc.NewLogicError(i18n.InvalidCredentials)
 	}
 
 	uuidV4, err := uuid.NewRandom()
@@ -261,12 +232,13 @@ func (s *systemUserService) Login(c *models.Context, request *mvc.SystemUserLogi
 	}
 	systemUserToken := uuidV4.String()
 
-	systemUserTokenResult := CacheService.RegisterSystemUserCredential(systemUserToken, systemUser)
+	systemUserTokenResult := s.cacheService.RegisterSystemUserCredential(c, systemUserToken, systemUser)
 	if !systemUserTokenResult.IsSuccess() {
 		return systemUserTokenResult
 	}
 
 	c.Token = systemUserToken
+	s.metrics.Login(true)
 
 	userData := map[string]string{
 		"suid": systemUser.Id.String(),
@@ -282,8 +254,8 @@ func (s *systemUserService) Login(c *models.Context, request *mvc.SystemUserLogi
 //#endregion Login
 
 // #region Logout
-func (s *systemUserService) Logout(token string) *lgo.OperationResult {
-	return CacheService.DeleteSystemUserCredential(token)
+func (s *systemUserService) Logout(c *models.Context) *lgo.OperationResult {
+	return s.cacheService.DeleteSystemUserCredential(c, c.Token)
 }
 
 //#endregion Logout
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/metrics"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/utils"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+var allSystemUserPermissions = []string{
+	datamodels.SYSTEM_USERS_VIEW, datamodels.SYSTEM_USERS_UPDATE, datamodels.SYSTEM_USERS_DELETE,
+	datamodels.SYSTEM_SETTINGS_ADD,
+}
+
+func (f *fixture) systemUserService() SystemUserService {
+	settingService := NewSystemUserSettingService(f.repos.Settings, f.cache, f.events)
+	return NewSystemUserService(f.repos.SystemUsers, settingService, f.cache, f.events, metrics.New())
+}
+
+func TestSystemUserServiceRules(t *testing.T) {
+	newUser := func(email string) *datamodels.SystemUser {
+		return &datamodels.SystemUser{Name: "Can", Surname: "Demir", Email: email, Password: "Parola.123", IsActive: true}
+	}
+
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s SystemUserService, f *fixture, other *datamodels.SystemUser) *lgo.OperationResult
+		want        expected
+	}{
+		// Data integrity
+		{
+			name:        "create",
+			permissions: allSystemUserPermissions,
+			run: func(s SystemUserService, f *fixture, _ *datamodels.SystemUser) *lgo.OperationResult {
+				return s.Create(newUser("can@example.com"), f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create rejects an invalid email",
+			permissions: allSystemUserPermissions,
+			run: func(s SystemUserService, f *fixture, _ *datamodels.SystemUser) *lgo.OperationResult {
+				return s.Create(newUser("can"), f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create rejects a used email",
+			permissions: allSystemUserPermissions,
+			run: func(s SystemUserService, f *fixture, other *datamodels.SystemUser) *lgo.OperationResult {
+				return s.Create(newUser(other.Email), f.c)
+			},
+			want: conflict(i18n.SystemUserEmailExists),
+		},
+		{
+			name:        "update keeps the user's own email",
+			permissions: allSystemUserPermissions,
+			run: func(s SystemUserService, f *fixture, other *datamodels.SystemUser) *lgo.OperationResult {
+				user := newUser(other.Email)
+				user.Id = other.Id
+				user.Password = ""
+				return s.Update(user, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "update rejects another user's email",
+			permissions: allSystemUserPermissions,
+			run: func(s SystemUserService, f *fixture, other *datamodels.SystemUser) *lgo.OperationResult {
+				user := newUser(f.user.Email)
+				user.Id = other.Id
+				return s.Update(user, f.c)
+			},
+			want: conflict(i18n.SystemUserEmailExists),
+		},
+		{
+			name:        "update forbidden",
+			permissions: []string{datamodels.SYSTEM_USERS_VIEW},
+			run: func(s SystemUserService, f *fixture, other *datamodels.SystemUser) *lgo.OperationResult {
+				user := newUser(other.Email)
+				user.Id = other.Id
+				return s.Update(user, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		// Foreign references
+		{
+			name:        "delete",
+			permissions: allSystemUserPermissions,
+			run: func(s SystemUserService, f *fixture, other *datamodels.SystemUser) *lgo.OperationResult {
+				return s.Delete(other.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "delete refuses a user with settings",
+			permissions: allSystemUserPermissions,
+			run: func(s SystemUserService, f *fixture, other *datamodels.SystemUser) *lgo.OperationResult {
+				f.repos.Settings.Grant(other.Id, datamodels.CLIENTS_VIEW)
+				return s.Delete(other.Id, f.c)
+			},
+			want: conflict(i18n.SystemUserHasSettings),
+		},
+		{
+			name:        "delete refuses a user with timings",
+			permissions: allSystemUserPermissions,
+			run: func(s SystemUserService, f *fixture, other *datamodels.SystemUser) *lgo.OperationResult {
+				start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+				f.addTiming(t, &datamodels.Timing{ClientProjectId: 1, SystemUserId: other.Id, Title: "Analiz", StartDateTime: start, EndDateTime: start})
+				return s.Delete(other.Id, f.c)
+			},
+			want: conflict(i18n.SystemUserHasTimings),
+		},
+		{
+			name:        "delete forbidden before references are checked",
+			permissions: []string{datamodels.SYSTEM_USERS_VIEW},
+			run: func(s SystemUserService, f *fixture, other *datamodels.SystemUser) *lgo.OperationResult {
+				f.repos.Settings.Grant(other.Id, datamodels.CLIENTS_VIEW)
+				return s.Delete(other.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "delete invalid id",
+			permissions: allSystemUserPermissions,
+			run: func(s SystemUserService, f *fixture, _ *datamodels.SystemUser) *lgo.OperationResult {
+				return s.Delete(uuid.Nil, f.c)
+			},
+			want: invalid(i18n.InvalidUserId),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			other := f.addUser(t, "other@example.com")
+			test.want.check(t, test.run(f.systemUserService(), f, other))
+		})
+	}
+}
+
+func TestSystemUserServiceCreateHashesPasswordAndGrantsDefaults(t *testing.T) {
+	f := newFixture(t, allSystemUserPermissions...)
+	user := &datamodels.SystemUser{Name: "Can", Surname: "Demir", Email: "can@example.com", Password: "Parola.123", IsActive: true}
+
+	ok().check(t, f.systemUserService().Create(user, f.c))
+
+	stored := f.repos.SystemUsers.GetById(f.c, user.Id).ReturnObject.(*datamodels.SystemUser)
+	if stored.Password != utils.ComputeSHA256("Parola.123", stored.PasswordSalt) {
+		t.Fatal("stored password is not the salted hash of the given password")
+	}
+	if count := f.repos.Settings.Count(func(setting *datamodels.SystemUserSetting) bool {
+		return setting.SystemUserId == user.Id && setting.Value == "1"
+	}); count != 20 {
+		t.Fatalf("want 20 default permissions, got %d", count)
+	}
+}
+
+func TestSystemUserServiceDeactivationEndsSessions(t *testing.T) {
+	tests := []struct {
+		name        string
+		isActive    bool
+		delete      bool
+		wantSession bool
+	}{
+		{name: "deactivated", isActive: false, wantSession: false},
+		{name: "unchanged", isActive: true, wantSession: true},
+		{name: "deleted", delete: true, wantSession: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, allSystemUserPermissions...)
+			other := f.addUser(t, "other@example.com")
+			f.repos.Cache.RegisterSystemUserCredential(f.c, "other-token", other)
+
+			if test.delete {
+				ok().check(t, f.systemUserService().Delete(other.Id, f.c))
+			} else {
+				update := *other
+				update.Password = ""
+				update.IsActive = test.isActive
+				ok().check(t, f.systemUserService().Update(&update, f.c))
+			}
+
+			// Oturumlar olay dağıtıldığında sonlandırılır
+			dispatcher := f.dispatcher(1)
+			dispatcher.Subscribe(&SystemUserEventHandlerSessions{CacheService: f.cache}, SystemUserSessionEvents...)
+			mustSucceed(t, dispatcher.DispatchDue(f.c))
+
+			if hasSession := f.cache.GetSystemUserCredential(f.c, "other-token").IsSuccess(); hasSession != test.wantSession {
+				t.Fatalf("want session %v, got %v", test.wantSession, hasSession)
+			}
+		})
+	}
+}
+
+func TestSystemUserServiceLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		want     expected
+	}{
+		{name: "valid credentials", email: "can@example.com", password: "Parola.123", want: ok()},
+		{name: "wrong password", email: "can@example.com", password: "parola.123", want: invalid(i18n.InvalidCredentials)},
+		{name: "unknown email", email: "nobody@example.com", password: "Parola.123", want: invalid(i18n.InvalidCredentials)},
+		{name: "missing password", email: "can@example.com", want: expected{errorCode: mvc.ErrorCodeValidation, code: i18n.LoginPasswordRequired}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, allSystemUserPermissions...)
+			service := f.systemUserService()
+			mustSucceed(t, service.Create(&datamodels.SystemUser{Name: "Can", Surname: "Demir", Email: "can@example.com", Password: "Parola.123", IsActive: true}, f.c))
+
+			result := service.Login(f.c, &mvc.SystemUserLoginRequest{Email: test.email, Password: test.password})
+			test.want.check(t, result)
+			if !result.IsSuccess() {
+				return
+			}
+
+			token := result.ReturnObject.(map[string]string)["t"]
+			credential := f.cache.GetSystemUserCredential(f.c, token)
+			if !credential.IsSuccess() || credential.ReturnObject.(*mvc.SystemUserCredential).Email != test.email {
+				t.Fatal("login did not register the session")
+			}
+		})
+	}
+}
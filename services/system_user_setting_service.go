@@ -1,8 +1,11 @@
 package services
 
 import (
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
 	repositories "lms-web-services-main/repositories"
 
 	"github.com/LGYtech/lgo"
@@ -23,19 +26,23 @@ type SystemUserSettingService interface {
 // #region System User Setting Service Implementation
 type systemUserSettingService struct {
 	repo        repositories.SystemUserSettingRepository
-	saveRules   SystemUserSettingRuleHandler
-	deleteRules SystemUserSettingRuleHandler
-	readRules   SystemUserSettingRuleHandler
+	events      EventService
+	saveRules   RuleChain[*datamodels.SystemUserSetting]
+	deleteRules RuleChain[*datamodels.SystemUserSetting]
+	readRules   RuleChain[*datamodels.SystemUserSetting]
 }
 
-func NewSystemUserSettingService(repo repositories.SystemUserSettingRepository) SystemUserSettingService {
+func NewSystemUserSettingService(repo repositories.SystemUserSettingRepository, cacheService CacheService, eventService EventService) SystemUserSettingService {
+	permissions := datamodels.SystemUserSettingPermissions
 	return &systemUserSettingService{
-		repo: repo,
-		saveRules: (&SystemUserSettingRuleHandlerValidation{}).
-			SetNext(&SystemUserSettingRuleHandlerCheckAlterAuthorization{}).
-			SetNext(&SystemUserSettingRuleHandlerDataIntegrity{}),
-		deleteRules: &SystemUserSettingRuleHandlerCheckDeleteAuthorization{},
-		readRules:   &SystemUserSettingRuleHandlerCheckReadAuthorization{},
+		repo:   repo,
+		events: eventService,
+		saveRules: Chain[*datamodels.SystemUserSetting](
+			ValidationRule[*datamodels.SystemUserSetting]{},
+			AlterPermissionRule[*datamodels.SystemUserSetting]{CacheService: cacheService, Permissions: permissions},
+		),
+		deleteRules: Chain[*datamodels.SystemUserSetting](PermissionRule[*datamodels.SystemUserSetting]{CacheService: cacheService, Key: permissions.Delete}),
+		readRules:   Chain[*datamodels.SystemUserSetting](PermissionRule[*datamodels.SystemUserSetting]{CacheService: cacheService, Key: permissions.View}),
 	}
 }
 
@@ -43,32 +50,36 @@ func NewSystemUserSettingService(repo repositories.SystemUserSettingRepository)
 
 // #region GetByUserId
 func (s *systemUserSettingService) GetByUserId(systemUserId uuid.UUID, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "SystemUserSettingService.GetByUserId")()
+
 	if systemUserId == uuid.Nil {
-		return lgo.NewLogicError("Geçersiz kullanıcı ID.", nil)
+		return mvc.NewLogicError(i18n.InvalidUserId)
 	}
 
-	if result := s.readRules.Handle(&datamodels.SystemUserSetting{SystemUserId: systemUserId}, c); !result.IsSuccess() {
+	if result := handleRules(c, "SystemUserSettingService.readRules", s.readRules, &datamodels.SystemUserSetting{SystemUserId: systemUserId}); !result.IsSuccess() {
 		return result
 	}
 
-	return s.repo.GetByUserId(systemUserId)
+	return s.repo.GetByUserId(c, systemUserId)
 }
 
 //#endregion GetByUserId
 
 // #region GetById
 func (s *systemUserSettingService) GetById(id int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "SystemUserSettingService.GetById")()
+
 	if id <= 0 {
-		return lgo.NewLogicError("Geçersiz ID.", nil)
+		return mvc.NewLogicError(i18n.InvalidId)
 	}
 
-	settingResult := s.repo.GetById(id)
+	settingResult := s.repo.GetById(c, id)
 	if !settingResult.IsSuccess() {
 		return settingResult
 	}
 
 	setting := settingResult.ReturnObject.(*datamodels.SystemUserSetting)
-	if result := s.readRules.Handle(setting, c); !result.IsSuccess() {
+	if result := handleRules(c, "SystemUserSettingService.readRules", s.readRules, setting); !result.IsSuccess() {
 		return result
 	}
 
@@ -79,56 +90,70 @@ func (s *systemUserSettingService) GetById(id int, c *models.Context) *lgo.Opera
 
 // #region Set
 func (s *systemUserSettingService) Set(setting *datamodels.SystemUserSetting, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "SystemUserSettingService.Set")()
+
 	if setting.SystemUserId == uuid.Nil {
-		return lgo.NewLogicError("Geçersiz kullanıcı ID.", nil)
+		return mvc.NewLogicError(i18n.InvalidUserId)
 	}
 	if setting.Key == "" {
-		return lgo.NewLogicError("Anahtar (key) alanı zorunludur.", nil)
+		return mvc.NewLogicError(i18n.Required, i18n.New(i18n.FieldKey))
 	}
 	if setting.Value == "" {
-		return lgo.NewLogicError("Değer (value) alanı zorunludur.", nil)
+		return mvc.NewLogicError(i18n.Required, i18n.New(i18n.FieldValue))
 	}
 
-	if result := s.saveRules.Handle(setting, c); !result.IsSuccess() {
+	if result := handleRules(c, "SystemUserSettingService.saveRules", s.saveRules, setting); !result.IsSuccess() {
 		return result
 	}
 
-	return s.repo.Set(setting)
+	return s.events.Record(c, func(tx *models.Context) *lgo.OperationResult {
+		return s.repo.Set(tx, setting)
+	}, func(*lgo.OperationResult) events.Event {
+		return &events.SystemUserSettingSet{SystemUserId: setting.SystemUserId, Key: setting.Key, Value: setting.Value}
+	})
 }
 
 //#endregion Set
 
 // #region Delete
 func (s *systemUserSettingService) Delete(id int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "SystemUserSettingService.Delete")()
+
 	if id <= 0 {
-		return lgo.NewLogicError("Geçersiz ID.", nil)
+		return mvc.NewLogicError(i18n.InvalidId)
 	}
 
-	settingResult := s.repo.GetById(id)
+	settingResult := s.repo.GetById(c, id)
 	if !settingResult.IsSuccess() {
 		return settingResult
 	}
 
 	setting := settingResult.ReturnObject.(*datamodels.SystemUserSetting)
-	if result := s.deleteRules.Handle(setting, c); !result.IsSuccess() {
+	if result := handleRules(c, "SystemUserSettingService.deleteRules", s.deleteRules, setting); !result.IsSuccess() {
 		return result
 	}
 
-	return s.repo.Delete(id)
+	return s.events.Record(c, func(tx *models.Context) *lgo.OperationResult {
+		return s.repo.Delete(tx, id)
+	}, func(*lgo.OperationResult) events.Event {
+		return &events.SystemUserSettingDeleted{SystemUserId: setting.SystemUserId, Key: setting.Key}
+	})
 }
 
 //#endregion Delete
 
 // #region GetValue
 func (s *systemUserSettingService) GetValue(systemUserId uuid.UUID, key string, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "SystemUserSettingService.GetValue")()
+
 	if systemUserId == uuid.Nil {
-		return lgo.NewLogicError("Geçersiz kullanıcı ID.", nil)
+		return mvc.NewLogicError(i18n.InvalidUserId)
 	}
 	if key == "" {
-		return lgo.NewLogicError("Anahtar (key) alanı zorunludur.", nil)
+		return mvc.NewLogicError(i18n.Required, i18n.New(i18n.FieldKey))
 	}
 
-	if result := s.readRules.Handle(&datamodels.SystemUserSetting{SystemUserId: systemUserId, Key: key}, c); !result.IsSuccess() {
+	if result := handleRules(c, "SystemUserSettingService.readRules", s.readRules, &datamodels.SystemUserSetting{SystemUserId: systemUserId, Key: key}); !result.IsSuccess() {
 		return result
 	}
 
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+func TestSystemUserSettingServiceRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s SystemUserSettingService, f *fixture) *lgo.OperationResult
+		want        expected
+	}{
+		{
+			name:        "set",
+			permissions: []string{datamodels.SYSTEM_SETTINGS_ADD},
+			run: func(s SystemUserSettingService, f *fixture) *lgo.OperationResult {
+				return s.Set(&datamodels.SystemUserSetting{SystemUserId: f.user.Id, Key: datamodels.SYSTEM_LANGUAGE, Value: "en"}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "set requires the user",
+			permissions: []string{datamodels.SYSTEM_SETTINGS_ADD},
+			run: func(s SystemUserSettingService, f *fixture) *lgo.OperationResult {
+				return s.Set(&datamodels.SystemUserSetting{SystemUserId: uuid.Nil, Key: datamodels.SYSTEM_LANGUAGE, Value: "en"}, f.c)
+			},
+			want: invalid(i18n.InvalidUserId),
+		},
+		{
+			name:        "set validates lengths",
+			permissions: []string{datamodels.SYSTEM_SETTINGS_ADD},
+			run: func(s SystemUserSettingService, f *fixture) *lgo.OperationResult {
+				return s.Set(&datamodels.SystemUserSetting{SystemUserId: f.user.Id, Key: datamodels.SYSTEM_LANGUAGE, Value: strings.Repeat("x", 201)}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "set forbidden",
+			permissions: []string{datamodels.SYSTEM_SETTINGS_VIEW},
+			run: func(s SystemUserSettingService, f *fixture) *lgo.OperationResult {
+				return s.Set(&datamodels.SystemUserSetting{SystemUserId: f.user.Id, Key: datamodels.SYSTEM_LANGUAGE, Value: "en"}, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "get by user id",
+			permissions: []string{datamodels.SYSTEM_SETTINGS_VIEW},
+			run: func(s SystemUserSettingService, f *fixture) *lgo.OperationResult {
+				return s.GetByUserId(f.user.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "get by user id forbidden",
+			permissions: []string{datamodels.SYSTEM_SETTINGS_ADD},
+			run: func(s SystemUserSettingService, f *fixture) *lgo.OperationResult {
+				return s.GetByUserId(f.user.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "delete missing setting",
+			permissions: []string{datamodels.SYSTEM_SETTINGS_DELETE},
+			run: func(s SystemUserSettingService, f *fixture) *lgo.OperationResult {
+				return s.Delete(999, f.c)
+			},
+			want: notFound(i18n.SettingNotFound),
+		},
+		{
+			name:        "delete invalid id",
+			permissions: []string{datamodels.SYSTEM_SETTINGS_DELETE},
+			run: func(s SystemUserSettingService, f *fixture) *lgo.OperationResult {
+				return s.Delete(0, f.c)
+			},
+			want: invalid(i18n.InvalidId),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			test.want.check(t, test.run(NewSystemUserSettingService(f.repos.Settings, f.cache, f.events), f))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// Tag'e özgü kurallar. Doğrulama ve yetki kuralları crudService tarafından kurulur.
+
+// #region Unique Tag Name
+// TagRuleHandlerUnique, aynı adla (büyük/küçük harf duyarsız) ikinci bir etiket açılmasını
+// engeller. Oluşturmada ve güncellemede çalışır.
+type TagRuleHandlerUnique struct {
+	TagRepository repositories.TagRepository
+}
+
+func (h *TagRuleHandlerUnique) Handle(model *datamodels.Tag, c *models.Context) *lgo.OperationResult {
+	result := h.TagRepository.GetByName(c, model.Name)
+	if result.IsSuccess() && result.ReturnObject.(*datamodels.Tag).Id != model.Id {
+		return mvc.NewConflictError(i18n.TagExists)
+	}
+	if !result.IsSuccess() && result.ErrorCode != mvc.ErrorCodeNotFound {
+		return result
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Unique Tag Name
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	repositories "lms-web-services-main/repositories"
+)
+
+// #region Tag Service Interface
+type TagService interface {
+	CrudService[datamodels.Tag, int]
+}
+
+//#endregion Tag Service Interface
+
+// #region Tag Service Implementation
+type tagService struct {
+	*crudService[datamodels.Tag, int, *datamodels.Tag]
+}
+
+func NewTagService(repo repositories.TagRepository, cacheService CacheService, eventService EventService) TagService {
+	service := &tagService{
+		crudService: newCrudService[datamodels.Tag, int](repo, cacheService, CrudServiceOptions{
+			Name:        "TagService",
+			Permissions: datamodels.TagPermissions,
+			InvalidId:   i18n.InvalidId,
+		}),
+	}
+
+	service.events = crudEvents[datamodels.Tag, int]{
+		service: eventService,
+		created: func(tag *datamodels.Tag) events.Event { return &events.TagCreated{Tag: *tag} },
+		updated: func(_ *datamodels.Tag, tag *datamodels.Tag) events.Event { return &events.TagUpdated{Tag: *tag} },
+		deleted: func(id int) events.Event { return &events.TagDeleted{TagId: id} },
+	}
+
+	unique := &TagRuleHandlerUnique{TagRepository: repo}
+	service.saveRules = service.saveRules.Then(unique)
+	service.updateRules = service.updateRules.Then(unique)
+
+	return service
+}
+
+//#endregion Tag Service Implementation
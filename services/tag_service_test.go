@@ -0,0 +1,121 @@
+package services
+
+import (
+	"testing"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+
+	"github.com/LGYtech/lgo"
+)
+
+var allTagPermissions = []string{datamodels.TIMINGS_VIEW, datamodels.SYSTEM_SETTINGS_UPDATE}
+
+func TestTagServiceRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s TagService, f *fixture, existing *datamodels.Tag) *lgo.OperationResult
+		want        expected
+	}{
+		{
+			name:        "create",
+			permissions: allTagPermissions,
+			run: func(s TagService, f *fixture, _ *datamodels.Tag) *lgo.OperationResult {
+				return s.Create(&datamodels.Tag{Name: "Destek", Color: "#43A047"}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create validates the color",
+			permissions: allTagPermissions,
+			run: func(s TagService, f *fixture, _ *datamodels.Tag) *lgo.OperationResult {
+				return s.Create(&datamodels.Tag{Name: "Destek", Color: "yeşil"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create requires a name",
+			permissions: allTagPermissions,
+			run: func(s TagService, f *fixture, _ *datamodels.Tag) *lgo.OperationResult {
+				return s.Create(&datamodels.Tag{Color: "#43A047"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create with a taken name",
+			permissions: allTagPermissions,
+			run: func(s TagService, f *fixture, _ *datamodels.Tag) *lgo.OperationResult {
+				return s.Create(&datamodels.Tag{Name: "toplantı", Color: "#43A047"}, f.c)
+			},
+			want: conflict(i18n.TagExists),
+		},
+		{
+			name:        "create needs the settings update permission",
+			permissions: []string{datamodels.TIMINGS_VIEW, datamodels.TIMINGS_ADD},
+			run: func(s TagService, f *fixture, _ *datamodels.Tag) *lgo.OperationResult {
+				return s.Create(&datamodels.Tag{Name: "Destek", Color: "#43A047"}, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "update keeping the name",
+			permissions: allTagPermissions,
+			run: func(s TagService, f *fixture, existing *datamodels.Tag) *lgo.OperationResult {
+				existing.Color = "#E53935"
+				return s.Update(existing, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "update to a taken name",
+			permissions: allTagPermissions,
+			run: func(s TagService, f *fixture, existing *datamodels.Tag) *lgo.OperationResult {
+				f.addTag(t, "Destek")
+				existing.Name = "destek"
+				return s.Update(existing, f.c)
+			},
+			want: conflict(i18n.TagExists),
+		},
+		{
+			name:        "update missing tag",
+			permissions: allTagPermissions,
+			run: func(s TagService, f *fixture, _ *datamodels.Tag) *lgo.OperationResult {
+				return s.Update(&datamodels.Tag{Id: 99, Name: "Destek", Color: "#43A047"}, f.c)
+			},
+			want: notFound(i18n.TagNotFound),
+		},
+		{
+			name:        "delete",
+			permissions: allTagPermissions,
+			run: func(s TagService, f *fixture, existing *datamodels.Tag) *lgo.OperationResult {
+				return s.Delete(existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "get by id with the timing view permission",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			run: func(s TagService, f *fixture, existing *datamodels.Tag) *lgo.OperationResult {
+				return s.GetById(existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "get by id forbidden",
+			permissions: []string{datamodels.SYSTEM_SETTINGS_UPDATE},
+			run: func(s TagService, f *fixture, existing *datamodels.Tag) *lgo.OperationResult {
+				return s.GetById(existing.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			existing := f.addTag(t, "Toplantı")
+			test.want.check(t, test.run(NewTagService(f.repos.Tags, f.cache, f.events), f, existing))
+		})
+	}
+}
@@ -1,145 +1,213 @@
 package services
 
 import (
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
-	"lms-web-services-main/models/data"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
 
 	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
 )
 
-type TimingRuleHandler interface {
-	Handle(model *data.Timing, c *models.Context) *lgo.OperationResult
-	SetNext(handler TimingRuleHandler) TimingRuleHandler
+// Timing'e özgü kurallar. Doğrulama ve yetki kuralları crudService tarafından kurulur.
+
+// #region Project Membership
+// TimingRuleHandlerProjectMembership, pasif projelere ya da pasif müşterilerin projelerine
+// kayıt girilmesini engeller. Kaydı giren kullanıcı projenin üyesi olmalıdır; başka bir
+// kullanıcı adına kayıt girmek TIMINGS_ADD_FOR_OTHERS yetkisi ve o kullanıcının da projenin
+// üyesi olmasını gerektirir. Yalnızca oluşturmada çalışır.
+type TimingRuleHandlerProjectMembership struct {
+	ClientProjectRepository       repositories.ClientProjectRepository
+	ClientRepository              repositories.ClientRepository
+	ClientProjectMemberRepository repositories.ClientProjectMemberRepository
+	CacheService                  CacheService
 }
 
-type BaseTimingRuleHandler struct {
-	next TimingRuleHandler
-}
-
-func (h *BaseTimingRuleHandler) SetNext(next TimingRuleHandler) TimingRuleHandler {
-	h.next = next
-	return h
-}
-
-func (h *BaseTimingRuleHandler) Handle(model *data.Timing, c *models.Context) *lgo.OperationResult {
-	if h.next != nil {
-		return h.next.Handle(model, c)
+func (h *TimingRuleHandlerProjectMembership) Handle(model *datamodels.Timing, c *models.Context) *lgo.OperationResult {
+	if c.Principal == nil {
+		return lgo.NewAuthError()
+	}
+	principalId, err := uuid.Parse(c.Principal.Id)
+	if err != nil {
+		return lgo.NewFailureWithError(err)
+	}
+	if model.SystemUserId != principalId {
+		if result := checkPermission(h.CacheService, c, datamodels.TIMINGS_ADD_FOR_OTHERS); !result.IsSuccess() {
+			return result
+		}
 	}
-	return lgo.NewSuccess(nil)
-}
-
-//#region Validation Handler
 
-type TimingRuleHandlerValidation struct {
-	BaseTimingRuleHandler
-}
+	result := h.ClientProjectRepository.GetById(c, model.ClientProjectId)
+	if !result.IsSuccess() {
+		return result
+	}
+	project := result.ReturnObject.(*datamodels.ClientProject)
+	if !project.IsActive {
+		return mvc.NewConflictError(i18n.ClientProjectInactive)
+	}
 
-func (h *TimingRuleHandlerValidation) Handle(model *data.Timing, c *models.Context) *lgo.OperationResult {
-	err := model.Validate()
-	if err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
+	result = h.ClientRepository.GetById(c, project.ClientId)
+	if !result.IsSuccess() {
+		return result
+	}
+	if !result.ReturnObject.(*datamodels.Client).IsActive {
+		return mvc.NewConflictError(i18n.ClientInactive)
 	}
 
-	if h.next != nil {
-		return h.next.Handle(model, c)
+	if result := checkMember(h.ClientProjectMemberRepository, c, model.ClientProjectId, principalId, "cpid"); !result.IsSuccess() {
+		return result
+	}
+	if model.SystemUserId != principalId {
+		return checkMember(h.ClientProjectMemberRepository, c, model.ClientProjectId, model.SystemUserId, "suid")
 	}
 	return lgo.NewSuccess(nil)
 }
 
-//#endregion Validation Handler
-
-//#region Update Validation Handler
+// checkMember, kullanıcı projenin üyesi değilse field alanında doğrulama hatası döndürür
+func checkMember(memberRepo repositories.ClientProjectMemberRepository, c *models.Context, clientProjectId int, systemUserId uuid.UUID, field string) *lgo.OperationResult {
+	result := memberRepo.GetByMember(c, clientProjectId, systemUserId)
+	if result.IsSuccess() {
+		return lgo.NewSuccess(nil)
+	}
+	if result.ErrorCode != mvc.ErrorCodeNotFound {
+		return result
+	}
+	return mvc.NewValidationErrorFrom(i18n.New(i18n.InvalidFields).WithField(field, i18n.New(i18n.NotProjectMember)))
+}
 
-type TimingRuleHandlerUpdateValidation struct {
-	BaseTimingRuleHandler
+//#endregion Project Membership
+
+// #region Timing Owner
+// TimingRuleHandlerOwner, mevcut kayıtların güncellenmesini, silinmesini ve etiketlenmesini
+// kaydın projesinin üyeleriyle sınırlar. Başka bir kullanıcının kaydını değiştirmek
+// TIMINGS_ADD_FOR_OTHERS yetkisi gerektirir. Proje ve kullanıcı mevcut kayıttan alınır; kayıt
+// yoksa karar sonraki adımlara bırakılır.
+type TimingRuleHandlerOwner struct {
+	TimingRepository              repositories.TimingRepository
+	ClientProjectMemberRepository repositories.ClientProjectMemberRepository
+	CacheService                  CacheService
 }
 
-func (h *TimingRuleHandlerUpdateValidation) Handle(model *data.Timing, c *models.Context) *lgo.OperationResult {
-	err := model.ValidateForUpdate()
+func (h *TimingRuleHandlerOwner) Handle(model *datamodels.Timing, c *models.Context) *lgo.OperationResult {
+	if c.Principal == nil {
+		return lgo.NewAuthError()
+	}
+	principalId, err := uuid.Parse(c.Principal.Id)
 	if err != nil {
-		return lgo.NewLogicError(err.Error(), nil)
+		return lgo.NewFailureWithError(err)
 	}
 
-	if h.next != nil {
-		return h.next.Handle(model, c)
+	result := h.TimingRepository.GetById(c, model.Id)
+	if !result.IsSuccess() {
+		if result.ErrorCode == mvc.ErrorCodeNotFound {
+			return lgo.NewSuccess(nil)
+		}
+		return result
 	}
-	return lgo.NewSuccess(nil)
-}
+	existing := result.ReturnObject.(*datamodels.Timing)
 
-//#endregion Update Validation Handler
+	if existing.SystemUserId != principalId {
+		if result := checkPermission(h.CacheService, c, datamodels.TIMINGS_ADD_FOR_OTHERS); !result.IsSuccess() {
+			return result
+		}
+	}
+	return checkMember(h.ClientProjectMemberRepository, c, existing.ClientProjectId, principalId, "cpid")
+}
 
-//#region Alter Authorization Handler
+//#endregion Timing Owner
 
-type TimingRuleHandlerCheckAlterAuthorization struct {
-	BaseTimingRuleHandler
+// #region Project Task
+// TimingRuleHandlerTask, kayda bağlanan görevin kaydın projesine ait olmasını zorunlu tutar.
+// Güncellemede proje değişmediğinden projeyi mevcut kayıttan alır.
+type TimingRuleHandlerTask struct {
+	ProjectTaskRepository repositories.ProjectTaskRepository
+	TimingRepository      repositories.TimingRepository
 }
 
-func (h *TimingRuleHandlerCheckAlterAuthorization) Handle(model *data.Timing, c *models.Context) *lgo.OperationResult {
-	var permissionKey string
-	if model.Id == 0 {
-		permissionKey = data.TIMINGS_ADD
-	} else {
-		permissionKey = data.TIMINGS_UPDATE
+func (h *TimingRuleHandlerTask) Handle(model *datamodels.Timing, c *models.Context) *lgo.OperationResult {
+	if model.TaskId == nil {
+		return lgo.NewSuccess(nil)
 	}
 
-	result := CacheService.GetSystemUserSetting(c, permissionKey)
-	if !result.IsSuccess() {
-		return result
-	}
-	if result.ReturnObject.(string) != "1" {
-		return lgo.NewLogicError("Bu işlem için yetkiniz yok.", nil)
+	clientProjectId := model.ClientProjectId
+	if model.Id > 0 {
+		result := h.TimingRepository.GetById(c, model.Id)
+		if !result.IsSuccess() {
+			return result
+		}
+		clientProjectId = result.ReturnObject.(*datamodels.Timing).ClientProjectId
 	}
 
-	if h.next != nil {
-		return h.next.Handle(model, c)
+	result := h.ProjectTaskRepository.GetById(c, *model.TaskId)
+	if !result.IsSuccess() && result.ErrorCode != mvc.ErrorCodeNotFound {
+		return result
 	}
-	return lgo.NewSuccess(nil)
+	if result.IsSuccess() && result.ReturnObject.(*datamodels.ProjectTask).ClientProjectId == clientProjectId {
+		return lgo.NewSuccess(nil)
+	}
+	return mvc.NewValidationErrorFrom(i18n.New(i18n.InvalidFields).WithField("tid", i18n.New(i18n.TaskNotInProject)))
 }
 
-//#endregion Alter Authorization Handler
+//#endregion Project Task
 
-//#region Read Authorization Handler
-
-type TimingRuleHandlerCheckReadAuthorization struct {
-	BaseTimingRuleHandler
+// #region Project Archived
+// TimingRuleHandlerProjectArchived, arşivlenmiş projelerin kayıtlarının güncellenmesini,
+// silinmesini ve etiketlenmesini engeller. Proje mevcut kayıttan alınır; kayıt yoksa
+// karar sonraki adımlara bırakılır.
+type TimingRuleHandlerProjectArchived struct {
+	TimingRepository        repositories.TimingRepository
+	ClientProjectRepository repositories.ClientProjectRepository
 }
 
-func (h *TimingRuleHandlerCheckReadAuthorization) Handle(model *data.Timing, c *models.Context) *lgo.OperationResult {
-	result := CacheService.GetSystemUserSetting(c, data.TIMINGS_VIEW)
+func (h *TimingRuleHandlerProjectArchived) Handle(model *datamodels.Timing, c *models.Context) *lgo.OperationResult {
+	result := h.TimingRepository.GetById(c, model.Id)
 	if !result.IsSuccess() {
+		if result.ErrorCode == mvc.ErrorCodeNotFound {
+			return lgo.NewSuccess(nil)
+		}
 		return result
 	}
-	if result.ReturnObject.(string) != "1" {
-		return lgo.NewLogicError("Bu kaydı görüntülemek için yetkiniz yok.", nil)
-	}
 
-	if h.next != nil {
-		return h.next.Handle(model, c)
+	result = h.ClientProjectRepository.GetById(c, result.ReturnObject.(*datamodels.Timing).ClientProjectId)
+	if !result.IsSuccess() {
+		return result
+	}
+	if !result.ReturnObject.(*datamodels.ClientProject).IsActive {
+		return mvc.NewConflictError(i18n.TimingProjectArchived)
 	}
 	return lgo.NewSuccess(nil)
 }
 
-//#endregion Read Authorization Handler
-
-//#region Delete Authorization Handler
+//#endregion Project Archived
 
-type TimingRuleHandlerCheckDeleteAuthorization struct {
-	BaseTimingRuleHandler
+// #region Project Budget
+// TimingRuleHandlerProjectBudget, bütçesi zorunlu tutulan projelerde bütçeyi aşan kayıtları
+// reddeder. Güncellemede proje ve kullanıcı mevcut kayıttan alınır ve kaydın önceki süresi
+// harcanan bütçeden düşülür; kayıt yoksa karar sonraki adımlara bırakılır.
+type TimingRuleHandlerProjectBudget struct {
+	BudgetService    BudgetService
+	TimingRepository repositories.TimingRepository
 }
 
-func (h *TimingRuleHandlerCheckDeleteAuthorization) Handle(model *data.Timing, c *models.Context) *lgo.OperationResult {
-	result := CacheService.GetSystemUserSetting(c, data.TIMINGS_DELETE)
-	if !result.IsSuccess() {
-		return result
-	}
-	if result.ReturnObject.(string) != "1" {
-		return lgo.NewLogicError("Bu kaydı silmek için yetkiniz yok.", nil)
+func (h *TimingRuleHandlerProjectBudget) Handle(model *datamodels.Timing, c *models.Context) *lgo.OperationResult {
+	if model.Id <= 0 {
+		return h.BudgetService.Allow(model, nil, c)
 	}
 
-	if h.next != nil {
-		return h.next.Handle(model, c)
+	result := h.TimingRepository.GetById(c, model.Id)
+	if !result.IsSuccess() {
+		if result.ErrorCode == mvc.ErrorCodeNotFound {
+			return lgo.NewSuccess(nil)
+		}
+		return result
 	}
-	return lgo.NewSuccess(nil)
+	previous := result.ReturnObject.(*datamodels.Timing)
+	updated := *previous
+	updated.StartDateTime = model.StartDateTime
+	updated.EndDateTime = model.EndDateTime
+	return h.BudgetService.Allow(&updated, previous, c)
 }
 
-//#endregion Delete Authorization Handler
+//#endregion Project Budget
@@ -1,149 +1,217 @@
 package services
 
 import (
+	"time"
+
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
 	"lms-web-services-main/models"
 	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
 	"lms-web-services-main/models/mvc"
 	repositories "lms-web-services-main/repositories"
 
-	"time"
-
 	"github.com/LGYtech/lgo"
 )
 
 type TimingService interface {
-	Create(timing *datamodels.Timing, c *models.Context) *lgo.OperationResult
-	Update(timing *datamodels.Timing, c *models.Context) *lgo.OperationResult
-	Delete(id int, c *models.Context) *lgo.OperationResult
-	GetById(id int, c *models.Context) *lgo.OperationResult
-	GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult
+	CrudService[datamodels.Timing, int]
 	GetByClientProjectId(clientProjectId int, c *models.Context) *lgo.OperationResult
 	GetByDateRange(startDate time.Time, endDate time.Time, c *models.Context) *lgo.OperationResult
+	// GetTags, kayda bağlı etiketleri döndürür
+	GetTags(timingId int, c *models.Context) *lgo.OperationResult
+	// SetTags, kaydın etiketlerini verilenlerle değiştirir ve yeni etiketleri döndürür
+	SetTags(timingId int, tags *mvc.TimingTags, c *models.Context) *lgo.OperationResult
 }
 
 type timingService struct {
-	repo        repositories.TimingRepository
-	saveRules   TimingRuleHandler
-	updateRules TimingRuleHandler
-	deleteRules TimingRuleHandler
-	readRules   TimingRuleHandler
+	*crudService[datamodels.Timing, int, *datamodels.Timing]
+	repo          repositories.TimingRepository
+	tagRepo       repositories.TagRepository
+	budgetService BudgetService
+	transactor    repositories.Transactor
+	tagRules      RuleHandler[*datamodels.Timing] // Etiketleri değiştirmek kaydı güncelleme yetkisi gerektirir
 }
 
-func NewTimingService(repo repositories.TimingRepository) TimingService {
-	return &timingService{
-		repo: repo,
-		saveRules: (&TimingRuleHandlerValidation{}).
-			SetNext(&TimingRuleHandlerCheckAlterAuthorization{}),
-		updateRules: (&TimingRuleHandlerUpdateValidation{}).
-			SetNext(&TimingRuleHandlerCheckAlterAuthorization{}),
-		deleteRules: (&TimingRuleHandlerCheckDeleteAuthorization{}),
-		readRules:   &TimingRuleHandlerCheckReadAuthorization{},
+func NewTimingService(repo repositories.TimingRepository, clientProjectRepo repositories.ClientProjectRepository, clientRepo repositories.ClientRepository, memberRepo repositories.ClientProjectMemberRepository, taskRepo repositories.ProjectTaskRepository, tagRepo repositories.TagRepository, budgetService BudgetService, transactor repositories.Transactor, cacheService CacheService, eventService EventService) TimingService {
+	service := &timingService{
+		crudService: newCrudService[datamodels.Timing, int](repo, cacheService, CrudServiceOptions{
+			Name:        "TimingService",
+			Permissions: datamodels.TimingPermissions,
+			InvalidId:   i18n.InvalidId,
+		}),
+		repo:          repo,
+		tagRepo:       tagRepo,
+		budgetService: budgetService,
+		transactor:    transactor,
+		tagRules:      PermissionRule[*datamodels.Timing]{CacheService: cacheService, Key: datamodels.TimingPermissions.Update},
 	}
+
+	service.events = crudEvents[datamodels.Timing, int]{
+		service: eventService,
+		created: func(timing *datamodels.Timing) events.Event { return &events.TimingCreated{Timing: *timing} },
+		updated: func(previous *datamodels.Timing, timing *datamodels.Timing) events.Event {
+			if timing.Status == enum.StatusCompleted && previous.Status != enum.StatusCompleted {
+				return &events.TimingCompleted{Timing: *timing}
+			}
+			return &events.TimingUpdated{Timing: *timing}
+		},
+		deleted: func(id int) events.Event { return &events.TimingDeleted{TimingId: id} },
+	}
+
+	taskRule := &TimingRuleHandlerTask{ProjectTaskRepository: taskRepo, TimingRepository: repo}
+	budgetRule := &TimingRuleHandlerProjectBudget{BudgetService: budgetService, TimingRepository: repo}
+	service.saveRules = service.saveRules.Then(
+		&TimingRuleHandlerProjectMembership{
+			ClientProjectRepository:       clientProjectRepo,
+			ClientRepository:              clientRepo,
+			ClientProjectMemberRepository: memberRepo,
+			CacheService:                  cacheService,
+		},
+		taskRule,
+		budgetRule,
+	)
+	archivedRule := &TimingRuleHandlerProjectArchived{TimingRepository: repo, ClientProjectRepository: clientProjectRepo}
+	ownerRule := &TimingRuleHandlerOwner{TimingRepository: repo, ClientProjectMemberRepository: memberRepo, CacheService: cacheService}
+	service.updateRules = service.updateRules.Then(archivedRule, ownerRule, taskRule, budgetRule)
+	service.deleteRules = service.deleteRules.Then(archivedRule, ownerRule)
+	service.tagRules = Chain(service.tagRules, archivedRule, ownerRule)
+
+	return service
 }
 
 // #region Create Timing
+// Create, kayıttan sonra projenin bütçe eşiklerini kontrol eder. Aşılan eşiklerin olayları
+// kayıtla aynı işlemde yazılır.
 func (s *timingService) Create(timing *datamodels.Timing, c *models.Context) *lgo.OperationResult {
-	if result := s.saveRules.Handle(timing, c); !result.IsSuccess() {
+	return s.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		result := s.crudService.Create(timing, tx)
+		if !result.IsSuccess() {
+			return result
+		}
+		if tracked := s.budgetService.Track(timing, nil, tx); !tracked.IsSuccess() {
+			return tracked
+		}
 		return result
-	}
-	return s.repo.Create(timing)
+	})
 }
 
 //#endregion Create Timing
 
 // #region Update Timing
+// Update, güncellemeden sonra projenin bütçe eşiklerini kaydın önceki süresine göre kontrol
+// eder. Aşılan eşiklerin olayları güncellemeyle aynı işlemde yazılır.
 func (s *timingService) Update(timing *datamodels.Timing, c *models.Context) *lgo.OperationResult {
-	if result := s.updateRules.Handle(timing, c); !result.IsSuccess() {
+	return s.transactor.Transaction(c, func(tx *models.Context) *lgo.OperationResult {
+		existing := s.repo.GetById(tx, timing.Id)
+
+		result := s.crudService.Update(timing, tx)
+		if !result.IsSuccess() || !existing.IsSuccess() {
+			return result
+		}
+		if tracked := s.budgetService.Track(result.ReturnObject.(*datamodels.Timing), existing.ReturnObject.(*datamodels.Timing), tx); !tracked.IsSuccess() {
+			return tracked
+		}
 		return result
-	}
-	return s.repo.Update(timing)
+	})
 }
 
 //#endregion Update Timing
 
-// #region Delete Timing
-func (s *timingService) Delete(id int, c *models.Context) *lgo.OperationResult {
-	if id <= 0 {
-		return lgo.NewLogicError("Geçersiz ID.", nil)
+// #region Get Timings By ClientProjectId
+func (s *timingService) GetByClientProjectId(clientProjectId int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "TimingService.GetByClientProjectId")()
+
+	if clientProjectId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidClientProjectId)
 	}
 
-	timing := &datamodels.Timing{Id: id}
-	if result := s.deleteRules.Handle(timing, c); !result.IsSuccess() {
+	timing := &datamodels.Timing{ClientProjectId: clientProjectId}
+	if result := handleRules(c, "TimingService.readRules", s.readRules, timing); !result.IsSuccess() {
 		return result
 	}
 
-	return s.repo.Delete(id)
+	return s.repo.GetByClientProjectId(c, clientProjectId)
 }
 
-//#endregion Delete Timing
+//#endregion Get Timings By ClientProjectId
 
-// #region Get Timing By Id
-func (s *timingService) GetById(id int, c *models.Context) *lgo.OperationResult {
-	if id <= 0 {
-		return lgo.NewLogicError("Geçersiz ID.", nil)
+// #region Get Timings By Date Range
+func (s *timingService) GetByDateRange(startDate time.Time, endDate time.Time, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "TimingService.GetByDateRange")()
+
+	if startDate.IsZero() || endDate.IsZero() {
+		return mvc.NewLogicError(i18n.DateRangeRequired)
+	}
+	if endDate.Before(startDate) {
+		return mvc.NewLogicError(i18n.EndBeforeStart)
 	}
 
-	timing := &datamodels.Timing{Id: id}
-	if result := s.readRules.Handle(timing, c); !result.IsSuccess() {
+	// Handle read rules (optional)
+	timing := &datamodels.Timing{
+		StartDateTime: startDate,
+		EndDateTime:   endDate,
+	}
+	if result := handleRules(c, "TimingService.readRules", s.readRules, timing); !result.IsSuccess() {
 		return result
 	}
 
-	return s.repo.GetById(id)
+	return s.repo.GetByDateRange(c, startDate, endDate)
 }
 
-//#endregion Get Timing By Id
+//#endregion Get Timings By Date Range
+
+// #region Timing Tags
+func (s *timingService) GetTags(timingId int, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "TimingService.GetTags")()
 
-// #region Get All Timings
-func (s *timingService) GetAll(query *mvc.QueryModel, c *models.Context) *lgo.OperationResult {
-	timing := &datamodels.Timing{}
-	if result := s.readRules.Handle(timing, c); !result.IsSuccess() {
+	if timingId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidId)
+	}
+	timing := &datamodels.Timing{Id: timingId}
+	if result := handleRules(c, "TimingService.readRules", s.readRules, timing); !result.IsSuccess() {
 		return result
 	}
-
-	if result := query.Validate(); !result.IsSuccess() {
-		return lgo.NewLogicError("Geçersiz sorgu parametreleri: "+result.ErrorMessage, nil)
+	if result := s.repo.GetById(c, timingId); !result.IsSuccess() {
+		return result
 	}
-
-	return s.repo.GetAll(query)
+	return s.repo.GetTags(c, timingId)
 }
 
-//#endregion Get All Timings
+func (s *timingService) SetTags(timingId int, tags *mvc.TimingTags, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "TimingService.SetTags")()
 
-// #region Get Timings By ClientProjectId
-func (s *timingService) GetByClientProjectId(clientProjectId int, c *models.Context) *lgo.OperationResult {
-	if clientProjectId <= 0 {
-		return lgo.NewLogicError("Geçersiz ClientProject ID.", nil)
+	if timingId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidId)
 	}
-
-	timing := &datamodels.Timing{ClientProjectId: clientProjectId}
-	if result := s.readRules.Handle(timing, c); !result.IsSuccess() {
+	timing := &datamodels.Timing{Id: timingId}
+	if result := handleRules(c, "TimingService.tagRules", s.tagRules, timing); !result.IsSuccess() {
 		return result
 	}
-
-	return s.repo.GetByClientProjectId(clientProjectId)
-}
-
-//#endregion Get Timings By ClientProjectId
-
-// #region Get Timings By Date Range
-func (s *timingService) GetByDateRange(startDate time.Time, endDate time.Time, c *models.Context) *lgo.OperationResult {
-	if startDate.IsZero() || endDate.IsZero() {
-		return lgo.NewLogicError("Başlangıç ve bitiş tarihleri zorunludur.", nil)
+	if result := tags.Validate(); !result.IsSuccess() {
+		return result
 	}
-	if endDate.Before(startDate) {
-		return lgo.NewLogicError("Bitiş tarihi, başlangıç tarihinden önce olamaz.", nil)
+	if result := s.repo.GetById(c, timingId); !result.IsSuccess() {
+		return result
 	}
 
-	// Handle read rules (optional)
-	timing := &datamodels.Timing{
-		StartDateTime: startDate,
-		EndDateTime:   endDate,
-	}
-	if result := s.readRules.Handle(timing, c); !result.IsSuccess() {
+	result := s.tagRepo.GetByIds(c, tags.TagIds)
+	if !result.IsSuccess() {
 		return result
 	}
+	if len(result.ReturnObject.([]*datamodels.Tag)) != len(tags.TagIds) {
+		return mvc.NewValidationErrorFrom(i18n.New(i18n.InvalidFields).WithField("tgs", i18n.New(i18n.TagNotFound)))
+	}
 
-	return s.repo.GetByDateRange(startDate, endDate)
+	result = s.events.service.Record(c, func(tx *models.Context) *lgo.OperationResult {
+		return s.repo.SetTags(tx, timingId, tags.TagIds)
+	}, func(*lgo.OperationResult) events.Event {
+		return &events.TimingTagsSet{TimingId: timingId, TagIds: append([]int{}, tags.TagIds...)}
+	})
+	if !result.IsSuccess() {
+		return result
+	}
+	return s.repo.GetTags(c, timingId)
 }
 
-//#endregion Get Timings By Date Range
+//#endregion Timing Tags
@@ -0,0 +1,502 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+	"github.com/google/uuid"
+)
+
+var allTimingPermissions = []string{
+	datamodels.TIMINGS_VIEW, datamodels.TIMINGS_ADD, datamodels.TIMINGS_UPDATE, datamodels.TIMINGS_DELETE,
+}
+
+// timingPermissionsForOthers, başka kullanıcılar adına kayıt girme yetkisini de içerir
+var timingPermissionsForOthers = append(append([]string{}, allTimingPermissions...), datamodels.TIMINGS_ADD_FOR_OTHERS)
+
+func TestTimingServiceRules(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	newTiming := func(f *fixture, existing *datamodels.Timing) *datamodels.Timing {
+		return &datamodels.Timing{
+			ClientProjectId: existing.ClientProjectId,
+			SystemUserId:    f.user.Id,
+			Title:           "Toplantı",
+			StartDateTime:   start,
+			EndDateTime:     start.Add(time.Hour),
+			Status:          enum.StatusStopped,
+		}
+	}
+
+	// leave, oturum açmış kullanıcıyı projenin üyeliğinden çıkarır
+	leave := func(t *testing.T, f *fixture, clientProjectId int) {
+		t.Helper()
+		result := f.repos.ClientProjectMembers.GetByMember(f.c, clientProjectId, f.user.Id)
+		mustSucceed(t, result)
+		mustSucceed(t, f.repos.ClientProjectMembers.Delete(f.c, result.ReturnObject.(*datamodels.ClientProjectMember).Id))
+	}
+
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult
+		want        expected
+	}{
+		{
+			name:        "create",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				return s.Create(newTiming(f, existing), f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create rejects an end before the start",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.EndDateTime = start.Add(-time.Minute)
+				return s.Create(timing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create rejects an unknown status",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.Status = enum.StatusEnum(9)
+				return s.Create(timing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create forbidden",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				return s.Create(newTiming(f, existing), f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "create for another member",
+			permissions: timingPermissionsForOthers,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.SystemUserId = f.addMember(t, existing.ClientProjectId, f.addUser(t, "grace@example.com")).SystemUserId
+				return s.Create(timing, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create for another member forbidden",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.SystemUserId = f.addMember(t, existing.ClientProjectId, f.addUser(t, "grace@example.com")).SystemUserId
+				return s.Create(timing, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "create rejects a user who is not a project member",
+			permissions: timingPermissionsForOthers,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.SystemUserId = f.addUser(t, "grace@example.com").Id
+				return s.Create(timing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create rejects a non-member logging for a member",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				other := f.addClientProject(t, f.addClient(t, "GLOBEX").Id, "Mobil uygulama")
+				timing := newTiming(f, existing)
+				timing.ClientProjectId = other.Id
+				timing.SystemUserId = f.addMember(t, other.Id, f.addUser(t, "grace@example.com")).SystemUserId
+				return s.Create(timing, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "create rejects a non-member logging for a member with permission",
+			permissions: timingPermissionsForOthers,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				other := f.addClientProject(t, f.addClient(t, "GLOBEX").Id, "Mobil uygulama")
+				timing := newTiming(f, existing)
+				timing.ClientProjectId = other.Id
+				timing.SystemUserId = f.addMember(t, other.Id, f.addUser(t, "grace@example.com")).SystemUserId
+				return s.Create(timing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create rejects a non-member logging for themselves",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				other := f.addClientProject(t, f.addClient(t, "GLOBEX").Id, "Mobil uygulama")
+				timing := newTiming(f, existing)
+				timing.ClientProjectId = other.Id
+				return s.Create(timing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create rejects an inactive project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				project := f.repos.ClientProjects.GetById(f.c, existing.ClientProjectId).ReturnObject.(*datamodels.ClientProject)
+				project.IsActive = false
+				mustSucceed(t, f.repos.ClientProjects.Update(f.c, project))
+				return s.Create(newTiming(f, existing), f.c)
+			},
+			want: conflict(i18n.ClientProjectInactive),
+		},
+		{
+			name:        "create rejects a project of an inactive client",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				project := f.repos.ClientProjects.GetById(f.c, existing.ClientProjectId).ReturnObject.(*datamodels.ClientProject)
+				client := f.repos.Clients.GetById(f.c, project.ClientId).ReturnObject.(*datamodels.Client)
+				client.IsActive = false
+				mustSucceed(t, f.repos.Clients.Update(f.c, client))
+				return s.Create(newTiming(f, existing), f.c)
+			},
+			want: conflict(i18n.ClientInactive),
+		},
+		{
+			name:        "create with a task of the project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.TaskId = &f.addTask(t, existing.ClientProjectId, "Backend").Id
+				return s.Create(timing, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create rejects a task of another project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				other := f.addClientProject(t, f.addClient(t, "GLOBEX").Id, "Mobil uygulama")
+				timing := newTiming(f, existing)
+				timing.TaskId = &f.addTask(t, other.Id, "Backend").Id
+				return s.Create(timing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create rejects a missing task",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.TaskId = ptr(99)
+				return s.Create(timing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "update checks the task against the stored project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				other := f.addClientProject(t, f.addClient(t, "GLOBEX").Id, "Mobil uygulama")
+				timing := newTiming(f, existing)
+				timing.Id = existing.Id
+				timing.ClientProjectId = other.Id
+				timing.TaskId = &f.addTask(t, other.Id, "Backend").Id
+				return s.Update(timing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "update does not require the project and user",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.Id = existing.Id
+				timing.ClientProjectId = 0
+				timing.SystemUserId = uuid.Nil
+				return s.Update(timing, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "update missing timing",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.Id = 99
+				return s.Update(timing, f.c)
+			},
+			want: notFound(i18n.TimingNotFound),
+		},
+		{
+			name:        "delete forbidden",
+			permissions: []string{datamodels.TIMINGS_UPDATE},
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				return s.Delete(existing.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "get by client project id rejects an invalid id",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, _ *datamodels.Timing) *lgo.OperationResult {
+				return s.GetByClientProjectId(0, f.c)
+			},
+			want: invalid(i18n.InvalidClientProjectId),
+		},
+		{
+			name:        "get by date range requires both dates",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, _ *datamodels.Timing) *lgo.OperationResult {
+				return s.GetByDateRange(start, time.Time{}, f.c)
+			},
+			want: invalid(i18n.DateRangeRequired),
+		},
+		{
+			name:        "get by date range rejects a reversed range",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, _ *datamodels.Timing) *lgo.OperationResult {
+				return s.GetByDateRange(start, start.Add(-time.Hour), f.c)
+			},
+			want: invalid(i18n.EndBeforeStart),
+		},
+		{
+			name:        "get by date range forbidden",
+			permissions: []string{datamodels.TIMINGS_ADD},
+			run: func(s TimingService, f *fixture, _ *datamodels.Timing) *lgo.OperationResult {
+				return s.GetByDateRange(start, start.Add(time.Hour), f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "set tags",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				return s.SetTags(existing.Id, &mvc.TimingTags{TagIds: []int{f.addTag(t, "Toplantı").Id}}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "set tags rejects a missing tag",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				return s.SetTags(existing.Id, &mvc.TimingTags{TagIds: []int{f.addTag(t, "Toplantı").Id, 99}}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "set tags rejects an invalid tag id",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				return s.SetTags(existing.Id, &mvc.TimingTags{TagIds: []int{0}}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "set tags rejects too many tags",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				tags := &mvc.TimingTags{}
+				for id := 1; id <= mvc.MaxTimingTags+1; id++ {
+					tags.TagIds = append(tags.TagIds, id)
+				}
+				return s.SetTags(existing.Id, tags, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "set tags of a missing timing",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, _ *datamodels.Timing) *lgo.OperationResult {
+				return s.SetTags(99, &mvc.TimingTags{}, f.c)
+			},
+			want: notFound(i18n.TimingNotFound),
+		},
+		{
+			name:        "set tags needs the timing update permission",
+			permissions: []string{datamodels.TIMINGS_VIEW, datamodels.TIMINGS_ADD},
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				return s.SetTags(existing.Id, &mvc.TimingTags{}, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "get tags",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				return s.GetTags(existing.Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "get tags of a missing timing",
+			permissions: []string{datamodels.TIMINGS_VIEW},
+			run: func(s TimingService, f *fixture, _ *datamodels.Timing) *lgo.OperationResult {
+				return s.GetTags(99, f.c)
+			},
+			want: notFound(i18n.TimingNotFound),
+		},
+		{
+			name:        "update on an archived project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				mustSucceed(t, f.repos.ClientProjects.Archive(f.c, existing.ClientProjectId, time.Now()))
+				existing.Title = "Tasarım"
+				return s.Update(existing, f.c)
+			},
+			want: conflict(i18n.TimingProjectArchived),
+		},
+		{
+			name:        "delete on an archived project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				mustSucceed(t, f.repos.ClientProjects.Archive(f.c, existing.ClientProjectId, time.Now()))
+				return s.Delete(existing.Id, f.c)
+			},
+			want: conflict(i18n.TimingProjectArchived),
+		},
+		{
+			name:        "set tags on an archived project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				mustSucceed(t, f.repos.ClientProjects.Archive(f.c, existing.ClientProjectId, time.Now()))
+				return s.SetTags(existing.Id, &mvc.TimingTags{}, f.c)
+			},
+			want: conflict(i18n.TimingProjectArchived),
+		},
+		{
+			name:        "update by a user who left the project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				leave(t, f, existing.ClientProjectId)
+				existing.Title = "Tasarım"
+				return s.Update(existing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "delete by a user who left the project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				leave(t, f, existing.ClientProjectId)
+				return s.Delete(existing.Id, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "set tags by a user who left the project",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				leave(t, f, existing.ClientProjectId)
+				return s.SetTags(existing.Id, &mvc.TimingTags{}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "update another member's timing",
+			permissions: timingPermissionsForOthers,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.SystemUserId = f.addMember(t, existing.ClientProjectId, f.addUser(t, "grace@example.com")).SystemUserId
+				mustSucceed(t, f.repos.Timings.Create(f.c, timing))
+				timing.Title = "Tasarım"
+				return s.Update(timing, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "update another member's timing forbidden",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.SystemUserId = f.addMember(t, existing.ClientProjectId, f.addUser(t, "grace@example.com")).SystemUserId
+				mustSucceed(t, f.repos.Timings.Create(f.c, timing))
+				timing.Title = "Tasarım"
+				return s.Update(timing, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "delete another member's timing forbidden",
+			permissions: allTimingPermissions,
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				timing := newTiming(f, existing)
+				timing.SystemUserId = f.addMember(t, existing.ClientProjectId, f.addUser(t, "grace@example.com")).SystemUserId
+				mustSucceed(t, f.repos.Timings.Create(f.c, timing))
+				return s.Delete(timing.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "get tags forbidden",
+			permissions: []string{datamodels.TIMINGS_ADD},
+			run: func(s TimingService, f *fixture, existing *datamodels.Timing) *lgo.OperationResult {
+				return s.GetTags(existing.Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			project := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+			f.addMember(t, project.Id, f.user)
+			existing := f.addTiming(t, &datamodels.Timing{
+				ClientProjectId: project.Id,
+				SystemUserId:    f.user.Id,
+				Title:           "Analiz",
+				StartDateTime:   start,
+				EndDateTime:     start.Add(2 * time.Hour),
+			})
+			test.want.check(t, test.run(f.timingService(f.budgetService(nil)), f, existing))
+		})
+	}
+}
+
+func TestTimingServiceSetTags(t *testing.T) {
+	f := newFixture(t, allTimingPermissions...)
+	project := f.addClientProject(t, f.addClient(t, "ACME").Id, "Web sitesi")
+	f.addMember(t, project.Id, f.user)
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	timing := f.addTiming(t, &datamodels.Timing{ClientProjectId: project.Id, SystemUserId: f.user.Id, Title: "Analiz", StartDateTime: start, EndDateTime: start.Add(time.Hour)})
+	meeting := f.addTag(t, "Toplantı")
+	support := f.addTag(t, "Destek")
+	service := f.timingService(f.budgetService(nil))
+
+	names := func(result *lgo.OperationResult) []string {
+		t.Helper()
+		mustSucceed(t, result)
+		var names []string
+		for _, tag := range result.ReturnObject.([]*datamodels.Tag) {
+			names = append(names, tag.Name)
+		}
+		return names
+	}
+
+	got := names(service.SetTags(timing.Id, &mvc.TimingTags{TagIds: []int{meeting.Id, support.Id, meeting.Id}}, f.c))
+	if len(got) != 2 || got[0] != "Destek" || got[1] != "Toplantı" {
+		t.Fatalf("want the tags deduplicated and sorted by name, got %v", got)
+	}
+
+	got = names(service.SetTags(timing.Id, &mvc.TimingTags{TagIds: []int{support.Id}}, f.c))
+	if len(got) != 1 || got[0] != "Destek" {
+		t.Fatalf("want the tags replaced, got %v", got)
+	}
+
+	if got = names(service.SetTags(timing.Id, &mvc.TimingTags{}, f.c)); len(got) != 0 {
+		t.Fatalf("want an empty list to clear the tags, got %v", got)
+	}
+	if got = names(service.GetTags(timing.Id, f.c)); len(got) != 0 {
+		t.Fatalf("want no tags after clearing, got %v", got)
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"lms-web-services-main/models"
+	"lms-web-services-main/tracing"
+
+	"github.com/LGYtech/lgo"
+)
+
+// startSpan, c'nin context'i altında bir span açar ve alt çağrılar span'in altında kalsın diye
+// c.Context'i günceller. Dönen fonksiyon span'i kapatır ve c.Context'i eski haline getirir.
+func startSpan(c *models.Context, name string) func() {
+	if c == nil || c.Context == nil {
+		return func() {}
+	}
+
+	parent := c.Context
+	ctx, span := tracing.Start(parent, name)
+	c.Context = ctx
+	return func() {
+		span.End()
+		c.Context = parent
+	}
+}
+
+// handleRules, kural zincirini kendi span'i içinde çalıştırır ve sonucu span'e işler
+func handleRules[T any](c *models.Context, name string, rules RuleHandler[T], model T) *lgo.OperationResult {
+	if c == nil || c.Context == nil {
+		return rules.Handle(model, c)
+	}
+
+	parent := c.Context
+	ctx, span := tracing.Start(parent, name)
+	c.Context = ctx
+	defer func() {
+		span.End()
+		c.Context = parent
+	}()
+
+	result := rules.Handle(model, c)
+	tracing.RecordResult(span, result.IsSuccess(), result.ErrorMessage)
+	return result
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"encoding/json"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// WebhookSubscription'a özgü kurallar ve olay aboneleri. Doğrulama ve yetki kuralları
+// crudService tarafından kurulur.
+
+// #region Event Types
+// WebhookRuleHandlerEventTypes, aboneliğin yalnızca bilinen olay türlerini dinlemesini sağlar.
+// Oluşturmada ve güncellemede çalışır.
+type WebhookRuleHandlerEventTypes struct{}
+
+func (h *WebhookRuleHandlerEventTypes) Handle(model *datamodels.WebhookSubscription, c *models.Context) *lgo.OperationResult {
+	known := events.Types()
+	for _, eventType := range model.EventTypes {
+		if _, found := slices.BinarySearch(known, eventType); !found {
+			return mvc.NewValidationErrorFrom(i18n.New(i18n.InvalidFields).WithField("evts", i18n.New(i18n.InvalidEventType, eventType)))
+		}
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Event Types
+
+// #region Url
+// WebhookRuleHandlerUrl, aboneliğin adresinin yerel makineyi veya özel bir ağı
+// göstermesini engeller. Yalnızca adresteki IP'ye ve localhost adlarına bakılır; alan
+// adlarının çözüldüğü IP'ler gönderim sırasında NewWebhookClient tarafından denetlenir.
+// Oluşturmada ve güncellemede çalışır.
+type WebhookRuleHandlerUrl struct{}
+
+func (h *WebhookRuleHandlerUrl) Handle(model *datamodels.WebhookSubscription, c *models.Context) *lgo.OperationResult {
+	parsed, err := url.Parse(model.Url)
+	if err == nil && isPublicHost(parsed.Hostname()) {
+		return lgo.NewSuccess(nil)
+	}
+	return mvc.NewValidationErrorFrom(i18n.New(i18n.InvalidFields).WithField("url", i18n.New(i18n.PrivateWebhookUrl)))
+}
+
+// isPublicHost, host localhost değilse ve bir IP ise herkese açık bir adresse true döndürür
+func isPublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if address, err := netip.ParseAddr(host); err == nil {
+		return isPublicAddress(address)
+	}
+	return true
+}
+
+//#endregion Url
+
+// #region Deliveries
+// WebhookEventHandlerDeliveries, her olay için olayı dinleyen etkin aboneliklere birer
+// gönderim kaydı açar; gönderimler WebhookSender tarafından yapılır. Tüm olaylara abone
+// olur; abonelik application.addRoutes'ta kurulur. Olay yeniden dağıtılırsa aynı aboneliğe
+// ikinci bir gönderim açılmaz.
+type WebhookEventHandlerDeliveries struct {
+	WebhookRepository         repositories.WebhookRepository
+	WebhookDeliveryRepository repositories.WebhookDeliveryRepository
+}
+
+func (h *WebhookEventHandlerDeliveries) Handle(envelope *events.Envelope, c *models.Context) *lgo.OperationResult {
+	result := h.WebhookRepository.GetActiveByEventType(c, envelope.Type)
+	if !result.IsSuccess() {
+		return result
+	}
+	subscriptions := result.ReturnObject.([]*datamodels.WebhookSubscription)
+	if len(subscriptions) == 0 {
+		return lgo.NewSuccess(nil)
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return lgo.NewFailureWithError(err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	for _, subscription := range subscriptions {
+		result := h.WebhookDeliveryRepository.Add(c, &datamodels.WebhookDelivery{
+			SubscriptionId: subscription.Id,
+			EventId:        envelope.Id,
+			EventType:      envelope.Type,
+			Payload:        string(payload),
+			Status:         enum.DeliveryStatusPending,
+			CreatedAt:      now,
+			NextAttemptAt:  &now,
+		})
+		if !result.IsSuccess() {
+			return result
+		}
+	}
+	return lgo.NewSuccess(nil)
+}
+
+//#endregion Deliveries
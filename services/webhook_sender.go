@@ -0,0 +1,261 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/mvc"
+	"lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// Webhook isteklerinin başlıkları. Alıcı imzayı X-LMS-Timestamp + "." + gövde üzerinde
+// aboneliğin anahtarıyla hesaplanan HMAC-SHA256 ile karşılaştırmalıdır.
+const (
+	WebhookEventHeader     = "X-LMS-Event"
+	WebhookDeliveryHeader  = "X-LMS-Delivery"
+	WebhookTimestampHeader = "X-LMS-Timestamp"
+	WebhookSignatureHeader = "X-LMS-Signature"
+)
+
+// webhookErrorBodyLimit, başarısız yanıtların gönderim günlüğüne yazılan en fazla bayt sayısıdır
+const webhookErrorBodyLimit = 512
+
+// WebhookSenderConfig, webhook gönderim işçisinin ayarlarıdır
+type WebhookSenderConfig struct {
+	PollInterval  time.Duration // Bekleyen gönderimlerin aranma aralığı
+	BatchSize     int           // Bir aramada alınacak en fazla gönderim
+	MaxAttempts   int           // Bir gönderimin en fazla deneme sayısı; sonrasında Failed olur
+	RetryDelay    time.Duration // İlk yeniden denemeden önceki bekleme; her denemede iki katına çıkar
+	MaxRetryDelay time.Duration // Yeniden denemeler arasındaki en uzun bekleme
+	Lease         time.Duration // Alınan bir partinin başka işçilere verilmeyeceği süre; parti eşzamanlı gönderildiği için Timeout'tan uzun olması yeterlidir
+	Timeout       time.Duration // Tek bir HTTP isteğinin zaman aşımı
+}
+
+// WebhookSender, bekleyen webhook gönderimlerini aboneliklerin adreslerine POST eder. 2xx
+// yanıt alınan gönderim Delivered olur; diğer yanıtlar ve bağlantı hataları üstel artan
+// beklemeyle MaxAttempts'e kadar yeniden denenir. Bir partinin gönderimleri eşzamanlı
+// yapılır. Birden fazla uygulama örneği aynı kuyruğu güvenle işleyebilir.
+type WebhookSender struct {
+	repo        repositories.WebhookDeliveryRepository
+	webhookRepo repositories.WebhookRepository
+	client      *http.Client
+	config      WebhookSenderConfig
+	logger      *slog.Logger
+}
+
+func NewWebhookSender(repo repositories.WebhookDeliveryRepository, webhookRepo repositories.WebhookRepository, client *http.Client, config WebhookSenderConfig, logger *slog.Logger) *WebhookSender {
+	return &WebhookSender{
+		repo:        repo,
+		webhookRepo: webhookRepo,
+		client:      client,
+		config:      config,
+		logger:      logger,
+	}
+}
+
+// #region Run
+// Run, ctx iptal edilene kadar bekleyen gönderimleri PollInterval aralıklarla gönderir. Bir
+// tur tam bir parti döndürdüyse beklemeden devam eder.
+func (s *WebhookSender) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		wait := s.config.PollInterval
+		result := s.SendDue(models.NewSystemContext(ctx))
+		if !result.IsSuccess() {
+			s.logger.ErrorContext(ctx, "sending webhooks failed", slog.String("error", result.ErrorMessage))
+		} else if claimed, _ := result.ReturnObject.(int); claimed >= s.config.BatchSize {
+			wait = 0
+		}
+		timer.Reset(wait)
+	}
+}
+
+//#endregion Run
+
+// #region Send Due
+// SendDue, zamanı gelmiş en fazla BatchSize gönderimi yapar ve alınan gönderim sayısını
+// döndürür
+func (s *WebhookSender) SendDue(c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "WebhookSender.SendDue")()
+
+	claimResult := s.repo.Claim(c, time.Now(), s.config.Lease, s.config.BatchSize)
+	if !claimResult.IsSuccess() {
+		return claimResult
+	}
+
+	// Gönderimler eşzamanlı yapılır. Her istek Timeout ile sınırlı olduğundan parti, parti
+	// büyüklüğünden bağımsız olarak kira süresi dolmadan biter ve gönderimler başka bir
+	// işçiye tekrar verilmez.
+	deliveries := claimResult.ReturnObject.([]*datamodels.WebhookDelivery)
+	results := make([]*lgo.OperationResult, len(deliveries))
+	var wg sync.WaitGroup
+	for i, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.send(c, delivery)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if !result.IsSuccess() {
+			return result
+		}
+	}
+	return lgo.NewSuccess(len(deliveries))
+}
+
+// send, gönderimi yapar ve sonucunu gönderim günlüğüne yazar. Silinmiş veya devre dışı
+// bırakılmış aboneliklerin gönderimleri yapılmadan Failed olur. Dönen sonuç yalnızca bu
+// yazmanın sonucudur.
+func (s *WebhookSender) send(c *models.Context, delivery *datamodels.WebhookDelivery) *lgo.OperationResult {
+	result := s.webhookRepo.GetById(c, delivery.SubscriptionId)
+	if !result.IsSuccess() {
+		if result.ErrorCode != mvc.ErrorCodeNotFound {
+			return result
+		}
+		// Abonelik silinmiş; gönderim yeniden denenmez
+		return s.repo.MarkFailed(c, delivery.Id, 0, "subscription not found", time.Now(), nil)
+	}
+	subscription := result.ReturnObject.(*datamodels.WebhookSubscription)
+	if !subscription.IsActive {
+		return s.repo.MarkFailed(c, delivery.Id, 0, "subscription inactive", time.Now(), nil)
+	}
+
+	status, err := s.post(c, subscription, delivery)
+	attemptedAt := time.Now()
+	if err == nil {
+		return s.repo.MarkDelivered(c, delivery.Id, status, attemptedAt)
+	}
+
+	logger := s.logger.With(
+		slog.Int64("delivery_id", delivery.Id),
+		slog.Int("subscription_id", delivery.SubscriptionId),
+		slog.String("event_type", delivery.EventType),
+		slog.Int("attempts", delivery.Attempts),
+		slog.String("error", err.Error()),
+	)
+	if delivery.Attempts >= s.config.MaxAttempts {
+		logger.WarnContext(c, "webhook delivery failed after max attempts")
+		return s.repo.MarkFailed(c, delivery.Id, status, err.Error(), attemptedAt, nil)
+	}
+
+	nextAttemptAt := attemptedAt.Add(backoff(s.config.RetryDelay, s.config.MaxRetryDelay, delivery.Attempts))
+	logger.InfoContext(c, "webhook delivery failed, retrying", slog.Time("next_attempt_at", nextAttemptAt))
+	return s.repo.MarkFailed(c, delivery.Id, status, err.Error(), attemptedAt, &nextAttemptAt)
+}
+
+// post, gönderimin gövdesini imzalayarak aboneliğin adresine gönderir. Yanıt alındıysa
+// durum kodunu döndürür; 2xx dışındaki yanıtlar hatadır.
+func (s *WebhookSender) post(c *models.Context, subscription *datamodels.WebhookSubscription, delivery *datamodels.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(c, s.config.Timeout)
+	defer cancel()
+
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, delivery.EventType)
+	request.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.Id, 10))
+	request.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(WebhookSignatureHeader, SignWebhook(subscription.Secret, timestamp, body))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		io.Copy(io.Discard, response.Body)
+		return response.StatusCode, nil
+	}
+	excerpt, _ := io.ReadAll(io.LimitReader(response.Body, webhookErrorBodyLimit))
+	return response.StatusCode, fmt.Errorf("unexpected status %d: %s", response.StatusCode, bytes.TrimSpace(excerpt))
+}
+
+//#endregion Send Due
+
+// NewWebhookClient, webhook gönderimleri için HTTP istemcisini kurar. İstemci yalnızca
+// herkese açık IP'lere bağlanır; alan adları çözüldükten sonra denetlendiği için DNS ile
+// yerel ağa yönlendirilen adresler de reddedilir. Yönlendirmeler izlenmez; 3xx yanıtlar
+// başarısız deneme sayılır.
+func NewWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			addressPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddress(addressPort.Addr()) {
+				return fmt.Errorf("webhook address %s is not public", addressPort.Addr())
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // Vekil sunucu üzerinden gidilirse hedef adres denetlenemez
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// sharedAddressSpace, taşıyıcı sınıfı NAT'ın (RFC 6598) adres bloğudur
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// isPublicAddress, adres yerel makineye, özel veya bağlantı-yerel bir ağa ait değilse true
+// döndürür
+func isPublicAddress(address netip.Addr) bool {
+	address = address.Unmap()
+	return address.IsGlobalUnicast() &&
+		!address.IsPrivate() &&
+		!sharedAddressSpace.Contains(address)
+}
+
+// SignWebhook, X-LMS-Signature başlığının değerini üretir: "sha256=" ve ardından
+// timestamp + "." + body üzerinde secret ile hesaplanan HMAC-SHA256'nın onaltılık hali.
+// Zaman damgası imzaya dahil edildiği için yakalanan bir istek başka bir zamanda geçerli
+// sayılmaz.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
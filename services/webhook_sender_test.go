@@ -0,0 +1,220 @@
+package services
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"lms-web-services-main/events"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+)
+
+// sender, yeniden denemeleri beklemeden gönderen bir gönderim işçisi kurar
+func (f *fixture) sender(maxAttempts int) *WebhookSender {
+	config := WebhookSenderConfig{BatchSize: 100, MaxAttempts: maxAttempts, Lease: time.Minute, Timeout: 5 * time.Second}
+	return NewWebhookSender(f.repos.WebhookDeliveries, f.repos.Webhooks, http.DefaultClient, config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// queueDelivery, aboneliğe hemen gönderilecek bir gönderim ekler
+func (f *fixture) queueDelivery(t *testing.T, subscriptionId int, payload string) *datamodels.WebhookDelivery {
+	t.Helper()
+	now := time.Now()
+	delivery := &datamodels.WebhookDelivery{
+		SubscriptionId: subscriptionId, EventId: 1, EventType: events.TypeTimingCreated, Payload: payload,
+		Status: enum.DeliveryStatusPending, CreatedAt: now, NextAttemptAt: &now,
+	}
+	mustSucceed(t, f.repos.WebhookDeliveries.Add(f.c, delivery))
+	return delivery
+}
+
+func TestWebhookSenderSignsDeliveries(t *testing.T) {
+	f := newFixture(t)
+	payload := `{"id":1,"type":"timing.created","data":{"timing":{"id":3}}}`
+
+	var request *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	webhook := f.addWebhook(t, receiver.URL, events.TypeTimingCreated)
+	delivery := f.queueDelivery(t, webhook.Id, payload)
+
+	ok().check(t, f.sender(3).SendDue(f.c))
+
+	if request == nil {
+		t.Fatal("want the delivery posted to the webhook url")
+	}
+	if string(body) != payload || request.Method != http.MethodPost || request.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("want the payload posted as JSON, got %s %q", request.Method, body)
+	}
+	if request.Header.Get(WebhookEventHeader) != events.TypeTimingCreated || request.Header.Get(WebhookDeliveryHeader) != strconv.FormatInt(delivery.Id, 10) {
+		t.Fatalf("want the event and delivery headers, got %v", request.Header)
+	}
+	timestamp, err := strconv.ParseInt(request.Header.Get(WebhookTimestampHeader), 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signature := request.Header.Get(WebhookSignatureHeader); signature != SignWebhook(webhook.Secret, timestamp, []byte(payload)) {
+		t.Fatalf("want the body signed with the webhook secret, got %q", signature)
+	}
+
+	stored := f.repos.WebhookDeliveries.GetById(f.c, delivery.Id).ReturnObject.(*datamodels.WebhookDelivery)
+	if stored.Status != enum.DeliveryStatusDelivered || stored.ResponseStatus != http.StatusNoContent || stored.DeliveredAt == nil || stored.NextAttemptAt != nil {
+		t.Fatalf("want the delivery marked delivered, got %+v", stored)
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	// printf '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163"
+	if got := SignWebhook("secret", 1700000000, []byte("{}")); got != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
+
+func TestWebhookSenderRetriesFailedDeliveries(t *testing.T) {
+	tests := []struct {
+		name         string
+		fails        int  // Alıcının başarısız yanıt vereceği istek sayısı
+		inactive     bool // Abonelik gönderim kuyruktayken devre dışı bırakılır
+		wantRequests int
+		wantStatus   enum.DeliveryStatusEnum
+		wantAttempts int
+	}{
+		{name: "succeeds on retry", fails: 2, wantRequests: 3, wantStatus: enum.DeliveryStatusDelivered, wantAttempts: 3},
+		{name: "fails after max attempts", fails: 10, wantRequests: 3, wantStatus: enum.DeliveryStatusFailed, wantAttempts: 3},
+		{name: "inactive subscription", inactive: true, wantRequests: 0, wantStatus: enum.DeliveryStatusFailed, wantAttempts: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t)
+
+			requests := 0
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests <= test.fails {
+					http.Error(w, "bakımda", http.StatusServiceUnavailable)
+				}
+			}))
+			defer receiver.Close()
+
+			webhook := f.addWebhook(t, receiver.URL, events.TypeTimingCreated)
+			delivery := f.queueDelivery(t, webhook.Id, `{"id":1}`)
+			if test.inactive {
+				webhook.IsActive = false
+				mustSucceed(t, f.repos.Webhooks.Update(f.c, webhook))
+			}
+
+			// Yeniden deneme beklemesi sıfır olduğu için her tur gönderimi yeniden alır
+			sender := f.sender(3)
+			for range 5 {
+				ok().check(t, sender.SendDue(f.c))
+			}
+
+			if requests != test.wantRequests {
+				t.Fatalf("want %d requests, got %d", test.wantRequests, requests)
+			}
+			stored := f.repos.WebhookDeliveries.GetById(f.c, delivery.Id).ReturnObject.(*datamodels.WebhookDelivery)
+			if stored.Status != test.wantStatus || stored.Attempts != test.wantAttempts {
+				t.Fatalf("want status %v after %d attempts, got %+v", test.wantStatus, test.wantAttempts, stored)
+			}
+			if test.wantStatus == enum.DeliveryStatusFailed && (stored.LastError == "" || stored.NextAttemptAt != nil) {
+				t.Fatalf("want a failed delivery to keep its error and stop retrying, got %+v", stored)
+			}
+			if test.fails > test.wantRequests && stored.ResponseStatus != http.StatusServiceUnavailable {
+				t.Fatalf("want the last response status kept, got %d", stored.ResponseStatus)
+			}
+		})
+	}
+}
+
+func TestWebhookSenderSendsTheBatchConcurrently(t *testing.T) {
+	f := newFixture(t)
+	const count = 5
+
+	// Alıcı, bütün istekler gelene kadar yanıt vermez; gönderimler sırayla yapılsaydı ilk
+	// istek zaman aşımına uğrardı
+	var mutex sync.Mutex
+	arrived := 0
+	all := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		if arrived++; arrived == count {
+			close(all)
+		}
+		mutex.Unlock()
+		select {
+		case <-all:
+		case <-r.Context().Done():
+		}
+	}))
+	defer receiver.Close()
+
+	var deliveries []*datamodels.WebhookDelivery
+	for range count {
+		webhook := f.addWebhook(t, receiver.URL, events.TypeTimingCreated)
+		deliveries = append(deliveries, f.queueDelivery(t, webhook.Id, `{"id":1}`))
+	}
+
+	result := f.sender(1).SendDue(f.c)
+	ok().check(t, result)
+	if claimed := result.ReturnObject.(int); claimed != count {
+		t.Fatalf("want %d deliveries claimed, got %d", count, claimed)
+	}
+	for _, delivery := range deliveries {
+		stored := f.repos.WebhookDeliveries.GetById(f.c, delivery.Id).ReturnObject.(*datamodels.WebhookDelivery)
+		if stored.Status != enum.DeliveryStatusDelivered {
+			t.Fatalf("want every delivery of the batch delivered, got %+v", stored)
+		}
+	}
+}
+
+func TestWebhookClientRefusesPrivateAddresses(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer receiver.Close()
+
+	client := NewWebhookClient()
+	if response, err := client.Post(receiver.URL, "application/json", nil); err == nil {
+		response.Body.Close()
+		t.Fatal("want the request to a loopback address refused")
+	}
+	if err := client.CheckRedirect(nil, nil); err != http.ErrUseLastResponse {
+		t.Fatalf("want redirects left unfollowed, got %v", err)
+	}
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.215.14":        true,
+		"2606:2800:21f::1":     true,
+		"127.0.0.1":            false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"100.64.0.1":           false,
+		"0.0.0.0":              false,
+		"::1":                  false,
+		"fe80::1":              false,
+		"fd00::1":              false,
+		"::ffff:127.0.0.1":     false,
+		"::ffff:93.184.215.14": true,
+	}
+	for address, want := range tests {
+		if got := isPublicAddress(netip.MustParseAddr(address)); got != want {
+			t.Errorf("isPublicAddress(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
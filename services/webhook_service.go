@@ -0,0 +1,117 @@
+package services
+
+import (
+	"time"
+
+	"lms-web-services-main/i18n"
+	"lms-web-services-main/models"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+	repositories "lms-web-services-main/repositories"
+
+	"github.com/LGYtech/lgo"
+)
+
+// #region Webhook Service Interface
+type WebhookService interface {
+	CrudService[datamodels.WebhookSubscription, int]
+	// GetDeliveries, aboneliğin gönderim günlüğünü yeniden eskiye sayfalar
+	GetDeliveries(subscriptionId int, query *mvc.QueryModel, c *models.Context) *lgo.OperationResult
+	// Redeliver, gönderimin olayını aboneliğe yeniden gönderilmek üzere kuyruğa ekler ve
+	// yeni gönderimi döndürür. Asıl gönderimin durumu değişmez.
+	Redeliver(subscriptionId int, deliveryId int64, c *models.Context) *lgo.OperationResult
+}
+
+//#endregion Webhook Service Interface
+
+// #region Webhook Service Implementation
+type webhookService struct {
+	*crudService[datamodels.WebhookSubscription, int, *datamodels.WebhookSubscription]
+	repo           repositories.WebhookRepository
+	deliveryRepo   repositories.WebhookDeliveryRepository
+	redeliverRules RuleHandler[*datamodels.WebhookSubscription] // Yeniden gönderim aboneliği güncelleme yetkisi gerektirir
+}
+
+func NewWebhookService(repo repositories.WebhookRepository, deliveryRepo repositories.WebhookDeliveryRepository, cacheService CacheService) WebhookService {
+	service := &webhookService{
+		crudService: newCrudService[datamodels.WebhookSubscription, int](repo, cacheService, CrudServiceOptions{
+			Name:        "WebhookService",
+			Permissions: datamodels.WebhookPermissions,
+			InvalidId:   i18n.InvalidId,
+		}),
+		repo:           repo,
+		deliveryRepo:   deliveryRepo,
+		redeliverRules: PermissionRule[*datamodels.WebhookSubscription]{CacheService: cacheService, Key: datamodels.WebhookPermissions.Update},
+	}
+
+	eventTypes := &WebhookRuleHandlerEventTypes{}
+	publicUrl := &WebhookRuleHandlerUrl{}
+	service.saveRules = service.saveRules.Then(eventTypes, publicUrl)
+	service.updateRules = service.updateRules.Then(eventTypes, publicUrl)
+
+	return service
+}
+
+//#endregion Webhook Service Implementation
+
+// #region Get Webhook Deliveries
+func (s *webhookService) GetDeliveries(subscriptionId int, query *mvc.QueryModel, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "WebhookService.GetDeliveries")()
+
+	if subscriptionId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidId)
+	}
+	subscription := &datamodels.WebhookSubscription{Id: subscriptionId}
+	if result := handleRules(c, "WebhookService.readRules", s.readRules, subscription); !result.IsSuccess() {
+		return result
+	}
+	if result := query.Validate(); !result.IsSuccess() {
+		return result
+	}
+	if result := s.repo.GetById(c, subscriptionId); !result.IsSuccess() {
+		return result
+	}
+	return s.deliveryRepo.GetBySubscriptionId(c, subscriptionId, query)
+}
+
+//#endregion Get Webhook Deliveries
+
+// #region Redeliver Webhook
+func (s *webhookService) Redeliver(subscriptionId int, deliveryId int64, c *models.Context) *lgo.OperationResult {
+	defer startSpan(c, "WebhookService.Redeliver")()
+
+	if subscriptionId <= 0 || deliveryId <= 0 {
+		return mvc.NewLogicError(i18n.InvalidId)
+	}
+	subscription := &datamodels.WebhookSubscription{Id: subscriptionId}
+	if result := handleRules(c, "WebhookService.redeliverRules", s.redeliverRules, subscription); !result.IsSuccess() {
+		return result
+	}
+	if result := s.repo.GetById(c, subscriptionId); !result.IsSuccess() {
+		return result
+	}
+
+	result := s.deliveryRepo.GetById(c, deliveryId)
+	if !result.IsSuccess() {
+		return result
+	}
+	original := result.ReturnObject.(*datamodels.WebhookDelivery)
+	if original.SubscriptionId != subscriptionId {
+		return mvc.NewNotFoundError(i18n.DeliveryNotFound)
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return s.deliveryRepo.Add(c, &datamodels.WebhookDelivery{
+		SubscriptionId: subscriptionId,
+		EventId:        original.EventId,
+		EventType:      original.EventType,
+		RedeliveryOf:   &original.Id,
+		Payload:        original.Payload,
+		Status:         enum.DeliveryStatusPending,
+		CreatedAt:      now,
+		NextAttemptAt:  &now,
+	})
+}
+
+//#endregion Redeliver Webhook
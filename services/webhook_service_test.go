@@ -0,0 +1,257 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"lms-web-services-main/events"
+	"lms-web-services-main/i18n"
+	datamodels "lms-web-services-main/models/data"
+	"lms-web-services-main/models/enum"
+	"lms-web-services-main/models/mvc"
+
+	"github.com/LGYtech/lgo"
+)
+
+var allWebhookPermissions = []string{datamodels.WEBHOOKS_VIEW, datamodels.WEBHOOKS_ADD, datamodels.WEBHOOKS_UPDATE, datamodels.WEBHOOKS_DELETE}
+
+func TestWebhookServiceRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		run         func(s WebhookService, f *fixture, existing *datamodels.WebhookSubscription) *lgo.OperationResult
+		want        expected
+	}{
+		{
+			name:        "create",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, _ *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Create(&datamodels.WebhookSubscription{Url: "https://chat.example.com/hook", EventTypes: []string{events.TypeClientUpdated}, Secret: "0123456789abcdef"}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "create with an unknown event type",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, _ *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Create(&datamodels.WebhookSubscription{Url: "https://chat.example.com/hook", EventTypes: []string{"timing.*"}, Secret: "0123456789abcdef"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create without event types",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, _ *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Create(&datamodels.WebhookSubscription{Url: "https://chat.example.com/hook", EventTypes: []string{}, Secret: "0123456789abcdef"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create with a non http url",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, _ *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Create(&datamodels.WebhookSubscription{Url: "ftp://chat.example.com/hook", EventTypes: []string{events.TypeClientUpdated}, Secret: "0123456789abcdef"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create with a loopback url",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, _ *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Create(&datamodels.WebhookSubscription{Url: "http://127.0.0.1:8080/hook", EventTypes: []string{events.TypeClientUpdated}, Secret: "0123456789abcdef"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create with localhost",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, _ *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Create(&datamodels.WebhookSubscription{Url: "http://api.localhost/hook", EventTypes: []string{events.TypeClientUpdated}, Secret: "0123456789abcdef"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create with the metadata address",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, _ *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Create(&datamodels.WebhookSubscription{Url: "http://169.254.169.254/latest/meta-data", EventTypes: []string{events.TypeClientUpdated}, Secret: "0123456789abcdef"}, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "create needs the add permission",
+			permissions: []string{datamodels.WEBHOOKS_VIEW},
+			run: func(s WebhookService, f *fixture, _ *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Create(&datamodels.WebhookSubscription{Url: "https://chat.example.com/hook", EventTypes: []string{events.TypeClientUpdated}, Secret: "0123456789abcdef"}, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+		{
+			name:        "update without a secret keeps the secret",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, existing *datamodels.WebhookSubscription) *lgo.OperationResult {
+				result := s.Update(&datamodels.WebhookSubscription{Id: existing.Id, Url: "https://billing.example.com/v2", EventTypes: []string{events.TypeTimingCompleted}, IsActive: true}, f.c)
+				if saved := f.repos.Webhooks.GetById(f.c, existing.Id).ReturnObject.(*datamodels.WebhookSubscription); saved.Secret != existing.Secret || saved.Url != "https://billing.example.com/v2" {
+					t.Fatalf("want the url changed and the secret kept, got %+v", saved)
+				}
+				return result
+			},
+			want: ok(),
+		},
+		{
+			name:        "update with a short secret",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, existing *datamodels.WebhookSubscription) *lgo.OperationResult {
+				existing.Secret = "kisa"
+				return s.Update(existing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "update to a private network url",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, existing *datamodels.WebhookSubscription) *lgo.OperationResult {
+				existing.Url = "https://[fd00::1]/hook"
+				return s.Update(existing, f.c)
+			},
+			want: invalidFields(),
+		},
+		{
+			name:        "deliveries",
+			permissions: []string{datamodels.WEBHOOKS_VIEW},
+			run: func(s WebhookService, f *fixture, existing *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.GetDeliveries(existing.Id, &mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10}, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "deliveries of a missing webhook",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, _ *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.GetDeliveries(99, &mvc.QueryModel{PageNumber: 1, RecordsPerPage: 10}, f.c)
+			},
+			want: notFound(i18n.WebhookNotFound),
+		},
+		{
+			name:        "redeliver",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, existing *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Redeliver(existing.Id, f.addDelivery(t, existing.Id, 7).Id, f.c)
+			},
+			want: ok(),
+		},
+		{
+			name:        "redeliver another webhook's delivery",
+			permissions: allWebhookPermissions,
+			run: func(s WebhookService, f *fixture, existing *datamodels.WebhookSubscription) *lgo.OperationResult {
+				other := f.addWebhook(t, "https://other.example.com/hook", events.TypeTimingCreated)
+				return s.Redeliver(existing.Id, f.addDelivery(t, other.Id, 7).Id, f.c)
+			},
+			want: notFound(i18n.DeliveryNotFound),
+		},
+		{
+			name:        "redeliver needs the update permission",
+			permissions: []string{datamodels.WEBHOOKS_VIEW},
+			run: func(s WebhookService, f *fixture, existing *datamodels.WebhookSubscription) *lgo.OperationResult {
+				return s.Redeliver(existing.Id, f.addDelivery(t, existing.Id, 7).Id, f.c)
+			},
+			want: invalid(i18n.PermissionNotFound),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := newFixture(t, test.permissions...)
+			existing := f.addWebhook(t, "https://billing.example.com/hook", events.TypeTimingCreated)
+			test.want.check(t, test.run(NewWebhookService(f.repos.Webhooks, f.repos.WebhookDeliveries, f.cache), f, existing))
+		})
+	}
+}
+
+func TestWebhookServiceRedeliverQueuesACopy(t *testing.T) {
+	f := newFixture(t, allWebhookPermissions...)
+	webhook := f.addWebhook(t, "https://billing.example.com/hook", events.TypeTimingCreated)
+	original := f.addDelivery(t, webhook.Id, 7)
+
+	result := NewWebhookService(f.repos.Webhooks, f.repos.WebhookDeliveries, f.cache).Redeliver(webhook.Id, original.Id, f.c)
+	ok().check(t, result)
+
+	redelivery := result.ReturnObject.(*datamodels.WebhookDelivery)
+	if redelivery.Id == original.Id || redelivery.RedeliveryOf == nil || *redelivery.RedeliveryOf != original.Id {
+		t.Fatalf("want a new delivery linked to the original, got %+v", redelivery)
+	}
+	if redelivery.Status != enum.DeliveryStatusPending || redelivery.NextAttemptAt == nil || redelivery.Payload != original.Payload || redelivery.EventId != original.EventId {
+		t.Fatalf("want a pending copy of the original, got %+v", redelivery)
+	}
+	if stored := f.repos.WebhookDeliveries.GetById(f.c, original.Id).ReturnObject.(*datamodels.WebhookDelivery); stored.Status != enum.DeliveryStatusFailed {
+		t.Fatalf("want the original left as it was, got %+v", stored)
+	}
+}
+
+func TestWebhookEventHandlerQueuesDeliveries(t *testing.T) {
+	f := newFixture(t)
+	timings := f.addWebhook(t, "https://billing.example.com/hook", events.TypeTimingCreated, events.TypeTimingCompleted)
+	all := f.addWebhook(t, "https://chat.example.com/hook", events.TypeTimingCreated, events.TypeClientUpdated)
+	inactive := f.addWebhook(t, "https://old.example.com/hook", events.TypeTimingCreated)
+	inactive.IsActive = false
+	mustSucceed(t, f.repos.Webhooks.Update(f.c, inactive))
+
+	mustSucceed(t, f.events.Publish(f.c,
+		&events.TimingCreated{Timing: datamodels.Timing{Id: 3, Title: "Analiz"}},
+		&events.ClientUpdated{Client: datamodels.Client{Id: 1, ShortTitle: "ACME"}},
+		&events.TimingDeleted{TimingId: 3},
+	))
+
+	dispatcher := f.dispatcher(3)
+	dispatcher.Subscribe(&WebhookEventHandlerDeliveries{WebhookRepository: f.repos.Webhooks, WebhookDeliveryRepository: f.repos.WebhookDeliveries})
+	ok().check(t, dispatcher.DispatchDue(f.c))
+
+	count := func(subscriptionId int) int64 {
+		return f.repos.WebhookDeliveries.Count(func(delivery *datamodels.WebhookDelivery) bool { return delivery.SubscriptionId == subscriptionId })
+	}
+	if count(timings.Id) != 1 || count(all.Id) != 2 || count(inactive.Id) != 0 {
+		t.Fatalf("want deliveries only for active subscribers of each event, got %d, %d, %d", count(timings.Id), count(all.Id), count(inactive.Id))
+	}
+
+	delivery := f.repos.WebhookDeliveries.Find(nil)[0]
+	var envelope struct {
+		Id   int64  `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			Timing datamodels.Timing `json:"timing"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(delivery.Payload), &envelope); err != nil {
+		t.Fatal(err)
+	}
+	if envelope.Id != delivery.EventId || envelope.Type != events.TypeTimingCreated || envelope.Data.Timing.Title != "Analiz" {
+		t.Fatalf("want the event envelope as the payload, got %s", delivery.Payload)
+	}
+
+	// Olay yeniden dağıtılırsa aynı aboneliğe ikinci bir gönderim açılmaz
+	handler := &WebhookEventHandlerDeliveries{WebhookRepository: f.repos.Webhooks, WebhookDeliveryRepository: f.repos.WebhookDeliveries}
+	ok().check(t, handler.Handle(&events.Envelope{Id: delivery.EventId, Type: events.TypeTimingCreated, Data: &events.TimingCreated{}}, f.c))
+	if count(timings.Id) != 1 {
+		t.Fatalf("want a redispatched event queued once, got %d deliveries", count(timings.Id))
+	}
+}
+
+// addWebhook, servis kurallarını atlayarak etkin bir abonelik ekler
+func (f *fixture) addWebhook(t *testing.T, url string, eventTypes ...string) *datamodels.WebhookSubscription {
+	t.Helper()
+	webhook := &datamodels.WebhookSubscription{Url: url, EventTypes: eventTypes, Secret: "gizli-anahtar-0123456789", IsActive: true}
+	mustSucceed(t, f.repos.Webhooks.Create(f.c, webhook))
+	return webhook
+}
+
+// addDelivery, aboneliğe deneme hakkını bitirmiş bir gönderim ekler
+func (f *fixture) addDelivery(t *testing.T, subscriptionId int, eventId int64) *datamodels.WebhookDelivery {
+	t.Helper()
+	delivery := &datamodels.WebhookDelivery{
+		SubscriptionId: subscriptionId, EventId: eventId, EventType: events.TypeTimingCreated, Payload: `{"id":7}`,
+		Status: enum.DeliveryStatusFailed, Attempts: 3, CreatedAt: time.Now(),
+	}
+	mustSucceed(t, f.repos.WebhookDeliveries.Add(f.c, delivery))
+	return delivery
+}
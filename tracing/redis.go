@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook, komutları çağıranın context'i altında span'lerle izleyen bir Redis hook'u
+// döndürür. Context'te aktif bir span yoksa komut izlenmez.
+func RedisHook() redis.Hook {
+	return redisHook{}
+}
+
+type redisHook struct{}
+
+func (redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !HasParent(ctx) {
+			return next(ctx, cmd)
+		}
+
+		ctx, span := startRedisSpan(ctx, "redis."+cmd.Name(), cmd.Name(), 1)
+		defer span.End()
+
+		err := next(ctx, cmd)
+		if err != nil && !errors.Is(err, redis.Nil) {
+			RecordError(span, err)
+		}
+		return err
+	}
+}
+
+func (redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !HasParent(ctx) {
+			return next(ctx, cmds)
+		}
+
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.Name()
+		}
+		ctx, span := startRedisSpan(ctx, "redis.pipeline", strings.Join(names, " "), len(cmds))
+		defer span.End()
+
+		err := next(ctx, cmds)
+		if err != nil && !errors.Is(err, redis.Nil) {
+			RecordError(span, err)
+		}
+		return err
+	}
+}
+
+// Anahtarlar token içerdiğinden span'e yalnızca komut adları yazılır
+func startRedisSpan(ctx context.Context, name string, operation string, count int) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperationName(operation),
+			attribute.Int("db.redis.command_count", count),
+		))
+}
@@ -0,0 +1,131 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ServiceName = "lms-web-services"
+	tracerName  = "lms-web-services-main"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Config, hangi exporter'ların kullanılacağını ve örnekleme oranını belirler. OTLP uç noktası
+// standart OTEL_EXPORTER_OTLP_ENDPOINT / OTEL_EXPORTER_OTLP_TRACES_ENDPOINT değişkenlerinden okunur.
+type Config struct {
+	Exporters   []string // "otlp", "stdout"; boşsa izleme kapalıdır
+	SampleRatio float64  // 0..1 arası, üst span örneklenmişse her zaman örneklenir
+}
+
+// ParseExporters, "otlp,stdout" biçimindeki listeyi doğrular
+func ParseExporters(value string) ([]string, error) {
+	var exporters []string
+	for _, exporter := range strings.Split(value, ",") {
+		exporter = strings.TrimSpace(strings.ToLower(exporter))
+		switch exporter {
+		case "", "none":
+		case ExporterOTLP, ExporterStdout:
+			exporters = append(exporters, exporter)
+		default:
+			return nil, fmt.Errorf("unknown tracing exporter %q", exporter)
+		}
+	}
+	return exporters, nil
+}
+
+// NewProvider, yapılandırılmış exporter'larla bir TracerProvider kurar ve global olarak kaydeder.
+// Dönen fonksiyon kapanışta bekleyen span'leri gönderir.
+func NewProvider(ctx context.Context, config Config, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if len(config.Exporters) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("creating tracing resource failed: %w", err)
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	}
+
+	for _, name := range config.Exporters {
+		var exporter sdktrace.SpanExporter
+		switch name {
+		case ExporterOTLP:
+			exporter, err = otlptracehttp.New(ctx)
+		case ExporterStdout:
+			exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stderr), stdouttrace.WithPrettyPrint())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("creating %s trace exporter failed: %w", name, err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer, uygulama genelinde kullanılan tracer'ı döndürür
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Start, verilen context'in altında yeni bir span başlatır
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// HasParent, context'te geçerli bir span olup olmadığını döndürür. Arka plan işlerinin
+// (ör. metrik toplama sorguları) kök span üretmemesi için kullanılır.
+func HasParent(ctx context.Context) bool {
+	return ctx != nil && trace.SpanContextFromContext(ctx).IsValid()
+}
+
+// RecordError, hatayı span'e işler ve durumunu Error yapar
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// RecordResult, lgo.OperationResult benzeri bir sonucun başarısızlığını span'e işler
+func RecordResult(span trace.Span, success bool, message string) {
+	span.SetAttributes(attribute.Bool("lms.result.success", success))
+	if !success {
+		if message == "" {
+			message = "operation failed"
+		}
+		RecordError(span, errors.New(message))
+	}
+}
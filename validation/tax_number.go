@@ -0,0 +1,63 @@
+package validation
+
+// Vergi kimlik numaraları. Tüzel kişiler 10 haneli Vergi Kimlik Numarası (VKN), şahıslar
+// 11 haneli T.C. Kimlik Numarası (TCKN) ile fatura keser; iki numara da son hanelerindeki
+// kontrol basamaklarıyla doğrulanır.
+
+// IsTaxNumber, değerin geçerli bir VKN veya TCKN olduğunu kontrol eder
+func IsTaxNumber(value string) bool {
+	return IsVKN(value) || IsTCKN(value)
+}
+
+// IsVKN, 10 haneli Vergi Kimlik Numarasının kontrol basamağını doğrular
+func IsVKN(value string) bool {
+	digits, ok := parseDigits(value, 10)
+	if !ok {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		tmp := (digits[i] + 9 - i) % 10
+		if tmp == 9 {
+			sum += tmp
+			continue
+		}
+		sum += (tmp << (9 - i)) % 9
+	}
+	return digits[9] == (10-sum%10)%10
+}
+
+// IsTCKN, 11 haneli T.C. Kimlik Numarasının iki kontrol basamağını doğrular. İlk hane 0 olamaz.
+func IsTCKN(value string) bool {
+	digits, ok := parseDigits(value, 11)
+	if !ok || digits[0] == 0 {
+		return false
+	}
+
+	odd := digits[0] + digits[2] + digits[4] + digits[6] + digits[8]
+	even := digits[1] + digits[3] + digits[5] + digits[7]
+	if digits[9] != ((odd*7-even)%10+10)%10 {
+		return false
+	}
+
+	sum := 0
+	for _, digit := range digits[:10] {
+		sum += digit
+	}
+	return digits[10] == sum%10
+}
+
+func parseDigits(value string, length int) ([]int, bool) {
+	if len(value) != length {
+		return nil, false
+	}
+	digits := make([]int, length)
+	for i := 0; i < length; i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return nil, false
+		}
+		digits[i] = int(value[i] - '0')
+	}
+	return digits, true
+}
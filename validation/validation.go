@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"lms-web-services-main/i18n"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Modeller doğrulama kurallarını `validate` etiketiyle, hata mesajlarında kullanılacak alan
+// adını ise `label` etiketiyle (i18n alan kodu) tanımlar:
+//
+//	Title string `json:"t" validate:"required,max=200" label:"field.title"`
+//
+// Uzunluk kuralları (min, max) karakter sayısıyla çalışır; "ş", "ğ" gibi harfler tek
+// karakter sayılır. Hatalar ilk hatada durmadan toplanır ve JSON alan adına göre döner.
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	// Hata anahtarları olarak JSON alan adları kullanılır
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	// enum: IsValid() bool metodu olan türlerin geçerli bir değer taşıdığını kontrol eder
+	v.RegisterValidation("enum", func(fl validator.FieldLevel) bool {
+		value, ok := fl.Field().Interface().(interface{ IsValid() bool })
+		return ok && value.IsValid()
+	})
+
+	// taxnumber: geçerli bir VKN veya TCKN (bkz. IsTaxNumber)
+	v.RegisterValidation("taxnumber", func(fl validator.FieldLevel) bool {
+		return IsTaxNumber(fl.Field().String())
+	})
+
+	return v
+}
+
+// Struct, modelin tüm alanlarını doğrular. Hata varsa i18n.InvalidFields kodlu ve alan
+// bazlı hataları taşıyan bir *i18n.Message döner.
+func Struct(model any) error {
+	return translate(model, validate.Struct(model))
+}
+
+// StructExcept, verilen alanlar (Go alan adları) dışındaki alanları doğrular. Güncellemede
+// değiştirilemeyen alanlar (örn. bağlı olunan kayıt) bu şekilde atlanır.
+func StructExcept(model any, fields ...string) error {
+	return translate(model, validate.StructExcept(model, fields...))
+}
+
+func translate(model any, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return err
+	}
+
+	modelType := reflect.Indirect(reflect.ValueOf(model)).Type()
+	message := i18n.New(i18n.InvalidFields)
+	for _, fieldError := range fieldErrors {
+		// Aynı alanda birden fazla kural bozulursa ilk kural raporlanır
+		if _, exists := message.Fields[fieldError.Field()]; exists {
+			continue
+		}
+		message.WithField(fieldError.Field(), fieldMessage(modelType, fieldError))
+	}
+	return message
+}
+
+// fieldMessage, bozulan kurala karşılık gelen mesajı üretir
+func fieldMessage(modelType reflect.Type, fieldError validator.FieldError) *i18n.Message {
+	label := labelOf(modelType, fieldError.StructField(), fieldError.Field())
+
+	switch fieldError.Tag() {
+	case "required":
+		return i18n.New(i18n.Required, label)
+	case "email":
+		return i18n.New(i18n.InvalidEmail)
+	case "taxnumber":
+		return i18n.New(i18n.InvalidTaxNumber)
+	case "iso4217":
+		return i18n.New(i18n.InvalidCurrency)
+	case "max", "min":
+		if fieldError.Kind() != reflect.String {
+			break
+		}
+		length, err := strconv.Atoi(fieldError.Param())
+		if err != nil {
+			break
+		}
+		if fieldError.Tag() == "max" {
+			return i18n.New(i18n.MaxLength, label, length)
+		}
+		return i18n.New(i18n.MinLength, label, length)
+	case "gtefield":
+		return i18n.New(i18n.NotBefore, label, labelOf(modelType, fieldError.Param(), fieldError.Param()))
+	}
+	return i18n.New(i18n.InvalidValue, label)
+}
+
+// labelOf, alanın `label` etiketindeki i18n kodunu döndürür; etiket yoksa alan adı kullanılır
+func labelOf(modelType reflect.Type, structField, fallback string) any {
+	if field, ok := modelType.FieldByName(structField); ok {
+		if label := field.Tag.Get("label"); label != "" {
+			return i18n.New(label)
+		}
+	}
+	return fallback
+}